@@ -0,0 +1,233 @@
+// Package app provides Runner, which composes a service's database connection,
+// scheduled functions, background workers, and HTTP server into one process
+// lifecycle: ordered startup, shared signal handling, and reverse-order graceful
+// shutdown.
+package app
+
+import (
+	"context"      // context provides cancellation of running components.
+	"database/sql" // sql provides the shared database handle.
+	"errors"       // errors provides joining of shutdown errors.
+	"fmt"          // fmt provides formatting and printing functions.
+	"net/http"     // http provides the server handler type.
+	"os"           // os provides the interrupt signal.
+	"os/signal"    // signal provides shutdown signal handling.
+	"syscall"      // syscall provides the SIGTERM constant.
+	"time"         // time provides intervals and the shutdown timeout.
+
+	"github.com/hekimapro/utils/database"  // database provides the PostgreSQL connection.
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"       // log provides colored logging utilities.
+	"github.com/hekimapro/utils/scheduler" // scheduler provides panic recovery and run metrics for scheduled functions.
+	"github.com/hekimapro/utils/server"    // server provides the HTTP server.
+)
+
+// DefaultShutdownTimeout bounds the whole shutdown sequence.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// component is one step of the lifecycle. Start runs during ordered startup; run, if
+// set, keeps running in the background until the component is stopped; stop runs
+// during reverse-order shutdown.
+type component struct {
+	name   string
+	start  func(ctx context.Context) error
+	run    func(ctx context.Context) error
+	stop   func(ctx context.Context) error
+	cancel context.CancelFunc // cancel stops run
+	done   chan struct{}      // done is closed when run returns
+}
+
+// Runner starts components in the order they were added and stops them in reverse
+// order, so the server stops accepting requests before workers stop and before the
+// database is closed.
+type Runner struct {
+	ShutdownTimeout time.Duration // ShutdownTimeout bounds the whole shutdown sequence (default DefaultShutdownTimeout)
+
+	components []*component
+	db         *sql.DB
+}
+
+// New creates an empty Runner.
+//
+// Example:
+//
+//	runner := app.New()
+//	runner.Database()
+//	runner.Every("cleanup", time.Hour, false, cleanupExpiredSessions)
+//	runner.Worker("emails", emailWorker)
+//	runner.Server(func() http.Handler { return routes(runner.DB()) })
+//	if err := runner.Run(); err != nil {
+//	    log.Error(err.Error())
+//	    os.Exit(1)
+//	}
+func New() *Runner {
+	return &Runner{ShutdownTimeout: DefaultShutdownTimeout}
+}
+
+// Add registers a resource with a start and a stop function; either may be nil.
+// Use it for anything with an explicit lifecycle, such as a queue connection or cache.
+func (r *Runner) Add(name string, start, stop func(ctx context.Context) error) *Runner {
+	r.components = append(r.components, &component{name: name, start: start, stop: stop})
+	return r
+}
+
+// Worker registers a background function that runs until its context is cancelled
+// during shutdown. A worker returning an error before shutdown stops the whole process;
+// a worker returning nil simply finishes.
+func (r *Runner) Worker(name string, run func(ctx context.Context) error) *Runner {
+	r.components = append(r.components, &component{name: name, run: run})
+	return r
+}
+
+// Database connects to PostgreSQL with database.ConnectToDatabase, registers its
+// health check, and closes the pool during shutdown. The handle is available from DB
+// to components added after it.
+func (r *Runner) Database() *Runner {
+	return r.Add("database",
+		func(ctx context.Context) error {
+			db, err := database.ConnectToDatabase()
+			if err != nil {
+				return err
+			}
+			database.RegisterHealthCheck(db)
+			r.db = db
+			return nil
+		},
+		func(ctx context.Context) error {
+			return r.db.Close()
+		})
+}
+
+// DB returns the database handle opened by Database, or nil before it has started.
+func (r *Runner) DB() *sql.DB {
+	return r.db
+}
+
+// Every runs fn at the given interval until shutdown, optionally once at startup.
+// Panics are recovered and recorded by the scheduler package, so one failing run
+// does not stop the schedule.
+func (r *Runner) Every(name string, interval time.Duration, runInstant bool, fn func()) *Runner {
+	return r.Worker(name, func(ctx context.Context) error {
+		if interval <= 0 {
+			return helpers.CreateErrorf("interval must be positive, got: %v", interval)
+		}
+		if runInstant {
+			scheduler.RunWithRecovery(fn, name)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				scheduler.RunWithRecovery(fn, name)
+			}
+		}
+	})
+}
+
+// Server starts the HTTP server with server.Run. newHandler is called during startup,
+// after the components added before it, so routes can use DB.
+func (r *Runner) Server(newHandler func() http.Handler) *Runner {
+	return r.Worker("server", func(ctx context.Context) error {
+		return server.Run(ctx, newHandler())
+	})
+}
+
+// Run starts every component and blocks until SIGINT or SIGTERM, or until a worker
+// fails, then shuts down in reverse order. It returns the startup or worker error,
+// joined with any shutdown errors.
+func (r *Runner) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return r.RunContext(ctx)
+}
+
+// RunContext behaves like Run but shuts down when ctx is cancelled instead of on signals.
+func (r *Runner) RunContext(ctx context.Context) error {
+	failures := make(chan error, len(r.components))
+
+	var runErr error
+	started := 0
+	for _, c := range r.components {
+		log.Info(fmt.Sprintf("🚀 Starting %s", c.name))
+		if c.start != nil {
+			if err := c.start(ctx); err != nil {
+				runErr = helpers.WrapErrorf(err, "failed to start %s", c.name)
+				log.Error(fmt.Sprintf("❌ %v", runErr))
+				break
+			}
+		}
+		if c.run != nil {
+			r.launch(c, failures)
+		}
+		started++
+	}
+
+	if runErr == nil {
+		log.Success(fmt.Sprintf("✅ Started %d component(s)", started))
+		select {
+		case <-ctx.Done():
+			log.Info("🛑 Received shutdown signal, stopping components in reverse order")
+		case runErr = <-failures:
+			log.Error(fmt.Sprintf("❌ %v, shutting down", runErr))
+		}
+	}
+
+	return errors.Join(runErr, r.shutdown(r.components[:started]))
+}
+
+// launch runs a component's background function on its own context, so components
+// can be stopped one at a time in reverse order.
+func (r *Runner) launch(c *component, failures chan<- error) {
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+		if err := c.run(runCtx); err != nil && runCtx.Err() == nil {
+			failures <- helpers.WrapErrorf(err, "%s stopped unexpectedly", c.name)
+		}
+	}()
+}
+
+// shutdown stops the started components in reverse order within ShutdownTimeout.
+func (r *Runner) shutdown(started []*component) error {
+	timeout := r.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		log.Info(fmt.Sprintf("⏹️ Stopping %s", c.name))
+
+		if c.cancel != nil {
+			c.cancel()
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+				errs = append(errs, helpers.CreateErrorf("%s did not stop within the shutdown timeout", c.name))
+				log.Error(fmt.Sprintf("❌ %s did not stop within the shutdown timeout", c.name))
+				continue
+			}
+		}
+
+		if c.stop != nil {
+			if err := c.stop(ctx); err != nil {
+				errs = append(errs, helpers.WrapErrorf(err, "failed to stop %s", c.name))
+				log.Error(fmt.Sprintf("❌ Failed to stop %s: %v", c.name, err))
+				continue
+			}
+		}
+		log.Success(fmt.Sprintf("✅ Stopped %s", c.name))
+	}
+	return errors.Join(errs...)
+}
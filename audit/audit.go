@@ -0,0 +1,186 @@
+// Package audit records who-did-what events (actor, action, entity, before/after
+// state, request ID) in a PostgreSQL table for compliance, with query helpers and an
+// HTTP middleware that records successful mutations automatically.
+package audit
+
+import (
+	"context"       // context provides actor and request ID propagation.
+	"database/sql"  // sql provides database access.
+	"encoding/json" // json provides state serialization.
+	"fmt"           // fmt provides formatting and printing functions.
+	"net/netip"     // netip provides the trusted proxy prefixes.
+	"strings"       // strings provides case-insensitive field redaction.
+	"time"          // time provides event timestamps.
+
+	"github.com/hekimapro/utils/env"     // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/geo"     // geo provides proxy-aware client IP resolution.
+	"github.com/hekimapro/utils/helpers" // helpers provides default value utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models"  // models provides the ContextKey type.
+)
+
+// Context keys for the audit actor and request ID.
+const (
+	ContextKeyActor     models.ContextKey = "audit_actor"           // ContextKeyActor holds the acting user or system as a string
+	ContextKeyRequestID                   = log.ContextKeyRequestID // ContextKeyRequestID is the log package's key, so audit events and log lines share one ID
+)
+
+// redactedValue replaces the value of sensitive fields.
+const redactedValue = "[REDACTED]"
+
+// Config holds audit log configuration.
+type Config struct {
+	Table          string   `env:"AUDIT_TABLE" default:"audit_log"`                                     // Table is the audit table name
+	RedactFields   []string `env:"AUDIT_REDACT_FIELDS" default:"password,secret,token,pin,api_key,otp"` // RedactFields lists JSON fields whose values are never stored
+	TrustedProxies []string `env:"TRUSTED_PROXIES"`                                                     // TrustedProxies lists proxy IPs or CIDRs whose X-Forwarded-For is believed for the recorded IP
+}
+
+// LoadConfig loads audit configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid audit configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// Entry describes an action to record. Before and After may be any JSON-serializable
+// value, usually the entity before and after the change; either may be nil.
+type Entry struct {
+	Actor    string                 // Actor is who acted; defaults to the actor in the context
+	Action   string                 // Action names what happened, e.g. "create", "update", "approve"
+	Entity   string                 // Entity is the kind of object acted on, e.g. "invoice"
+	EntityID string                 // EntityID identifies the object
+	Before   interface{}            // Before is the state before the action
+	After    interface{}            // After is the state after the action
+	Metadata map[string]interface{} // Metadata holds extra context such as the IP address or route
+}
+
+// Change is a single field difference between the before and after states.
+type Change struct {
+	Field string      `json:"field"` // Field is the dotted path of the changed field
+	From  interface{} `json:"from"`  // From is the old value, or nil when the field was added
+	To    interface{} `json:"to"`    // To is the new value, or nil when the field was removed
+}
+
+// Event is a recorded audit entry.
+type Event struct {
+	ID        int64                  `json:"id"`                 // ID is the event's primary key
+	Actor     string                 `json:"actor"`              // Actor is who acted
+	Action    string                 `json:"action"`             // Action names what happened
+	Entity    string                 `json:"entity"`             // Entity is the kind of object acted on
+	EntityID  string                 `json:"entity_id"`          // EntityID identifies the object
+	Before    json.RawMessage        `json:"before,omitempty"`   // Before is the redacted state before the action
+	After     json.RawMessage        `json:"after,omitempty"`    // After is the redacted state after the action
+	Changes   []Change               `json:"changes,omitempty"`  // Changes lists the fields that differ between Before and After
+	RequestID string                 `json:"request_id"`         // RequestID correlates the event with logs
+	Metadata  map[string]interface{} `json:"metadata,omitempty"` // Metadata holds extra context
+	CreatedAt time.Time              `json:"created_at"`         // CreatedAt is when the event was recorded
+}
+
+// Logger records and queries audit events.
+type Logger struct {
+	db      *sql.DB
+	config  Config
+	redact  map[string]bool
+	trusted []netip.Prefix
+}
+
+// NewLogger creates an audit logger using the given database connection.
+//
+// Example:
+//
+//	auditLog := audit.NewLogger(db, audit.LoadConfig())
+//	auditLog.CreateTable(ctx)
+//	auditLog.Record(ctx, audit.Entry{
+//	    Action:   "update",
+//	    Entity:   "invoice",
+//	    EntityID: invoice.ID,
+//	    Before:   oldInvoice,
+//	    After:    invoice,
+//	})
+func NewLogger(db *sql.DB, config Config) *Logger {
+	config.Table = helpers.DefaultIfEmpty(config.Table, "audit_log")
+
+	redact := make(map[string]bool, len(config.RedactFields))
+	for _, field := range config.RedactFields {
+		redact[strings.ToLower(field)] = true
+	}
+
+	// An invalid proxy list trusts no proxy, so recorded IPs fall back to the connection address.
+	trusted, err := geo.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid audit trusted proxies, recording connection addresses: %v", err))
+	}
+	return &Logger{db: db, config: config, redact: redact, trusted: trusted}
+}
+
+// WithActor returns a context carrying the actor recorded by Record when an entry has none.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ContextKeyActor, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(ContextKeyActor).(string)
+	return actor
+}
+
+// WithRequestID returns a context carrying the request ID recorded with every event.
+// It is log.WithRequestID, so log lines carry the same ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return log.WithRequestID(ctx, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, Middleware, or
+// log.Middleware, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	return log.RequestIDFromContext(ctx)
+}
+
+// normalize converts a value to its generic JSON form with sensitive fields redacted.
+// Returns nil for nil values.
+func (l *Logger) normalize(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var data []byte
+	switch typed := value.(type) {
+	case json.RawMessage:
+		data = typed
+	case []byte:
+		data = typed
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		data = encoded
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return l.redactValue(generic), nil
+}
+
+// redactValue replaces the values of sensitive keys at any depth.
+func (l *Logger) redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if l.redact[strings.ToLower(key)] {
+				typed[key] = redactedValue
+			} else {
+				typed[key] = l.redactValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = l.redactValue(nested)
+		}
+	}
+	return value
+}
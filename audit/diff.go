@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"reflect" // reflect provides deep equality of JSON values.
+	"sort"    // sort provides stable change ordering.
+)
+
+// Diff returns the fields that differ between two JSON-serializable values, with
+// nested objects reported as dotted paths (e.g. "address.city"). Arrays are compared
+// as a whole.
+//
+// Example:
+//
+//	changes, err := audit.Diff(oldInvoice, newInvoice)
+//	// [{Field: "status", From: "draft", To: "sent"}]
+func Diff(before, after interface{}) ([]Change, error) {
+	var logger Logger
+	normalizedBefore, err := logger.normalize(before)
+	if err != nil {
+		return nil, err
+	}
+	normalizedAfter, err := logger.normalize(after)
+	if err != nil {
+		return nil, err
+	}
+	return diffValues(normalizedBefore, normalizedAfter), nil
+}
+
+// diffValues compares two normalized JSON values.
+func diffValues(before, after interface{}) []Change {
+	var changes []Change
+	collectChanges("", before, after, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// collectChanges appends the differences under path.
+func collectChanges(path string, before, after interface{}, changes *[]Change) {
+	beforeObject, beforeIsObject := before.(map[string]interface{})
+	afterObject, afterIsObject := after.(map[string]interface{})
+
+	// A missing side of an object (e.g. before a create) reports each field as added or removed.
+	if before == nil && afterIsObject {
+		beforeObject, beforeIsObject = map[string]interface{}{}, true
+	}
+	if after == nil && beforeIsObject {
+		afterObject, afterIsObject = map[string]interface{}{}, true
+	}
+
+	if beforeIsObject && afterIsObject {
+		keys := make(map[string]bool, len(beforeObject)+len(afterObject))
+		for key := range beforeObject {
+			keys[key] = true
+		}
+		for key := range afterObject {
+			keys[key] = true
+		}
+		for key := range keys {
+			field := key
+			if path != "" {
+				field = path + "." + key
+			}
+			collectChanges(field, beforeObject[key], afterObject[key], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, Change{Field: path, From: before, To: after})
+	}
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"bytes"         // bytes provides request body capture.
+	"context"       // context provides a detached context for recording.
+	"encoding/json" // json provides request body validation.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io"            // io provides request body reading.
+	"net/http"      // http provides the middleware types.
+	"strings"       // strings provides route pattern parsing.
+
+	"github.com/hekimapro/utils/geo"               // geo provides the resolved client IP and location.
+	"github.com/hekimapro/utils/internal/recorder" // recorder provides the shared response status recorder.
+	"github.com/hekimapro/utils/log"               // log provides colored logging utilities.
+	"github.com/hekimapro/utils/server"            // server provides the authenticated user context key.
+)
+
+// HeaderRequestID is read from incoming requests and set on responses by Middleware.
+const HeaderRequestID = log.HeaderRequestID
+
+// maxCapturedBody limits the request body stored as the after state.
+const maxCapturedBody = 64 << 10
+
+// actions maps mutating HTTP methods to audit actions.
+var actions = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+// Middleware assigns every request an ID with log.AssignRequestID, keeping the ID set
+// by log.Middleware when it runs first, takes the actor from the JWT middleware, and
+// records successful POST, PUT, PATCH, and DELETE requests. The entity is the last
+// literal segment of the matched route pattern and the entity ID the last wildcard,
+// so "PUT /invoices/{id}" records action "update" on entity "invoices"; requests
+// without a route pattern record only their path. A JSON request body is stored,
+// redacted, as the after state.
+//
+// Example:
+//
+//	mux.Handle("PUT /invoices/{id}", server.JWTMiddleware(jwtConfig)(auditLog.Middleware()(updateInvoice)))
+func (l *Logger) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = log.AssignRequestID(w, r)
+			ctx := r.Context()
+			if userID, ok := ctx.Value(server.ContextKeyUserID).(string); ok && ActorFromContext(ctx) == "" {
+				ctx = WithActor(ctx, userID)
+			}
+			r = r.WithContext(ctx)
+
+			action, mutating := actions[r.Method]
+			if !mutating {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := captureBody(r)
+			writer := recorder.New(w)
+			next.ServeHTTP(writer, r)
+
+			if writer.Status() >= http.StatusBadRequest {
+				return
+			}
+
+			entity, entityID := routeEntity(r)
+			entry := Entry{
+				Action:   action,
+				Entity:   entity,
+				EntityID: entityID,
+				Metadata: map[string]interface{}{
+					"method":     r.Method,
+					"path":       r.URL.Path,
+					"status":     writer.Status(),
+					"ip_address": geo.ClientIP(r, l.trusted).String(),
+					"user_agent": r.UserAgent(),
+				},
+			}
+			// Prefer the proxy-aware client resolved by geo.Resolver.Middleware when present.
+			if client, ok := geo.FromContext(r.Context()); ok {
+				entry.Metadata["ip_address"] = client.IP
+				entry.Metadata["fingerprint"] = client.Fingerprint
+				if client.Location != nil {
+					entry.Metadata["country"] = client.Location.CountryCode
+				}
+			}
+			if body != nil && action != "delete" {
+				entry.After = body
+			}
+
+			// Record even if the client has gone away; the change already happened.
+			if err := l.Record(context.WithoutCancel(r.Context()), entry); err != nil {
+				log.Error(fmt.Sprintf("❌ Failed to record audit event for %s %s: %v", r.Method, r.URL.Path, err))
+			}
+		})
+	}
+}
+
+// captureBody reads a JSON request body and restores it for the handler.
+// Returns nil for non-JSON or oversized bodies.
+func captureBody(r *http.Request) json.RawMessage {
+	if r.Body == nil || !strings.Contains(r.Header.Get("Content-Type"), "json") {
+		return nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxCapturedBody+1))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), r.Body))
+	if err != nil || len(data) > maxCapturedBody || !json.Valid(data) {
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
+// routeEntity derives the entity and entity ID from the matched route pattern. Without
+// a pattern, e.g. behind a router other than http.ServeMux, both are empty rather than
+// guessed from the path, which is recorded in the metadata instead.
+func routeEntity(r *http.Request) (string, string) {
+	pattern := r.Pattern
+	if _, path, found := strings.Cut(pattern, " "); found {
+		pattern = path
+	}
+	if pattern == "" {
+		return "", ""
+	}
+
+	var entity, entityID string
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.TrimSuffix(strings.Trim(segment, "{}"), "...")
+			if name != "$" {
+				entityID = r.PathValue(name)
+			}
+			continue
+		}
+		if segment != "" {
+			entity = segment
+			entityID = ""
+		}
+	}
+	return entity, entityID
+}
@@ -0,0 +1,224 @@
+package audit
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"database/sql"  // sql provides database access.
+	"encoding/json" // json provides state serialization.
+	"fmt"           // fmt provides query formatting.
+	"strings"       // strings provides filter clause joining.
+	"time"          // time provides the query time range.
+
+	"github.com/hekimapro/utils/database" // database provides context-aware query helpers.
+	"github.com/hekimapro/utils/helpers"  // helpers provides error utilities.
+)
+
+// execer is satisfied by *sql.DB and *sql.Tx so events can be recorded inside a transaction.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// eventColumns lists the columns scanned into an Event.
+const eventColumns = "id, actor, action, entity, entity_id, before, after, changes, request_id, metadata, created_at"
+
+// CreateTable creates the audit table and its indexes if they do not exist.
+func (l *Logger) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %[1]s (
+			id BIGSERIAL PRIMARY KEY,
+			actor TEXT NOT NULL DEFAULT '',
+			action TEXT NOT NULL,
+			entity TEXT NOT NULL DEFAULT '',
+			entity_id TEXT NOT NULL DEFAULT '',
+			before JSONB,
+			after JSONB,
+			changes JSONB,
+			request_id TEXT NOT NULL DEFAULT '',
+			metadata JSONB,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS %[1]s_entity_idx ON %[1]s (entity, entity_id, created_at);
+		CREATE INDEX IF NOT EXISTS %[1]s_actor_idx ON %[1]s (actor, created_at);`, l.config.Table)
+
+	if _, err := database.ExecWithContext(ctx, l.db, query); err != nil {
+		return helpers.WrapError(err, "failed to create audit table")
+	}
+	return nil
+}
+
+// Record stores an audit event. The actor and request ID default to the values in ctx.
+func (l *Logger) Record(ctx context.Context, entry Entry) error {
+	return l.record(ctx, l.db, entry)
+}
+
+// RecordTx stores an audit event inside an existing transaction, so it is only
+// persisted if the audited change commits.
+func (l *Logger) RecordTx(ctx context.Context, transaction *sql.Tx, entry Entry) error {
+	return l.record(ctx, transaction, entry)
+}
+
+// record redacts the states, computes the changes, and inserts the event.
+func (l *Logger) record(ctx context.Context, db execer, entry Entry) error {
+	if entry.Action == "" {
+		return helpers.CreateError("audit action cannot be empty")
+	}
+	entry.Actor = helpers.DefaultIfEmpty(entry.Actor, ActorFromContext(ctx))
+
+	before, err := l.normalize(entry.Before)
+	if err != nil {
+		return helpers.WrapError(err, "failed to encode audit before state")
+	}
+	after, err := l.normalize(entry.After)
+	if err != nil {
+		return helpers.WrapError(err, "failed to encode audit after state")
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (actor, action, entity, entity_id, before, after, changes, request_id, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, l.config.Table)
+
+	_, err = db.ExecContext(ctx, query, entry.Actor, entry.Action, entry.Entity, entry.EntityID,
+		jsonColumn(before), jsonColumn(after), jsonColumn(diffValues(before, after)),
+		RequestIDFromContext(ctx), jsonColumn(entry.Metadata))
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to record audit event %s %s", entry.Action, entry.Entity)
+	}
+	return nil
+}
+
+// jsonColumn encodes a value for a nullable JSONB column.
+func jsonColumn(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case []Change:
+		if len(typed) == 0 {
+			return nil
+		}
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			return nil
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// Filter selects events for Query. Zero values do not filter.
+type Filter struct {
+	Actor    string    // Actor matches the actor exactly
+	Action   string    // Action matches the action exactly
+	Entity   string    // Entity matches the entity exactly
+	EntityID string    // EntityID matches the entity ID exactly
+	From     time.Time // From includes events at or after this time
+	To       time.Time // To includes events before this time
+	Limit    int       // Limit caps the result size (default 100, maximum 1000)
+	Offset   int       // Offset skips events for pagination
+}
+
+// Query returns the events matching filter, newest first.
+//
+// Example:
+//
+//	events, err := auditLog.Query(ctx, audit.Filter{Actor: "user-42", From: time.Now().AddDate(0, 0, -7)})
+func (l *Logger) Query(ctx context.Context, filter Filter) ([]Event, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Actor != "" {
+		add("actor = $%d", filter.Actor)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Entity != "" {
+		add("entity = $%d", filter.Entity)
+	}
+	if filter.EntityID != "" {
+		add("entity_id = $%d", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		add("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("created_at < $%d", filter.To)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	limit = min(limit, 1000)
+
+	query := fmt.Sprintf("SELECT %s FROM %s %s ORDER BY created_at DESC, id DESC LIMIT %d OFFSET %d",
+		eventColumns, l.config.Table, where, limit, max(filter.Offset, 0))
+
+	rows, err := database.QueryWithContext(ctx, l.db, query, args...)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to query audit events")
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to read audit event")
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// History returns every event for one entity, newest first.
+func (l *Logger) History(ctx context.Context, entity, entityID string) ([]Event, error) {
+	return l.Query(ctx, Filter{Entity: entity, EntityID: entityID, Limit: 1000})
+}
+
+// DeleteOlderThan removes events older than the given age, for retention policies,
+// and returns how many were deleted.
+func (l *Logger) DeleteOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE created_at < $1", l.config.Table)
+
+	result, err := database.ExecWithContext(ctx, l.db, query, time.Now().Add(-age))
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to delete old audit events")
+	}
+	return result.RowsAffected()
+}
+
+// scanEvent reads a row selected with eventColumns.
+func scanEvent(rows *sql.Rows) (Event, error) {
+	var event Event
+	var before, after, changes, metadata []byte
+	err := rows.Scan(&event.ID, &event.Actor, &event.Action, &event.Entity, &event.EntityID,
+		&before, &after, &changes, &event.RequestID, &metadata, &event.CreatedAt)
+	if err != nil {
+		return event, err
+	}
+
+	event.Before = json.RawMessage(before)
+	event.After = json.RawMessage(after)
+	if len(changes) > 0 {
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			return event, err
+		}
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &event.Metadata); err != nil {
+			return event, err
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,294 @@
+// Package cache provides a concurrency-safe in-memory cache with per-entry TTL,
+// LRU eviction, single-flight loading, and hit/miss metrics.
+package cache
+
+import (
+	"container/list" // list provides the LRU ordering.
+	"context"        // context provides cancellation for loaders and cleanup.
+	"fmt"            // fmt provides formatting and printing functions.
+	"sync"           // sync provides synchronization primitives.
+	"time"           // time provides expiry handling.
+
+	"github.com/hekimapro/utils/env"     // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Config holds cache configuration.
+type Config struct {
+	MaxEntries int           `env:"CACHE_MAX_ENTRIES" default:"10000"`        // MaxEntries bounds the cache size (0 = unbounded)
+	DefaultTTL time.Duration `env:"CACHE_DEFAULT_TTL" default:"300" unit:"s"` // DefaultTTL applies to Set and GetOrLoad (0 = no expiry)
+}
+
+// LoadConfig loads cache configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid cache configuration: " + err.Error())
+	}
+	return config
+}
+
+// Stats holds cache metrics.
+type Stats struct {
+	Hits        uint64 // Hits counts lookups that found a live entry
+	Misses      uint64 // Misses counts lookups that found nothing or an expired entry
+	Evictions   uint64 // Evictions counts entries removed to respect MaxEntries
+	Expirations uint64 // Expirations counts entries removed because their TTL passed
+	Loads       uint64 // Loads counts successful GetOrLoad loader calls
+	LoadErrors  uint64 // LoadErrors counts failed GetOrLoad loader calls
+	Entries     int    // Entries is the current number of stored entries
+}
+
+// HitRatio returns hits / (hits + misses), or 0 when there were no lookups.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// entry is a cached value with its LRU position and expiry.
+type entry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time // expiresAt is zero for entries without expiry
+}
+
+// call tracks an in-flight GetOrLoad so concurrent callers share one load.
+type call struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// Cache is a concurrency-safe LRU cache with per-entry TTL.
+type Cache struct {
+	mu       sync.Mutex
+	config   Config
+	items    map[string]*list.Element
+	order    *list.List // order holds entries from most to least recently used
+	inflight map[string]*call
+	stats    Stats
+}
+
+// New creates a cache with the given configuration.
+//
+// Example:
+//
+//	users := cache.New(cache.LoadConfig())
+//	user, err := users.GetOrLoad(ctx, "user:42", func(ctx context.Context) (interface{}, error) {
+//	    return fetchUser(ctx, 42)
+//	})
+func New(config Config) *Cache {
+	return &Cache{
+		config:   config,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		inflight: make(map[string]*call),
+	}
+}
+
+// Get returns the value stored under key and whether it was found and not expired.
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key, time.Now())
+}
+
+// get looks up a live entry and marks it recently used. Callers must hold c.mu.
+func (c *Cache) get(key string, now time.Time) (interface{}, bool) {
+	element, exists := c.items[key]
+	if !exists {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	item := element.Value.(*entry)
+	if !item.expiresAt.IsZero() && now.After(item.expiresAt) {
+		c.removeElement(element)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	c.stats.Hits++
+	return item.value, true
+}
+
+// Set stores a value with the default TTL.
+func (c *Cache) Set(key string, value interface{}) {
+	c.SetWithTTL(key, value, c.config.DefaultTTL)
+}
+
+// SetWithTTL stores a value that expires after ttl (0 = never).
+// The least recently used entry is evicted when the cache is full.
+func (c *Cache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, ttl)
+}
+
+// set stores value under key, evicting the least recently used entries past
+// MaxEntries. Callers must hold c.mu.
+func (c *Cache) set(key string, value interface{}, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if element, exists := c.items[key]; exists {
+		item := element.Value.(*entry)
+		item.value = value
+		item.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+
+	for c.config.MaxEntries > 0 && c.order.Len() > c.config.MaxEntries {
+		c.removeElement(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Delete removes the entry stored under key.
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, exists := c.items[key]; exists {
+		c.removeElement(element)
+	}
+}
+
+// Clear removes all entries. Metrics are kept.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// Len returns the number of stored entries, including expired entries not yet removed.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns a snapshot of the cache metrics.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+// GetOrLoad returns the cached value for key or calls loader to produce it, caching
+// the result with the default TTL. Concurrent calls for the same key share a single
+// loader call. Loader errors are returned and not cached.
+func (c *Cache) GetOrLoad(ctx context.Context, key string, loader func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return c.GetOrLoadWithTTL(ctx, key, c.config.DefaultTTL, loader)
+}
+
+// GetOrLoadWithTTL is GetOrLoad with an explicit TTL for the loaded value.
+func (c *Cache) GetOrLoadWithTTL(ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if value, found := c.get(key, time.Now()); found {
+		c.mu.Unlock()
+		return value, nil
+	}
+
+	// Another caller is already loading this key; wait for its result.
+	if pending, loading := c.inflight[key]; loading {
+		c.mu.Unlock()
+		select {
+		case <-pending.done:
+			return pending.value, pending.err
+		case <-ctx.Done():
+			return nil, helpers.WrapError(ctx.Err(), "cache load cancelled")
+		}
+	}
+
+	pending := &call{done: make(chan struct{})}
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				pending.err = helpers.CreateErrorf("cache loader panicked: %v", r)
+			}
+		}()
+		pending.value, pending.err = loader(ctx)
+	}()
+
+	// Publish the value and clear the in-flight entry together, so a caller arriving
+	// in between never sees neither and starts a second load.
+	c.mu.Lock()
+	if pending.err != nil {
+		c.stats.LoadErrors++
+	} else {
+		c.stats.Loads++
+		c.set(key, pending.value, ttl)
+	}
+	delete(c.inflight, key)
+	close(pending.done)
+	c.mu.Unlock()
+
+	return pending.value, pending.err
+}
+
+// Cleanup removes all expired entries and returns how many were removed.
+func (c *Cache) Cleanup() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	removed := 0
+	for element := c.order.Back(); element != nil; {
+		previous := element.Prev()
+		item := element.Value.(*entry)
+		if !item.expiresAt.IsZero() && now.After(item.expiresAt) {
+			c.removeElement(element)
+			removed++
+		}
+		element = previous
+	}
+	c.stats.Expirations += uint64(removed)
+	return removed
+}
+
+// StartCleanup removes expired entries every interval in the background until ctx is cancelled.
+// Without it, expired entries are removed lazily on access or by eviction.
+func (c *Cache) StartCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if removed := c.Cleanup(); removed > 0 {
+					log.Info(fmt.Sprintf("🧹 Removed %d expired cache entries", removed))
+				}
+			}
+		}
+	}()
+}
+
+// removeElement unlinks an entry from the list and map. Callers must hold c.mu.
+func (c *Cache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry).key)
+}
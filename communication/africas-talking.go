@@ -4,7 +4,10 @@ package communication
 import (
 	"encoding/json" // json provides functions for JSON encoding and decoding.
 	"fmt"           // fmt provides formatting and printing functions.
+	"time"          // time provides send durations for metrics.
 
+	"github.com/hekimapro/utils/config"  // config provides the default Africa's Talking credentials.
+	"github.com/hekimapro/utils/helpers" // helpers provides default value utilities.
 	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
 	"github.com/hekimapro/utils/models"  // models contains data structures for API payloads and responses.
 	"github.com/hekimapro/utils/request" // request provides utilities for making HTTP requests.
@@ -17,16 +20,16 @@ var ATBaseURL = "https://api.africastalking.com/version1/messaging/bulk"
 // MessageStatusCodes maps Africa's Talking API status codes to human-readable messages.
 // Provides descriptions for common success and error states.
 var MessageStatusCodes = map[int]string{
-	100: "Processed",            // Message has been processed by the API.
-	101: "Sent",                 // Message successfully sent to the recipient.
-	102: "Queued",               // Message queued for sending.
-	401: "RiskHold",             // Message held due to risk checks.
-	402: "InvalidSenderId",      // Invalid sender ID provided.
-	403: "InvalidPhoneNumber",   // Invalid recipient phone number.
+	100: "Processed",             // Message has been processed by the API.
+	101: "Sent",                  // Message successfully sent to the recipient.
+	102: "Queued",                // Message queued for sending.
+	401: "RiskHold",              // Message held due to risk checks.
+	402: "InvalidSenderId",       // Invalid sender ID provided.
+	403: "InvalidPhoneNumber",    // Invalid recipient phone number.
 	404: "UnsupportedNumberType", // Number type not supported by the API.
 	405: "InsufficientBalance",   // Insufficient account balance to send message.
-	406: "UserInBlacklist",      // Recipient is blacklisted.
-	407: "CouldNotRoute",        // Unable to route the message.
+	406: "UserInBlacklist",       // Recipient is blacklisted.
+	407: "CouldNotRoute",         // Unable to route the message.
 	409: "DoNotDisturbRejection", // Message rejected due to Do Not Disturb settings.
 	500: "InternalServerError",   // API server encountered an internal error.
 	501: "GatewayError",          // Error occurred at the gateway.
@@ -46,10 +49,21 @@ func GetStatusMessage(code int) string {
 
 // SendAfricasTalkingSMS sends a bulk SMS request to the Africa's Talking API.
 // Marshals the SMS payload, sends a POST request, and parses the response.
+// Empty credentials and sender ID fall back to the AFRICASTALKING_ values of the shared configuration.
 // Returns the SMS response or an error if the request fails.
-func SendAfricasTalkingSMS(payload *models.ATSMSPayload) (*models.ATSMSResponse, error) {
+func SendAfricasTalkingSMS(payload *models.ATSMSPayload) (_ *models.ATSMSResponse, err error) {
+	defer func(start time.Time) { recordMessage("sms", "africastalking", start, err) }(time.Now())
+
 	var response models.ATSMSResponse
 
+	// Fill missing credentials from the configuration without modifying the caller's payload.
+	africasTalking := config.Get().SMS.AfricasTalking
+	filled := *payload
+	filled.ATAPIKey = helpers.DefaultIfEmpty(filled.ATAPIKey, africasTalking.APIKey)
+	filled.Username = helpers.DefaultIfEmpty(filled.Username, africasTalking.Username)
+	filled.SenderID = helpers.DefaultIfEmpty(filled.SenderID, africasTalking.SenderID)
+	payload = &filled
+
 	// Set API key in request headers for authentication.
 	headers := &request.Headers{
 		"apiKey": payload.ATAPIKey,
@@ -67,4 +81,4 @@ func SendAfricasTalkingSMS(payload *models.ATSMSPayload) (*models.ATSMSResponse,
 		return nil, fmt.Errorf("failed to deserialize response")
 	}
 	return &response, nil
-}
\ No newline at end of file
+}
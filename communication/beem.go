@@ -4,7 +4,10 @@ import (
 	"encoding/base64" // base64 provides functions for encoding authentication credentials.
 	"encoding/json"   // json provides functions for JSON encoding and decoding.
 	"fmt"             // fmt provides formatting and printing functions.
+	"time"            // time provides send durations for metrics.
 
+	"github.com/hekimapro/utils/config"  // config provides the default Beem credentials.
+	"github.com/hekimapro/utils/helpers" // helpers provides default value utilities.
 	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
 	"github.com/hekimapro/utils/models"  // models contains data structures for API payloads and responses.
 	"github.com/hekimapro/utils/request" // request provides utilities for making HTTP requests.
@@ -30,17 +33,28 @@ func createAuthHeader(apiKey, secretKey string) string {
 
 // SendBeemSMS sends an SMS request to the Beem API.
 // Constructs the request payload, sends a POST request, and parses the response.
+// Empty credentials and sender name fall back to the BEEM_ values of the shared configuration.
 // Returns the SMS response or an error if the request fails.
-func SendBeemSMS(payload *models.BeemSMSPayload) (*models.BeemSMSResponse, error) {
+func SendBeemSMS(payload *models.BeemSMSPayload) (_ *models.BeemSMSResponse, err error) {
+	defer func(start time.Time) { recordMessage("sms", "beem", start, err) }(time.Now())
+
 	var response models.BeemSMSResponse
 
+	// Fill missing credentials from the configuration without modifying the caller's payload.
+	beem := config.Get().SMS.Beem
+	filled := *payload
+	filled.APIKey = helpers.DefaultIfEmpty(filled.APIKey, beem.APIKey)
+	filled.SecretKey = helpers.DefaultIfEmpty(filled.SecretKey, beem.SecretKey)
+	filled.SenderName = helpers.DefaultIfEmpty(filled.SenderName, beem.SenderName)
+	payload = &filled
+
 	// Construct the request body with payload details.
 	requestData := models.BeemSMSRequestBody{
 		SourceAddr:   payload.SenderName,   // Sender name for the SMS.
 		ScheduleTime: payload.ScheduleTime, // Optional scheduling time for the SMS.
-		Encoding:     "0",                 // Default encoding (plain text).
-		Message:      payload.Message,     // SMS message content.
-		Recipients:   payload.Recipients,  // List of recipient phone numbers.
+		Encoding:     "0",                  // Default encoding (plain text).
+		Message:      payload.Message,      // SMS message content.
+		Recipients:   payload.Recipients,   // List of recipient phone numbers.
 	}
 
 	// Set Authorization header using API key and secret key.
@@ -92,4 +106,4 @@ func GetDeliveryStatus(payload *models.BeemSMSDeliveryStatusPayload) (*models.Be
 	}
 
 	return &response, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,54 @@
+package communication
+
+import (
+	"context" // context provides cancellation of the batch.
+	"fmt"     // fmt provides formatting and printing functions.
+
+	"github.com/hekimapro/utils/log"    // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models" // models contains data structures for API payloads and responses.
+	"github.com/hekimapro/utils/pool"   // pool provides the bounded concurrent sends.
+)
+
+// BulkSMSWorkers is how many provider requests a bulk send makes at once.
+var BulkSMSWorkers = 5
+
+// SendAfricasTalkingSMSBatch sends several Africa's Talking payloads concurrently, e.g.
+// personalized messages that cannot share one request. Results are in payload order;
+// a failed payload does not stop the others.
+//
+// Example:
+//
+//	results := communication.SendAfricasTalkingSMSBatch(ctx, payloads)
+//	if err := pool.Errors(results); err != nil {
+//	    log.Error(err.Error())
+//	}
+func SendAfricasTalkingSMSBatch(ctx context.Context, payloads []*models.ATSMSPayload) []pool.Result[*models.ATSMSResponse] {
+	log.Info(fmt.Sprintf("📨 Sending %d Africa's Talking SMS payloads", len(payloads)))
+	results := pool.Run(ctx, BulkSMSWorkers, "sms-africastalking", payloads,
+		func(ctx context.Context, payload *models.ATSMSPayload) (*models.ATSMSResponse, error) {
+			return SendAfricasTalkingSMS(payload)
+		})
+	logBatchOutcome("Africa's Talking SMS", pool.Errors(results), len(payloads))
+	return results
+}
+
+// SendBeemSMSBatch sends several Beem payloads concurrently. Results are in payload
+// order; a failed payload does not stop the others.
+func SendBeemSMSBatch(ctx context.Context, payloads []*models.BeemSMSPayload) []pool.Result[*models.BeemSMSResponse] {
+	log.Info(fmt.Sprintf("📨 Sending %d Beem SMS payloads", len(payloads)))
+	results := pool.Run(ctx, BulkSMSWorkers, "sms-beem", payloads,
+		func(ctx context.Context, payload *models.BeemSMSPayload) (*models.BeemSMSResponse, error) {
+			return SendBeemSMS(payload)
+		})
+	logBatchOutcome("Beem SMS", pool.Errors(results), len(payloads))
+	return results
+}
+
+// logBatchOutcome logs the result of a bulk send.
+func logBatchOutcome(kind string, err error, total int) {
+	if err != nil {
+		log.Error(fmt.Sprintf("❌ Some %s payloads failed: %v", kind, err))
+		return
+	}
+	log.Success(fmt.Sprintf("✅ All %d %s payloads sent", total, kind))
+}
@@ -6,16 +6,20 @@ import (
 	"fmt"        // fmt provides formatting and printing functions.
 
 	// io provides I/O interfaces for attachment handling.
-	"mime" // mime provides MIME type detection.
-	// net provides network utilities for custom timeouts.
+	"mime"          // mime provides MIME type detection.
+	"net"           // net provides host and port joining for the SMTP health check.
 	"os"            // os provides file system operations for attachments.
 	"path/filepath" // filepath provides utilities for file path manipulation.
+	"strconv"       // strconv provides port formatting.
 	"strings"       // strings provides utilities for string manipulation.
 	"time"          // time provides functionality for timeouts and durations.
 
+	"github.com/hekimapro/utils/config"  // config provides the shared SMTP configuration.
+	"github.com/hekimapro/utils/health"  // health provides the SMTP health check.
 	"github.com/hekimapro/utils/helpers" // helpers provides utility functions.
 	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
 	"github.com/hekimapro/utils/models"  // models contains data structures for email payloads.
+	"github.com/hekimapro/utils/retry"   // retry provides the send retry loop.
 	"gopkg.in/gomail.v2"                 // gomail provides utilities for sending emails via SMTP.
 )
 
@@ -31,20 +35,32 @@ type EmailConfig struct {
 	RetryDelay         time.Duration // RetryDelay specifies delay between retries
 }
 
-// LoadEmailConfig loads email configuration with defaults.
+// LoadEmailConfig loads email configuration with defaults from the SMTP section
+// of the shared configuration.
 func LoadEmailConfig() EmailConfig {
+	settings := config.Get().SMTP
 	return EmailConfig{
-		SMTPHost:           helpers.GetENVValue("smtp host"),
-		SMTPPort:           helpers.GetENVIntValue("smtp port", 587),
-		Username:           helpers.GetENVValue("smtp username"),
-		Password:           helpers.GetENVValue("smtp password"),
-		InsecureSkipVerify: helpers.GetENVBoolValue("smtp insecure skip verify", false),
-		Timeout:            time.Duration(helpers.GetENVIntValue("email timeout", 30)) * time.Second,
-		MaxRetries:         helpers.GetENVIntValue("email max retries", 3),
-		RetryDelay:         time.Duration(helpers.GetENVIntValue("email retry delay", 2)) * time.Second,
+		SMTPHost:           settings.Host,
+		SMTPPort:           settings.Port,
+		Username:           settings.Username,
+		Password:           settings.Password,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+		Timeout:            settings.Timeout,
+		MaxRetries:         settings.MaxRetries,
+		RetryDelay:         settings.RetryDelay,
 	}
 }
 
+// SMTPHealthCheck returns a health check that succeeds when the configured SMTP
+// server accepts TCP connections.
+//
+// Example:
+//
+//	health.Register(health.Check{Name: "smtp", Check: communication.SMTPHealthCheck(communication.LoadEmailConfig())})
+func SMTPHealthCheck(config EmailConfig) health.CheckFunc {
+	return health.TCPCheck(net.JoinHostPort(config.SMTPHost, strconv.Itoa(config.SMTPPort)))
+}
+
 // validateEmailDetails validates the email details before sending.
 func validateEmailDetails(details models.EmailDetails) error {
 	if details.From == "" {
@@ -70,7 +86,6 @@ func validateEmailDetails(details models.EmailDetails) error {
 	return nil
 }
 
-
 // checkAttachmentExists verifies that attachment files exist and are readable.
 func checkAttachmentExists(attachments []string) error {
 	for _, file := range attachments {
@@ -143,7 +158,9 @@ func createDialerWithTimeout(config EmailConfig) *gomail.Dialer {
 }
 
 // sendEmailWithContext is the internal implementation with context support.
-func sendEmailWithContext(ctx context.Context, config EmailConfig, details models.EmailDetails) error {
+func sendEmailWithContext(ctx context.Context, config EmailConfig, details models.EmailDetails) (err error) {
+	defer func(start time.Time) { recordMessage("email", "smtp", start, err) }(time.Now())
+
 	// Check context cancellation before starting
 	select {
 	case <-ctx.Done():
@@ -321,60 +338,39 @@ func SendEmailWithRetry(config EmailConfig, details models.EmailDetails) error {
 
 // sendEmailWithRetryAndContext is the internal implementation with context support and retry logic.
 func sendEmailWithRetryAndContext(ctx context.Context, config EmailConfig, details models.EmailDetails) error {
-	var lastError error
-
-	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
-		// Check context cancellation before each attempt
-		select {
-		case <-ctx.Done():
-			return helpers.WrapError(ctx.Err(), "email sending with retry cancelled")
-		default:
-			// Continue with attempt
-		}
-
-		// Log retry attempt if not the first attempt
-		if attempt > 0 {
-			log.Warning(fmt.Sprintf("🔄 Email retry attempt %d/%d", attempt, config.MaxRetries))
-			// Wait before retry
-			select {
-			case <-ctx.Done():
-				return helpers.WrapError(ctx.Err(), "email retry cancelled during delay")
-			case <-time.After(config.RetryDelay * time.Duration(attempt)):
-				// Continue with next attempt
-			}
-		}
-
-		// Create a new context for this attempt with the original timeout
-		attemptCtx, attemptCancel := context.WithTimeout(ctx, config.Timeout)
-
-		// Attempt to send email
-		err := sendEmailWithContext(attemptCtx, config, details)
-		attemptCancel()
-
-		if err == nil {
-			// Success
-			if attempt > 0 {
-				log.Success(fmt.Sprintf("✅ Email sent successfully on attempt %d", attempt+1))
-			}
-			return nil
-		}
-
-		lastError = err
-
-		// Check if error is retryable
-		if !isRetryableEmailError(err) {
+	attempts := 0
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		attempts++
+		err := sendEmailWithContext(ctx, config, details)
+		if err != nil && !isRetryableEmailError(err) {
 			log.Warning("⚠️ Non-retryable email error, not retrying")
-			return err
+			return retry.Permanent(err)
 		}
-
-		// Log that we will retry
-		if attempt < config.MaxRetries {
+		return err
+	},
+		retry.WithMaxAttempts(config.MaxRetries+1),
+		retry.WithLinearBackoff(config.RetryDelay, 0),
+		retry.WithAttemptTimeout(config.Timeout),
+		retry.WithOnRetry(func(attempt int, err error, delay time.Duration) {
 			log.Warning(fmt.Sprintf("⚠️ Retryable email error, will retry: %v", err))
+			log.Warning(fmt.Sprintf("🔄 Email retry attempt %d/%d in %v", attempt, config.MaxRetries, delay))
+		}),
+	)
+
+	switch {
+	case err == nil:
+		if attempts > 1 {
+			log.Success(fmt.Sprintf("✅ Email sent successfully on attempt %d", attempts))
 		}
+		return nil
+	case ctx.Err() != nil:
+		return helpers.WrapError(err, "email sending with retry cancelled")
+	case !isRetryableEmailError(err):
+		return err
 	}
 
-	log.Error(fmt.Sprintf("❌ Email sending failed after %d attempts: %v", config.MaxRetries+1, lastError))
-	return helpers.WrapError(lastError, "email sending failed after maximum retries")
+	log.Error(fmt.Sprintf("❌ Email sending failed after %d attempts: %v", attempts, err))
+	return helpers.WrapError(err, "email sending failed after maximum retries")
 }
 
 // isRetryableEmailError checks if an email error is retryable.
@@ -0,0 +1,25 @@
+package communication
+
+import (
+	"time" // time provides send durations.
+
+	"github.com/hekimapro/utils/metrics" // metrics provides message counters and durations.
+)
+
+// Messaging metrics, labelled by channel (email, sms) and provider.
+var (
+	messagesSent = metrics.NewCounter("communication_messages_total",
+		"Messages sent, by channel, provider, and result.", "channel", "provider", "result")
+	sendDuration = metrics.NewHistogram("communication_send_duration_seconds",
+		"Time taken to hand a message to the provider, in seconds.", []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}, "channel", "provider")
+)
+
+// recordMessage records the outcome and latency of one send.
+func recordMessage(channel, provider string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	messagesSent.Inc(channel, provider, result)
+	sendDuration.ObserveDuration(start, channel, provider)
+}
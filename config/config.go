@@ -0,0 +1,186 @@
+// Package config centralizes the environment configuration of the utils modules
+// (server, database, encryption, SMTP, and SMS providers) into typed sections.
+//
+// Configuration is read and validated once, typically at startup with Load, and
+// every problem is reported together. Modules read the cached values with Get
+// instead of parsing environment variables on every call. When env.Watch or
+// env.Reload changes a value, the configuration is reloaded automatically.
+package config
+
+import (
+	"fmt"         // fmt provides formatting and printing functions.
+	"os"          // os provides process exit for MustLoad.
+	"sort"        // sort provides stable error report ordering.
+	"strings"     // strings provides error report formatting.
+	"sync"        // sync guards the one-time reload registration.
+	"sync/atomic" // atomic provides lock-free access to the current configuration.
+
+	"github.com/hekimapro/utils/env" // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// Section names one part of the configuration.
+type Section string
+
+// Configuration sections.
+const (
+	SectionServer     Section = "server"
+	SectionDatabase   Section = "database"
+	SectionEncryption Section = "encryption"
+	SectionSMTP       Section = "smtp"
+	SectionSMS        Section = "sms"
+)
+
+// Sections lists every section in report order.
+var Sections = []Section{SectionServer, SectionDatabase, SectionEncryption, SectionSMTP, SectionSMS}
+
+// Config holds every section. Sections an application does not use may be
+// invalid; their problems are kept and reported by Err instead of failing Load.
+type Config struct {
+	Server     Server     // Server holds the HTTP server settings
+	Database   Database   // Database holds the PostgreSQL connection and pool settings
+	Encryption Encryption // Encryption holds the AES settings and token length
+	SMTP       SMTP       // SMTP holds the outgoing mail settings
+	SMS        SMS        // SMS holds the SMS provider credentials
+
+	problems map[Section]error // problems holds the binding and validation error of each invalid section
+}
+
+// Err returns the problem found in a section, or nil when the section is valid.
+func (c *Config) Err(section Section) error {
+	return c.problems[section]
+}
+
+// Error reports every invalid section found by Load.
+type Error struct {
+	Problems map[Section]error // Problems maps each invalid section to its error
+}
+
+// Error returns a multi-line report with one line per invalid section.
+func (e *Error) Error() string {
+	sections := make([]string, 0, len(e.Problems))
+	for section := range e.Problems {
+		sections = append(sections, string(section))
+	}
+	sort.Strings(sections)
+
+	var report strings.Builder
+	report.WriteString("invalid configuration:")
+	for _, section := range sections {
+		fmt.Fprintf(&report, "\n  %s: %v", section, e.Problems[Section(section)])
+	}
+	return report.String()
+}
+
+// current is the configuration returned by Get.
+var current atomic.Pointer[Config]
+
+// watchOnce registers the reload listener the first time configuration is loaded.
+var watchOnce sync.Once
+
+// read binds and validates every section without publishing the result.
+func read() *Config {
+	config := &Config{problems: make(map[Section]error)}
+
+	binders := map[Section]func() error{
+		SectionServer:     func() error { return bindSection("", &config.Server) },
+		SectionDatabase:   func() error { return bindSection("DATABASE_", &config.Database) },
+		SectionEncryption: func() error { return bindSection("", &config.Encryption) },
+		SectionSMTP:       func() error { return bindSection("", &config.SMTP) },
+		SectionSMS:        func() error { return bindSection("", &config.SMS) },
+	}
+	for section, bind := range binders {
+		if err := bind(); err != nil {
+			config.problems[section] = err
+		}
+	}
+	return config
+}
+
+// validator is implemented by sections with checks beyond required variables.
+type validator interface {
+	validate() error
+}
+
+// bindSection binds one section and, when binding succeeds, validates it.
+func bindSection(prefix string, section validator) error {
+	if err := env.BindWithPrefix(prefix, section); err != nil {
+		return err
+	}
+	return section.validate()
+}
+
+// Load reads and validates every section, makes the result available through Get,
+// and returns an *Error listing the problems in the required sections. Problems in
+// other sections do not fail Load; they are returned by Config.Err when a module
+// uses the section.
+//
+// Example:
+//
+//	cfg, err := config.Load(config.SectionServer, config.SectionDatabase)
+//	if err != nil {
+//	    log.Fatal(err.Error())
+//	}
+//	fmt.Println(cfg.Server.Port)
+func Load(required ...Section) (*Config, error) {
+	config := read()
+	publish(config)
+
+	failed := make(map[Section]error)
+	for _, section := range required {
+		if err := config.Err(section); err != nil {
+			failed[section] = err
+		}
+	}
+	if len(failed) > 0 {
+		return config, &Error{Problems: failed}
+	}
+	return config, nil
+}
+
+// MustLoad behaves like Load but logs the report and exits the process when a
+// required section is invalid.
+func MustLoad(required ...Section) *Config {
+	config, err := Load(required...)
+	if err != nil {
+		log.Error("❌ " + err.Error())
+		os.Exit(1)
+	}
+	return config
+}
+
+// Get returns the current configuration, loading it on first use when Load has
+// not been called. It never fails; check Config.Err for the sections you use.
+func Get() *Config {
+	if config := current.Load(); config != nil {
+		return config
+	}
+	config := read()
+	publish(config)
+	return config
+}
+
+// Reload re-reads every section and replaces the configuration returned by Get.
+// It is called automatically when env.Reload reports changed variables, and logs
+// a warning for each section that was valid before and is invalid now.
+func Reload() *Config {
+	previous := current.Load()
+	config := read()
+	publish(config)
+	for _, section := range Sections {
+		if err := config.Err(section); err != nil && previous != nil && previous.Err(section) == nil {
+			log.Warning(fmt.Sprintf("⚠️ Reloaded %s configuration is invalid: %v", section, err))
+		}
+	}
+	return config
+}
+
+// publish stores the configuration for Get and starts following env reloads.
+func publish(config *Config) {
+	current.Store(config)
+	watchOnce.Do(func() {
+		env.OnChange(func(map[string]string) {
+			Reload()
+		})
+	})
+}
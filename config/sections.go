@@ -0,0 +1,216 @@
+package config
+
+import (
+	"crypto/aes" // aes provides the AES block size for IV validation.
+	"net"        // net provides listener address parsing.
+	"strconv"    // strconv provides port parsing.
+	"strings"    // strings provides parsing of id=key entries.
+	"time"       // time provides timeout and lifetime durations.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error creation utilities.
+	"github.com/hekimapro/utils/models"  // models contains the shared database and encryption options.
+)
+
+// Server holds the HTTP server settings read by the server package.
+type Server struct {
+	Port                  string        `env:"PORT" default:"8080"`                       // Port is the TCP port the server listens on
+	Listeners             []string      `env:"LISTENERS"`                                 // Listeners replaces Port with addresses like ":8080", "127.0.0.1:9090", or "unix:/run/app.sock"
+	SSLKeyPath            string        `env:"SSL_KEY_PATH"`                              // SSLKeyPath is the SSL private key file; HTTPS is used when both SSL paths are set
+	SSLCertPath           string        `env:"SSL_CERT_PATH"`                             // SSLCertPath is the SSL certificate file
+	MetricsEnabled        bool          `env:"METRICS_ENABLED" default:"false"`           // MetricsEnabled exposes Prometheus metrics at MetricsPath
+	MetricsPath           string        `env:"METRICS_PATH" default:"/metrics"`           // MetricsPath is the metrics endpoint path
+	ACMEDomains           []string      `env:"ACME_DOMAINS"`                              // ACMEDomains enables Let's Encrypt certificates for these domains, comma-separated
+	ACMEEmail             string        `env:"ACME_EMAIL"`                                // ACMEEmail is the optional contact for expiry notices
+	ACMECacheDir          string        `env:"ACME_CACHE_DIR" default:"acme-certs"`       // ACMECacheDir stores issued certificates across restarts
+	ACMEHTTPPort          string        `env:"ACME_HTTP_PORT" default:"80"`               // ACMEHTTPPort serves the HTTP-01 challenge; Let's Encrypt requires 80
+	HTTPRedirectPort      string        `env:"HTTP_REDIRECT_PORT"`                        // HTTPRedirectPort redirects plain HTTP to HTTPS when set (e.g. 80)
+	HSTSMaxAge            time.Duration `env:"HSTS_MAX_AGE" default:"0" unit:"s"`         // HSTSMaxAge sends Strict-Transport-Security over HTTPS when positive
+	HSTSIncludeSubdomains bool          `env:"HSTS_INCLUDE_SUBDOMAINS" default:"false"`   // HSTSIncludeSubdomains extends HSTS to every subdomain
+	MaxBodyBytes          int64         `env:"MAX_BODY_BYTES" default:"10485760"`         // MaxBodyBytes limits request bodies (10MB); 0 disables the limit
+	HealthPath            string        `env:"HEALTH_PATH" default:"/health"`             // HealthPath serves the aggregate health report; "-" disables it
+	LivenessPath          string        `env:"LIVENESS_PATH" default:"/healthz"`          // LivenessPath serves the liveness probe; "-" disables it
+	ReadinessPath         string        `env:"READINESS_PATH" default:"/readyz"`          // ReadinessPath serves the readiness probe; "-" disables it
+	ShutdownDrainDelay    time.Duration `env:"SHUTDOWN_DRAIN_DELAY" default:"0" unit:"s"` // ShutdownDrainDelay is how long readiness fails before shutdown starts
+}
+
+// validate checks the port range (0 picks an ephemeral port), that SSL paths are set together, the plain-HTTP ports,
+// and the listener addresses.
+func (s *Server) validate() error {
+	port, err := strconv.Atoi(s.Port)
+	if err != nil || port < 0 || port > 65535 {
+		return helpers.CreateErrorf("PORT must be a number between 0 and 65535, got %q", s.Port)
+	}
+	if (s.SSLKeyPath == "") != (s.SSLCertPath == "") {
+		return helpers.CreateError("SSL_KEY_PATH and SSL_CERT_PATH must be set together")
+	}
+	if len(s.ACMEDomains) > 0 {
+		if port, err := strconv.Atoi(s.ACMEHTTPPort); err != nil || port < 1 || port > 65535 {
+			return helpers.CreateErrorf("ACME_HTTP_PORT must be a number between 1 and 65535, got %q", s.ACMEHTTPPort)
+		}
+		if s.ACMEHTTPPort == s.Port {
+			return helpers.CreateError("ACME_HTTP_PORT must differ from PORT")
+		}
+	}
+	if s.HTTPRedirectPort != "" {
+		if port, err := strconv.Atoi(s.HTTPRedirectPort); err != nil || port < 1 || port > 65535 {
+			return helpers.CreateErrorf("HTTP_REDIRECT_PORT must be a number between 1 and 65535, got %q", s.HTTPRedirectPort)
+		}
+		if s.HTTPRedirectPort == s.Port {
+			return helpers.CreateError("HTTP_REDIRECT_PORT must differ from PORT")
+		}
+	}
+	for _, path := range []string{s.HealthPath, s.LivenessPath, s.ReadinessPath} {
+		if path != "-" && !strings.HasPrefix(path, "/") {
+			return helpers.CreateErrorf("health and probe paths must start with / (or be - to disable), got %q", path)
+		}
+	}
+	for _, address := range s.Listeners {
+		if path, found := strings.CutPrefix(address, "unix:"); found {
+			if path == "" {
+				return helpers.CreateError("LISTENERS unix entries need a socket path, e.g. unix:/run/app.sock")
+			}
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return helpers.CreateErrorf("LISTENERS entries must be host:port or unix:/path, got %q", address)
+		}
+	}
+	if s.ShutdownDrainDelay < 0 {
+		return helpers.CreateErrorf("SHUTDOWN_DRAIN_DELAY must not be negative, got %v", s.ShutdownDrainDelay)
+	}
+	if s.HSTSMaxAge < 0 {
+		return helpers.CreateErrorf("HSTS_MAX_AGE must not be negative, got %v", s.HSTSMaxAge)
+	}
+	return nil
+}
+
+// Database holds the PostgreSQL connection and pool settings, read with the DATABASE_ prefix.
+type Database struct {
+	models.DatabaseOptions               // DatabaseOptions holds the connection credentials (DATABASE_HOST, DATABASE_NAME, ...)
+	MaxIdleConns           int           `env:"MAXIMUM_IDLE_CONNECTIONS" default:"5"`              // MaxIdleConns is the maximum number of idle connections
+	MaxOpenConns           int           `env:"MAXIMUM_OPEN_CONNECTIONS" default:"5"`              // MaxOpenConns is the maximum number of open connections
+	ConnMaxLifetime        time.Duration `env:"CONNECTION_MAXIMUM_LIFETIME" default:"60" unit:"m"` // ConnMaxLifetime is how long a connection may be reused
+	ConnMaxIdleTime        time.Duration `env:"CONNECTION_MAXIMUM_IDLE_TIME" default:"5" unit:"m"` // ConnMaxIdleTime is how long a connection may stay idle
+	ConnectTimeout         time.Duration `env:"CONNECT_TIMEOUT" default:"30" unit:"s"`             // ConnectTimeout bounds establishing the connection
+	PingTimeout            time.Duration `env:"PING_TIMEOUT" default:"10" unit:"s"`                // PingTimeout bounds the connectivity check
+	ConnectAttempts        int           `env:"CONNECT_ATTEMPTS" default:"5"`                      // ConnectAttempts is how many times startup tries to reach the database
+}
+
+// validate checks the pool limits and timeouts.
+func (d *Database) validate() error {
+	if d.MaxIdleConns < 0 || d.MaxOpenConns < 0 {
+		return helpers.CreateError("DATABASE_MAXIMUM_IDLE_CONNECTIONS and DATABASE_MAXIMUM_OPEN_CONNECTIONS cannot be negative")
+	}
+	if d.ConnectTimeout <= 0 || d.PingTimeout <= 0 {
+		return helpers.CreateError("DATABASE_CONNECT_TIMEOUT and DATABASE_PING_TIMEOUT must be positive")
+	}
+	if d.ConnectAttempts < 1 {
+		return helpers.CreateError("DATABASE_CONNECT_ATTEMPTS must be at least 1")
+	}
+	return nil
+}
+
+// Encryption holds the cipher settings read by the encryption package.
+type Encryption struct {
+	models.EncryptionConfig               // EncryptionConfig holds ENCRYPTION_KEY, ENCRYPTION_TYPE, INITIALIZATION_VECTOR, and ENCRYPTION_ALGORITHM
+	RefreshTokenLength      int           `env:"REFRESH_TOKEN_LENGTH" default:"12"`            // RefreshTokenLength is the random byte length of refresh tokens
+	PreviousKeys            []string      `env:"ENCRYPTION_PREVIOUS_KEYS"`                     // PreviousKeys lists retired keys as id=key, still accepted for decryption
+	PasswordHashTarget      time.Duration `env:"PASSWORD_HASH_TARGET" default:"250" unit:"ms"` // PasswordHashTarget is the bcrypt hashing time GetRecommendedCost aims for
+}
+
+// validate checks the algorithm, the key and IV lengths, and the output encoding.
+func (e *Encryption) validate() error {
+	switch e.Algorithm {
+	case "aes-cbc", "":
+		if keyLength := len(e.EncryptionKey); keyLength != 16 && keyLength != 24 && keyLength != 32 {
+			return helpers.CreateErrorf("ENCRYPTION_KEY must be 16, 24, or 32 bytes long, got %d bytes", keyLength)
+		}
+		if len(e.InitializationVector) != aes.BlockSize {
+			return helpers.CreateErrorf("INITIALIZATION_VECTOR must be exactly %d bytes long", aes.BlockSize)
+		}
+	case "chacha20-poly1305":
+		if keyLength := len(e.EncryptionKey); keyLength != 32 {
+			return helpers.CreateErrorf("ENCRYPTION_KEY must be 32 bytes long for chacha20-poly1305, got %d bytes", keyLength)
+		}
+	default:
+		return helpers.CreateErrorf("ENCRYPTION_ALGORITHM must be 'aes-cbc' or 'chacha20-poly1305', got %q", e.Algorithm)
+	}
+	if e.EncryptionType != "base64" && e.EncryptionType != "base64url" && e.EncryptionType != "hex" {
+		return helpers.CreateErrorf("ENCRYPTION_TYPE must be 'base64', 'base64url', or 'hex', got %q", e.EncryptionType)
+	}
+	if e.RefreshTokenLength < 1 {
+		return helpers.CreateErrorf("REFRESH_TOKEN_LENGTH must be positive, got %d", e.RefreshTokenLength)
+	}
+	if e.PasswordHashTarget <= 0 {
+		return helpers.CreateErrorf("PASSWORD_HASH_TARGET must be positive, got %v", e.PasswordHashTarget)
+	}
+	for _, entry := range e.PreviousKeys {
+		if id, key, found := strings.Cut(entry, "="); !found || id == "" || key == "" {
+			return helpers.CreateError("ENCRYPTION_PREVIOUS_KEYS entries must be id=key")
+		} else if id == e.KeyID {
+			return helpers.CreateErrorf("ENCRYPTION_PREVIOUS_KEYS reuses the current ENCRYPTION_KEY_ID %q", id)
+		}
+	}
+	return nil
+}
+
+// SMTP holds the outgoing mail settings read by the communication package.
+type SMTP struct {
+	Host               string        `env:"SMTP_HOST" required:"true"`                 // Host is the SMTP server hostname
+	Port               int           `env:"SMTP_PORT" default:"587"`                   // Port is the SMTP server port
+	Username           string        `env:"SMTP_USERNAME"`                             // Username for SMTP authentication
+	Password           string        `env:"SMTP_PASSWORD"`                             // Password for SMTP authentication
+	InsecureSkipVerify bool          `env:"SMTP_INSECURE_SKIP_VERIFY" default:"false"` // InsecureSkipVerify disables TLS certificate verification
+	Timeout            time.Duration `env:"EMAIL_TIMEOUT" default:"30" unit:"s"`       // Timeout bounds one send
+	MaxRetries         int           `env:"EMAIL_MAX_RETRIES" default:"3"`             // MaxRetries is the number of retries for transient failures
+	RetryDelay         time.Duration `env:"EMAIL_RETRY_DELAY" default:"2" unit:"s"`    // RetryDelay is the delay between retries
+}
+
+// validate checks the port range and retry count.
+func (s *SMTP) validate() error {
+	if s.Port < 1 || s.Port > 65535 {
+		return helpers.CreateErrorf("SMTP_PORT must be between 1 and 65535, got %d", s.Port)
+	}
+	if s.MaxRetries < 0 {
+		return helpers.CreateErrorf("EMAIL_MAX_RETRIES cannot be negative, got %d", s.MaxRetries)
+	}
+	return nil
+}
+
+// BeemSMS holds the Beem Africa credentials.
+type BeemSMS struct {
+	APIKey     string `env:"API_KEY"`     // APIKey is the Beem API key
+	SecretKey  string `env:"SECRET_KEY"`  // SecretKey is the Beem secret key
+	SenderName string `env:"SENDER_NAME"` // SenderName is the default registered sender name
+}
+
+// AfricasTalkingSMS holds the Africa's Talking credentials.
+type AfricasTalkingSMS struct {
+	APIKey   string `env:"API_KEY"`   // APIKey is the Africa's Talking API key
+	Username string `env:"USERNAME"`  // Username is the Africa's Talking account username
+	SenderID string `env:"SENDER_ID"` // SenderID is the default sender ID
+}
+
+// SMS holds the credentials of the SMS providers. Payloads sent without
+// credentials fall back to these values.
+type SMS struct {
+	Beem           BeemSMS           `prefix:"BEEM_"`           // Beem holds BEEM_API_KEY, BEEM_SECRET_KEY, and BEEM_SENDER_NAME
+	AfricasTalking AfricasTalkingSMS `prefix:"AFRICASTALKING_"` // AfricasTalking holds AFRICASTALKING_API_KEY, AFRICASTALKING_USERNAME, and AFRICASTALKING_SENDER_ID
+}
+
+// validate checks that at least one provider is configured and that credentials are complete.
+func (s *SMS) validate() error {
+	beem := s.Beem.APIKey != "" || s.Beem.SecretKey != ""
+	africasTalking := s.AfricasTalking.APIKey != "" || s.AfricasTalking.Username != ""
+
+	if !beem && !africasTalking {
+		return helpers.CreateError("no SMS provider configured (set BEEM_API_KEY or AFRICASTALKING_API_KEY)")
+	}
+	if beem && (s.Beem.APIKey == "" || s.Beem.SecretKey == "") {
+		return helpers.CreateError("BEEM_API_KEY and BEEM_SECRET_KEY must be set together")
+	}
+	if africasTalking && (s.AfricasTalking.APIKey == "" || s.AfricasTalking.Username == "") {
+		return helpers.CreateError("AFRICASTALKING_API_KEY and AFRICASTALKING_USERNAME must be set together")
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Package csv streams structs to and from CSV files using struct tags, reporting
+// row-level validation errors on import and supporting HTTP upload and download.
+package csv
+
+import (
+	"encoding" // encoding provides the text marshaling interfaces.
+	"fmt"      // fmt provides formatting and printing functions.
+	"reflect"  // reflect provides struct field mapping.
+	"strconv"  // strconv provides value conversion.
+	"strings"  // strings provides header matching.
+	"sync"     // sync caches struct field mappings.
+	"time"     // time provides date and duration handling.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// Struct tags understood by the reader and writer.
+const (
+	tagName     = "csv"      // tagName names the column for a field; "-" skips it
+	tagRequired = "required" // tagRequired rejects rows with an empty value for the field
+	tagFormat   = "format"   // tagFormat sets the time layout for time.Time fields (default RFC 3339)
+)
+
+// Options controls the CSV dialect and import limits.
+type Options struct {
+	Comma     rune // Comma is the field delimiter (default ',')
+	BOM       bool // BOM writes a UTF-8 byte order mark so Excel detects the encoding
+	MaxErrors int  // MaxErrors stops an import after this many invalid rows (default 100)
+}
+
+// comma returns the configured delimiter or the default.
+func (o Options) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+// maxErrors returns the configured error limit or the default.
+func (o Options) maxErrors() int {
+	if o.MaxErrors <= 0 {
+		return 100
+	}
+	return o.MaxErrors
+}
+
+// utf8BOM is the UTF-8 byte order mark written for Excel and stripped on import.
+const utf8BOM = "\uFEFF"
+
+// column maps a CSV column to a struct field.
+type column struct {
+	name     string
+	index    []int
+	required bool
+	format   string
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	columnCache         sync.Map // map[reflect.Type][]column
+)
+
+// structType returns the struct type behind T, which may be a struct or a pointer to one.
+func structType[T any]() (reflect.Type, error) {
+	recordType := reflect.TypeOf((*T)(nil)).Elem()
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType.Kind() != reflect.Struct {
+		return nil, helpers.CreateErrorf("csv: record type must be a struct, got %s", recordType)
+	}
+	return recordType, nil
+}
+
+// columnsOf returns the columns for a struct type in field order, including embedded structs.
+func columnsOf(recordType reflect.Type) []column {
+	if cached, ok := columnCache.Load(recordType); ok {
+		return cached.([]column)
+	}
+	columns := collectColumns(recordType, nil)
+	columnCache.Store(recordType, columns)
+	return columns
+}
+
+// collectColumns walks exported fields, recursing into untagged embedded structs.
+func collectColumns(recordType reflect.Type, parent []int) []column {
+	var columns []column
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		index := append(append([]int{}, parent...), i)
+		name, tagged := field.Tag.Lookup(tagName)
+		if name == "-" {
+			continue
+		}
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, collectColumns(field.Type, index)...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		required, _ := strconv.ParseBool(field.Tag.Get(tagRequired))
+		columns = append(columns, column{
+			name:     name,
+			index:    index,
+			required: required,
+			format:   field.Tag.Get(tagFormat),
+		})
+	}
+	return columns
+}
+
+// normalizeHeader prepares a header cell for case-insensitive matching.
+func normalizeHeader(header string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, utf8BOM)))
+}
+
+// formatValue converts a field value to its CSV text.
+func formatValue(value reflect.Value, format string) (string, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Type() == timeType:
+		t := value.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(helpers.DefaultIfEmpty(format, time.RFC3339)), nil
+	case value.Type() == durationType:
+		return time.Duration(value.Int()).String(), nil
+	case value.Type().Implements(textMarshalerType):
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	case value.CanAddr() && value.Addr().Type().Implements(textMarshalerType):
+		text, err := value.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %s", value.Type())
+	}
+}
+
+// parseValue parses raw CSV text into a field value. Empty text leaves the zero value.
+func parseValue(raw string, value reflect.Value, format string) error {
+	if raw == "" {
+		return nil
+	}
+
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Type() == timeType:
+		parsed, err := time.Parse(helpers.DefaultIfEmpty(format, time.RFC3339), raw)
+		if err != nil {
+			return fmt.Errorf("invalid date, expected format %s", helpers.DefaultIfEmpty(format, time.RFC3339))
+		}
+		value.Set(reflect.ValueOf(parsed))
+		return nil
+	case value.Type() == durationType:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		value.SetInt(int64(parsed))
+		return nil
+	case value.Addr().Type().Implements(textUnmarshalerType):
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := parseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.ReplaceAll(raw, ",", ""), 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(strings.ReplaceAll(raw, ",", ""), 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", ""), value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+	return nil
+}
+
+// parseBool accepts the spellings spreadsheet users commonly type.
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(raw) {
+	case "1", "t", "true", "y", "yes", "ndiyo":
+		return true, nil
+	case "0", "f", "false", "n", "no", "hapana":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %q", raw)
+	}
+}
@@ -0,0 +1,145 @@
+package csv
+
+import (
+	"errors"   // errors provides error inspection.
+	"io"       // io provides streaming between readers and writers.
+	"mime"     // mime provides Content-Type and Content-Disposition handling.
+	"net/http" // http provides request and response handling.
+	"strings"  // strings provides file name handling.
+
+	"github.com/hekimapro/utils/file"    // file provides saving exports to the upload directory.
+	"github.com/hekimapro/utils/helpers" // helpers provides error and JSON response utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// SetDownloadHeaders marks the response as a CSV attachment with the given file name.
+func SetDownloadHeaders(w http.ResponseWriter, filename string) {
+	if !strings.HasSuffix(strings.ToLower(filename), ".csv") {
+		filename += ".csv"
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
+
+// NewDownload sets download headers and returns a writer that streams rows
+// straight to the response, for exports too large to build in memory.
+// A UTF-8 BOM is always written so Excel shows non-ASCII text correctly.
+//
+// Example:
+//
+//	writer, err := csv.NewDownload[Customer](w, "customers.csv")
+//	for rows.Next() {
+//	    ...scan customer...
+//	    writer.Write(customer)
+//	}
+//	writer.Flush()
+func NewDownload[T any](w http.ResponseWriter, filename string) (*Writer[T], error) {
+	writer, err := NewWriter[T](w, Options{BOM: true})
+	if err != nil {
+		return nil, err
+	}
+	SetDownloadHeaders(w, filename)
+	return writer, nil
+}
+
+// Download writes records to the response as a CSV attachment.
+func Download[T any](w http.ResponseWriter, filename string, records []T) error {
+	writer, err := NewDownload[T](w, filename)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		log.Error("❌ CSV download failed: " + err.Error())
+		return err
+	}
+	return nil
+}
+
+// Save writes records to a CSV file in uploadDirectory using the file package.
+// Returns the unique filename generated for the export.
+func Save[T any](records []T, name, uploadDirectory string) (string, error) {
+	reader, pipe := io.Pipe()
+	writer, err := NewWriter[T](pipe, Options{BOM: true})
+	if err != nil {
+		return "", err
+	}
+	go func() {
+		pipe.CloseWithError(writer.WriteAll(records))
+	}()
+
+	filename, err := file.UploadFile(reader, strings.TrimSuffix(name, ".csv")+".csv", uploadDirectory, false)
+	reader.Close()
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to save CSV export")
+	}
+	return filename, nil
+}
+
+// uploadReader returns the CSV content of a request: either the named multipart
+// file field or, for text/csv requests, the raw body. Multipart parts are read
+// as a stream, so uploads are never buffered in memory or on disk.
+func uploadReader(r *http.Request, fieldName string) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" || mediaType == "text/plain" || mediaType == "application/csv" {
+		return r.Body, nil
+	}
+
+	multipartReader, err := r.MultipartReader()
+	if err != nil {
+		return nil, helpers.WrapError(err, "expected a multipart upload or a text/csv body")
+	}
+	for {
+		part, err := multipartReader.NextPart()
+		if err == io.EOF {
+			return nil, helpers.CreateErrorf("no file uploaded in field %q", fieldName)
+		}
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to read upload")
+		}
+		if part.FormName() == fieldName {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// ImportRequest imports CSV rows uploaded in an HTTP request; see Import and uploadReader.
+func ImportRequest[T any](r *http.Request, fieldName string, options Options, handle func(record T) error) (*ImportResult, error) {
+	reader, err := uploadReader(r, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	return Import(reader, options, handle)
+}
+
+// ImportHandler returns an HTTP handler that imports an uploaded CSV file from
+// the "file" form field (or a text/csv body) and responds with the ImportResult
+// as JSON: 200 when every row was imported, 422 when some rows failed, and 400
+// when the file could not be read.
+//
+// Example:
+//
+//	mux.Handle("POST /customers/import", csv.ImportHandler(func(r *http.Request, customer Customer) error {
+//	    return saveCustomer(r.Context(), customer)
+//	}))
+func ImportHandler[T any](handle func(r *http.Request, record T) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := ImportRequest(r, "file", Options{}, func(record T) error {
+			return handle(r, record)
+		})
+		if err != nil && !errors.Is(err, ErrTooManyErrors) {
+			log.Error("❌ CSV import failed: " + err.Error())
+			helpers.RespondWithJSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if result.Failed > 0 {
+			log.Warning("⚠️ CSV import completed with invalid rows")
+			helpers.RespondWithJSON(w, http.StatusUnprocessableEntity, result)
+			return
+		}
+
+		log.Success("✅ CSV import completed")
+		helpers.RespondWithJSON(w, http.StatusOK, result)
+	}
+}
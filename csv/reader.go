@@ -0,0 +1,255 @@
+package csv
+
+import (
+	stdcsv "encoding/csv" // stdcsv provides CSV decoding.
+	"errors"              // errors provides sentinel errors and error inspection.
+	"fmt"                 // fmt provides formatting and printing functions.
+	"io"                  // io provides the input reader.
+	"reflect"             // reflect provides struct field access.
+	"strings"             // strings provides error message assembly.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// ErrTooManyErrors is returned by Import when MaxErrors invalid rows have been seen.
+var ErrTooManyErrors = errors.New("csv: too many invalid rows")
+
+// Validator is implemented by record types that check their own values after parsing.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes a single invalid cell.
+type FieldError struct {
+	Column  string `json:"column"`  // Column is the header name
+	Value   string `json:"value"`   // Value is the raw cell text
+	Message string `json:"message"` // Message explains the problem
+}
+
+// RowError describes an invalid row. Line is the 1-based line number in the file,
+// so users can find the row in their spreadsheet.
+type RowError struct {
+	Line   int          `json:"line"`             // Line is the line number in the file
+	Fields []FieldError `json:"fields,omitempty"` // Fields lists invalid cells
+	Reason string       `json:"reason,omitempty"` // Reason describes row-level failures, e.g. from Validate or the import handler
+}
+
+// Error returns the string representation of the row error.
+func (e *RowError) Error() string {
+	var parts []string
+	for _, field := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", field.Column, field.Message))
+	}
+	if e.Reason != "" {
+		parts = append(parts, e.Reason)
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, strings.Join(parts, "; "))
+}
+
+// Reader streams CSV rows into records of type T, matching columns by header name.
+type Reader[T any] struct {
+	reader    *stdcsv.Reader
+	columns   []column
+	positions []int // positions[i] is the CSV column for columns[i], or -1 when absent
+	line      int
+}
+
+// NewReader reads the header row and maps it to the fields of T.
+// Headers match csv tags case-insensitively; extra columns are ignored, and a
+// missing column for a required field is an error.
+//
+// Example:
+//
+//	reader, err := csv.NewReader[Customer](file, csv.Options{})
+//	for {
+//	    customer, err := reader.Read()
+//	    if err == io.EOF { break }
+//	    var rowErr *csv.RowError
+//	    if errors.As(err, &rowErr) { ...skip or report... }
+//	}
+func NewReader[T any](r io.Reader, options Options) (*Reader[T], error) {
+	recordType, err := structType[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := stdcsv.NewReader(r)
+	reader.Comma = options.comma()
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, helpers.CreateError("CSV file is empty")
+	}
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to read CSV header")
+	}
+
+	headerIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		headerIndex[normalizeHeader(name)] = i
+	}
+
+	columns := columnsOf(recordType)
+	positions := make([]int, len(columns))
+	var missing []string
+	for i, column := range columns {
+		position, exists := headerIndex[normalizeHeader(column.name)]
+		if !exists {
+			position = -1
+			if column.required {
+				missing = append(missing, column.name)
+			}
+		}
+		positions[i] = position
+	}
+	if len(missing) > 0 {
+		return nil, helpers.CreateErrorf("CSV file is missing required column(s): %s", strings.Join(missing, ", "))
+	}
+
+	return &Reader[T]{reader: reader, columns: columns, positions: positions, line: 1}, nil
+}
+
+// Line returns the file line number of the last row read.
+func (r *Reader[T]) Line() int {
+	return r.line
+}
+
+// Read returns the next record. It returns io.EOF at the end of the input,
+// a *RowError when the row is invalid (reading may continue), and any other
+// error when the file itself is malformed.
+func (r *Reader[T]) Read() (T, error) {
+	var record T
+
+	for {
+		row, err := r.reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return record, io.EOF
+			}
+			var parseErr *stdcsv.ParseError
+			if errors.As(err, &parseErr) {
+				r.line = parseErr.StartLine
+			}
+			return record, helpers.WrapError(err, "malformed CSV")
+		}
+		r.line, _ = r.reader.FieldPos(0)
+
+		if isBlank(row) {
+			continue
+		}
+		return r.decode(row)
+	}
+}
+
+// decode maps one row onto a new record.
+func (r *Reader[T]) decode(row []string) (T, error) {
+	var record T
+	value := reflect.ValueOf(&record).Elem()
+	if value.Kind() == reflect.Ptr {
+		value.Set(reflect.New(value.Type().Elem()))
+		value = value.Elem()
+	}
+
+	rowErr := &RowError{Line: r.line}
+	for i, column := range r.columns {
+		raw := ""
+		if position := r.positions[i]; position >= 0 && position < len(row) {
+			raw = strings.TrimSpace(row[position])
+		}
+
+		if raw == "" {
+			if column.required {
+				rowErr.Fields = append(rowErr.Fields, FieldError{Column: column.name, Message: "is required"})
+			}
+			continue
+		}
+
+		field, err := value.FieldByIndexErr(column.index)
+		if err != nil {
+			continue
+		}
+		if err := parseValue(raw, field, column.format); err != nil {
+			rowErr.Fields = append(rowErr.Fields, FieldError{Column: column.name, Value: raw, Message: err.Error()})
+		}
+	}
+
+	if len(rowErr.Fields) == 0 {
+		// The pointer's method set covers both value and pointer receivers.
+		if validator, ok := value.Addr().Interface().(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				rowErr.Reason = err.Error()
+			}
+		}
+	}
+
+	if len(rowErr.Fields) > 0 || rowErr.Reason != "" {
+		return record, rowErr
+	}
+	return record, nil
+}
+
+// isBlank reports whether every cell in the row is empty.
+func isBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportResult summarises an import.
+type ImportResult struct {
+	Imported int        `json:"imported"`         // Imported counts rows accepted by the handler
+	Failed   int        `json:"failed"`           // Failed counts invalid or rejected rows
+	Errors   []RowError `json:"errors,omitempty"` // Errors describes each failed row, up to MaxErrors
+}
+
+// Import reads every row, passing valid records to handle. Invalid rows and rows
+// rejected by handle are recorded in the result instead of stopping the import.
+// It stops with ErrTooManyErrors after options.MaxErrors failures, and returns
+// other errors only when the file cannot be read.
+//
+// Example:
+//
+//	result, err := csv.Import(file, csv.Options{}, func(customer Customer) error {
+//	    return saveCustomer(ctx, customer)
+//	})
+func Import[T any](r io.Reader, options Options, handle func(record T) error) (*ImportResult, error) {
+	reader, err := NewReader[T](r, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+
+		var rowErr *RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return result, err
+		}
+		if rowErr == nil {
+			if handleErr := handle(record); handleErr != nil {
+				rowErr = &RowError{Line: reader.Line(), Reason: handleErr.Error()}
+			}
+		}
+
+		if rowErr == nil {
+			result.Imported++
+			continue
+		}
+
+		result.Failed++
+		result.Errors = append(result.Errors, *rowErr)
+		if result.Failed >= options.maxErrors() {
+			return result, ErrTooManyErrors
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package csv
+
+import (
+	"database/sql"        // sql provides streaming export of query results.
+	stdcsv "encoding/csv" // stdcsv provides CSV encoding.
+	"io"                  // io provides the output writer.
+	"reflect"             // reflect provides struct field access.
+	"strconv"             // strconv provides number formatting.
+	"time"                // time provides timestamp formatting.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// Writer streams records of type T as CSV rows, writing the header before the first row.
+type Writer[T any] struct {
+	writer        *stdcsv.Writer
+	output        io.Writer
+	columns       []column
+	bom           bool
+	headerWritten bool
+	record        []string
+}
+
+// NewWriter creates a writer for struct type T (or a pointer to one).
+// Columns follow field order and use the csv tag as the header.
+//
+// Example:
+//
+//	type Customer struct {
+//	    Name    string    `csv:"name"`
+//	    Phone   string    `csv:"phone"`
+//	    Joined  time.Time `csv:"joined" format:"2006-01-02"`
+//	}
+//	writer, err := csv.NewWriter[Customer](file, csv.Options{BOM: true})
+//	for _, customer := range customers {
+//	    if err := writer.Write(customer); err != nil { ... }
+//	}
+//	err = writer.Flush()
+func NewWriter[T any](w io.Writer, options Options) (*Writer[T], error) {
+	recordType, err := structType[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	writer := stdcsv.NewWriter(w)
+	writer.Comma = options.comma()
+	columns := columnsOf(recordType)
+
+	return &Writer[T]{
+		writer:  writer,
+		output:  w,
+		columns: columns,
+		bom:     options.BOM,
+		record:  make([]string, len(columns)),
+	}, nil
+}
+
+// writeHeader writes the optional BOM and the header row once.
+func (w *Writer[T]) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	if w.bom {
+		if _, err := io.WriteString(w.output, utf8BOM); err != nil {
+			return helpers.WrapError(err, "failed to write CSV byte order mark")
+		}
+	}
+
+	for i, column := range w.columns {
+		w.record[i] = column.name
+	}
+	if err := w.writer.Write(w.record); err != nil {
+		return helpers.WrapError(err, "failed to write CSV header")
+	}
+	return nil
+}
+
+// Write writes one record.
+func (w *Writer[T]) Write(record T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(&record).Elem()
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return helpers.CreateError("csv: cannot write a nil record")
+		}
+		value = value.Elem()
+	}
+
+	for i, column := range w.columns {
+		field, err := value.FieldByIndexErr(column.index)
+		if err != nil {
+			// A nil embedded pointer leaves the column empty.
+			w.record[i] = ""
+			continue
+		}
+		text, err := formatValue(field, column.format)
+		if err != nil {
+			return helpers.WrapErrorf(err, "failed to format column %s", column.name)
+		}
+		w.record[i] = text
+	}
+
+	if err := w.writer.Write(w.record); err != nil {
+		return helpers.WrapError(err, "failed to write CSV row")
+	}
+	return nil
+}
+
+// WriteAll writes every record and flushes.
+func (w *Writer[T]) WriteAll(records []T) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush writes any buffered data, including the header when no rows were written.
+func (w *Writer[T]) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return helpers.WrapError(err, "failed to flush CSV output")
+	}
+	return nil
+}
+
+// WriteRows streams SQL query results as CSV, using the column names as the header.
+// Rows are read and written one at a time, so large result sets are not held in memory.
+// Returns the number of data rows written. The caller still closes rows.
+//
+// Example:
+//
+//	rows, err := database.QueryWithContext(ctx, db, "SELECT id, name, created_at FROM customers")
+//	defer rows.Close()
+//	count, err := csv.WriteRows(w, rows, csv.Options{})
+func WriteRows(w io.Writer, rows *sql.Rows, options Options) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to read result columns")
+	}
+
+	if options.BOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return 0, helpers.WrapError(err, "failed to write CSV byte order mark")
+		}
+	}
+
+	writer := stdcsv.NewWriter(w)
+	writer.Comma = options.comma()
+	if err := writer.Write(columns); err != nil {
+		return 0, helpers.WrapError(err, "failed to write CSV header")
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return count, helpers.WrapError(err, "failed to scan row")
+		}
+		for i, value := range values {
+			record[i] = sqlValueString(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return count, helpers.WrapError(err, "failed to write CSV row")
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, helpers.WrapError(err, "failed to iterate rows")
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return count, helpers.WrapError(err, "failed to flush CSV output")
+	}
+	return count, nil
+}
+
+// sqlValueString converts a scanned database value to CSV text.
+func sqlValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		text, _ := formatValue(reflect.ValueOf(v), "")
+		return text
+	}
+}
@@ -9,31 +9,41 @@ import (
 	"strings" // strings provides utilities for string manipulation.
 	"time"    // time provides functionality for handling connection timeouts.
 
+	"github.com/hekimapro/utils/config" // config provides the shared database configuration.
 	"github.com/hekimapro/utils/helpers"
 	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
 	"github.com/hekimapro/utils/models"
-	_ "github.com/lib/pq" // pq registers the PostgreSQL driver.
+	"github.com/hekimapro/utils/retry" // retry provides the startup connection attempts.
+	_ "github.com/lib/pq"              // pq registers the PostgreSQL driver.
 )
 
+// logger is the database package logger; LOG_LEVEL_DATABASE sets its level apart from the app's.
+var logger = log.Named("database")
+
 // DatabaseConfig holds configuration for database connection and connection pooling.
+// Fields are bound from DATABASE_-prefixed environment variables.
 type DatabaseConfig struct {
-	MaxIdleConns    int           // MaxIdleConns sets the maximum number of connections in the idle connection pool
-	MaxOpenConns    int           // MaxOpenConns sets the maximum number of open connections to the database
-	ConnMaxLifetime time.Duration // ConnMaxLifetime sets the maximum amount of time a connection may be reused
-	ConnMaxIdleTime time.Duration // ConnMaxIdleTime sets the maximum amount of time a connection may be idle
-	ConnectTimeout  time.Duration // ConnectTimeout sets the maximum time for establishing connection
-	PingTimeout     time.Duration // PingTimeout sets the maximum time for ping operations
+	MaxIdleConns    int           `env:"MAXIMUM_IDLE_CONNECTIONS" default:"5"`              // MaxIdleConns sets the maximum number of connections in the idle connection pool
+	MaxOpenConns    int           `env:"MAXIMUM_OPEN_CONNECTIONS" default:"5"`              // MaxOpenConns sets the maximum number of open connections to the database
+	ConnMaxLifetime time.Duration `env:"CONNECTION_MAXIMUM_LIFETIME" default:"60" unit:"m"` // ConnMaxLifetime sets the maximum amount of time a connection may be reused
+	ConnMaxIdleTime time.Duration `env:"CONNECTION_MAXIMUM_IDLE_TIME" default:"5" unit:"m"` // ConnMaxIdleTime sets the maximum amount of time a connection may be idle
+	ConnectTimeout  time.Duration `env:"CONNECT_TIMEOUT" default:"30" unit:"s"`             // ConnectTimeout sets the maximum time for establishing connection
+	PingTimeout     time.Duration `env:"PING_TIMEOUT" default:"10" unit:"s"`                // PingTimeout sets the maximum time for ping operations
+	ConnectAttempts int           `env:"CONNECT_ATTEMPTS" default:"5"`                      // ConnectAttempts sets how many times startup tries to reach the database
 }
 
-// LoadDatabaseConfig loads database configuration with defaults from environment variables.
+// LoadDatabaseConfig returns the pool settings from the database section of the
+// shared configuration. Invalid values are replaced by their defaults.
 func LoadDatabaseConfig() DatabaseConfig {
+	settings := config.Get().Database
 	return DatabaseConfig{
-		MaxIdleConns:    helpers.GetENVIntValue("database maximum idle connections", 5),
-		MaxOpenConns:    helpers.GetENVIntValue("database maximum open connections", 5),
-		ConnMaxLifetime: time.Duration(helpers.GetENVIntValue("database connection maximum lifetime", 60)) * time.Minute,
-		ConnMaxIdleTime: time.Duration(helpers.GetENVIntValue("database connection maximum idle time", 5)) * time.Minute,
-		ConnectTimeout:  time.Duration(helpers.GetENVIntValue("database connect timeout", 30)) * time.Second,
-		PingTimeout:     time.Duration(helpers.GetENVIntValue("database ping timeout", 10)) * time.Second,
+		MaxIdleConns:    settings.MaxIdleConns,
+		MaxOpenConns:    settings.MaxOpenConns,
+		ConnMaxLifetime: settings.ConnMaxLifetime,
+		ConnMaxIdleTime: settings.ConnMaxIdleTime,
+		ConnectTimeout:  settings.ConnectTimeout,
+		PingTimeout:     settings.PingTimeout,
+		ConnectAttempts: settings.ConnectAttempts,
 	}
 }
 
@@ -89,24 +99,23 @@ func connectToDatabaseWithContext(ctx context.Context) (*sql.DB, error) {
 		// Continue with connection
 	}
 
-	databaseOptions := models.DatabaseOptions{
-		Host:         helpers.GetENVValue("database host"),
-		Port:         helpers.GetENVValue("database port"),
-		DatabaseName: helpers.GetENVValue("database name"),
-		Username:     helpers.GetENVValue("database username"),
-		Password:     helpers.GetENVValue("database password"),
-		SSLMode:      helpers.GetENVValue("database ssl mode"),
-	}
-
-	log.Info("🔌 Starting database connection process")
+	logger.Info("🔌 Starting database connection process")
 
 	// Warn about beginning validation
-	log.Warning("⚠️ Validating database options")
+	logger.Warning("⚠️ Validating database options")
 
-	// Validate required fields
+	// Read connection options from the database section, which reports every missing value at once
+	settings := config.Get()
+	if err := settings.Err(config.SectionDatabase); err != nil {
+		logger.Error(fmt.Sprintf("❌ Invalid database configuration: %v", err))
+		return nil, retry.Permanent(err)
+	}
+	databaseOptions := settings.Database.DatabaseOptions
+
+	// Validate required fields are not just whitespace
 	if err := validateDatabaseOptions(databaseOptions); err != nil {
-		log.Error(fmt.Sprintf("❌ Invalid database configuration: %v", err))
-		return nil, err
+		logger.Error(fmt.Sprintf("❌ Invalid database configuration: %v", err))
+		return nil, retry.Permanent(err)
 	}
 
 	// Check context cancellation after validation
@@ -121,10 +130,10 @@ func connectToDatabaseWithContext(ctx context.Context) (*sql.DB, error) {
 	config := LoadDatabaseConfig()
 
 	// Open a connection to the PostgreSQL database using the provided URI.
-	log.Info("📡 Opening connection to PostgreSQL database")
+	logger.Info("📡 Opening connection to PostgreSQL database")
 	db, err := sql.Open("postgres", getURI(databaseOptions))
 	if err != nil {
-		log.Error(fmt.Sprintf("❌ Failed to open database connection: %v", err))
+		logger.Error(fmt.Sprintf("❌ Failed to open database connection: %v", err))
 		return nil, helpers.WrapError(err, "unable to open database connection")
 	}
 
@@ -138,8 +147,8 @@ func connectToDatabaseWithContext(ctx context.Context) (*sql.DB, error) {
 	}
 
 	// Configure connection pool settings
-	log.Info("⚙️ Configuring database connection pool")
-	log.Info(fmt.Sprintf("📊 Connection pool settings - MaxIdle: %d, MaxOpen: %d, MaxLifetime: %v, MaxIdleTime: %v",
+	logger.Info("⚙️ Configuring database connection pool")
+	logger.Info(fmt.Sprintf("📊 Connection pool settings - MaxIdle: %d, MaxOpen: %d, MaxLifetime: %v, MaxIdleTime: %v",
 		config.MaxIdleConns, config.MaxOpenConns, config.ConnMaxLifetime, config.ConnMaxIdleTime))
 
 	db.SetMaxIdleConns(config.MaxIdleConns)
@@ -157,12 +166,12 @@ func connectToDatabaseWithContext(ctx context.Context) (*sql.DB, error) {
 	}
 
 	// Verify connectivity with context timeout
-	log.Info("🔎 Verifying database connectivity with ping")
+	logger.Info("🔎 Verifying database connectivity with ping")
 	pingCtx, pingCancel := context.WithTimeout(ctx, config.PingTimeout)
 	defer pingCancel()
 
 	if err := db.PingContext(pingCtx); err != nil {
-		log.Error(fmt.Sprintf("❌ Failed to ping database: %v", err))
+		logger.Error(fmt.Sprintf("❌ Failed to ping database: %v", err))
 		db.Close()
 		return nil, helpers.WrapError(err, "unable to connect to the database")
 	}
@@ -176,14 +185,16 @@ func connectToDatabaseWithContext(ctx context.Context) (*sql.DB, error) {
 		// Continue with success
 	}
 
-	log.Success(fmt.Sprintf("✅ Successfully connected to database: %s", databaseOptions.DatabaseName))
-	log.Info(fmt.Sprintf("📈 Database connection pool configured - Idle: %d, Open: %d", config.MaxIdleConns, config.MaxOpenConns))
+	logger.Success(fmt.Sprintf("✅ Successfully connected to database: %s", databaseOptions.DatabaseName))
+	logger.Info(fmt.Sprintf("📈 Database connection pool configured - Idle: %d, Open: %d", config.MaxIdleConns, config.MaxOpenConns))
 
 	return db, nil
 }
 
 // ConnectToDatabase establishes a connection to a PostgreSQL database.
-// Configures connection pooling and verifies connectivity.
+// Configures connection pooling and verifies connectivity, retrying with exponential
+// backoff up to DATABASE_CONNECT_ATTEMPTS times within DATABASE_CONNECT_TIMEOUT, so
+// services starting alongside the database wait for it instead of exiting.
 // Returns the database handle or an error if the connection fails.
 func ConnectToDatabase() (*sql.DB, error) {
 	// Create context with timeout for database connection
@@ -191,7 +202,11 @@ func ConnectToDatabase() (*sql.DB, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
 	defer cancel()
 
-	return connectToDatabaseWithContext(ctx)
+	return retry.DoValue(ctx, connectToDatabaseWithContext,
+		retry.WithMaxAttempts(config.ConnectAttempts),
+		retry.WithExponentialBackoff(time.Second, 10*time.Second),
+		retry.WithName("Database connection"),
+	)
 }
 
 // PingDatabase pings the database to verify connectivity with context support.
@@ -213,13 +228,13 @@ func pingDatabaseWithContext(ctx context.Context, db *sql.DB) error {
 		// Continue with ping
 	}
 
-	log.Info("🔍 Pinging database to verify connectivity")
+	logger.Info("🔍 Pinging database to verify connectivity")
 	if err := db.PingContext(ctx); err != nil {
-		log.Error(fmt.Sprintf("❌ Database ping failed: %v", err))
+		logger.Error(fmt.Sprintf("❌ Database ping failed: %v", err))
 		return helpers.WrapError(err, "database ping failed")
 	}
 
-	log.Success("✅ Database ping successful")
+	logger.Success("✅ Database ping successful")
 	return nil
 }
 
@@ -242,7 +257,7 @@ func closeDatabaseWithContext(ctx context.Context, db *sql.DB) error {
 		// Continue with close
 	}
 
-	log.Info("🔌 Closing database connection")
+	logger.Info("🔌 Closing database connection")
 
 	// Use a channel to handle the close operation with context
 	closeDone := make(chan error, 1)
@@ -253,14 +268,14 @@ func closeDatabaseWithContext(ctx context.Context, db *sql.DB) error {
 	// Wait for either the close to complete or context cancellation
 	select {
 	case <-ctx.Done():
-		log.Warning("⚠️ Database close operation cancelled or timed out")
+		logger.Warning("⚠️ Database close operation cancelled or timed out")
 		return helpers.WrapError(ctx.Err(), "database close cancelled")
 	case err := <-closeDone:
 		if err != nil {
-			log.Error(fmt.Sprintf("❌ Failed to close database connection: %v", err))
+			logger.Error(fmt.Sprintf("❌ Failed to close database connection: %v", err))
 			return helpers.WrapError(err, "failed to close database connection")
 		}
-		log.Success("✅ Database connection closed successfully")
+		logger.Success("✅ Database connection closed successfully")
 		return nil
 	}
 }
@@ -274,14 +289,14 @@ func GetDatabaseStats(db *sql.DB) sql.DBStats {
 func PrintDatabaseStats(db *sql.DB) {
 	stats := GetDatabaseStats(db)
 
-	log.Info("📊 Database Connection Pool Statistics:")
-	log.Info(fmt.Sprintf("   Open Connections: %d", stats.OpenConnections))
-	log.Info(fmt.Sprintf("   In Use: %d", stats.InUse))
-	log.Info(fmt.Sprintf("   Idle: %d", stats.Idle))
-	log.Info(fmt.Sprintf("   Wait Count: %d", stats.WaitCount))
-	log.Info(fmt.Sprintf("   Wait Duration: %v", stats.WaitDuration))
-	log.Info(fmt.Sprintf("   Max Idle Closed: %d", stats.MaxIdleClosed))
-	log.Info(fmt.Sprintf("   Max Lifetime Closed: %d", stats.MaxLifetimeClosed))
+	logger.Info("📊 Database Connection Pool Statistics:")
+	logger.Info(fmt.Sprintf("   Open Connections: %d", stats.OpenConnections))
+	logger.Info(fmt.Sprintf("   In Use: %d", stats.InUse))
+	logger.Info(fmt.Sprintf("   Idle: %d", stats.Idle))
+	logger.Info(fmt.Sprintf("   Wait Count: %d", stats.WaitCount))
+	logger.Info(fmt.Sprintf("   Wait Duration: %v", stats.WaitDuration))
+	logger.Info(fmt.Sprintf("   Max Idle Closed: %d", stats.MaxIdleClosed))
+	logger.Info(fmt.Sprintf("   Max Lifetime Closed: %d", stats.MaxLifetimeClosed))
 }
 
 // IsDatabaseConnected checks if the database is connected and responsive.
@@ -307,17 +322,26 @@ func isDatabaseConnectedWithContext(ctx context.Context, db *sql.DB) bool {
 
 // QueryRowWithContext is a convenience function for querying a single row with context.
 func QueryRowWithContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) *sql.Row {
-	return db.QueryRowContext(ctx, query, args...)
+	start := time.Now()
+	row := db.QueryRowContext(ctx, query, args...)
+	recordQuery("query_row", start, row.Err())
+	return row
 }
 
 // QueryWithContext is a convenience function for querying multiple rows with context.
 func QueryWithContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
-	return db.QueryContext(ctx, query, args...)
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query, args...)
+	recordQuery("query", start, err)
+	return rows, err
 }
 
 // ExecWithContext is a convenience function for executing queries with context.
 func ExecWithContext(ctx context.Context, db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
-	return db.ExecContext(ctx, query, args...)
+	start := time.Now()
+	result, err := db.ExecContext(ctx, query, args...)
+	recordQuery("exec", start, err)
+	return result, err
 }
 
 // GetDatabaseVersion returns the PostgreSQL server version.
@@ -336,4 +360,4 @@ func getDatabaseVersionWithContext(ctx context.Context, db *sql.DB) (string, err
 		return "", helpers.WrapError(err, "failed to get database version")
 	}
 	return version, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"database/sql" // sql provides the connection pool to ping.
+
+	"github.com/hekimapro/utils/health" // health provides the shared check registry.
+)
+
+// RegisterHealthCheck registers a critical "database" check that pings db, so the
+// server's /health endpoint reports unhealthy when the database is unreachable.
+//
+// Example:
+//
+//	db, err := database.ConnectToDatabase()
+//	database.RegisterHealthCheck(db)
+func RegisterHealthCheck(db *sql.DB) {
+	_ = health.Register(health.Check{
+		Name:     "database",
+		Check:    db.PingContext,
+		Timeout:  LoadDatabaseConfig().PingTimeout,
+		Critical: true,
+	})
+}
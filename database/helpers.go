@@ -8,8 +8,7 @@ import (
 	"time"    // time provides functionality for timeouts and durations.
 
 	// helpers provides utility functions.
-	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
-	"github.com/lib/pq"              // pq provides PostgreSQL driver error handling.
+	"github.com/lib/pq" // pq provides PostgreSQL driver error handling.
 )
 
 // DatabaseError represents a structured database error with context.
@@ -50,7 +49,7 @@ func isDuplicateErrorWithContext(ctx context.Context, err error) *string {
 	// Check context cancellation before starting
 	select {
 	case <-ctx.Done():
-		log.Warning("⚠️ Duplicate error check cancelled")
+		logger.Warning("⚠️ Duplicate error check cancelled")
 		return nil
 	default:
 		// Continue with error analysis
@@ -61,7 +60,7 @@ func isDuplicateErrorWithContext(ctx context.Context, err error) *string {
 	if errors.As(err, &pqErr) {
 		if pqErr.Code == "23505" {
 			label := extractColumnLabel(pqErr.Constraint) + " already exists"
-			log.Warning("⚠️ Duplicate entry detected: " + label)
+			logger.Warning("⚠️ Duplicate entry detected: " + label)
 			return &label
 		}
 	}
@@ -97,7 +96,7 @@ func analyzeDatabaseErrorWithContext(ctx context.Context, err error) *DatabaseEr
 	// Check context cancellation before starting
 	select {
 	case <-ctx.Done():
-		log.Warning("⚠️ Database error analysis cancelled")
+		logger.Warning("⚠️ Database error analysis cancelled")
 		return &DatabaseError{
 			OriginalError: err,
 			ErrorType:     "analysis_cancelled",
@@ -111,7 +110,7 @@ func analyzeDatabaseErrorWithContext(ctx context.Context, err error) *DatabaseEr
 		return nil
 	}
 
-	log.Info("🔍 Analyzing database error")
+	logger.Info("🔍 Analyzing database error")
 
 	var pqErr *pq.Error
 	if errors.As(err, &pqErr) {
@@ -126,57 +125,57 @@ func analyzeDatabaseErrorWithContext(ctx context.Context, err error) *DatabaseEr
 		case "23505": // unique_violation
 			dbError.ErrorType = "duplicate"
 			dbError.Message = generateDuplicateErrorMessage(pqErr.Constraint, pqErr.Table)
-			log.Warning("⚠️ Duplicate entry error: " + dbError.Message)
+			logger.Warning("⚠️ Duplicate entry error: " + dbError.Message)
 
 		case "23503": // foreign_key_violation
 			dbError.ErrorType = "foreign_key"
 			dbError.Message = generateForeignKeyErrorMessage(pqErr.Constraint, pqErr.Table)
-			log.Warning("⚠️ Foreign key violation: " + dbError.Message)
+			logger.Warning("⚠️ Foreign key violation: " + dbError.Message)
 
 		case "23502": // not_null_violation
 			dbError.ErrorType = "not_null"
 			dbError.Message = generateNotNullErrorMessage(pqErr.Column, pqErr.Table)
-			log.Warning("⚠️ Not null violation: " + dbError.Message)
+			logger.Warning("⚠️ Not null violation: " + dbError.Message)
 
 		case "23514": // check_violation
 			dbError.ErrorType = "check_constraint"
 			dbError.Message = generateCheckConstraintErrorMessage(pqErr.Constraint)
-			log.Warning("⚠️ Check constraint violation: " + dbError.Message)
+			logger.Warning("⚠️ Check constraint violation: " + dbError.Message)
 
 		case "42P01": // undefined_table
 			dbError.ErrorType = "undefined_table"
 			dbError.Message = fmt.Sprintf("Table '%s' does not exist", pqErr.Table)
-			log.Error("❌ Undefined table: " + dbError.Message)
+			logger.Error("❌ Undefined table: " + dbError.Message)
 
 		case "42703": // undefined_column
 			dbError.ErrorType = "undefined_column"
 			dbError.Message = fmt.Sprintf("Column '%s' does not exist in table '%s'", pqErr.Column, pqErr.Table)
-			log.Error("❌ Undefined column: " + dbError.Message)
+			logger.Error("❌ Undefined column: " + dbError.Message)
 
 		case "28000", "28P01": // invalid_authorization
 			dbError.ErrorType = "authentication"
 			dbError.Message = "Database authentication failed"
-			log.Error("❌ Authentication failed")
+			logger.Error("❌ Authentication failed")
 
 		case "55P03": // lock_not_available
 			dbError.ErrorType = "lock_timeout"
 			dbError.Message = "Database lock timeout occurred"
-			log.Warning("⚠️ Lock timeout occurred")
+			logger.Warning("⚠️ Lock timeout occurred")
 
 		case "53300": // too_many_connections
 			dbError.ErrorType = "too_many_connections"
 			dbError.Message = "Too many database connections"
-			log.Error("❌ Too many database connections")
+			logger.Error("❌ Too many database connections")
 
 		case "57014": // query_canceled
 			dbError.ErrorType = "query_cancelled"
 			dbError.Message = "Database query was cancelled"
-			log.Warning("⚠️ Query cancelled")
+			logger.Warning("⚠️ Query cancelled")
 
 		default:
 			dbError.ErrorType = "unknown"
 			dbError.Message = fmt.Sprintf("Database error: %s", pqErr.Message)
-			log.Error("❌ Unknown database error: " + pqErr.Message)
+			logger.Error("❌ Unknown database error: " + pqErr.Message)
 		}
 
 		return dbError
@@ -391,16 +390,16 @@ func LogDatabaseError(err error, operation string) {
 
 	switch severity {
 	case "warning":
-		log.Warning("⚠️ " + logMessage)
+		logger.Warning("⚠️ " + logMessage)
 	case "critical":
-		log.Error("❌ " + logMessage)
+		logger.Error("❌ " + logMessage)
 	default:
-		log.Error("❌ " + logMessage)
+		logger.Error("❌ " + logMessage)
 	}
 
 	// Log additional context for debugging
 	if dbError != nil && dbError.OriginalError != nil {
-		log.Info(fmt.Sprintf("📋 Error details - Type: %s, Constraint: %s, Table: %s, Column: %s",
+		logger.Info(fmt.Sprintf("📋 Error details - Type: %s, Constraint: %s, Table: %s, Column: %s",
 			dbError.ErrorType, dbError.Constraint, dbError.Table, dbError.Column))
 	}
 }
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql" // sql provides connection pool statistics.
+	"time"         // time provides query durations.
+
+	"github.com/hekimapro/utils/metrics" // metrics provides query counters and pool gauges.
+)
+
+// Query metrics recorded by ExecWithContext, QueryWithContext, and QueryRowWithContext.
+var (
+	queryCount = metrics.NewCounter("database_queries_total",
+		"Database queries executed through the helpers, by operation (exec, query, query_row) and result.", "operation", "result")
+	queryDuration = metrics.NewHistogram("database_query_duration_seconds",
+		"Database query latency in seconds, by operation.", []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 5}, "operation")
+)
+
+// recordQuery records the outcome and latency of one query.
+func recordQuery(operation string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	queryCount.Inc(operation, result)
+	queryDuration.ObserveDuration(start, operation)
+}
+
+// RegisterPoolMetrics exposes the connection pool statistics of db as gauges,
+// read at scrape time. Register one pool per process; later calls keep the first.
+//
+// Example:
+//
+//	db, err := database.ConnectToDatabase()
+//	database.RegisterPoolMetrics(db)
+func RegisterPoolMetrics(db *sql.DB) {
+	metrics.NewGaugeFunc("database_connections_open", "Open database connections, in use or idle.", func() float64 {
+		return float64(db.Stats().OpenConnections)
+	})
+	metrics.NewGaugeFunc("database_connections_in_use", "Database connections currently in use.", func() float64 {
+		return float64(db.Stats().InUse)
+	})
+	metrics.NewGaugeFunc("database_connections_idle", "Idle database connections.", func() float64 {
+		return float64(db.Stats().Idle)
+	})
+	metrics.NewGaugeFunc("database_connections_max_open", "Maximum open database connections allowed.", func() float64 {
+		return float64(db.Stats().MaxOpenConnections)
+	})
+	metrics.NewGaugeFunc("database_connection_waits", "Total times a query waited for a free connection.", func() float64 {
+		return float64(db.Stats().WaitCount)
+	})
+	metrics.NewGaugeFunc("database_connection_wait_seconds", "Total time spent waiting for a free connection.", func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+}
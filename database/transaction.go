@@ -8,7 +8,7 @@ import (
 	"time" // time provides functionality for tracking transaction duration.
 
 	"github.com/hekimapro/utils/helpers" // helpers provides utility functions.
-	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/retry"   // retry provides the transaction retry loop.
 )
 
 // TransactionFunction defines the signature for the transactional operation.
@@ -41,15 +41,15 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 	// Record the start time of the transaction.
 	startTime := time.Now()
 	// Log the start of the transaction with timestamp.
-	log.Info(fmt.Sprintf("🔄 Beginning DB transaction at %s", startTime.Format(timestampFormat)))
+	logger.Info(fmt.Sprintf("🔄 Beginning DB transaction at %s", startTime.Format(timestampFormat)))
 	// Log that the transactional operation is being executed.
-	log.Info("🛠️  Executing transactional operation...")
+	logger.Info("🛠️  Executing transactional operation...")
 
 	// Begin the database transaction with context support.
 	transaction, err := database.BeginTx(ctx, nil)
 	if err != nil {
 		// Log and return an error if starting the transaction fails.
-		log.Error(fmt.Sprintf("❌ Failed to begin transaction: %s", err.Error()))
+		logger.Error(fmt.Sprintf("❌ Failed to begin transaction: %s", err.Error()))
 		return helpers.WrapError(err, "failed to start transaction")
 	}
 
@@ -58,7 +58,7 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 		// Check context cancellation in defer
 		select {
 		case <-ctx.Done():
-			log.Warning("⚠️ Transaction context cancelled during cleanup")
+			logger.Warning("⚠️ Transaction context cancelled during cleanup")
 		default:
 			// Continue with cleanup
 		}
@@ -67,20 +67,20 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 		endTime := time.Now()
 		duration := endTime.Sub(startTime)
 		// Log transaction completion with timestamp and duration.
-		log.Info(fmt.Sprintf("🕒 Transaction ended at %s (duration: %s)", endTime.Format(timestampFormat), duration))
+		logger.Info(fmt.Sprintf("🕒 Transaction ended at %s (duration: %s)", endTime.Format(timestampFormat), duration))
 
 		// Handle any panic that occurred during the transaction.
 		if recovered := recover(); recovered != nil {
 			// Log the panic details.
-			log.Error(fmt.Sprintf("💥 Panic during transaction: %v", recovered))
+			logger.Error(fmt.Sprintf("💥 Panic during transaction: %v", recovered))
 			// Attempt to rollback the transaction.
 			if rollbackErr := transaction.Rollback(); rollbackErr != nil {
 				// Log if rollback fails after panic.
-				log.Error(fmt.Sprintf("❌ Rollback failed after panic: %s", rollbackErr.Error()))
+				logger.Error(fmt.Sprintf("❌ Rollback failed after panic: %s", rollbackErr.Error()))
 				err = helpers.WrapError(rollbackErr, "rollback failed after panic")
 			} else {
 				// Log successful rollback due to panic.
-				log.Warning("⚠️  Transaction rolled back due to panic")
+				logger.Warning("⚠️  Transaction rolled back due to panic")
 				err = helpers.WrapError(fmt.Errorf("%v", recovered), "transaction panicked")
 			}
 			return
@@ -89,15 +89,15 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 		// Handle any error from the transaction operation.
 		if err != nil {
 			// Log the operation error.
-			log.Error(fmt.Sprintf("❌ Transaction operation error: %s", err.Error()))
+			logger.Error(fmt.Sprintf("❌ Transaction operation error: %s", err.Error()))
 			// Attempt to rollback the transaction.
 			if rollbackErr := transaction.Rollback(); rollbackErr != nil {
 				// Log if rollback fails and combine errors.
-				log.Error(fmt.Sprintf("❌ Rollback failed: %s", rollbackErr.Error()))
+				logger.Error(fmt.Sprintf("❌ Rollback failed: %s", rollbackErr.Error()))
 				err = helpers.WrapError(rollbackErr, "rollback failed")
 			} else {
 				// Log successful rollback due to error.
-				log.Warning("⚠️  Transaction rolled back due to error")
+				logger.Warning("⚠️  Transaction rolled back due to error")
 			}
 			return
 		}
@@ -105,9 +105,9 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 		// Check context cancellation before commit
 		select {
 		case <-ctx.Done():
-			log.Warning("⚠️ Transaction context cancelled before commit, rolling back")
+			logger.Warning("⚠️ Transaction context cancelled before commit, rolling back")
 			if rollbackErr := transaction.Rollback(); rollbackErr != nil {
-				log.Error(fmt.Sprintf("❌ Rollback failed after context cancellation: %s", rollbackErr.Error()))
+				logger.Error(fmt.Sprintf("❌ Rollback failed after context cancellation: %s", rollbackErr.Error()))
 				err = helpers.WrapError(rollbackErr, "rollback failed after context cancellation")
 			} else {
 				err = helpers.WrapError(ctx.Err(), "transaction cancelled before commit")
@@ -118,14 +118,14 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 		}
 
 		// Attempt to commit the transaction if no errors occurred.
-		log.Info("📝 Committing transaction...")
+		logger.Info("📝 Committing transaction...")
 		if commitErr := transaction.Commit(); commitErr != nil {
 			// Log and set error if commit fails.
-			log.Error(fmt.Sprintf("❌ Commit failed: %s", commitErr.Error()))
+			logger.Error(fmt.Sprintf("❌ Commit failed: %s", commitErr.Error()))
 			err = helpers.WrapError(commitErr, "failed to commit transaction")
 		} else {
 			// Log successful transaction commit.
-			log.Success("✅ Transaction committed successfully")
+			logger.Success("✅ Transaction committed successfully")
 		}
 	}()
 
@@ -141,10 +141,10 @@ func transactionWithContext(ctx context.Context, database *sql.DB, operation Tra
 	err = operation(transaction)
 	if err != nil {
 		// Log if the operation fails.
-		log.Error(fmt.Sprintf("⚠️  Transaction operation failed: %s", err.Error()))
+		logger.Error(fmt.Sprintf("⚠️  Transaction operation failed: %s", err.Error()))
 	} else {
 		// Log successful operation execution.
-		log.Info("✔️  Transaction operation completed successfully")
+		logger.Info("✔️  Transaction operation completed successfully")
 	}
 
 	return err
@@ -166,60 +166,41 @@ func transactionWithRetryAndContext(ctx context.Context, database *sql.DB, opera
 		maxRetries = 0
 	}
 
-	var lastError error
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// Check context cancellation before each attempt
-		select {
-		case <-ctx.Done():
-			return helpers.WrapError(ctx.Err(), "transaction with retry cancelled")
-		default:
-			// Continue with attempt
-		}
-
-		// Log retry attempt if not the first attempt
-		if attempt > 0 {
-			log.Warning(fmt.Sprintf("🔄 Transaction retry attempt %d/%d", attempt, maxRetries))
-			// Exponential backoff
-			backoffDuration := time.Duration(attempt*attempt) * time.Second
-			if backoffDuration > 10*time.Second {
-				backoffDuration = 10 * time.Second
-			}
-
-			select {
-			case <-ctx.Done():
-				return helpers.WrapError(ctx.Err(), "transaction retry cancelled during backoff")
-			case <-time.After(backoffDuration):
-				// Continue with next attempt
-			}
-		}
-
-		// Execute the transaction
+	attempts := 0
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		attempts++
 		err := transactionWithContext(ctx, database, operation)
-		if err == nil {
-			// Success
-			if attempt > 0 {
-				log.Success(fmt.Sprintf("✅ Transaction succeeded on attempt %d", attempt+1))
-			}
-			return nil
+		if err != nil && !isRetryableTransactionError(err) {
+			logger.Warning("⚠️ Non-retryable transaction error, not retrying")
+			return retry.Permanent(err)
 		}
-
-		lastError = err
-
-		// Check if error is retryable
-		if !isRetryableTransactionError(err) {
-			log.Warning("⚠️ Non-retryable transaction error, not retrying")
-			return err
-		}
-
-		// Log that we will retry
-		if attempt < maxRetries {
-			log.Warning(fmt.Sprintf("⚠️ Retryable transaction error, will retry: %v", err))
+		return err
+	},
+		retry.WithMaxAttempts(maxRetries+1),
+		// Quadratic backoff: 1s, 4s, 9s, then 10s
+		retry.WithBackoff(func(attempt int) time.Duration {
+			return min(time.Duration(attempt*attempt)*time.Second, 10*time.Second)
+		}),
+		retry.WithOnRetry(func(attempt int, err error, delay time.Duration) {
+			logger.Warning(fmt.Sprintf("⚠️ Retryable transaction error, will retry: %v", err))
+			logger.Warning(fmt.Sprintf("🔄 Transaction retry attempt %d/%d in %v", attempt, maxRetries, delay))
+		}),
+	)
+
+	switch {
+	case err == nil:
+		if attempts > 1 {
+			logger.Success(fmt.Sprintf("✅ Transaction succeeded on attempt %d", attempts))
 		}
+		return nil
+	case ctx.Err() != nil:
+		return helpers.WrapError(err, "transaction with retry cancelled")
+	case !isRetryableTransactionError(err):
+		return err
 	}
 
-	log.Error(fmt.Sprintf("❌ Transaction failed after %d attempts: %v", maxRetries+1, lastError))
-	return helpers.WrapError(lastError, "transaction failed after maximum retries")
+	logger.Error(fmt.Sprintf("❌ Transaction failed after %d attempts: %v", attempts, err))
+	return helpers.WrapError(err, "transaction failed after maximum retries")
 }
 
 // isRetryableTransactionError checks if a transaction error is retryable.
@@ -274,7 +255,7 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 
 	// Log the isolation level
 	isolationName := getIsolationLevelName(isolationLevel)
-	log.Info(fmt.Sprintf("🔄 Beginning DB transaction with isolation level '%s' at %s",
+	logger.Info(fmt.Sprintf("🔄 Beginning DB transaction with isolation level '%s' at %s",
 		isolationName, startTime.Format(timestampFormat)))
 
 	// Begin the database transaction with specific isolation level.
@@ -283,7 +264,7 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 		ReadOnly:  false,
 	})
 	if err != nil {
-		log.Error(fmt.Sprintf("❌ Failed to begin transaction with isolation level '%s': %s", isolationName, err.Error()))
+		logger.Error(fmt.Sprintf("❌ Failed to begin transaction with isolation level '%s': %s", isolationName, err.Error()))
 		return helpers.WrapError(err, "failed to start transaction with isolation level")
 	}
 
@@ -292,15 +273,15 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 		// Record the end time and calculate transaction duration.
 		endTime := time.Now()
 		duration := endTime.Sub(startTime)
-		log.Info(fmt.Sprintf("🕒 Isolation transaction ended at %s (duration: %s)", endTime.Format(timestampFormat), duration))
+		logger.Info(fmt.Sprintf("🕒 Isolation transaction ended at %s (duration: %s)", endTime.Format(timestampFormat), duration))
 
 		// Handle panic
 		if recovered := recover(); recovered != nil {
-			log.Error(fmt.Sprintf("💥 Panic during isolation transaction: %v", recovered))
+			logger.Error(fmt.Sprintf("💥 Panic during isolation transaction: %v", recovered))
 			if rollbackErr := transaction.Rollback(); rollbackErr != nil {
-				log.Error(fmt.Sprintf("❌ Rollback failed after panic: %s", rollbackErr.Error()))
+				logger.Error(fmt.Sprintf("❌ Rollback failed after panic: %s", rollbackErr.Error()))
 			} else {
-				log.Warning("⚠️  Isolation transaction rolled back due to panic")
+				logger.Warning("⚠️  Isolation transaction rolled back due to panic")
 			}
 			err = helpers.WrapError(fmt.Errorf("%v", recovered), "isolation transaction panicked")
 			return
@@ -308,12 +289,12 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 
 		// Handle error
 		if err != nil {
-			log.Error(fmt.Sprintf("❌ Isolation transaction operation error: %s", err.Error()))
+			logger.Error(fmt.Sprintf("❌ Isolation transaction operation error: %s", err.Error()))
 			if rollbackErr := transaction.Rollback(); rollbackErr != nil {
-				log.Error(fmt.Sprintf("❌ Rollback failed: %s", rollbackErr.Error()))
+				logger.Error(fmt.Sprintf("❌ Rollback failed: %s", rollbackErr.Error()))
 				err = helpers.WrapError(rollbackErr, "rollback failed")
 			} else {
-				log.Warning("⚠️  Isolation transaction rolled back due to error")
+				logger.Warning("⚠️  Isolation transaction rolled back due to error")
 			}
 			return
 		}
@@ -321,9 +302,9 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 		// Check context cancellation before commit
 		select {
 		case <-ctx.Done():
-			log.Warning("⚠️ Isolation transaction context cancelled before commit, rolling back")
+			logger.Warning("⚠️ Isolation transaction context cancelled before commit, rolling back")
 			if rollbackErr := transaction.Rollback(); rollbackErr != nil {
-				log.Error(fmt.Sprintf("❌ Rollback failed after context cancellation: %s", rollbackErr.Error()))
+				logger.Error(fmt.Sprintf("❌ Rollback failed after context cancellation: %s", rollbackErr.Error()))
 				err = helpers.WrapError(rollbackErr, "rollback failed after context cancellation")
 			} else {
 				err = helpers.WrapError(ctx.Err(), "isolation transaction cancelled before commit")
@@ -334,12 +315,12 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 		}
 
 		// Commit
-		log.Info("📝 Committing isolation transaction...")
+		logger.Info("📝 Committing isolation transaction...")
 		if commitErr := transaction.Commit(); commitErr != nil {
-			log.Error(fmt.Sprintf("❌ Commit failed: %s", commitErr.Error()))
+			logger.Error(fmt.Sprintf("❌ Commit failed: %s", commitErr.Error()))
 			err = helpers.WrapError(commitErr, "failed to commit isolation transaction")
 		} else {
-			log.Success("✅ Isolation transaction committed successfully")
+			logger.Success("✅ Isolation transaction committed successfully")
 		}
 	}()
 
@@ -354,9 +335,9 @@ func transactionWithIsolationAndContext(ctx context.Context, database *sql.DB, o
 	// Execute the operation
 	err = operation(transaction)
 	if err != nil {
-		log.Error(fmt.Sprintf("⚠️  Isolation transaction operation failed: %s", err.Error()))
+		logger.Error(fmt.Sprintf("⚠️  Isolation transaction operation failed: %s", err.Error()))
 	} else {
-		log.Info("✔️  Isolation transaction operation completed successfully")
+		logger.Info("✔️  Isolation transaction operation completed successfully")
 	}
 
 	return err
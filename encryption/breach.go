@@ -0,0 +1,72 @@
+package encryption
+
+import (
+	"bufio"        // bufio provides line scanning of the range response.
+	"bytes"        // bytes provides a reader over the response body.
+	"context"      // context provides support for cancellation and timeouts.
+	"crypto/sha1"  // sha1 provides the hash the range API is keyed by.
+	"encoding/hex" // hex provides the hash prefix and suffixes.
+	"strconv"      // strconv provides parsing of breach counts.
+	"strings"      // strings provides suffix matching.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides environment and error utilities.
+	"github.com/hekimapro/utils/request" // request provides the HTTP client with retries.
+)
+
+// DefaultPwnedPasswordsURL is the Have I Been Pwned range API, used when
+// HIBP_RANGE_URL is not set.
+const DefaultPwnedPasswordsURL = "https://api.pwnedpasswords.com/range/"
+
+// CheckPasswordBreached reports how many times a password appears in known breaches,
+// using the Have I Been Pwned k-anonymity range API: only the first 5 hex characters
+// of the password's SHA-1 are sent, and the matching suffix is looked up locally in
+// the response. Zero means the password was not found.
+//
+// Example:
+//
+//	count, err := encryption.CheckPasswordBreached(ctx, input.Password)
+//	if err == nil && count > 0 {
+//	    return helpers.CreateError("this password has appeared in a data breach, choose another")
+//	}
+func CheckPasswordBreached(ctx context.Context, password string) (int, error) {
+	if password == "" {
+		return 0, helpers.CreateError("password cannot be empty")
+	}
+	logger.Info("🔎 Checking password against breach corpus")
+
+	digest := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(digest[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	// Add-Padding makes every response a similar size, hiding the prefix from observers.
+	headers := &request.Headers{"Accept": "text/plain", "Add-Padding": "true", "User-Agent": "hekimapro-utils"}
+	url := helpers.GetENVValueWithDefault("HIBP_RANGE_URL", DefaultPwnedPasswordsURL) + prefix
+	body, err := request.GetRawWithContext(ctx, url, headers)
+	if err != nil {
+		logger.Error("❌ Breach check failed: " + err.Error())
+		return 0, helpers.WrapError(err, "failed to query breached passwords")
+	}
+
+	// Each line is "SUFFIX:COUNT"; padding lines have a count of 0.
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		candidate, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		occurrences, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, helpers.WrapError(err, "invalid breach count in response")
+		}
+		if occurrences > 0 {
+			logger.Warning("⚠️ Password found in breach corpus")
+		}
+		return occurrences, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, helpers.WrapError(err, "failed to read breach response")
+	}
+
+	logger.Success("✅ Password not found in breach corpus")
+	return 0, nil
+}
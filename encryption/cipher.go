@@ -0,0 +1,430 @@
+package encryption
+
+import (
+	"context"         // context provides support for cancellation.
+	"crypto/aes"      // aes provides AES encryption and decryption functionality.
+	"crypto/cipher"   // cipher provides block cipher modes like CBC and the AEAD interface.
+	"crypto/rand"     // rand provides AEAD nonces.
+	"crypto/sha256"   // sha256 provides key fingerprints.
+	"encoding/base64" // base64 provides Base64 encoding/decoding.
+	"encoding/hex"    // hex provides hexadecimal encoding/decoding.
+	"encoding/json"   // json provides JSON encoding/decoding.
+	"errors"          // errors provides the wrong key sentinel.
+	"io"              // io provides full reads from the random source.
+	"strings"         // strings provides base64url padding removal.
+
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	"github.com/hekimapro/utils/models"    // models contains data structures for encryption payloads.
+	"golang.org/x/crypto/chacha20poly1305" // chacha20poly1305 provides the ChaCha20-Poly1305 AEAD.
+)
+
+// Supported cipher algorithms, selected with EncryptionConfig.Algorithm.
+const (
+	AlgorithmAESCBC           = "aes-cbc"           // AlgorithmAESCBC is AES-CBC with PKCS7 padding and the configured IV (default)
+	AlgorithmChaCha20Poly1305 = "chacha20-poly1305" // AlgorithmChaCha20Poly1305 is authenticated encryption with a random nonce per message, fast without AES-NI
+)
+
+// Supported payload encodings, set globally with ENCRYPTION_TYPE or per call with
+// EncryptWithEncoding.
+const (
+	EncodingBase64    = "base64"    // EncodingBase64 is standard base64 with padding
+	EncodingBase64URL = "base64url" // EncodingBase64URL is unpadded URL-safe base64, for URLs and JWT-like tokens
+	EncodingHex       = "hex"       // EncodingHex is lowercase hexadecimal
+)
+
+// encodingRaw marks ciphertext handed to DecryptRaw, carried unencoded in Payload.
+const encodingRaw = "raw"
+
+// validateEncoding checks that encoding is a supported payload encoding.
+func validateEncoding(encoding string) error {
+	switch encoding {
+	case EncodingBase64, EncodingBase64URL, EncodingHex:
+		return nil
+	}
+	return helpers.CreateErrorf("invalid encryption type %q (use '%s', '%s', or '%s')", encoding, EncodingBase64, EncodingBase64URL, EncodingHex)
+}
+
+// encodeCiphertext encodes ciphertext with a validated payload encoding.
+func encodeCiphertext(ciphertext []byte, encoding string) string {
+	switch encoding {
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(ciphertext)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(ciphertext)
+	}
+	return hex.EncodeToString(ciphertext)
+}
+
+// decodeCiphertext reverses encodeCiphertext. Padded base64url is accepted too.
+func decodeCiphertext(payload, encoding string) ([]byte, error) {
+	switch encoding {
+	case encodingRaw:
+		return []byte(payload), nil
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(payload)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(payload, "="))
+	case EncodingHex:
+		return hex.DecodeString(payload)
+	}
+	return nil, validateEncoding(encoding)
+}
+
+// ErrWrongKey reports a payload whose key fingerprint does not match the decryption key.
+var ErrWrongKey = errors.New("payload was encrypted with a different key")
+
+// FingerprintKey returns a short public identifier of a key: the first 8 bytes of its
+// SHA-256 digest, hex encoded. It is stored in every payload so decryption with the
+// wrong key fails fast with ErrWrongKey instead of a padding error or garbage, and it
+// is safe to log when comparing keys across services.
+//
+// Example:
+//
+//	log.Info("🔑 Using encryption key " + encryption.FingerprintKey(key))
+func FingerprintKey(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:8])
+}
+
+// Cipher encrypts and decrypts with a key, algorithm, IV, and output encoding supplied
+// in code, for services that load secrets from a vault or secret manager instead of
+// .env. A Cipher is safe for concurrent use.
+type Cipher struct {
+	config      models.EncryptionConfig
+	fingerprint string       // fingerprint is FingerprintKey of the key
+	block       cipher.Block // block is the AES cipher for aes-cbc
+	aead        cipher.AEAD  // aead is the AEAD for chacha20-poly1305
+}
+
+// NewCipher validates config and returns a Cipher using it. Unlike Encrypt and
+// Decrypt, it never reads environment variables.
+//
+// Example:
+//
+//	aesCipher, err := encryption.NewCipher(models.EncryptionConfig{
+//	    EncryptionKey:        secrets.Get("aes-key"),
+//	    EncryptionType:       "base64",
+//	    InitializationVector: secrets.Get("aes-iv"),
+//	})
+//	encrypted, err := aesCipher.Encrypt(payload)
+//
+// With Algorithm set to AlgorithmChaCha20Poly1305 the key must be 32 bytes, no IV is
+// needed, and each payload carries its own random nonce.
+func NewCipher(config models.EncryptionConfig) (*Cipher, error) {
+	config.Algorithm = helpers.DefaultIfEmpty(config.Algorithm, AlgorithmAESCBC)
+	if err := validateEncryptionConfig(&config); err != nil {
+		return nil, err
+	}
+
+	if config.Algorithm == AlgorithmChaCha20Poly1305 {
+		aead, err := chacha20poly1305.New([]byte(config.EncryptionKey))
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to initialize ChaCha20-Poly1305 cipher")
+		}
+		return &Cipher{config: config, fingerprint: FingerprintKey(config.EncryptionKey), aead: aead}, nil
+	}
+
+	block, err := aes.NewCipher([]byte(config.EncryptionKey))
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to initialize AES cipher")
+	}
+	return &Cipher{config: config, fingerprint: FingerprintKey(config.EncryptionKey), block: block}, nil
+}
+
+// Algorithm returns the cipher algorithm in use.
+func (c *Cipher) Algorithm() string {
+	return c.config.Algorithm
+}
+
+// seal encrypts plaintext: AES-CBC with padding and the configured IV, or an AEAD
+// with a random nonce prepended to the ciphertext.
+func (c *Cipher) seal(plaintext []byte) ([]byte, error) {
+	if c.aead != nil {
+		return sealAEAD(c.aead, plaintext)
+	}
+
+	// Apply PKCS7 padding to the data to match AES block size.
+	paddedData := pad(plaintext, aes.BlockSize)
+	mode := cipher.NewCBCEncrypter(c.block, []byte(c.config.InitializationVector))
+	ciphertext := make([]byte, len(paddedData))
+	mode.CryptBlocks(ciphertext, paddedData)
+	return ciphertext, nil
+}
+
+// open reverses seal.
+func (c *Cipher) open(ciphertext []byte) ([]byte, error) {
+	if c.aead != nil {
+		return openAEAD(c.aead, ciphertext)
+	}
+
+	// CryptBlocks panics on partial blocks, so reject them up front.
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, helpers.CreateError("ciphertext is not a multiple of the block size")
+	}
+	mode := cipher.NewCBCDecrypter(c.block, []byte(c.config.InitializationVector))
+	plaintext := make([]byte, len(ciphertext))
+	mode.CryptBlocks(plaintext, ciphertext)
+
+	// Remove PKCS7 padding from the decrypted data.
+	plaintext, err := unpad(plaintext)
+	if err != nil {
+		return nil, helpers.WrapError(err, "padding removal failed")
+	}
+	return plaintext, nil
+}
+
+// sealAEAD encrypts plaintext with aead, prepending a random nonce.
+func sealAEAD(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, helpers.WrapError(err, "failed to generate nonce")
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openAEAD reverses sealAEAD.
+func openAEAD(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, helpers.CreateError("ciphertext is too short")
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, helpers.WrapError(err, "message authentication failed")
+	}
+	return plaintext, nil
+}
+
+// Encrypt marshals data to JSON, encrypts it with the configured algorithm, and encodes the ciphertext.
+func (c *Cipher) Encrypt(data interface{}) (*models.EncryptReturnType, error) {
+	return c.EncryptWithContext(context.Background(), data)
+}
+
+// EncryptWithContext is Encrypt with cancellation support.
+func (c *Cipher) EncryptWithContext(ctx context.Context, data interface{}) (*models.EncryptReturnType, error) {
+	ciphertext, err := c.encrypt(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return c.encode(ciphertext, "", ""), nil
+}
+
+// EncryptWithEncoding is Encrypt with a payload encoding chosen for this call instead
+// of EncryptionType. The encoding is recorded in the payload so Decrypt reads it back.
+//
+// Example:
+//
+//	encrypted, err := aesCipher.EncryptWithEncoding(claims, encryption.EncodingBase64URL)
+//	link := "https://app.example.com/invite?token=" + encrypted.Payload
+func (c *Cipher) EncryptWithEncoding(data interface{}, encoding string) (*models.EncryptReturnType, error) {
+	if err := validateEncoding(encoding); err != nil {
+		return nil, err
+	}
+	ciphertext, err := c.encrypt(context.Background(), data)
+	if err != nil {
+		return nil, err
+	}
+	return c.encode(ciphertext, encoding, ""), nil
+}
+
+// EncryptRaw is Encrypt returning the ciphertext bytes without any text encoding,
+// for binary storage such as BYTEA columns or files. Decrypt them with DecryptRaw.
+func (c *Cipher) EncryptRaw(data interface{}) ([]byte, error) {
+	return c.encrypt(context.Background(), data)
+}
+
+// encode builds the payload of ciphertext, encoded with encoding or with
+// EncryptionType when encoding is empty.
+func (c *Cipher) encode(ciphertext []byte, encoding, format string) *models.EncryptReturnType {
+	encryptedData := &models.EncryptReturnType{KeyID: c.config.KeyID, KeyFingerprint: c.fingerprint, Format: format}
+	if encoding != "" && encoding != c.config.EncryptionType {
+		encryptedData.Encoding = encoding
+	}
+	encryptedData.Payload = encodeCiphertext(ciphertext, helpers.DefaultIfEmpty(encoding, c.config.EncryptionType))
+	return encryptedData
+}
+
+// encrypt marshals data to JSON and encrypts it.
+func (c *Cipher) encrypt(ctx context.Context, data interface{}) ([]byte, error) {
+	// Log the start of the encryption process.
+	logger.Info("🔐 Starting encryption process")
+
+	if err := ctx.Err(); err != nil {
+		return nil, helpers.WrapError(err, "encryption cancelled before start")
+	}
+
+	// Marshal the input data to JSON for encryption.
+	dataToEncrypt, err := json.Marshal(data)
+	if err != nil {
+		logger.Error("❌ Failed to marshal input data: " + err.Error())
+		return nil, helpers.WrapError(err, "failed to marshal input data")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, helpers.WrapError(err, "encryption cancelled after data marshaling")
+	}
+	return c.encryptPlaintext(dataToEncrypt)
+}
+
+// encryptPlaintext encrypts prepared plaintext.
+func (c *Cipher) encryptPlaintext(plaintext []byte) ([]byte, error) {
+	logger.Info("🔁 Performing " + c.config.Algorithm + " encryption")
+	ciphertext, err := c.seal(plaintext)
+	if err != nil {
+		logger.Error("❌ Encryption failed: " + err.Error())
+		return nil, err
+	}
+
+	// Log successful encryption.
+	logger.Success("✅ Data encrypted successfully")
+	return ciphertext, nil
+}
+
+// Decrypt decodes and decrypts a payload produced by Encrypt and unmarshals the JSON.
+func (c *Cipher) Decrypt(encryptedData models.EncryptReturnType) (interface{}, error) {
+	return c.DecryptWithContext(context.Background(), encryptedData)
+}
+
+// DecryptWithContext is Decrypt with cancellation support.
+func (c *Cipher) DecryptWithContext(ctx context.Context, encryptedData models.EncryptReturnType) (interface{}, error) {
+	var decryptedData interface{}
+	if err := c.decryptInto(ctx, encryptedData, &decryptedData); err != nil {
+		return nil, err
+	}
+	return decryptedData, nil
+}
+
+// DecryptRaw decrypts ciphertext bytes produced by EncryptRaw.
+func (c *Cipher) DecryptRaw(ciphertext []byte) (interface{}, error) {
+	return c.Decrypt(rawPayload(ciphertext))
+}
+
+// rawPayload wraps ciphertext bytes for the payload decryption path.
+func rawPayload(ciphertext []byte) models.EncryptReturnType {
+	return models.EncryptReturnType{Payload: string(ciphertext), Encoding: encodingRaw}
+}
+
+// DecryptTo decrypts a payload produced by Encrypt and unmarshals the JSON into out,
+// which must be a pointer, like json.Unmarshal.
+//
+// Example:
+//
+//	var patient Patient
+//	err := aesCipher.DecryptTo(encrypted, &patient)
+func (c *Cipher) DecryptTo(encryptedData models.EncryptReturnType, out interface{}) error {
+	return c.decryptInto(context.Background(), encryptedData, out)
+}
+
+// decryptInto decrypts a payload and unmarshals the JSON into out.
+func (c *Cipher) decryptInto(ctx context.Context, encryptedData models.EncryptReturnType, out interface{}) error {
+	// Log the start of the decryption process.
+	logger.Info("🔓 Starting decryption process")
+
+	if err := ctx.Err(); err != nil {
+		return helpers.WrapError(err, "decryption cancelled before start")
+	}
+
+	logger.Info("🔁 Performing " + c.config.Algorithm + " decryption")
+	plaintext, err := c.decryptPayload(encryptedData)
+	if err != nil {
+		logger.Error("❌ Decryption failed: " + err.Error())
+		return err
+	}
+
+	// Unmarshal the decrypted JSON data into the destination.
+	logger.Info("🧩 Unmarshaling decrypted data")
+	if err := unmarshalPlaintext(plaintext, encryptedData.Format, out); err != nil {
+		logger.Error("❌ " + err.Error())
+		return err
+	}
+
+	// Log successful decryption.
+	logger.Success("✅ Data decrypted successfully")
+	return nil
+}
+
+// decryptPayload checks the key ID, decodes the payload, and decrypts it.
+func (c *Cipher) decryptPayload(encryptedData models.EncryptReturnType) ([]byte, error) {
+	if encryptedData.KeyID != "" && c.config.KeyID != "" && encryptedData.KeyID != c.config.KeyID {
+		return nil, helpers.WrapErrorf(ErrWrongKey, "payload key %q, this key %q", encryptedData.KeyID, c.config.KeyID)
+	}
+	if encryptedData.KeyFingerprint != "" && encryptedData.KeyFingerprint != c.fingerprint {
+		return nil, helpers.WrapErrorf(ErrWrongKey, "payload key fingerprint %s, this key %s", encryptedData.KeyFingerprint, c.fingerprint)
+	}
+
+	// Decode the payload with its own encoding, falling back to the configured one.
+	ciphertext, err := decodeCiphertext(encryptedData.Payload, helpers.DefaultIfEmpty(encryptedData.Encoding, c.config.EncryptionType))
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decode payload")
+	}
+	return c.open(ciphertext)
+}
+
+// Fingerprint returns FingerprintKey of this Cipher's key.
+func (c *Cipher) Fingerprint() string {
+	return c.fingerprint
+}
+
+// KeyID returns the ID recorded in payloads encrypted by this Cipher.
+func (c *Cipher) KeyID() string {
+	return c.config.KeyID
+}
+
+// EncryptString encrypts a string.
+func (c *Cipher) EncryptString(data string) (*models.EncryptReturnType, error) {
+	return c.Encrypt(data)
+}
+
+// DecryptString decrypts a payload produced by EncryptString.
+func (c *Cipher) DecryptString(encryptedData models.EncryptReturnType) (string, error) {
+	result, err := c.Decrypt(encryptedData)
+	if err != nil {
+		return "", err
+	}
+	return decryptedString(result)
+}
+
+// EncryptBytes encrypts a byte slice as is, without a JSON round trip, and tags the
+// payload with FormatBytes so Decrypt returns the bytes unchanged.
+func (c *Cipher) EncryptBytes(data []byte) (*models.EncryptReturnType, error) {
+	logger.Info("🔐 Starting encryption process")
+	ciphertext, err := c.encryptPlaintext(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.encode(ciphertext, "", FormatBytes), nil
+}
+
+// DecryptBytes decrypts a payload produced by EncryptBytes.
+func (c *Cipher) DecryptBytes(encryptedData models.EncryptReturnType) ([]byte, error) {
+	result, err := c.Decrypt(encryptedData)
+	if err != nil {
+		return nil, err
+	}
+	return decryptedBytes(result)
+}
+
+// FormatBytes tags payloads whose plaintext is raw bytes rather than JSON.
+const FormatBytes = "bytes"
+
+// unmarshalPlaintext stores decrypted plaintext in out: raw bytes for FormatBytes
+// payloads, JSON otherwise.
+func unmarshalPlaintext(plaintext []byte, format string, out interface{}) error {
+	switch format {
+	case "":
+		if err := json.Unmarshal(plaintext, out); err != nil {
+			return helpers.WrapError(err, "JSON unmarshaling failed")
+		}
+		return nil
+	case FormatBytes:
+		switch target := out.(type) {
+		case *[]byte:
+			*target = plaintext
+			return nil
+		case *interface{}:
+			*target = plaintext
+			return nil
+		}
+		return helpers.CreateErrorf("payload holds raw bytes and cannot be decrypted into %T", out)
+	}
+	return helpers.CreateErrorf("unsupported payload format %q", format)
+}
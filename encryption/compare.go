@@ -0,0 +1,30 @@
+package encryption
+
+import (
+	"crypto/sha256" // sha256 provides fixed-length digests of the compared values.
+	"crypto/subtle" // subtle provides constant-time comparison.
+)
+
+// SecureCompare reports whether a and b are equal in constant time, for API keys, OTP
+// codes, MACs, and other secrets. Both values are hashed first, so neither the
+// position of the first difference nor the lengths can be learned from the timing.
+//
+// Example:
+//
+//	if !encryption.SecureCompare([]byte(presentedKey), []byte(storedKey)) {
+//	    return helpers.CreateError("invalid API key")
+//	}
+func SecureCompare(a, b []byte) bool {
+	digestA := sha256.Sum256(a)
+	digestB := sha256.Sum256(b)
+	return subtle.ConstantTimeCompare(digestA[:], digestB[:]) == 1
+}
+
+// SecureCompareString is SecureCompare for strings.
+//
+// Example:
+//
+//	valid := encryption.SecureCompareString(submittedOTP, expectedOTP)
+func SecureCompareString(a, b string) bool {
+	return SecureCompare([]byte(a), []byte(b))
+}
@@ -4,22 +4,23 @@ import (
 	"bytes"           // bytes provides utilities for byte slice manipulation (e.g., padding).
 	"context"         // context provides support for cancellation and timeouts.
 	"crypto/aes"      // aes provides AES encryption and decryption functionality.
-	"crypto/cipher"   // cipher provides block cipher modes like CBC.
 	"crypto/rand"     // rand provides cryptographically secure random number generation.
-	"encoding/base64" // base64 provides Base64 encoding/decoding.
-	"encoding/hex"    // hex provides hexadecimal encoding/decoding.
-	"encoding/json"   // json provides JSON encoding/decoding.
+	"encoding/base64" // base64 provides decoding of legacy byte payloads.
 	"errors"          // errors provides error creation utilities.
 	"fmt"
 	"io"
-	"strings"
 	"time" // time provides functionality for timeouts and durations.
 
+	"github.com/hekimapro/utils/config" // config provides the cached encryption configuration.
 	"github.com/hekimapro/utils/helpers"
 	"github.com/hekimapro/utils/log"    // log provides colored logging utilities.
 	"github.com/hekimapro/utils/models" // models contains data structures for encryption payloads.
+	"golang.org/x/crypto/chacha20poly1305"
 )
 
+// logger is the encryption package logger; LOG_LEVEL_ENCRYPTION sets its level apart from the app's.
+var logger = log.Named("encryption")
+
 // pad applies PKCS7 padding to the plaintext to align with AES block size.
 // Returns the padded byte slice.
 func pad(src []byte, blockSize int) []byte {
@@ -59,48 +60,42 @@ func getEncryptionConfig(ctx context.Context) (*models.EncryptionConfig, error)
 		// Continue with config loading
 	}
 
-	var missing []string
-	config := &models.EncryptionConfig{
-		EncryptionType:       helpers.GetENVValue("encryption type"),
-		EncryptionKey:        helpers.GetENVValue("encryption key"),
-		InitializationVector: helpers.GetENVValue("initialization vector"),
-	}
-
-	if config.EncryptionKey == "" {
-		missing = append(missing, "ENCRYPTION_KEY")
-	}
-
-	if config.EncryptionType == "" {
-		missing = append(missing, "ENCRYPTION_TYPE")
-	}
-
-	if config.InitializationVector == "" {
-		missing = append(missing, "INITIALIZATION_VECTOR")
+	// Read the cached encryption section instead of parsing the environment on every call
+	settings := config.Get()
+	if err := settings.Err(config.SectionEncryption); err != nil {
+		return &models.EncryptionConfig{}, helpers.WrapError(err, "invalid encryption configuration")
 	}
 
-	if len(missing) > 0 {
-		return config, fmt.Errorf(".env file is missing required encryption config(s): %s", strings.Join(missing, ", "))
-	}
-
-	return config, nil
+	encryptionConfig := settings.Encryption.EncryptionConfig
+	return &encryptionConfig, nil
 }
 
 // validateEncryptionConfig validates encryption configuration parameters.
 func validateEncryptionConfig(config *models.EncryptionConfig) error {
-	// Validate that the initialization vector is exactly 16 bytes (AES block size).
-	if len(config.InitializationVector) != aes.BlockSize {
-		return errors.New("initialization vector must be exactly 16 bytes long")
-	}
-
-	// Validate that the encryption type is either "base64" or "hex".
-	if config.EncryptionType != "base64" && config.EncryptionType != "hex" {
-		return errors.New("invalid encryption type (use 'base64' or 'hex')")
+	// Validate that the encryption type is "base64", "base64url", or "hex".
+	if err := validateEncoding(config.EncryptionType); err != nil {
+		return err
 	}
 
-	// Validate encryption key length (should be 16, 24, or 32 bytes for AES)
 	keyLength := len(config.EncryptionKey)
-	if keyLength != 16 && keyLength != 24 && keyLength != 32 {
-		return fmt.Errorf("encryption key must be 16, 24, or 32 bytes long, got %d bytes", keyLength)
+	switch config.Algorithm {
+	case AlgorithmAESCBC, "":
+		// Validate that the initialization vector is exactly 16 bytes (AES block size).
+		if len(config.InitializationVector) != aes.BlockSize {
+			return errors.New("initialization vector must be exactly 16 bytes long")
+		}
+
+		// Validate encryption key length (should be 16, 24, or 32 bytes for AES)
+		if keyLength != 16 && keyLength != 24 && keyLength != 32 {
+			return fmt.Errorf("encryption key must be 16, 24, or 32 bytes long, got %d bytes", keyLength)
+		}
+	case AlgorithmChaCha20Poly1305:
+		// ChaCha20-Poly1305 uses a random nonce per message instead of the configured IV.
+		if keyLength != chacha20poly1305.KeySize {
+			return fmt.Errorf("encryption key must be %d bytes long for %s, got %d bytes", chacha20poly1305.KeySize, AlgorithmChaCha20Poly1305, keyLength)
+		}
+	default:
+		return fmt.Errorf("unsupported encryption algorithm %q (use %q or %q)", config.Algorithm, AlgorithmAESCBC, AlgorithmChaCha20Poly1305)
 	}
 
 	return nil
@@ -115,226 +110,149 @@ func generateRandomIV() ([]byte, error) {
 	return iv, nil
 }
 
-// Encrypt encrypts data using AES in CBC mode and returns an encoded payload.
-// Supports Base64 or hex encoding for the ciphertext.
+// Encrypt encrypts data using AES in CBC mode (or ChaCha20-Poly1305 when
+// ENCRYPTION_ALGORITHM selects it) and returns an encoded payload.
+// Supports base64, base64url, or hex encoding for the ciphertext. The key, IV, and encoding are
+// read from the ENCRYPTION_ configuration; use NewCipher to supply them in code.
 // Returns the encrypted payload or an error if encryption fails.
 func Encrypt(data interface{}) (*models.EncryptReturnType, error) {
 	// Create context with timeout for encryption operation
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	return encryptWithContext(ctx, data)
+	return EncryptCtx(ctx, data)
 }
 
-// encryptWithContext is the internal implementation with context support.
-func encryptWithContext(ctx context.Context, data interface{}) (*models.EncryptReturnType, error) {
-	// Log the start of the encryption process.
-	log.Info("🔐 Starting encryption process")
-
-	// Check context cancellation before starting
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "encryption cancelled before start")
-	default:
-		// Continue with encryption
-	}
-
-	config, err := getEncryptionConfig(ctx)
+// EncryptCtx is Encrypt with a caller-supplied context instead of the default 30-second
+// timeout; cancellation is honored while loading the configuration and encrypting.
+//
+// Example:
+//
+//	encrypted, err := encryption.EncryptCtx(r.Context(), record)
+func EncryptCtx(ctx context.Context, data interface{}) (*models.EncryptReturnType, error) {
+	ring, err := keyRingFromEnv(ctx)
 	if err != nil {
-		log.Error("❌ " + err.Error())
-		return nil, err
-	}
-
-	// Validate configuration
-	if err := validateEncryptionConfig(config); err != nil {
-		log.Error("❌ " + err.Error())
 		return nil, err
 	}
+	return ring.EncryptWithContext(ctx, data)
+}
 
-	// Check context cancellation after config validation
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "encryption cancelled after config validation")
-	default:
-		// Continue with encryption
-	}
+// EncryptWithEncoding is Encrypt with a payload encoding chosen for this call
+// (EncodingBase64, EncodingBase64URL, or EncodingHex) instead of ENCRYPTION_TYPE.
+// Decrypt reads the encoding back from the payload.
+//
+// Example:
+//
+//	encrypted, err := encryption.EncryptWithEncoding(resetClaims, encryption.EncodingBase64URL)
+//	link := "https://app.example.com/reset?token=" + encrypted.Payload
+func EncryptWithEncoding(data interface{}, encoding string) (*models.EncryptReturnType, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// Marshal the input data to JSON for encryption.
-	dataToEncrypt, err := json.Marshal(data)
+	ring, err := keyRingFromEnv(ctx)
 	if err != nil {
-		log.Error("❌ Failed to marshal input data: " + err.Error())
-		return nil, helpers.WrapError(err, "failed to marshal input data")
+		return nil, err
 	}
+	return ring.EncryptWithEncoding(data, encoding)
+}
 
-	// Check context cancellation after marshaling
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "encryption cancelled after data marshaling")
-	default:
-		// Continue with encryption
-	}
+// EncryptRaw is Encrypt returning the ciphertext bytes without any text encoding,
+// for binary columns and files. Decrypt them with DecryptRaw.
+func EncryptRaw(data interface{}) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// Initialize AES cipher with the provided key.
-	block, err := aes.NewCipher([]byte(config.EncryptionKey))
+	ring, err := keyRingFromEnv(ctx)
 	if err != nil {
-		log.Error("❌ Failed to initialize AES cipher: " + err.Error())
-		return nil, helpers.WrapError(err, "failed to initialize AES cipher")
-	}
-
-	// Apply PKCS7 padding to the data to match AES block size.
-	log.Info("📦 Padding data")
-	paddedData := pad(dataToEncrypt, aes.BlockSize)
-
-	// Check context cancellation after padding
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "encryption cancelled after padding")
-	default:
-		// Continue with encryption
-	}
-
-	// Perform AES-CBC encryption.
-	log.Info("🔁 Performing AES-CBC encryption")
-	mode := cipher.NewCBCEncrypter(block, []byte(config.InitializationVector))
-	ciphertext := make([]byte, len(paddedData))
-	mode.CryptBlocks(ciphertext, paddedData)
-
-	// Check context cancellation after encryption
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "encryption cancelled after encryption")
-	default:
-		// Continue with encoding
-	}
-
-	// Encode the ciphertext based on the specified encoding type.
-	var encryptedPayload string
-	if config.EncryptionType == "base64" {
-		encryptedPayload = base64.StdEncoding.EncodeToString(ciphertext)
-	} else {
-		encryptedPayload = hex.EncodeToString(ciphertext)
+		return nil, err
 	}
-
-	// Log successful encryption.
-	log.Success("✅ Data encrypted successfully")
-	return &models.EncryptReturnType{Payload: encryptedPayload}, nil
+	return ring.EncryptRaw(data)
 }
 
-// Decrypt decrypts AES-encrypted data in CBC mode and returns the original data.
-// Supports Base64 or hex-encoded input.
+// Decrypt decrypts data encrypted by Encrypt and returns the original data.
+// Supports base64, base64url, hex, and per-payload encodings. Like Encrypt, it reads the ENCRYPTION_
+// configuration; use NewCipher to supply the key in code.
 // Returns the decrypted data or an error if decryption fails.
 func Decrypt(encryptedData models.EncryptReturnType) (interface{}, error) {
 	// Create context with timeout for decryption operation
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	return decryptWithContext(ctx, encryptedData)
+	return DecryptCtx(ctx, encryptedData)
 }
 
-// decryptWithContext is the internal implementation with context support.
-func decryptWithContext(ctx context.Context, encryptedData models.EncryptReturnType) (interface{}, error) {
-	// Log the start of the decryption process.
-	log.Info("🔓 Starting decryption process")
-
-	// Check context cancellation before starting
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "decryption cancelled before start")
-	default:
-		// Continue with decryption
-	}
-
-	config, err := getEncryptionConfig(ctx)
+// DecryptCtx is Decrypt with a caller-supplied context instead of the default 30-second
+// timeout; cancellation is honored while loading the configuration and decrypting.
+func DecryptCtx(ctx context.Context, encryptedData models.EncryptReturnType) (interface{}, error) {
+	ring, err := keyRingFromEnv(ctx)
 	if err != nil {
-		log.Error("❌ " + err.Error())
 		return nil, err
 	}
+	return ring.DecryptWithContext(ctx, encryptedData)
+}
 
-	// Validate configuration
-	if err := validateEncryptionConfig(config); err != nil {
-		log.Error("❌ " + err.Error())
-		return nil, err
-	}
+// DecryptRaw decrypts ciphertext bytes produced by EncryptRaw.
+func DecryptRaw(ciphertext []byte) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// Check context cancellation after config validation
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "decryption cancelled after config validation")
-	default:
-		// Continue with decryption
+	ring, err := keyRingFromEnv(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return ring.DecryptWithContext(ctx, rawPayload(ciphertext))
+}
 
-	// Decode the encrypted payload based on the specified encoding type.
-	log.Info("📥 Decoding encrypted payload")
-	var ciphertext []byte
+// EncryptValue encrypts a value of any JSON-serializable type, like Encrypt, and is
+// the typed counterpart of DecryptInto.
+func EncryptValue[T any](value T) (*models.EncryptReturnType, error) {
+	return Encrypt(value)
+}
 
-	if config.EncryptionType == "base64" {
-		ciphertext, err = base64.StdEncoding.DecodeString(encryptedData.Payload)
-	} else {
-		ciphertext, err = hex.DecodeString(encryptedData.Payload)
-	}
-	if err != nil {
-		log.Error("❌ Failed to decode payload: " + err.Error())
-		return nil, helpers.WrapError(err, "failed to decode payload")
-	}
+// DecryptInto decrypts a payload produced by Encrypt or EncryptValue and unmarshals
+// it into T, so callers get their concrete type back instead of interface{}.
+// Use DecryptTo on a Cipher, KeyRing, or ProviderCipher for keys supplied in code.
+//
+// Example:
+//
+//	encrypted, err := encryption.EncryptValue(patient)
+//	patient, err := encryption.DecryptInto[Patient](*encrypted)
+func DecryptInto[T any](encryptedData models.EncryptReturnType) (T, error) {
+	// Create context with timeout for decryption operation
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// Check context cancellation after decoding
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "decryption cancelled after payload decoding")
-	default:
-		// Continue with decryption
-	}
+	return DecryptIntoCtx[T](ctx, encryptedData)
+}
 
-	// Initialize AES cipher with the provided key.
-	block, err := aes.NewCipher([]byte(config.EncryptionKey))
+// DecryptIntoCtx is DecryptInto with a caller-supplied context.
+func DecryptIntoCtx[T any](ctx context.Context, encryptedData models.EncryptReturnType) (T, error) {
+	var value T
+	ring, err := keyRingFromEnv(ctx)
 	if err != nil {
-		log.Error("❌ Failed to initialize AES cipher: " + err.Error())
-		return nil, helpers.WrapError(err, "failed to initialize AES cipher")
+		return value, err
 	}
-
-	// Perform AES-CBC decryption.
-	log.Info("🔁 Performing AES-CBC decryption")
-	mode := cipher.NewCBCDecrypter(block, []byte(config.InitializationVector))
-	plaintext := make([]byte, len(ciphertext))
-	mode.CryptBlocks(plaintext, ciphertext)
-
-	// Check context cancellation after decryption
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "decryption cancelled after decryption")
-	default:
-		// Continue with padding removal
+	if err := ring.decryptInto(ctx, encryptedData, &value); err != nil {
+		var zero T
+		return zero, err
 	}
+	return value, nil
+}
 
-	// Remove PKCS7 padding from the decrypted data.
-	log.Info("🧹 Removing padding")
-	plaintext, err = unpad(plaintext)
+// cipherFromEnv builds a Cipher from the ENCRYPTION_ values of the shared configuration.
+func cipherFromEnv(ctx context.Context) (*Cipher, error) {
+	config, err := getEncryptionConfig(ctx)
 	if err != nil {
-		log.Error("❌ Padding removal failed: " + err.Error())
-		return nil, helpers.WrapError(err, "padding removal failed")
-	}
-
-	// Check context cancellation after padding removal
-	select {
-	case <-ctx.Done():
-		return nil, helpers.WrapError(ctx.Err(), "decryption cancelled after padding removal")
-	default:
-		// Continue with unmarshaling
+		logger.Error("❌ " + err.Error())
+		return nil, err
 	}
 
-	// Unmarshal the decrypted JSON data into an interface.
-	log.Info("🧩 Unmarshaling decrypted data")
-	var decryptedData interface{}
-	err = json.Unmarshal(plaintext, &decryptedData)
+	aesCipher, err := NewCipher(*config)
 	if err != nil {
-		log.Error("❌ JSON unmarshaling failed: " + err.Error())
-		return nil, helpers.WrapError(err, "JSON unmarshaling failed")
+		logger.Error("❌ " + err.Error())
+		return nil, err
 	}
-
-	// Log successful decryption.
-	log.Success("✅ Data decrypted successfully")
-	return decryptedData, nil
+	return aesCipher, nil
 }
 
 // EncryptString is a convenience function for encrypting string data.
@@ -348,7 +266,11 @@ func DecryptString(encryptedData models.EncryptReturnType) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return decryptedString(result)
+}
 
+// decryptedString converts a decrypted value to a string.
+func decryptedString(result interface{}) (string, error) {
 	str, ok := result.(string)
 	if !ok {
 		return "", helpers.CreateError("decrypted data is not a string")
@@ -357,9 +279,17 @@ func DecryptString(encryptedData models.EncryptReturnType) (string, error) {
 	return str, nil
 }
 
-// EncryptBytes is a convenience function for encrypting byte data.
+// EncryptBytes encrypts byte data as is, without a JSON round trip. The payload is
+// tagged with FormatBytes so Decrypt and DecryptBytes return the bytes unchanged.
 func EncryptBytes(data []byte) (*models.EncryptReturnType, error) {
-	return Encrypt(data)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ring, err := keyRingFromEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ring.EncryptBytes(data)
 }
 
 // DecryptBytes is a convenience function for decrypting to byte data.
@@ -368,25 +298,34 @@ func DecryptBytes(encryptedData models.EncryptReturnType) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	return decryptedBytes(result)
+}
 
-	bytes, ok := result.([]byte)
-	if !ok {
-		// Try to convert if it's a slice of interfaces
-		if slice, ok := result.([]interface{}); ok {
-			bytes := make([]byte, len(slice))
-			for i, v := range slice {
-				if b, ok := v.(float64); ok {
-					bytes[i] = byte(b)
-				} else {
-					return nil, helpers.CreateError("decrypted data cannot be converted to bytes")
-				}
+// decryptedBytes converts a decrypted value to a byte slice. Besides tagged raw
+// bytes, it accepts the JSON forms of payloads encrypted before FormatBytes existed.
+func decryptedBytes(result interface{}) ([]byte, error) {
+	switch value := result.(type) {
+	case []byte:
+		return value, nil
+	case string:
+		// json.Marshal encodes []byte as a base64 string.
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, helpers.CreateError("decrypted data is not bytes")
+		}
+		return decoded, nil
+	case []interface{}:
+		decoded := make([]byte, len(value))
+		for i, v := range value {
+			b, ok := v.(float64)
+			if !ok {
+				return nil, helpers.CreateError("decrypted data cannot be converted to bytes")
 			}
-			return bytes, nil
+			decoded[i] = byte(b)
 		}
-		return nil, helpers.CreateError("decrypted data is not bytes")
+		return decoded, nil
 	}
-
-	return bytes, nil
+	return nil, helpers.CreateError("decrypted data is not bytes")
 }
 
 // GenerateEncryptionKey generates a cryptographically secure random encryption key.
@@ -436,5 +375,5 @@ func GetSupportedKeySizes() []int {
 
 // GetSupportedEncryptionTypes returns the supported encryption encoding types.
 func GetSupportedEncryptionTypes() []string {
-	return []string{"base64", "hex"}
+	return []string{EncodingBase64, EncodingBase64URL, EncodingHex}
 }
@@ -0,0 +1,200 @@
+package encryption
+
+import (
+	"context"         // context provides support for cancellation and timeouts.
+	"crypto/aes"      // aes provides the data key cipher.
+	"crypto/cipher"   // cipher provides the GCM AEAD.
+	"crypto/rand"     // rand provides data keys.
+	"encoding/base64" // base64 provides the envelope field encoding.
+	"encoding/json"   // json provides data marshaling.
+	"io"              // io provides full reads from the random source.
+	"time"            // time provides the default operation timeout.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// EnvelopeAlgorithm is the data cipher of envelopes: AES-256-GCM with a random
+// nonce prepended to the ciphertext.
+const EnvelopeAlgorithm = "aes-256-gcm"
+
+// dataKeySize is the size of envelope data keys (AES-256).
+const dataKeySize = 32
+
+// Envelope is a payload encrypted with its own random data key, stored alongside the
+// data key encrypted with a master key. Rotating the master key only re-encrypts
+// EncryptedKey (see RewrapEnvelope), never the data. Store it as JSON.
+type Envelope struct {
+	Algorithm    string `json:"algorithm"`     // Algorithm is the data cipher, EnvelopeAlgorithm
+	EncryptedKey string `json:"encrypted_key"` // EncryptedKey is the data key encrypted with the master key, base64
+	Ciphertext   string `json:"ciphertext"`    // Ciphertext is the nonce and the encrypted JSON data, base64
+}
+
+// EncryptEnvelope encrypts data with a fresh data key, wrapped with this Cipher as
+// the master key.
+//
+// Example:
+//
+//	master, _ := encryption.NewCipher(masterConfig)
+//	envelope, err := master.EncryptEnvelope(patientRecord)
+//	stored, _ := json.Marshal(envelope)
+func (c *Cipher) EncryptEnvelope(data interface{}) (*Envelope, error) {
+	logger.Info("✉️ Starting envelope encryption")
+
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		logger.Error("❌ Failed to marshal input data: " + err.Error())
+		return nil, helpers.WrapError(err, "failed to marshal input data")
+	}
+
+	// Generate a data key used for this payload only.
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, helpers.WrapError(err, "failed to generate data key")
+	}
+
+	ciphertext, err := sealGCM(dataKey, plaintext)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	encryptedKey, err := c.seal(dataKey)
+	if err != nil {
+		logger.Error("❌ Failed to encrypt data key: " + err.Error())
+		return nil, helpers.WrapError(err, "failed to encrypt data key")
+	}
+
+	logger.Success("✅ Data envelope-encrypted successfully")
+	return &Envelope{
+		Algorithm:    EnvelopeAlgorithm,
+		EncryptedKey: base64.StdEncoding.EncodeToString(encryptedKey),
+		Ciphertext:   base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// DecryptEnvelope unwraps the data key with this Cipher and decrypts the data.
+func (c *Cipher) DecryptEnvelope(envelope Envelope) (interface{}, error) {
+	logger.Info("✉️ Starting envelope decryption")
+
+	dataKey, err := c.unwrapDataKey(envelope)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decode envelope ciphertext")
+	}
+	plaintext, err := openGCM(dataKey, ciphertext)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	var decryptedData interface{}
+	if err := json.Unmarshal(plaintext, &decryptedData); err != nil {
+		return nil, helpers.WrapError(err, "JSON unmarshaling failed")
+	}
+
+	logger.Success("✅ Envelope decrypted successfully")
+	return decryptedData, nil
+}
+
+// RewrapEnvelope re-encrypts the data key of envelope from this master key to
+// newMaster, leaving the data ciphertext untouched.
+//
+// Example:
+//
+//	rotated, err := oldMaster.RewrapEnvelope(envelope, newMaster)
+func (c *Cipher) RewrapEnvelope(envelope Envelope, newMaster *Cipher) (*Envelope, error) {
+	dataKey, err := c.unwrapDataKey(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKey, err := newMaster.seal(dataKey)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to encrypt data key")
+	}
+
+	envelope.EncryptedKey = base64.StdEncoding.EncodeToString(encryptedKey)
+	return &envelope, nil
+}
+
+// unwrapDataKey checks the envelope algorithm and decrypts its data key.
+func (c *Cipher) unwrapDataKey(envelope Envelope) ([]byte, error) {
+	if envelope.Algorithm != EnvelopeAlgorithm {
+		return nil, helpers.CreateErrorf("unsupported envelope algorithm %q", envelope.Algorithm)
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(envelope.EncryptedKey)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decode envelope data key")
+	}
+	dataKey, err := c.open(encryptedKey)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decrypt data key")
+	}
+	if len(dataKey) != dataKeySize {
+		return nil, helpers.CreateError("envelope data key has an invalid size")
+	}
+	return dataKey, nil
+}
+
+// sealGCM encrypts plaintext with AES-GCM under key, prepending a random nonce.
+func sealGCM(key, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return sealAEAD(aead, plaintext)
+}
+
+// openGCM reverses sealGCM.
+func openGCM(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return openAEAD(aead, ciphertext)
+}
+
+// newGCM returns an AES-GCM AEAD for key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to initialize AES cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to initialize AES-GCM")
+	}
+	return aead, nil
+}
+
+// EncryptEnvelope envelope-encrypts data using the ENCRYPTION_ configuration as the
+// master key.
+func EncryptEnvelope(data interface{}) (*Envelope, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	master, err := cipherFromEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return master.EncryptEnvelope(data)
+}
+
+// DecryptEnvelope decrypts an envelope using the ENCRYPTION_ configuration as the
+// master key.
+func DecryptEnvelope(envelope Envelope) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	master, err := cipherFromEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return master.DecryptEnvelope(envelope)
+}
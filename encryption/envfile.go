@@ -0,0 +1,131 @@
+package encryption
+
+import (
+	"bytes"           // bytes provides parsing of the decrypted file.
+	"context"         // context provides support for cancellation and timeouts.
+	"encoding/base64" // base64 provides the file body encoding.
+	"os"              // os provides file reads and writes.
+	"strings"         // strings provides header handling.
+
+	"github.com/hekimapro/utils/env"     // env provides the configuration source registry.
+	"github.com/hekimapro/utils/helpers" // helpers provides environment and error utilities.
+	"github.com/joho/godotenv"           // godotenv provides .env parsing.
+)
+
+// DefaultEncryptedEnvFile is the encrypted secrets file read when SECRETS_FILE is not set.
+const DefaultEncryptedEnvFile = ".env.enc"
+
+// encryptedEnvHeader is the first line of an encrypted secrets file.
+const encryptedEnvHeader = "# hekimapro/utils encrypted env v1 (aes-256-gcm)"
+
+// EncryptEnvFile encrypts a plaintext .env file with a 32-byte master key, writing a
+// file that is safe to bake into images or commit. Run it when secrets change and
+// ship only the encrypted file.
+//
+// Example:
+//
+//	err := encryption.EncryptEnvFile(".env.production", ".env.enc", os.Getenv("SECRETS_MASTER_KEY"))
+func EncryptEnvFile(plaintextPath, encryptedPath, masterKey string) error {
+	plaintext, err := os.ReadFile(plaintextPath)
+	if err != nil {
+		return helpers.WrapError(err, "failed to read plaintext env file")
+	}
+	// Refuse files godotenv cannot parse rather than failing at startup.
+	if _, err := godotenv.UnmarshalBytes(plaintext); err != nil {
+		return helpers.WrapError(err, "invalid env file")
+	}
+	if len(masterKey) != dataKeySize {
+		return helpers.CreateErrorf("master key must be %d bytes long, got %d bytes", dataKeySize, len(masterKey))
+	}
+
+	ciphertext, err := sealGCM([]byte(masterKey), plaintext)
+	if err != nil {
+		return err
+	}
+
+	content := encryptedEnvHeader + "\n" + base64.StdEncoding.EncodeToString(ciphertext) + "\n"
+	if err := os.WriteFile(encryptedPath, []byte(content), 0o600); err != nil {
+		return helpers.WrapError(err, "failed to write encrypted env file")
+	}
+	logger.Success("✅ Encrypted env file written to " + encryptedPath)
+	return nil
+}
+
+// DecryptEnvFile decrypts a file written by EncryptEnvFile and returns its variables.
+func DecryptEnvFile(encryptedPath, masterKey string) (map[string]string, error) {
+	content, err := os.ReadFile(encryptedPath)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to read encrypted env file")
+	}
+
+	body, found := strings.CutPrefix(strings.TrimSpace(string(content)), encryptedEnvHeader)
+	if !found {
+		return nil, helpers.CreateErrorf("%s is not an encrypted env file", encryptedPath)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(body))
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decode encrypted env file")
+	}
+	if len(masterKey) != dataKeySize {
+		return nil, helpers.CreateErrorf("master key must be %d bytes long, got %d bytes", dataKeySize, len(masterKey))
+	}
+
+	plaintext, err := openGCM([]byte(masterKey), ciphertext)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decrypt env file (wrong master key?)")
+	}
+	values, err := godotenv.Parse(bytes.NewReader(plaintext))
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to parse decrypted env file")
+	}
+	return values, nil
+}
+
+// EncryptedEnvSource is an env.Source reading a file written by EncryptEnvFile, with
+// the master key fetched from a KeyProvider (the environment, a file, AWS KMS, or
+// Vault). Like other sources, variables already set locally win.
+type EncryptedEnvSource struct {
+	Path        string      // Path is the encrypted file (default DefaultEncryptedEnvFile)
+	KeyProvider KeyProvider // KeyProvider supplies the 32-byte master key
+}
+
+// NewEncryptedEnvSourceFromEnv builds an EncryptedEnvSource from SECRETS_FILE and the
+// master key in SECRETS_MASTER_KEY.
+func NewEncryptedEnvSourceFromEnv() *EncryptedEnvSource {
+	return &EncryptedEnvSource{
+		Path:        helpers.GetENVValueWithDefault("SECRETS_FILE", DefaultEncryptedEnvFile),
+		KeyProvider: &EnvKeyProvider{KeyVariable: "SECRETS_MASTER_KEY"},
+	}
+}
+
+// Name identifies the source in logs and errors.
+func (s *EncryptedEnvSource) Name() string {
+	return "encrypted-file:" + helpers.DefaultIfEmpty(s.Path, DefaultEncryptedEnvFile)
+}
+
+// Load fetches the master key and decrypts the file.
+func (s *EncryptedEnvSource) Load(ctx context.Context) (map[string]string, error) {
+	if s.KeyProvider == nil {
+		return nil, helpers.CreateError("encrypted env source requires a key provider")
+	}
+	masterKey, err := s.KeyProvider.FetchKey(ctx)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to fetch master key from %s", s.KeyProvider.Name())
+	}
+	return DecryptEnvFile(helpers.DefaultIfEmpty(s.Path, DefaultEncryptedEnvFile), masterKey.Key)
+}
+
+// LoadEncryptedEnv registers the encrypted secrets file described by SECRETS_FILE and
+// SECRETS_MASTER_KEY as an env source and loads the registered sources, so the
+// secrets are visible through helpers.GetENVValue and env.Bind. Call it at startup
+// before anything reads the configuration.
+//
+// Example:
+//
+//	if err := encryption.LoadEncryptedEnv(ctx); err != nil {
+//	    log.Fatal("❌ " + err.Error())
+//	}
+func LoadEncryptedEnv(ctx context.Context) error {
+	env.AddSource(NewEncryptedEnvSourceFromEnv())
+	return env.LoadSources(ctx)
+}
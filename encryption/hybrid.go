@@ -0,0 +1,105 @@
+package encryption
+
+import (
+	"crypto/rand"     // rand provides data keys.
+	"encoding/base64" // base64 provides the payload field encoding.
+	"io"              // io provides full reads from the random source.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// HybridKeyAlgorithm is the key wrapping of hybrid payloads: RSA-OAEP with SHA-256.
+const HybridKeyAlgorithm = "rsa-oaep-sha256"
+
+// HybridPayload is data encrypted for an RSA public key: the data is encrypted with a
+// random AES-256-GCM key, and that key is encrypted with RSA-OAEP. Unlike plain
+// RSA-OAEP, the data can be any size. Send it as JSON.
+type HybridPayload struct {
+	KeyAlgorithm  string `json:"key_algorithm"`  // KeyAlgorithm is the key wrapping, HybridKeyAlgorithm
+	DataAlgorithm string `json:"data_algorithm"` // DataAlgorithm is the data cipher, EnvelopeAlgorithm
+	EncryptedKey  string `json:"encrypted_key"`  // EncryptedKey is the RSA-OAEP encrypted data key, base64
+	Ciphertext    string `json:"ciphertext"`     // Ciphertext is the nonce and the encrypted data, base64
+}
+
+// HybridEncrypt encrypts data of any size for a partner's PEM RSA public key.
+//
+// Example:
+//
+//	payload, err := encryption.HybridEncrypt(partnerPublicKeyPEM, reportPDF)
+//	body, _ := json.Marshal(payload)
+func HybridEncrypt(publicKeyPEM string, data []byte) (*HybridPayload, error) {
+	logger.Info("🔐 Starting hybrid RSA+AES encryption")
+
+	publicKey, err := ParseRSAPublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		logger.Error("❌ Invalid RSA public key: " + err.Error())
+		return nil, err
+	}
+
+	// Generate a data key used for this payload only.
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, helpers.WrapError(err, "failed to generate data key")
+	}
+
+	ciphertext, err := sealGCM(dataKey, data)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	encryptedKey, err := EncryptOAEP(publicKey, dataKey, nil)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	logger.Success("✅ Data encrypted with hybrid RSA+AES")
+	return &HybridPayload{
+		KeyAlgorithm:  HybridKeyAlgorithm,
+		DataAlgorithm: EnvelopeAlgorithm,
+		EncryptedKey:  base64.StdEncoding.EncodeToString(encryptedKey),
+		Ciphertext:    base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// HybridDecrypt decrypts a HybridPayload with the matching PEM RSA private key.
+func HybridDecrypt(privateKeyPEM string, payload HybridPayload) ([]byte, error) {
+	logger.Info("🔓 Starting hybrid RSA+AES decryption")
+
+	if payload.KeyAlgorithm != HybridKeyAlgorithm || payload.DataAlgorithm != EnvelopeAlgorithm {
+		return nil, helpers.CreateErrorf("unsupported hybrid algorithms %q and %q", payload.KeyAlgorithm, payload.DataAlgorithm)
+	}
+
+	privateKey, err := ParseRSAPrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		logger.Error("❌ Invalid RSA private key: " + err.Error())
+		return nil, err
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(payload.EncryptedKey)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decode hybrid data key")
+	}
+	dataKey, err := DecryptOAEP(privateKey, encryptedKey, nil)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+	if len(dataKey) != dataKeySize {
+		return nil, helpers.CreateError("hybrid data key has an invalid size")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(payload.Ciphertext)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to decode hybrid ciphertext")
+	}
+	plaintext, err := openGCM(dataKey, ciphertext)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	logger.Success("✅ Data decrypted with hybrid RSA+AES")
+	return plaintext, nil
+}
@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"crypto/rand"   // rand provides salt generation.
+	"crypto/sha256" // sha256 provides the PBKDF2 PRF.
+	"io"            // io provides full reads from the random source.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"golang.org/x/crypto/argon2"         // argon2 provides Argon2id key derivation.
+	"golang.org/x/crypto/pbkdf2"         // pbkdf2 provides PBKDF2 key derivation.
+	"golang.org/x/crypto/scrypt"         // scrypt provides scrypt key derivation.
+)
+
+// Supported key derivation functions.
+const (
+	KDFArgon2id = "argon2id" // KDFArgon2id is memory-hard and the recommended default
+	KDFScrypt   = "scrypt"   // KDFScrypt is memory-hard and widely supported
+	KDFPBKDF2   = "pbkdf2"   // KDFPBKDF2 is PBKDF2-HMAC-SHA256, for FIPS environments and interoperability
+)
+
+// MinSaltSize is the smallest salt DeriveKey accepts.
+const MinSaltSize = 8
+
+// KDFParams configures DeriveKey. Zero fields use the defaults of DefaultKDFParams.
+type KDFParams struct {
+	Algorithm  string // Algorithm is KDFArgon2id, KDFScrypt, or KDFPBKDF2 (default KDFArgon2id)
+	KeyLength  int    // KeyLength is the derived key size in bytes (default 32, for AES-256)
+	Iterations int    // Iterations is the PBKDF2 iteration count (default 600000)
+	N          int    // N is the scrypt CPU/memory cost, a power of two (default 32768)
+	R          int    // R is the scrypt block size (default 8)
+	P          int    // P is the scrypt parallelization (default 1)
+	Time       uint32 // Time is the Argon2id number of passes (default 3)
+	Memory     uint32 // Memory is the Argon2id memory in KiB (default 65536, i.e. 64 MiB)
+	Threads    uint8  // Threads is the Argon2id parallelism (default 4)
+}
+
+// DefaultKDFParams returns Argon2id parameters producing a 32-byte key, following the
+// OWASP recommendations, with scrypt and PBKDF2 defaults filled in as well.
+func DefaultKDFParams() KDFParams {
+	return KDFParams{
+		Algorithm:  KDFArgon2id,
+		KeyLength:  32,
+		Iterations: 600000,
+		N:          32768,
+		R:          8,
+		P:          1,
+		Time:       3,
+		Memory:     64 * 1024,
+		Threads:    4,
+	}
+}
+
+// withDefaults fills zero fields from DefaultKDFParams.
+func (p KDFParams) withDefaults() KDFParams {
+	defaults := DefaultKDFParams()
+	p.Algorithm = helpers.DefaultIfEmpty(p.Algorithm, defaults.Algorithm)
+	if p.KeyLength <= 0 {
+		p.KeyLength = defaults.KeyLength
+	}
+	if p.Iterations <= 0 {
+		p.Iterations = defaults.Iterations
+	}
+	if p.N <= 0 {
+		p.N = defaults.N
+	}
+	if p.R <= 0 {
+		p.R = defaults.R
+	}
+	if p.P <= 0 {
+		p.P = defaults.P
+	}
+	if p.Time == 0 {
+		p.Time = defaults.Time
+	}
+	if p.Memory == 0 {
+		p.Memory = defaults.Memory
+	}
+	if p.Threads == 0 {
+		p.Threads = defaults.Threads
+	}
+	return p
+}
+
+// DeriveKey derives a key from a human passphrase, deterministically for the same
+// passphrase, salt, and parameters. Store the salt (it is not secret) and the
+// parameters next to the data so the key can be derived again.
+//
+// Example:
+//
+//	salt, _ := encryption.GenerateSalt(16)
+//	key, err := encryption.DeriveKey(passphrase, salt, encryption.DefaultKDFParams())
+//	aesCipher, err := encryption.NewCipher(models.EncryptionConfig{
+//	    EncryptionKey: string(key), EncryptionType: "base64", InitializationVector: iv,
+//	})
+func DeriveKey(passphrase string, salt []byte, params KDFParams) ([]byte, error) {
+	if passphrase == "" {
+		return nil, helpers.CreateError("passphrase cannot be empty")
+	}
+	if len(salt) < MinSaltSize {
+		return nil, helpers.CreateErrorf("salt must be at least %d bytes long, got %d bytes", MinSaltSize, len(salt))
+	}
+
+	params = params.withDefaults()
+	switch params.Algorithm {
+	case KDFArgon2id:
+		return argon2.IDKey([]byte(passphrase), salt, params.Time, params.Memory, params.Threads, uint32(params.KeyLength)), nil
+	case KDFScrypt:
+		key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.KeyLength)
+		if err != nil {
+			return nil, helpers.WrapError(err, "scrypt key derivation failed")
+		}
+		return key, nil
+	case KDFPBKDF2:
+		return pbkdf2.Key([]byte(passphrase), salt, params.Iterations, params.KeyLength, sha256.New), nil
+	}
+	return nil, helpers.CreateErrorf("unsupported key derivation function %q (use %s, %s, or %s)",
+		params.Algorithm, KDFArgon2id, KDFScrypt, KDFPBKDF2)
+}
+
+// GenerateSalt returns size cryptographically secure random bytes (default 16).
+func GenerateSalt(size int) ([]byte, error) {
+	if size == 0 {
+		size = 16
+	}
+	if size < MinSaltSize {
+		return nil, helpers.CreateErrorf("salt must be at least %d bytes long, got %d bytes", MinSaltSize, size)
+	}
+
+	salt := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, helpers.WrapError(err, "failed to generate salt")
+	}
+	return salt, nil
+}
@@ -0,0 +1,306 @@
+package encryption
+
+import (
+	"context"       // context provides support for cancellation.
+	"encoding/json" // json provides validation of re-encrypted plaintext.
+	"errors"        // errors provides joining of fallback failures.
+	"strings"       // strings provides parsing of id=key entries.
+	"sync"          // sync protects the key set.
+
+	"github.com/hekimapro/utils/config"  // config provides the cached ENCRYPTION_ settings.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/models"  // models contains data structures for encryption payloads.
+)
+
+// KeyRing holds several versioned keys: Encrypt always uses the primary (newest) key
+// and records its ID in the payload, and Decrypt picks the key named by the payload,
+// so rotating the key does not break records encrypted before the rotation.
+// A KeyRing is safe for concurrent use.
+type KeyRing struct {
+	mu      sync.RWMutex
+	ciphers map[string]*Cipher
+	order   []string // order lists key IDs from oldest to newest
+	primary string
+}
+
+// NewKeyRing creates an empty key ring.
+//
+// Example:
+//
+//	ring := encryption.NewKeyRing()
+//	ring.Add(models.EncryptionConfig{KeyID: "2024-01", EncryptionKey: oldKey, EncryptionType: "base64", InitializationVector: oldIV})
+//	ring.Add(models.EncryptionConfig{KeyID: "2025-06", EncryptionKey: newKey, EncryptionType: "base64", InitializationVector: newIV})
+//	encrypted, err := ring.Encrypt(record) // encrypted.KeyID == "2025-06"
+//	record, err := ring.Decrypt(oldPayload) // uses "2024-01"
+func NewKeyRing() *KeyRing {
+	return &KeyRing{ciphers: make(map[string]*Cipher)}
+}
+
+// Add validates keyConfig and adds its key, which becomes the primary key. Adding an
+// existing ID replaces that key. keyConfig.KeyID may be empty only for a single
+// unversioned legacy key.
+func (k *KeyRing) Add(keyConfig models.EncryptionConfig) error {
+	aesCipher, err := NewCipher(keyConfig)
+	if err != nil {
+		return helpers.WrapErrorf(err, "invalid key %q", keyConfig.KeyID)
+	}
+	return k.AddCipher(aesCipher)
+}
+
+// AddCipher adds a Cipher under its key ID and makes it the primary key.
+func (k *KeyRing) AddCipher(aesCipher *Cipher) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	keyID := aesCipher.KeyID()
+	if _, exists := k.ciphers[keyID]; !exists {
+		k.order = append(k.order, keyID)
+	}
+	k.ciphers[keyID] = aesCipher
+	k.primary = keyID
+	return nil
+}
+
+// SetPrimary makes an existing key the one used by Encrypt, e.g. to roll back a rotation.
+func (k *KeyRing) SetPrimary(keyID string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, exists := k.ciphers[keyID]; !exists {
+		return helpers.CreateErrorf("unknown encryption key %q", keyID)
+	}
+	k.primary = keyID
+	return nil
+}
+
+// Primary returns the ID of the key used by Encrypt.
+func (k *KeyRing) Primary() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.primary
+}
+
+// KeyIDs returns the IDs of the keys in the ring, oldest first.
+func (k *KeyRing) KeyIDs() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return append([]string(nil), k.order...)
+}
+
+// Cipher returns the Cipher of a key ID.
+func (k *KeyRing) Cipher(keyID string) (*Cipher, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	aesCipher, exists := k.ciphers[keyID]
+	return aesCipher, exists
+}
+
+// primaryCipher returns the Cipher used for encryption.
+func (k *KeyRing) primaryCipher() (*Cipher, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.ciphers) == 0 {
+		return nil, helpers.CreateError("key ring is empty")
+	}
+	return k.ciphers[k.primary], nil
+}
+
+// Encrypt encrypts data with the primary key.
+func (k *KeyRing) Encrypt(data interface{}) (*models.EncryptReturnType, error) {
+	return k.EncryptWithContext(context.Background(), data)
+}
+
+// EncryptWithContext is Encrypt with cancellation support.
+func (k *KeyRing) EncryptWithContext(ctx context.Context, data interface{}) (*models.EncryptReturnType, error) {
+	aesCipher, err := k.primaryCipher()
+	if err != nil {
+		return nil, err
+	}
+	return aesCipher.EncryptWithContext(ctx, data)
+}
+
+// EncryptWithEncoding encrypts data with the primary key and a payload encoding
+// chosen for this call.
+func (k *KeyRing) EncryptWithEncoding(data interface{}, encoding string) (*models.EncryptReturnType, error) {
+	aesCipher, err := k.primaryCipher()
+	if err != nil {
+		return nil, err
+	}
+	return aesCipher.EncryptWithEncoding(data, encoding)
+}
+
+// EncryptRaw encrypts data with the primary key and returns the ciphertext bytes.
+// Raw ciphertext carries no key ID, so DecryptRaw tries every key.
+func (k *KeyRing) EncryptRaw(data interface{}) ([]byte, error) {
+	aesCipher, err := k.primaryCipher()
+	if err != nil {
+		return nil, err
+	}
+	return aesCipher.EncryptRaw(data)
+}
+
+// EncryptBytes encrypts a byte slice with the primary key, without a JSON round trip.
+func (k *KeyRing) EncryptBytes(data []byte) (*models.EncryptReturnType, error) {
+	aesCipher, err := k.primaryCipher()
+	if err != nil {
+		return nil, err
+	}
+	return aesCipher.EncryptBytes(data)
+}
+
+// Decrypt decrypts a payload with the key named by its KeyID. Payloads without a
+// KeyID (encrypted before keys were versioned) are tried against every key, newest first.
+func (k *KeyRing) Decrypt(encryptedData models.EncryptReturnType) (interface{}, error) {
+	return k.DecryptWithContext(context.Background(), encryptedData)
+}
+
+// DecryptWithContext is Decrypt with cancellation support.
+func (k *KeyRing) DecryptWithContext(ctx context.Context, encryptedData models.EncryptReturnType) (interface{}, error) {
+	var decryptedData interface{}
+	if err := k.decryptInto(ctx, encryptedData, &decryptedData); err != nil {
+		return nil, err
+	}
+	return decryptedData, nil
+}
+
+// DecryptRaw decrypts ciphertext bytes produced by EncryptRaw, trying every key
+// newest first.
+func (k *KeyRing) DecryptRaw(ciphertext []byte) (interface{}, error) {
+	return k.Decrypt(rawPayload(ciphertext))
+}
+
+// DecryptTo decrypts a payload and unmarshals the JSON into out, which must be a
+// pointer, like json.Unmarshal.
+func (k *KeyRing) DecryptTo(encryptedData models.EncryptReturnType, out interface{}) error {
+	return k.decryptInto(context.Background(), encryptedData, out)
+}
+
+// decryptInto picks the key of a payload, decrypts it, and unmarshals the JSON into out.
+func (k *KeyRing) decryptInto(ctx context.Context, encryptedData models.EncryptReturnType, out interface{}) error {
+	if encryptedData.KeyID != "" {
+		aesCipher, exists := k.Cipher(encryptedData.KeyID)
+		if !exists {
+			logger.Error("❌ Unknown encryption key: " + encryptedData.KeyID)
+			return helpers.CreateErrorf("unknown encryption key %q", encryptedData.KeyID)
+		}
+		return aesCipher.decryptInto(ctx, encryptedData, out)
+	}
+
+	logger.Info("🔓 Starting decryption of unversioned payload")
+	if err := ctx.Err(); err != nil {
+		return helpers.WrapError(err, "decryption cancelled before start")
+	}
+
+	keyIDs := k.KeyIDs()
+	var failures []error
+	var unmarshalErr error
+	for i := len(keyIDs) - 1; i >= 0; i-- {
+		aesCipher, _ := k.Cipher(keyIDs[i])
+		plaintext, err := aesCipher.decryptPayload(encryptedData)
+		if err != nil {
+			failures = append(failures, helpers.WrapErrorf(err, "key %q", keyIDs[i]))
+			continue
+		}
+		if err := unmarshalPlaintext(plaintext, encryptedData.Format, out); err != nil {
+			// The key may be right and the destination type wrong; keep trying the others.
+			unmarshalErr = err
+			continue
+		}
+		logger.Success("✅ Data decrypted successfully with key " + keyIDs[i])
+		return nil
+	}
+
+	if unmarshalErr != nil {
+		logger.Error("❌ " + unmarshalErr.Error())
+		return unmarshalErr
+	}
+	logger.Error("❌ No key in the key ring decrypts the payload")
+	return helpers.WrapError(errors.Join(failures...), "no key in the key ring decrypts the payload")
+}
+
+// Reencrypt decrypts a payload and encrypts it again with the primary key, for
+// migrating stored records after a rotation. The plaintext is sealed again as is,
+// without a JSON round trip, and the payload keeps its Format and Encoding. Payloads
+// already on the primary key are returned unchanged.
+func (k *KeyRing) Reencrypt(encryptedData models.EncryptReturnType) (*models.EncryptReturnType, error) {
+	if encryptedData.KeyID != "" && encryptedData.KeyID == k.Primary() {
+		return &encryptedData, nil
+	}
+	primary, err := k.primaryCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, source, err := k.openPayload(encryptedData)
+	if err != nil {
+		logger.Error("❌ Re-encryption failed: " + err.Error())
+		return nil, err
+	}
+	ciphertext, err := primary.encryptPlaintext(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	// A payload without an encoding was encoded with its key's EncryptionType.
+	encoding := helpers.DefaultIfEmpty(encryptedData.Encoding, source.config.EncryptionType)
+	return primary.encode(ciphertext, encoding, encryptedData.Format), nil
+}
+
+// openPayload decrypts a payload to its plaintext and returns the key that opened it.
+// Unversioned payloads are tried against every key, newest first; since a wrong key
+// can still produce valid padding, a key only counts when the plaintext is JSON,
+// unless the payload holds raw bytes.
+func (k *KeyRing) openPayload(encryptedData models.EncryptReturnType) ([]byte, *Cipher, error) {
+	if encryptedData.KeyID != "" {
+		aesCipher, exists := k.Cipher(encryptedData.KeyID)
+		if !exists {
+			return nil, nil, helpers.CreateErrorf("unknown encryption key %q", encryptedData.KeyID)
+		}
+		plaintext, err := aesCipher.decryptPayload(encryptedData)
+		return plaintext, aesCipher, err
+	}
+
+	keyIDs := k.KeyIDs()
+	var failures []error
+	for i := len(keyIDs) - 1; i >= 0; i-- {
+		aesCipher, _ := k.Cipher(keyIDs[i])
+		plaintext, err := aesCipher.decryptPayload(encryptedData)
+		if err != nil {
+			failures = append(failures, helpers.WrapErrorf(err, "key %q", keyIDs[i]))
+			continue
+		}
+		if encryptedData.Format != FormatBytes && !json.Valid(plaintext) {
+			failures = append(failures, helpers.CreateErrorf("key %q: decrypted data is not JSON", keyIDs[i]))
+			continue
+		}
+		return plaintext, aesCipher, nil
+	}
+	return nil, nil, helpers.WrapError(errors.Join(failures...), "no key in the key ring decrypts the payload")
+}
+
+// keyRingFromEnv builds a key ring from the ENCRYPTION_ configuration: the retired
+// keys of ENCRYPTION_PREVIOUS_KEYS, which share the algorithm, encoding, and IV of the
+// current key, followed by the current key as the primary.
+func keyRingFromEnv(ctx context.Context) (*KeyRing, error) {
+	current, err := getEncryptionConfig(ctx)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	ring := NewKeyRing()
+	for _, entry := range config.Get().Encryption.PreviousKeys {
+		keyID, key, _ := strings.Cut(entry, "=")
+		previous := *current
+		previous.KeyID, previous.EncryptionKey = keyID, key
+		if err := ring.Add(previous); err != nil {
+			logger.Error("❌ " + err.Error())
+			return nil, err
+		}
+	}
+	if err := ring.Add(*current); err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+	return ring, nil
+}
@@ -1,13 +1,21 @@
 package encryption
 
 import (
-	"context" // context provides support for cancellation and timeouts.
-	"fmt"     // fmt provides formatting and printing functions.
-	"time"    // time provides functionality for timeouts and durations.
-
+	"context"         // context provides support for cancellation and timeouts.
+	"crypto/rand"     // rand provides password salts.
+	"encoding/base64" // base64 provides PHC salt and hash encoding.
+	"errors"          // errors provides the verification sentinel errors.
+	"fmt"             // fmt provides formatting and printing functions.
+	"math/bits"       // bits provides log2 of the scrypt cost.
+	"strings"         // strings provides PHC string parsing.
+	"sync"            // sync protects the recommended cost cache.
+	"time"            // time provides functionality for timeouts and durations.
+
+	"github.com/hekimapro/utils/config"  // config provides the password hashing target.
 	"github.com/hekimapro/utils/helpers" // helpers provides utility functions.
-	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"golang.org/x/crypto/argon2"         // argon2 provides Argon2id password hashing.
 	"golang.org/x/crypto/bcrypt"         // bcrypt provides password hashing and verification functions.
+	"golang.org/x/crypto/scrypt"         // scrypt provides scrypt password hashing.
 )
 
 // CreateHash generates a bcrypt hash from a plain text password.
@@ -31,11 +39,11 @@ func createHashWithContext(ctx context.Context, Password string) (string, error)
 	}
 
 	// Log the start of the password hashing process.
-	log.Info("🔐 Generating bcrypt hash from password")
+	logger.Info("🔐 Generating bcrypt hash from password")
 
 	// Validate input
 	if Password == "" {
-		log.Error("❌ Cannot hash empty password")
+		logger.Error("❌ Cannot hash empty password")
 		return "", helpers.CreateError("password cannot be empty")
 	}
 
@@ -52,17 +60,17 @@ func createHashWithContext(ctx context.Context, Password string) (string, error)
 	select {
 	case <-ctx.Done():
 		// Context was cancelled or timed out
-		log.Warning("⚠️ Password hashing operation cancelled or timed out")
+		logger.Warning("⚠️ Password hashing operation cancelled or timed out")
 		return "", helpers.WrapError(ctx.Err(), "password hashing cancelled")
 	case result := <-resultChan:
 		if result.err != nil {
 			// Log and return an error if hashing fails.
-			log.Error("❌ Failed to generate hash: " + result.err.Error())
+			logger.Error("❌ Failed to generate hash: " + result.err.Error())
 			return "", helpers.WrapError(result.err, "failed to generate password hash")
 		}
 
 		// Log successful hash generation.
-		log.Success("✅ Password hash created successfully")
+		logger.Success("✅ Password hash created successfully")
 		// Convert the hash to a string and return it.
 		return result.hash, nil
 	}
@@ -83,22 +91,22 @@ func compareWithHashContext(ctx context.Context, HashedString string, Password s
 	// Check context cancellation before starting
 	select {
 	case <-ctx.Done():
-		log.Warning("⚠️ Password verification cancelled before start")
+		logger.Warning("⚠️ Password verification cancelled before start")
 		return false
 	default:
 		// Continue with verification
 	}
 
 	// Log the start of the password verification process.
-	log.Info("🔎 Verifying password against bcrypt hash")
+	logger.Info("🔎 Verifying password against bcrypt hash")
 
 	// Validate inputs
 	if HashedString == "" {
-		log.Error("❌ Cannot verify with empty hash")
+		logger.Error("❌ Cannot verify with empty hash")
 		return false
 	}
 	if Password == "" {
-		log.Error("❌ Cannot verify empty password")
+		logger.Error("❌ Cannot verify empty password")
 		return false
 	}
 
@@ -115,17 +123,17 @@ func compareWithHashContext(ctx context.Context, HashedString string, Password s
 	select {
 	case <-ctx.Done():
 		// Context was cancelled or timed out
-		log.Warning("⚠️ Password verification cancelled or timed out")
+		logger.Warning("⚠️ Password verification cancelled or timed out")
 		return false
 	case result := <-resultChan:
 		if !result {
 			// Log and return false if the password does not match.
-			log.Error("❌ Password does not match hash")
+			logger.Error("❌ Password does not match hash")
 			return false
 		}
 
 		// Log successful password verification.
-		log.Success("✅ Password verification successful")
+		logger.Success("✅ Password verification successful")
 		return true
 	}
 }
@@ -158,16 +166,16 @@ func createHashWithCostContext(ctx context.Context, Password string, cost int) (
 
 	// Validate cost factor
 	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
-		log.Warning(fmt.Sprintf("⚠️ Cost factor %d is outside recommended range (%d-%d), using default",
+		logger.Warning(fmt.Sprintf("⚠️ Cost factor %d is outside recommended range (%d-%d), using default",
 			cost, bcrypt.MinCost, bcrypt.MaxCost))
 		cost = bcrypt.DefaultCost
 	}
 
-	log.Info(fmt.Sprintf("🔐 Generating bcrypt hash with cost factor %d", cost))
+	logger.Info(fmt.Sprintf("🔐 Generating bcrypt hash with cost factor %d", cost))
 
 	// Validate input
 	if Password == "" {
-		log.Error("❌ Cannot hash empty password")
+		logger.Error("❌ Cannot hash empty password")
 		return "", helpers.CreateError("password cannot be empty")
 	}
 
@@ -184,17 +192,17 @@ func createHashWithCostContext(ctx context.Context, Password string, cost int) (
 	select {
 	case <-ctx.Done():
 		// Context was cancelled or timed out
-		log.Warning("⚠️ Password hashing with custom cost cancelled or timed out")
+		logger.Warning("⚠️ Password hashing with custom cost cancelled or timed out")
 		return "", helpers.WrapError(ctx.Err(), "password hashing with custom cost cancelled")
 	case result := <-resultChan:
 		if result.err != nil {
 			// Log and return an error if hashing fails.
-			log.Error("❌ Failed to generate hash with custom cost: " + result.err.Error())
+			logger.Error("❌ Failed to generate hash with custom cost: " + result.err.Error())
 			return "", helpers.WrapError(result.err, "failed to generate password hash with custom cost")
 		}
 
 		// Log successful hash generation.
-		log.Success("✅ Password hash created successfully with custom cost")
+		logger.Success("✅ Password hash created successfully with custom cost")
 		// Convert the hash to a string and return it.
 		return result.hash, nil
 	}
@@ -255,15 +263,57 @@ func NeedsRehash(hashedString string, minCost int) (bool, error) {
 	return currentCost < minCost, nil
 }
 
-// GetRecommendedCost returns the recommended bcrypt cost factor for the current system.
-// This can be used to automatically adjust cost based on system performance.
+// DefaultPasswordHashTarget is the hashing time GetRecommendedCost aims for when
+// PASSWORD_HASH_TARGET is not set.
+const DefaultPasswordHashTarget = 250 * time.Millisecond
+
+// recommendedCosts caches benchmark results by target duration.
+var (
+	recommendedCostsMu sync.Mutex
+	recommendedCosts   = make(map[time.Duration]int)
+)
+
+// GetRecommendedCost returns the highest bcrypt cost factor that keeps hashing on this
+// host under PASSWORD_HASH_TARGET (default 250ms). See GetRecommendedCostFor.
 func GetRecommendedCost() int {
-	// Start with default cost
+	return GetRecommendedCostFor(config.Get().Encryption.PasswordHashTarget)
+}
+
+// GetRecommendedCostFor benchmarks bcrypt on this host and returns the highest cost
+// factor whose hashing time stays under target. Each cost step doubles the time, so
+// one hash at the default cost is enough to extrapolate. The result is cached per
+// target, so only the first call pays for the benchmark. The result is never below
+// bcrypt.DefaultCost, even on hosts too slow to meet target.
+//
+// Example:
+//
+//	hash, err := encryption.CreateHashWithCost(password, encryption.GetRecommendedCostFor(500*time.Millisecond))
+func GetRecommendedCostFor(target time.Duration) int {
+	if target <= 0 {
+		target = DefaultPasswordHashTarget
+	}
+
+	recommendedCostsMu.Lock()
+	defer recommendedCostsMu.Unlock()
+	if cost, cached := recommendedCosts[target]; cached {
+		return cost
+	}
+
 	cost := bcrypt.DefaultCost
+	start := time.Now()
+	if _, err := bcrypt.GenerateFromPassword([]byte("benchmark-password"), cost); err != nil {
+		logger.Warning("⚠️ bcrypt benchmark failed, using default cost: " + err.Error())
+		return cost
+	}
+	elapsed := time.Since(start)
 
-	// In a real implementation, you might want to benchmark the system
-	// and adjust the cost factor accordingly. For now, we return the default.
+	for cost < bcrypt.MaxCost && elapsed*2 <= target {
+		cost++
+		elapsed *= 2
+	}
 
+	logger.Info(fmt.Sprintf("⏱️ Recommended bcrypt cost %d (about %v per hash, target %v)", cost, elapsed, target))
+	recommendedCosts[target] = cost
 	return cost
 }
 
@@ -287,7 +337,7 @@ func hashAndVerifyContext(ctx context.Context, Password string) (string, error)
 		// Continue with operation
 	}
 
-	log.Info("🔐 Generating and verifying password hash")
+	logger.Info("🔐 Generating and verifying password hash")
 
 	// Generate the hash
 	hashed, err := createHashWithContext(ctx, Password)
@@ -308,6 +358,493 @@ func hashAndVerifyContext(ctx context.Context, Password string) (string, error)
 		return "", helpers.CreateError("generated hash failed verification against original password")
 	}
 
-	log.Success("✅ Password hash generated and verified successfully")
+	logger.Success("✅ Password hash generated and verified successfully")
 	return hashed, nil
 }
+
+// Argon2Params configures CreateArgon2Hash. Zero fields use the defaults of DefaultArgon2Params.
+type Argon2Params struct {
+	Memory      uint32 // Memory is the memory cost in KiB (default 65536, i.e. 64 MiB)
+	Time        uint32 // Time is the number of passes (default 3)
+	Parallelism uint8  // Parallelism is the number of threads (default 4)
+	SaltLength  uint32 // SaltLength is the random salt size in bytes (default 16)
+	KeyLength   uint32 // KeyLength is the hash size in bytes (default 32)
+}
+
+// DefaultArgon2Params returns the RFC 9106 recommended Argon2id parameters, the same
+// cost as DefaultKDFParams.
+func DefaultArgon2Params() Argon2Params {
+	return Argon2Params{Memory: 64 * 1024, Time: 3, Parallelism: 4, SaltLength: 16, KeyLength: 32}
+}
+
+// withDefaults fills zero fields from DefaultArgon2Params.
+func (p Argon2Params) withDefaults() Argon2Params {
+	defaults := DefaultArgon2Params()
+	if p.Memory == 0 {
+		p.Memory = defaults.Memory
+	}
+	if p.Time == 0 {
+		p.Time = defaults.Time
+	}
+	if p.Parallelism == 0 {
+		p.Parallelism = defaults.Parallelism
+	}
+	if p.SaltLength == 0 {
+		p.SaltLength = defaults.SaltLength
+	}
+	if p.KeyLength == 0 {
+		p.KeyLength = defaults.KeyLength
+	}
+	return p
+}
+
+// argon2Prefix starts every Argon2id PHC string.
+const argon2Prefix = "$argon2id$"
+
+// CreateArgon2Hash hashes a password with Argon2id and returns a PHC string such as
+// "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>", which records the parameters so
+// they can be raised later without breaking existing hashes. Unlike bcrypt, the whole
+// password is used, not only the first 72 bytes.
+//
+// Example:
+//
+//	hash, err := encryption.CreateArgon2Hash(password, encryption.DefaultArgon2Params())
+//	ok := encryption.CompareArgon2Hash(hash, password)
+func CreateArgon2Hash(Password string, params Argon2Params) (string, error) {
+	// Create context with timeout for hashing operation
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if Password == "" {
+		logger.Error("❌ Cannot hash empty password")
+		return "", helpers.CreateError("password cannot be empty")
+	}
+	params = params.withDefaults()
+	logger.Info(fmt.Sprintf("🔐 Generating argon2id hash (m=%d, t=%d, p=%d)", params.Memory, params.Time, params.Parallelism))
+
+	hash, err := hashWithContext(ctx, func() (string, error) {
+		salt := make([]byte, params.SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", helpers.WrapError(err, "failed to generate salt")
+		}
+		key := argon2.IDKey([]byte(Password), salt, params.Time, params.Memory, params.Parallelism, params.KeyLength)
+		return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2Prefix, argon2.Version,
+			params.Memory, params.Time, params.Parallelism,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+	})
+	if err != nil {
+		logger.Error("❌ Failed to generate argon2id hash: " + err.Error())
+		return "", err
+	}
+
+	logger.Success("✅ Argon2id password hash created successfully")
+	return hash, nil
+}
+
+// CompareArgon2Hash verifies a password against a hash from CreateArgon2Hash, using
+// the parameters stored in the hash.
+func CompareArgon2Hash(HashedString string, Password string) bool {
+	// Create context with timeout for verification operation
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	logger.Info("🔎 Verifying password against argon2id hash")
+	if Password == "" {
+		logger.Error("❌ Cannot verify empty password")
+		return false
+	}
+
+	params, salt, key, err := parseArgon2Hash(HashedString)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return false
+	}
+
+	_, err = hashWithContext(ctx, func() (string, error) {
+		candidate := argon2.IDKey([]byte(Password), salt, params.Time, params.Memory, params.Parallelism, params.KeyLength)
+		if !SecureCompare(candidate, key) {
+			return "", helpers.CreateError("password does not match hash")
+		}
+		return "", nil
+	})
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return false
+	}
+
+	logger.Success("✅ Password verification successful")
+	return true
+}
+
+// parseArgon2Hash splits an Argon2id PHC string into its parameters, salt, and key.
+func parseArgon2Hash(hash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+	fields := strings.Split(hash, "$")
+	if len(fields) != 6 || fields[1] != "argon2id" {
+		return params, nil, nil, helpers.CreateError("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(fields[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, helpers.CreateErrorf("unsupported argon2id version %q", fields[2])
+	}
+	if _, err := fmt.Sscanf(fields[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Parallelism); err != nil {
+		return params, nil, nil, helpers.WrapError(err, "invalid argon2id parameters")
+	}
+	if params.Memory == 0 || params.Time == 0 || params.Parallelism == 0 {
+		return params, nil, nil, helpers.CreateError("invalid argon2id parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(fields[4])
+	if err != nil {
+		return params, nil, nil, helpers.WrapError(err, "invalid argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(fields[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, helpers.CreateError("invalid argon2id hash value")
+	}
+	params.SaltLength, params.KeyLength = uint32(len(salt)), uint32(len(key))
+	return params, salt, key, nil
+}
+
+// ScryptParams configures CreateScryptHash. Zero fields use the defaults of DefaultScryptParams.
+type ScryptParams struct {
+	N          int // N is the CPU/memory cost, a power of two (default 32768)
+	R          int // R is the block size (default 8)
+	P          int // P is the parallelization (default 1)
+	SaltLength int // SaltLength is the random salt size in bytes (default 16)
+	KeyLength  int // KeyLength is the hash size in bytes (default 32)
+}
+
+// DefaultScryptParams returns N=32768, r=8, p=1, the same cost as DefaultKDFParams.
+func DefaultScryptParams() ScryptParams {
+	return ScryptParams{N: 32768, R: 8, P: 1, SaltLength: 16, KeyLength: 32}
+}
+
+// withDefaults fills zero fields from DefaultScryptParams.
+func (p ScryptParams) withDefaults() ScryptParams {
+	defaults := DefaultScryptParams()
+	if p.N <= 0 {
+		p.N = defaults.N
+	}
+	if p.R <= 0 {
+		p.R = defaults.R
+	}
+	if p.P <= 0 {
+		p.P = defaults.P
+	}
+	if p.SaltLength <= 0 {
+		p.SaltLength = defaults.SaltLength
+	}
+	if p.KeyLength <= 0 {
+		p.KeyLength = defaults.KeyLength
+	}
+	return p
+}
+
+// scryptPrefix starts every scrypt PHC string.
+const scryptPrefix = "$scrypt$"
+
+// CreateScryptHash hashes a password with scrypt and returns a PHC string such as
+// "$scrypt$ln=15,r=8,p=1$<salt>$<hash>" (ln is log2 of N). CompareScryptHash also
+// reads the "." alphabet variant written by passlib, so hashes from systems being
+// migrated can be stored as is.
+//
+// Example:
+//
+//	hash, err := encryption.CreateScryptHash(password, encryption.DefaultScryptParams())
+//	ok := encryption.CompareScryptHash(hash, password)
+func CreateScryptHash(Password string, params ScryptParams) (string, error) {
+	// Create context with timeout for hashing operation
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if Password == "" {
+		logger.Error("❌ Cannot hash empty password")
+		return "", helpers.CreateError("password cannot be empty")
+	}
+	params = params.withDefaults()
+	if params.N < 2 || params.N&(params.N-1) != 0 {
+		return "", helpers.CreateErrorf("scrypt N must be a power of two greater than 1, got %d", params.N)
+	}
+	logger.Info(fmt.Sprintf("🔐 Generating scrypt hash (N=%d, r=%d, p=%d)", params.N, params.R, params.P))
+
+	hash, err := hashWithContext(ctx, func() (string, error) {
+		salt := make([]byte, params.SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", helpers.WrapError(err, "failed to generate salt")
+		}
+		key, err := scrypt.Key([]byte(Password), salt, params.N, params.R, params.P, params.KeyLength)
+		if err != nil {
+			return "", helpers.WrapError(err, "scrypt hashing failed")
+		}
+		return fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s", scryptPrefix, bits.TrailingZeros(uint(params.N)), params.R, params.P,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+	})
+	if err != nil {
+		logger.Error("❌ Failed to generate scrypt hash: " + err.Error())
+		return "", err
+	}
+
+	logger.Success("✅ Scrypt password hash created successfully")
+	return hash, nil
+}
+
+// CompareScryptHash verifies a password against a scrypt PHC string, using the
+// parameters stored in the hash.
+func CompareScryptHash(HashedString string, Password string) bool {
+	// Create context with timeout for verification operation
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	logger.Info("🔎 Verifying password against scrypt hash")
+	if Password == "" {
+		logger.Error("❌ Cannot verify empty password")
+		return false
+	}
+
+	params, salt, key, err := parseScryptHash(HashedString)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return false
+	}
+
+	_, err = hashWithContext(ctx, func() (string, error) {
+		candidate, err := scrypt.Key([]byte(Password), salt, params.N, params.R, params.P, params.KeyLength)
+		if err != nil {
+			return "", helpers.WrapError(err, "scrypt hashing failed")
+		}
+		if !SecureCompare(candidate, key) {
+			return "", helpers.CreateError("password does not match hash")
+		}
+		return "", nil
+	})
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return false
+	}
+
+	logger.Success("✅ Password verification successful")
+	return true
+}
+
+// parseScryptHash splits a scrypt PHC string into its parameters, salt, and key.
+func parseScryptHash(hash string) (ScryptParams, []byte, []byte, error) {
+	var params ScryptParams
+	fields := strings.Split(hash, "$")
+	if len(fields) != 5 || fields[1] != "scrypt" {
+		return params, nil, nil, helpers.CreateError("invalid scrypt hash format")
+	}
+
+	var logN int
+	if _, err := fmt.Sscanf(fields[2], "ln=%d,r=%d,p=%d", &logN, &params.R, &params.P); err != nil {
+		return params, nil, nil, helpers.WrapError(err, "invalid scrypt parameters")
+	}
+	if logN < 1 || logN > 30 || params.R < 1 || params.P < 1 {
+		return params, nil, nil, helpers.CreateError("invalid scrypt parameters")
+	}
+	params.N = 1 << logN
+
+	// passlib writes "." where standard base64 uses "+".
+	salt, err := base64.RawStdEncoding.DecodeString(strings.ReplaceAll(fields[3], ".", "+"))
+	if err != nil {
+		return params, nil, nil, helpers.WrapError(err, "invalid scrypt salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(strings.ReplaceAll(fields[4], ".", "+"))
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, helpers.CreateError("invalid scrypt hash value")
+	}
+	params.SaltLength, params.KeyLength = len(salt), len(key)
+	return params, salt, key, nil
+}
+
+// hashWithContext runs a slow hashing operation, returning early when ctx ends.
+func hashWithContext(ctx context.Context, operation func() (string, error)) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", helpers.WrapError(err, "password hashing cancelled before start")
+	}
+
+	resultChan := make(chan hashResult, 1)
+	go func() {
+		hash, err := operation()
+		resultChan <- hashResult{hash: hash, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Warning("⚠️ Password hashing operation cancelled or timed out")
+		return "", helpers.WrapError(ctx.Err(), "password hashing cancelled")
+	case result := <-resultChan:
+		return result.hash, result.err
+	}
+}
+
+// VerifyAndUpgradeHash verifies a password and, when it matches a hash made with an
+// older algorithm (bcrypt or legacy scrypt) or with Argon2id parameters weaker than
+// params, returns a new Argon2id hash at params to persist in its place. newHash is
+// empty when the stored hash is already good enough. Zero fields of params use the
+// defaults of DefaultArgon2Params. An error is returned only when rehashing fails, in
+// which case ok is still true.
+//
+// Example:
+//
+//	ok, newHash, err := encryption.VerifyAndUpgradeHash(user.PasswordHash, password, encryption.DefaultArgon2Params())
+//	if ok && newHash != "" {
+//	    user.PasswordHash = newHash // save; login succeeds even if this update fails
+//	}
+func VerifyAndUpgradeHash(HashedString string, Password string, params Argon2Params) (ok bool, newHash string, err error) {
+	params = params.withDefaults()
+
+	ok = VerifyAny(HashedString, Password)
+	upgrade := true
+	if DetectHashAlgorithm(HashedString) == HashArgon2id {
+		stored, _, _, err := parseArgon2Hash(HashedString)
+		upgrade = err == nil && (stored.Memory < params.Memory || stored.Time < params.Time ||
+			stored.Parallelism < params.Parallelism || stored.KeyLength < params.KeyLength)
+	}
+	if !ok || !upgrade {
+		return ok, "", nil
+	}
+
+	logger.Info(fmt.Sprintf("🔄 Upgrading password hash to argon2id (m=%d, t=%d, p=%d)", params.Memory, params.Time, params.Parallelism))
+	newHash, err = CreateArgon2Hash(Password, params)
+	if err != nil {
+		return true, "", helpers.WrapError(err, "failed to upgrade password hash")
+	}
+	return true, newHash, nil
+}
+
+// Password hash algorithms recognised by DetectHashAlgorithm.
+const (
+	HashBcrypt   = "bcrypt"   // HashBcrypt is a $2a$, $2b$, $2x$, or $2y$ hash
+	HashArgon2id = "argon2id" // HashArgon2id is a $argon2id$ PHC string
+	HashScrypt   = "scrypt"   // HashScrypt is a $scrypt$ PHC string
+)
+
+// DetectHashAlgorithm returns the algorithm of a stored password hash from its
+// prefix, or "" when the format is not recognised.
+func DetectHashAlgorithm(HashedString string) string {
+	switch {
+	case strings.HasPrefix(HashedString, argon2Prefix):
+		return HashArgon2id
+	case strings.HasPrefix(HashedString, scryptPrefix):
+		return HashScrypt
+	case IsHashValid(HashedString):
+		return HashBcrypt
+	}
+	return ""
+}
+
+// VerifyAny verifies a password against a bcrypt, Argon2id, or scrypt hash, picking
+// the verifier from the hash prefix, for user tables holding several formats during
+// a migration. Unrecognised formats never verify.
+//
+// Example:
+//
+//	if !encryption.VerifyAny(user.PasswordHash, password) {
+//	    return helpers.CreateError("invalid credentials")
+//	}
+func VerifyAny(HashedString string, Password string) bool {
+	switch DetectHashAlgorithm(HashedString) {
+	case HashBcrypt:
+		return CompareWithHash(HashedString, Password)
+	case HashArgon2id:
+		return CompareArgon2Hash(HashedString, Password)
+	case HashScrypt:
+		return CompareScryptHash(HashedString, Password)
+	}
+	logger.Error("❌ Unrecognised password hash format")
+	return false
+}
+
+// Errors returned by VerifyPassword. Cancellation and timeouts wrap ctx.Err() instead,
+// so errors.Is(err, context.DeadlineExceeded) detects them.
+var (
+	// ErrPasswordMismatch means the hash is valid but the password does not match it.
+	ErrPasswordMismatch = errors.New("password does not match hash")
+	// ErrMalformedHash means the stored hash is corrupted or in an unknown format.
+	ErrMalformedHash = errors.New("malformed password hash")
+)
+
+// VerifyPassword verifies a password against a bcrypt, Argon2id, or scrypt hash and
+// explains a failure: ErrPasswordMismatch for a wrong password, ErrMalformedHash for
+// a corrupted or unrecognised hash, or a wrapped context error when hashing was
+// cancelled or timed out. It returns nil on a match. The boolean CompareWithHash and
+// VerifyAny remain for callers that only need a yes or no.
+//
+// Example:
+//
+//	switch err := encryption.VerifyPassword(user.PasswordHash, password); {
+//	case errors.Is(err, encryption.ErrPasswordMismatch):
+//	    // wrong password: count the failed attempt
+//	case errors.Is(err, encryption.ErrMalformedHash):
+//	    // corrupted hash: alert, the user cannot log in until it is reset
+//	}
+func VerifyPassword(HashedString string, Password string) error {
+	// Create context with timeout for verification operation
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return VerifyPasswordWithContext(ctx, HashedString, Password)
+}
+
+// VerifyPasswordWithContext is VerifyPassword with caller-controlled cancellation.
+func VerifyPasswordWithContext(ctx context.Context, HashedString string, Password string) error {
+	logger.Info("🔎 Verifying password against stored hash")
+	if Password == "" {
+		return helpers.CreateError("password cannot be empty")
+	}
+
+	var verify func() error
+	switch DetectHashAlgorithm(HashedString) {
+	case HashBcrypt:
+		verify = func() error {
+			err := bcrypt.CompareHashAndPassword([]byte(HashedString), []byte(Password))
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return ErrPasswordMismatch
+			} else if err != nil {
+				return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+			}
+			return nil
+		}
+	case HashArgon2id:
+		params, salt, key, err := parseArgon2Hash(HashedString)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+		}
+		verify = func() error {
+			candidate := argon2.IDKey([]byte(Password), salt, params.Time, params.Memory, params.Parallelism, params.KeyLength)
+			if !SecureCompare(candidate, key) {
+				return ErrPasswordMismatch
+			}
+			return nil
+		}
+	case HashScrypt:
+		params, salt, key, err := parseScryptHash(HashedString)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+		}
+		verify = func() error {
+			candidate, err := scrypt.Key([]byte(Password), salt, params.N, params.R, params.P, params.KeyLength)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrMalformedHash, err)
+			}
+			if !SecureCompare(candidate, key) {
+				return ErrPasswordMismatch
+			}
+			return nil
+		}
+	default:
+		logger.Error("❌ Unrecognised password hash format")
+		return fmt.Errorf("%w: unrecognised format", ErrMalformedHash)
+	}
+
+	_, err := hashWithContext(ctx, func() (string, error) { return "", verify() })
+	if err != nil {
+		logger.Error("❌ Password verification failed: " + err.Error())
+		return err
+	}
+
+	logger.Success("✅ Password verification successful")
+	return nil
+}
@@ -0,0 +1,64 @@
+package encryption
+
+import (
+	"crypto/hmac"     // hmac provides the keyed password digest.
+	"crypto/sha256"   // sha256 provides the HMAC hash function.
+	"encoding/base64" // base64 keeps the digest printable and under bcrypt's 72-byte limit.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides environment and error utilities.
+)
+
+// PasswordPepperVariable is the environment variable read when no pepper is passed.
+const PasswordPepperVariable = "PASSWORD_PEPPER"
+
+// PepperPassword returns HMAC-SHA256(pepper, password) as base64, to be hashed in
+// place of the password. The pepper is a server-side secret kept out of the
+// database, so a leaked user table cannot be brute-forced without it. An empty
+// pepper is read from PASSWORD_PEPPER; if that is unset too, an error is returned
+// rather than silently hashing without a pepper.
+//
+// Example:
+//
+//	peppered, err := encryption.PepperPassword(password, "")
+//	hash, err := encryption.CreateArgon2Hash(peppered, encryption.DefaultArgon2Params())
+func PepperPassword(Password string, pepper string) (string, error) {
+	if Password == "" {
+		return "", helpers.CreateError("password cannot be empty")
+	}
+	pepper = helpers.DefaultIfEmpty(pepper, helpers.GetENVValue(PasswordPepperVariable))
+	if pepper == "" {
+		return "", helpers.CreateErrorf("password pepper is not set (pass one or set %s)", PasswordPepperVariable)
+	}
+
+	mac := hmac.New(sha256.New, []byte(pepper))
+	mac.Write([]byte(Password))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// CreateHashWithPepper peppers a password with PepperPassword and hashes the result
+// with bcrypt. Because the digest has a fixed length, passwords longer than bcrypt's
+// 72-byte limit are no longer truncated.
+//
+// Example:
+//
+//	hash, err := encryption.CreateHashWithPepper(password, "") // pepper from PASSWORD_PEPPER
+//	ok := encryption.CompareWithHashAndPepper(hash, password, "")
+func CreateHashWithPepper(Password string, pepper string) (string, error) {
+	peppered, err := PepperPassword(Password, pepper)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return "", err
+	}
+	return CreateHash(peppered)
+}
+
+// CompareWithHashAndPepper verifies a password against a hash from
+// CreateHashWithPepper, using the same pepper.
+func CompareWithHashAndPepper(HashedString string, Password string, pepper string) bool {
+	peppered, err := PepperPassword(Password, pepper)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return false
+	}
+	return CompareWithHash(HashedString, peppered)
+}
@@ -0,0 +1,130 @@
+package encryption
+
+import (
+	"fmt"          // fmt provides formatting of violation messages.
+	"strings"      // strings provides case-insensitive banned list matching.
+	"unicode"      // unicode provides character class detection.
+	"unicode/utf8" // utf8 provides length in characters rather than bytes.
+)
+
+// Password policy violation codes, stable for translation and API responses.
+const (
+	ViolationTooShort         = "too_short"         // ViolationTooShort is fewer characters than MinLength
+	ViolationTooLong          = "too_long"          // ViolationTooLong is more characters than MaxLength
+	ViolationMissingLowercase = "missing_lowercase" // ViolationMissingLowercase is no lowercase letter
+	ViolationMissingUppercase = "missing_uppercase" // ViolationMissingUppercase is no uppercase letter
+	ViolationMissingDigit     = "missing_digit"     // ViolationMissingDigit is no digit
+	ViolationMissingSymbol    = "missing_symbol"    // ViolationMissingSymbol is no punctuation or symbol
+	ViolationBanned           = "banned"            // ViolationBanned is a password on the banned list
+)
+
+// PasswordPolicy describes the passwords accepted at registration and password change,
+// so every app enforces the same rules. Zero fields disable their rule.
+type PasswordPolicy struct {
+	MinLength        int      // MinLength is the minimum number of characters
+	MaxLength        int      // MaxLength is the maximum number of characters (bcrypt uses only the first 72 bytes)
+	RequireLowercase bool     // RequireLowercase requires a lowercase letter
+	RequireUppercase bool     // RequireUppercase requires an uppercase letter
+	RequireDigit     bool     // RequireDigit requires a digit
+	RequireSymbol    bool     // RequireSymbol requires a punctuation character or symbol
+	Banned           []string // Banned lists rejected passwords, matched case-insensitively
+}
+
+// PolicyViolation is one rule a password breaks.
+type PolicyViolation struct {
+	Code    string `json:"code"`    // Code is one of the Violation constants
+	Message string `json:"message"` // Message is a human-readable description
+}
+
+// PasswordPolicyError aggregates every rule a password breaks, so users can fix them
+// all at once instead of one per attempt.
+type PasswordPolicyError struct {
+	Violations []PolicyViolation // Violations lists the broken rules in policy order
+}
+
+// Error returns the violation messages joined with "; ".
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return "password does not meet the policy: " + strings.Join(messages, "; ")
+}
+
+// DefaultPasswordPolicy returns a policy following NIST SP 800-63B: 8 to 64
+// characters, no composition rules, and a list of very common passwords banned.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength: 8,
+		MaxLength: 64,
+		Banned: []string{
+			"password", "password1", "password123", "passw0rd", "12345678", "123456789",
+			"1234567890", "qwerty123", "qwertyuiop", "11111111", "iloveyou", "letmein1",
+			"welcome1", "admin123", "abc12345", "football", "baseball", "sunshine",
+		},
+	}
+}
+
+// Validate checks a password against every rule of the policy and returns a
+// *PasswordPolicyError listing all violations, or nil when the password is accepted.
+//
+// Example:
+//
+//	policy := encryption.DefaultPasswordPolicy()
+//	policy.RequireDigit = true
+//	if err := policy.Validate(input.Password); err != nil {
+//	    var policyErr *encryption.PasswordPolicyError
+//	    errors.As(err, &policyErr) // policyErr.Violations for the response
+//	}
+func (p PasswordPolicy) Validate(password string) error {
+	var violations []PolicyViolation
+	add := func(code, message string) {
+		violations = append(violations, PolicyViolation{Code: code, Message: message})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		add(ViolationTooShort, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add(ViolationTooLong, fmt.Sprintf("must be at most %d characters long", p.MaxLength))
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireLowercase && !hasLower {
+		add(ViolationMissingLowercase, "must contain a lowercase letter")
+	}
+	if p.RequireUppercase && !hasUpper {
+		add(ViolationMissingUppercase, "must contain an uppercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		add(ViolationMissingDigit, "must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add(ViolationMissingSymbol, "must contain a symbol")
+	}
+
+	for _, banned := range p.Banned {
+		if strings.EqualFold(password, banned) {
+			add(ViolationBanned, "is too common")
+			break
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
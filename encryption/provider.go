@@ -0,0 +1,466 @@
+package encryption
+
+import (
+	"context"         // context provides support for cancellation and timeouts.
+	"encoding/base64" // base64 provides decoding of stored key material.
+	"encoding/hex"    // hex provides decoding of stored key material.
+	"encoding/json"   // json provides encoding of KMS and Vault payloads.
+	"fmt"             // fmt provides formatting and printing functions.
+	"os"              // os provides key file reads.
+	"strconv"         // strconv provides Vault version formatting.
+	"strings"         // strings provides string manipulation utilities.
+	"sync"            // sync protects the cached key ring.
+	"time"            // time provides the refresh interval and signing timestamp.
+
+	"github.com/hekimapro/utils/helpers"        // helpers provides environment and error utilities.
+	"github.com/hekimapro/utils/internal/sigv4" // sigv4 signs AWS KMS requests.
+	"github.com/hekimapro/utils/models"         // models contains data structures for encryption payloads.
+	"github.com/hekimapro/utils/request"        // request provides HTTP requests with retries.
+)
+
+// DefaultKeyRefreshInterval is how long a ProviderCipher uses a fetched key before
+// asking its provider again.
+const DefaultKeyRefreshInterval = 5 * time.Minute
+
+// keyRetryDelay is the wait after a failed key fetch, doubled for each further
+// failure up to the refresh interval, so a provider outage costs one call per delay
+// instead of one per operation.
+const keyRetryDelay = 5 * time.Second
+
+// unknownKeyRefetchInterval limits re-fetches triggered by payloads naming an unknown
+// key, since the key ID in a payload is chosen by whoever supplied it.
+const unknownKeyRefetchInterval = 30 * time.Second
+
+// KeyProvider supplies the current encryption key from wherever it is kept: the
+// environment, a mounted file, AWS KMS, or HashiCorp Vault.
+type KeyProvider interface {
+	Name() string                                       // Name identifies the provider in logs and errors
+	FetchKey(ctx context.Context) (*ProvidedKey, error) // FetchKey returns the current key
+}
+
+// ProvidedKey is a key returned by a KeyProvider.
+type ProvidedKey struct {
+	ID  string // ID is the key version, recorded in payloads; FingerprintKey(Key) when empty
+	Key string // Key is the raw key material
+}
+
+// decodeKeyMaterial decodes stored key material: "base64", "hex", or raw when empty.
+func decodeKeyMaterial(material, encoding string) (string, error) {
+	switch encoding {
+	case "":
+		return material, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(material)
+		if err != nil {
+			return "", helpers.WrapError(err, "failed to decode base64 key")
+		}
+		return string(decoded), nil
+	case "hex":
+		decoded, err := hex.DecodeString(material)
+		if err != nil {
+			return "", helpers.WrapError(err, "failed to decode hex key")
+		}
+		return string(decoded), nil
+	}
+	return "", helpers.CreateErrorf("unsupported key encoding %q (use base64 or hex)", encoding)
+}
+
+// EnvKeyProvider reads the key from environment variables on every fetch, so a value
+// changed by env.Watch or a reloaded secret file is picked up on the next refresh.
+type EnvKeyProvider struct {
+	KeyVariable string // KeyVariable holds the key (default ENCRYPTION_KEY)
+	IDVariable  string // IDVariable holds the key ID (default ENCRYPTION_KEY_ID)
+}
+
+// Name identifies the provider in logs and errors.
+func (p *EnvKeyProvider) Name() string {
+	return "env:" + helpers.DefaultIfEmpty(p.KeyVariable, "ENCRYPTION_KEY")
+}
+
+// FetchKey reads the key and its ID from the environment.
+func (p *EnvKeyProvider) FetchKey(ctx context.Context) (*ProvidedKey, error) {
+	key := helpers.GetENVValue(helpers.DefaultIfEmpty(p.KeyVariable, "ENCRYPTION_KEY"))
+	if key == "" {
+		return nil, helpers.CreateErrorf("environment variable %s is not set", helpers.DefaultIfEmpty(p.KeyVariable, "ENCRYPTION_KEY"))
+	}
+	return &ProvidedKey{ID: helpers.GetENVValue(helpers.DefaultIfEmpty(p.IDVariable, "ENCRYPTION_KEY_ID")), Key: key}, nil
+}
+
+// FileKeyProvider reads the key from a file, such as a mounted Kubernetes or Docker
+// secret. Surrounding whitespace is ignored.
+type FileKeyProvider struct {
+	Path     string // Path is the key file
+	Encoding string // Encoding of the file content: "base64", "hex", or raw when empty
+	ID       string // ID is the key version; derived from the key when empty
+}
+
+// Name identifies the provider in logs and errors.
+func (p *FileKeyProvider) Name() string {
+	return "file:" + p.Path
+}
+
+// FetchKey reads and decodes the key file.
+func (p *FileKeyProvider) FetchKey(ctx context.Context) (*ProvidedKey, error) {
+	content, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to read key file")
+	}
+	key, err := decodeKeyMaterial(strings.TrimSpace(string(content)), p.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &ProvidedKey{ID: p.ID, Key: key}, nil
+}
+
+// AWSKMSKeyProvider decrypts a data key stored encrypted under a KMS key (for
+// example the CiphertextBlob of "aws kms generate-data-key"), so the plaintext key
+// never appears in configuration.
+type AWSKMSKeyProvider struct {
+	Region       string            // Region is the AWS region (e.g., eu-west-1)
+	EncryptedKey string            // EncryptedKey is the base64 CiphertextBlob of the data key
+	KMSKeyID     string            // KMSKeyID optionally pins the KMS key ID or ARN that must have encrypted the data key
+	Credentials  sigv4.Credentials // Credentials are the static AWS credentials used to sign requests
+	Endpoint     string            // Endpoint optionally overrides the KMS endpoint URL
+}
+
+// NewAWSKMSKeyProviderFromEnv builds an AWSKMSKeyProvider from AWS_REGION,
+// ENCRYPTION_KMS_ENCRYPTED_KEY, ENCRYPTION_KMS_KEY_ID, AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN.
+func NewAWSKMSKeyProviderFromEnv() *AWSKMSKeyProvider {
+	return &AWSKMSKeyProvider{
+		Region:       sigv4.RegionFromEnv(),
+		EncryptedKey: helpers.GetENVValue("ENCRYPTION_KMS_ENCRYPTED_KEY"),
+		KMSKeyID:     helpers.GetENVValue("ENCRYPTION_KMS_KEY_ID"),
+		Credentials:  sigv4.CredentialsFromEnv(),
+	}
+}
+
+// Name identifies the provider in logs and errors.
+func (p *AWSKMSKeyProvider) Name() string {
+	return "aws-kms:" + helpers.DefaultIfEmpty(p.KMSKeyID, p.Region)
+}
+
+// FetchKey calls KMS Decrypt on the encrypted data key.
+func (p *AWSKMSKeyProvider) FetchKey(ctx context.Context) (*ProvidedKey, error) {
+	if p.Region == "" || p.EncryptedKey == "" || !p.Credentials.Valid() {
+		return nil, helpers.CreateError("aws kms key provider requires region, encrypted key, and AWS credentials")
+	}
+
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com/", p.Region)
+	}
+
+	payload := map[string]interface{}{"CiphertextBlob": p.EncryptedKey}
+	if p.KMSKeyID != "" {
+		payload["KeyId"] = p.KMSKeyID
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to encode KMS request")
+	}
+
+	headers, err := sigv4.SignHeaders("POST", endpoint, map[string]string{
+		"Content-Type": "application/x-amz-json-1.1",
+		"X-Amz-Target": "TrentService.Decrypt",
+	}, body, p.Credentials, p.Region, "kms", time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	requestHeaders := request.Headers(headers)
+	raw, err := request.PostWithContext(ctx, endpoint, json.RawMessage(body), &requestHeaders)
+	if err != nil {
+		return nil, helpers.WrapError(err, "KMS request failed")
+	}
+
+	var response struct {
+		Plaintext string `json:"Plaintext"`
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode KMS response")
+	}
+	key, err := decodeKeyMaterial(response.Plaintext, "base64")
+	if err != nil {
+		return nil, err
+	}
+	return &ProvidedKey{Key: key}, nil
+}
+
+// VaultKeyProvider reads the key from a field of a HashiCorp Vault KV secret. With
+// KV version 2 the secret version becomes the key ID, so writing a new version
+// rotates the key.
+type VaultKeyProvider struct {
+	Address   string // Address is the Vault server URL (e.g., https://vault.example.com:8200)
+	Token     string // Token is the Vault token used for authentication
+	Mount     string // Mount is the KV engine mount path (default "secret")
+	Path      string // Path is the secret path inside the mount (e.g., "myapp/encryption")
+	Field     string // Field is the secret field holding the key (default "key")
+	Encoding  string // Encoding of the field: "base64", "hex", or raw when empty
+	KVVersion int    // KVVersion is the KV engine version, 1 or 2 (default 2)
+	Namespace string // Namespace is the optional Vault Enterprise namespace
+}
+
+// NewVaultKeyProviderFromEnv builds a VaultKeyProvider from VAULT_ADDR, VAULT_TOKEN,
+// VAULT_KV_MOUNT, ENCRYPTION_VAULT_PATH, ENCRYPTION_VAULT_FIELD,
+// ENCRYPTION_VAULT_ENCODING, VAULT_KV_VERSION, and VAULT_NAMESPACE.
+func NewVaultKeyProviderFromEnv() *VaultKeyProvider {
+	return &VaultKeyProvider{
+		Address:   helpers.GetENVValue("VAULT_ADDR"),
+		Token:     helpers.GetENVValue("VAULT_TOKEN"),
+		Mount:     helpers.GetENVValueWithDefault("VAULT_KV_MOUNT", "secret"),
+		Path:      helpers.GetENVValue("ENCRYPTION_VAULT_PATH"),
+		Field:     helpers.GetENVValueWithDefault("ENCRYPTION_VAULT_FIELD", "key"),
+		Encoding:  helpers.GetENVValue("ENCRYPTION_VAULT_ENCODING"),
+		KVVersion: helpers.GetENVIntValue("VAULT_KV_VERSION", 2),
+		Namespace: helpers.GetENVValue("VAULT_NAMESPACE"),
+	}
+}
+
+// Name identifies the provider in logs and errors.
+func (p *VaultKeyProvider) Name() string {
+	return "vault:" + p.Path
+}
+
+// FetchKey reads the secret and returns its key field.
+func (p *VaultKeyProvider) FetchKey(ctx context.Context) (*ProvidedKey, error) {
+	if p.Address == "" || p.Token == "" || p.Path == "" {
+		return nil, helpers.CreateError("vault key provider requires address, token, and secret path")
+	}
+
+	mount := strings.Trim(helpers.DefaultIfEmpty(p.Mount, "secret"), "/")
+	path := strings.Trim(p.Path, "/")
+
+	// KV v2 nests secrets under /data/ and wraps them in a second "data" object.
+	url := fmt.Sprintf("%s/v1/%s/%s", strings.TrimRight(p.Address, "/"), mount, path)
+	if p.KVVersion != 1 {
+		url = fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(p.Address, "/"), mount, path)
+	}
+
+	headers := &request.Headers{"X-Vault-Token": p.Token}
+	if p.Namespace != "" {
+		(*headers)["X-Vault-Namespace"] = p.Namespace
+	}
+
+	raw, err := request.GetWithContext(ctx, url, headers)
+	if err != nil {
+		return nil, helpers.WrapError(err, "vault request failed")
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode vault response")
+	}
+
+	var secret map[string]interface{}
+	id := ""
+	if p.KVVersion != 1 {
+		var nested struct {
+			Data     map[string]interface{} `json:"data"`
+			Metadata struct {
+				Version int `json:"version"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(response.Data, &nested); err != nil {
+			return nil, helpers.WrapError(err, "failed to decode vault KV v2 payload")
+		}
+		secret = nested.Data
+		if nested.Metadata.Version > 0 {
+			id = "v" + strconv.Itoa(nested.Metadata.Version)
+		}
+	} else if err := json.Unmarshal(response.Data, &secret); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode vault secret data")
+	}
+
+	field := helpers.DefaultIfEmpty(p.Field, "key")
+	material, ok := secret[field].(string)
+	if !ok || material == "" {
+		return nil, helpers.CreateErrorf("vault secret %s has no %q field", p.Path, field)
+	}
+	key, err := decodeKeyMaterial(material, p.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &ProvidedKey{ID: id, Key: key}, nil
+}
+
+// ProviderCipher encrypts with keys fetched from a KeyProvider. The key is cached and
+// fetched again every refresh interval; when the provider returns a new key ID, that
+// key becomes the primary key and earlier keys are kept for decryption. A payload
+// naming an unknown key triggers an immediate re-fetch, so a rotation made by another
+// instance is picked up without waiting; such re-fetches happen at most once every
+// 30 seconds. After a failed fetch the provider is not asked again until a backoff
+// delay has passed. A ProviderCipher is safe for concurrent use.
+type ProviderCipher struct {
+	provider KeyProvider
+	template models.EncryptionConfig
+	interval time.Duration
+
+	mu             sync.Mutex
+	ring           *KeyRing
+	fetchedAt      time.Time
+	attemptedAt    time.Time // attemptedAt is when the provider was last asked, successfully or not
+	failures       int       // failures counts consecutive failed fetches
+	unknownFetchAt time.Time // unknownFetchAt is when an unknown key ID last triggered a fetch
+}
+
+// NewProviderCipher returns a ProviderCipher that takes the key from provider and the
+// algorithm, encoding, and IV from template (its EncryptionKey and KeyID are
+// ignored). A refreshInterval of 0 uses DefaultKeyRefreshInterval. The first key is
+// fetched on first use.
+//
+// Example:
+//
+//	keys := encryption.NewProviderCipher(encryption.NewVaultKeyProviderFromEnv(),
+//	    models.EncryptionConfig{EncryptionType: "base64", Algorithm: encryption.AlgorithmChaCha20Poly1305}, 0)
+//	encrypted, err := keys.Encrypt(record)
+func NewProviderCipher(provider KeyProvider, template models.EncryptionConfig, refreshInterval time.Duration) *ProviderCipher {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultKeyRefreshInterval
+	}
+	return &ProviderCipher{provider: provider, template: template, interval: refreshInterval, ring: NewKeyRing()}
+}
+
+// Refresh fetches the key from the provider now, adding it as the primary key if its
+// ID is new.
+func (p *ProviderCipher) Refresh(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refresh(ctx)
+}
+
+// refresh fetches the key and records the attempt; callers hold p.mu.
+func (p *ProviderCipher) refresh(ctx context.Context) error {
+	p.attemptedAt = time.Now()
+	provided, err := p.provider.FetchKey(ctx)
+	if err != nil {
+		p.failures++
+		return helpers.WrapErrorf(err, "failed to fetch encryption key from %s", p.provider.Name())
+	}
+
+	keyID := helpers.DefaultIfEmpty(provided.ID, FingerprintKey(provided.Key))
+	p.failures = 0
+	p.fetchedAt = time.Now()
+	if p.ring.Primary() == keyID {
+		return nil
+	}
+
+	keyConfig := p.template
+	keyConfig.KeyID, keyConfig.EncryptionKey = keyID, provided.Key
+	if _, exists := p.ring.Cipher(keyID); exists {
+		return p.ring.SetPrimary(keyID)
+	}
+	if err := p.ring.Add(keyConfig); err != nil {
+		return err
+	}
+	logger.Info("🔑 Encryption key " + keyID + " loaded from " + p.provider.Name())
+	return nil
+}
+
+// retryDelay returns how long to wait after the last failed fetch; callers hold p.mu.
+func (p *ProviderCipher) retryDelay() time.Duration {
+	if p.failures == 0 {
+		return 0
+	}
+	delay := keyRetryDelay
+	for i := 1; i < p.failures && delay < p.interval; i++ {
+		delay *= 2
+	}
+	return min(delay, p.interval)
+}
+
+// current returns the key ring, fetching the key first when it is missing or stale.
+// A failed refresh keeps the cached key so a provider outage does not stop encryption,
+// and the provider is not asked again until the retry delay has passed.
+func (p *ProviderCipher) current(ctx context.Context) (*KeyRing, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.fetchedAt) < p.interval || time.Since(p.attemptedAt) < p.retryDelay() {
+		if len(p.ring.KeyIDs()) == 0 {
+			return nil, helpers.CreateErrorf("no encryption key from %s yet; retrying after %v", p.provider.Name(), p.retryDelay())
+		}
+		return p.ring, nil
+	}
+	if err := p.refresh(ctx); err != nil {
+		if len(p.ring.KeyIDs()) == 0 {
+			logger.Error("❌ " + err.Error())
+			return nil, err
+		}
+		logger.Warning("⚠️ " + err.Error() + ", using cached key")
+	}
+	return p.ring, nil
+}
+
+// KeyRing returns the keys fetched so far, fetching the key first when needed.
+func (p *ProviderCipher) KeyRing(ctx context.Context) (*KeyRing, error) {
+	return p.current(ctx)
+}
+
+// Encrypt encrypts data with the provider's current key.
+func (p *ProviderCipher) Encrypt(data interface{}) (*models.EncryptReturnType, error) {
+	return p.EncryptWithContext(context.Background(), data)
+}
+
+// EncryptWithContext is Encrypt with cancellation support.
+func (p *ProviderCipher) EncryptWithContext(ctx context.Context, data interface{}) (*models.EncryptReturnType, error) {
+	ring, err := p.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ring.EncryptWithContext(ctx, data)
+}
+
+// Decrypt decrypts a payload with the key it names, re-fetching from the provider
+// when that key is not known yet.
+func (p *ProviderCipher) Decrypt(encryptedData models.EncryptReturnType) (interface{}, error) {
+	return p.DecryptWithContext(context.Background(), encryptedData)
+}
+
+// DecryptWithContext is Decrypt with cancellation support.
+func (p *ProviderCipher) DecryptWithContext(ctx context.Context, encryptedData models.EncryptReturnType) (interface{}, error) {
+	var decryptedData interface{}
+	if err := p.decryptInto(ctx, encryptedData, &decryptedData); err != nil {
+		return nil, err
+	}
+	return decryptedData, nil
+}
+
+// DecryptTo decrypts a payload and unmarshals the JSON into out, which must be a
+// pointer, like json.Unmarshal.
+func (p *ProviderCipher) DecryptTo(encryptedData models.EncryptReturnType, out interface{}) error {
+	return p.decryptInto(context.Background(), encryptedData, out)
+}
+
+// decryptInto re-fetches the key when the payload names an unknown one, then decrypts.
+func (p *ProviderCipher) decryptInto(ctx context.Context, encryptedData models.EncryptReturnType, out interface{}) error {
+	ring, err := p.current(ctx)
+	if err != nil {
+		return err
+	}
+	if _, exists := ring.Cipher(encryptedData.KeyID); encryptedData.KeyID != "" && !exists {
+		p.refreshUnknown(ctx, encryptedData.KeyID)
+	}
+	return ring.decryptInto(ctx, encryptedData, out)
+}
+
+// refreshUnknown fetches the key after a payload named the unknown keyID, unless
+// another call fetched it meanwhile or a fetch for an unknown key or a failed fetch
+// happened too recently.
+func (p *ProviderCipher) refreshUnknown(ctx context.Context, keyID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.ring.Cipher(keyID); exists {
+		return
+	}
+	if time.Since(p.unknownFetchAt) < unknownKeyRefetchInterval || time.Since(p.attemptedAt) < p.retryDelay() {
+		return
+	}
+	p.unknownFetchAt = time.Now()
+	if err := p.refresh(ctx); err != nil {
+		logger.Warning("⚠️ " + err.Error())
+	}
+}
@@ -0,0 +1,214 @@
+package encryption
+
+import (
+	"crypto/rand"     // rand provides key generation and OAEP randomness.
+	"crypto/rsa"      // rsa provides RSA keys and OAEP encryption.
+	"crypto/sha256"   // sha256 provides the OAEP hash.
+	"crypto/x509"     // x509 provides PKCS#1, PKCS#8, and PKIX key encoding.
+	"encoding/base64" // base64 provides ciphertext encoding for transport.
+	"encoding/pem"    // pem provides PEM encoding of keys.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// MinRSAKeySize is the smallest RSA key size GenerateRSAKeyPair accepts.
+const MinRSAKeySize = 2048
+
+// GenerateRSAKeyPair generates an RSA private key of the given size in bits
+// (at least MinRSAKeySize; 0 uses 3072).
+func GenerateRSAKeyPair(bits int) (*rsa.PrivateKey, error) {
+	if bits == 0 {
+		bits = 3072
+	}
+	if bits < MinRSAKeySize {
+		return nil, helpers.CreateErrorf("RSA key size must be at least %d bits, got %d", MinRSAKeySize, bits)
+	}
+
+	logger.Info("🔑 Generating RSA key pair")
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to generate RSA key pair")
+	}
+	return privateKey, nil
+}
+
+// GenerateRSAKeyPairPEM generates an RSA key pair and returns the private key as
+// PKCS#8 PEM and the public key as PKIX PEM, ready to store or share.
+//
+// Example:
+//
+//	privatePEM, publicPEM, err := encryption.GenerateRSAKeyPairPEM(4096)
+//	// keep privatePEM secret, send publicPEM to the partner
+func GenerateRSAKeyPairPEM(bits int) (privateKeyPEM, publicKeyPEM string, err error) {
+	privateKey, err := GenerateRSAKeyPair(bits)
+	if err != nil {
+		return "", "", err
+	}
+	if privateKeyPEM, err = EncodeRSAPrivateKeyPEM(privateKey); err != nil {
+		return "", "", err
+	}
+	if publicKeyPEM, err = EncodeRSAPublicKeyPEM(&privateKey.PublicKey); err != nil {
+		return "", "", err
+	}
+	return privateKeyPEM, publicKeyPEM, nil
+}
+
+// EncodeRSAPrivateKeyPEM encodes a private key as a PKCS#8 "PRIVATE KEY" PEM block.
+func EncodeRSAPrivateKeyPEM(privateKey *rsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to encode RSA private key")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// EncodeRSAPublicKeyPEM encodes a public key as a PKIX "PUBLIC KEY" PEM block.
+func EncodeRSAPublicKeyPEM(publicKey *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to encode RSA public key")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// ParseRSAPrivateKeyPEM parses a PKCS#8 ("PRIVATE KEY") or PKCS#1
+// ("RSA PRIVATE KEY") PEM-encoded private key.
+func ParseRSAPrivateKeyPEM(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, err := decodePEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse PKCS#1 private key")
+		}
+		return privateKey, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse PKCS#8 private key")
+		}
+		privateKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, helpers.CreateErrorf("private key is %T, not RSA", key)
+		}
+		return privateKey, nil
+	}
+	return nil, helpers.CreateErrorf("unsupported private key PEM type %q", block.Type)
+}
+
+// ParseRSAPublicKeyPEM parses a PKIX ("PUBLIC KEY") or PKCS#1 ("RSA PUBLIC KEY")
+// PEM-encoded public key, or takes the public key of a "CERTIFICATE".
+func ParseRSAPublicKeyPEM(publicKeyPEM string) (*rsa.PublicKey, error) {
+	block, err := decodePEM(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse PKCS#1 public key")
+		}
+		return publicKey, nil
+	case "PUBLIC KEY":
+		if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, helpers.WrapError(err, "failed to parse PKIX public key")
+		}
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse certificate")
+		}
+		key = certificate.PublicKey
+	default:
+		return nil, helpers.CreateErrorf("unsupported public key PEM type %q", block.Type)
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, helpers.CreateErrorf("public key is %T, not RSA", key)
+	}
+	return publicKey, nil
+}
+
+// decodePEM returns the first PEM block of data.
+func decodePEM(data string) (*pem.Block, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, helpers.CreateError("no PEM block found")
+	}
+	return block, nil
+}
+
+// EncryptOAEP encrypts plaintext for publicKey with RSA-OAEP and SHA-256. The label
+// may be nil and must match on decryption. Plaintext is limited to the key size in
+// bytes minus 66 (190 bytes for a 2048-bit key); use hybrid encryption for more.
+func EncryptOAEP(publicKey *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, plaintext, label)
+	if err != nil {
+		return nil, helpers.WrapError(err, "RSA-OAEP encryption failed")
+	}
+	return ciphertext, nil
+}
+
+// DecryptOAEP decrypts an RSA-OAEP (SHA-256) ciphertext produced by EncryptOAEP.
+func DecryptOAEP(privateKey *rsa.PrivateKey, ciphertext, label []byte) ([]byte, error) {
+	plaintext, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privateKey, ciphertext, label)
+	if err != nil {
+		return nil, helpers.WrapError(err, "RSA-OAEP decryption failed")
+	}
+	return plaintext, nil
+}
+
+// EncryptRSA encrypts plaintext for a partner's PEM public key with RSA-OAEP
+// (SHA-256) and returns the ciphertext as standard base64.
+//
+// Example:
+//
+//	encrypted, err := encryption.EncryptRSA(partnerPublicKeyPEM, []byte(apiSecret))
+func EncryptRSA(publicKeyPEM string, plaintext []byte) (string, error) {
+	publicKey, err := ParseRSAPublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		logger.Error("❌ Invalid RSA public key: " + err.Error())
+		return "", err
+	}
+
+	ciphertext, err := EncryptOAEP(publicKey, plaintext, nil)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return "", err
+	}
+
+	logger.Success("✅ Data encrypted with RSA-OAEP")
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptRSA decrypts a base64 RSA-OAEP (SHA-256) ciphertext with a PEM private key.
+func DecryptRSA(privateKeyPEM string, ciphertext string) ([]byte, error) {
+	privateKey, err := ParseRSAPrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		logger.Error("❌ Invalid RSA private key: " + err.Error())
+		return nil, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		logger.Error("❌ Failed to decode RSA ciphertext: " + err.Error())
+		return nil, helpers.WrapError(err, "failed to decode RSA ciphertext")
+	}
+
+	plaintext, err := DecryptOAEP(privateKey, decoded, nil)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return nil, err
+	}
+
+	logger.Success("✅ Data decrypted with RSA-OAEP")
+	return plaintext, nil
+}
@@ -0,0 +1,223 @@
+package encryption
+
+import (
+	"crypto"          // crypto provides the Signer and key interfaces.
+	"crypto/ecdsa"    // ecdsa provides ECDSA signatures.
+	"crypto/ed25519"  // ed25519 provides Ed25519 signatures.
+	"crypto/elliptic" // elliptic provides the P-256 curve.
+	"crypto/rand"     // rand provides key generation and ECDSA nonces.
+	"crypto/sha256"   // sha256 provides the ECDSA message digest.
+	"crypto/x509"     // x509 provides PKCS#8, SEC 1, and PKIX key encoding.
+	"encoding/base64" // base64 provides signature encoding for transport.
+	"encoding/pem"    // pem provides PEM encoding of keys.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// Supported signing algorithms.
+const (
+	SigningEd25519   = "ed25519"    // SigningEd25519 is Ed25519, compact and fast (default)
+	SigningECDSAP256 = "ecdsa-p256" // SigningECDSAP256 is ECDSA on P-256 with SHA-256, for platforms without Ed25519 (e.g. older mobile keystores)
+)
+
+// GenerateSigningKey generates a private key for algorithm (SigningEd25519 when empty).
+// The result is an ed25519.PrivateKey or *ecdsa.PrivateKey; both implement crypto.Signer.
+func GenerateSigningKey(algorithm string) (crypto.Signer, error) {
+	algorithm = helpers.DefaultIfEmpty(algorithm, SigningEd25519)
+	switch algorithm {
+	case SigningEd25519:
+		logger.Info("🔑 Generating Ed25519 signing key")
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to generate Ed25519 key")
+		}
+		return privateKey, nil
+	case SigningECDSAP256:
+		logger.Info("🔑 Generating ECDSA P-256 signing key")
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to generate ECDSA key")
+		}
+		return privateKey, nil
+	}
+	return nil, helpers.CreateErrorf("unsupported signing algorithm %q (use %s or %s)", algorithm, SigningEd25519, SigningECDSAP256)
+}
+
+// GenerateSigningKeyPairPEM generates a signing key pair and returns the private key as
+// PKCS#8 PEM and the public key as PKIX PEM.
+//
+// Example:
+//
+//	privatePEM, publicPEM, err := encryption.GenerateSigningKeyPairPEM(encryption.SigningEd25519)
+//	// keep privatePEM in the signing service, distribute publicPEM to verifiers
+func GenerateSigningKeyPairPEM(algorithm string) (privateKeyPEM, publicKeyPEM string, err error) {
+	privateKey, err := GenerateSigningKey(algorithm)
+	if err != nil {
+		return "", "", err
+	}
+	if privateKeyPEM, err = EncodeSigningPrivateKeyPEM(privateKey); err != nil {
+		return "", "", err
+	}
+	if publicKeyPEM, err = EncodeSigningPublicKeyPEM(privateKey.Public()); err != nil {
+		return "", "", err
+	}
+	return privateKeyPEM, publicKeyPEM, nil
+}
+
+// EncodeSigningPrivateKeyPEM encodes an Ed25519 or ECDSA private key as a PKCS#8
+// "PRIVATE KEY" PEM block.
+func EncodeSigningPrivateKeyPEM(privateKey crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to encode signing private key")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// EncodeSigningPublicKeyPEM encodes an Ed25519 or ECDSA public key as a PKIX
+// "PUBLIC KEY" PEM block.
+func EncodeSigningPublicKeyPEM(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to encode signing public key")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// ParseSigningPrivateKeyPEM parses a PKCS#8 ("PRIVATE KEY") Ed25519 or ECDSA key, or a
+// SEC 1 ("EC PRIVATE KEY") ECDSA key as written by openssl ecparam.
+func ParseSigningPrivateKeyPEM(privateKeyPEM string) (crypto.Signer, error) {
+	block, err := decodePEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse SEC 1 private key")
+		}
+		return privateKey, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse PKCS#8 private key")
+		}
+		switch privateKey := key.(type) {
+		case ed25519.PrivateKey:
+			return privateKey, nil
+		case *ecdsa.PrivateKey:
+			return privateKey, nil
+		}
+		return nil, helpers.CreateErrorf("private key is %T, not Ed25519 or ECDSA", key)
+	}
+	return nil, helpers.CreateErrorf("unsupported private key PEM type %q", block.Type)
+}
+
+// ParseSigningPublicKeyPEM parses a PKIX ("PUBLIC KEY") Ed25519 or ECDSA public key, or
+// takes the public key of a "CERTIFICATE".
+func ParseSigningPublicKeyPEM(publicKeyPEM string) (crypto.PublicKey, error) {
+	block, err := decodePEM(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PUBLIC KEY":
+		if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, helpers.WrapError(err, "failed to parse PKIX public key")
+		}
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to parse certificate")
+		}
+		key = certificate.PublicKey
+	default:
+		return nil, helpers.CreateErrorf("unsupported public key PEM type %q", block.Type)
+	}
+
+	switch publicKey := key.(type) {
+	case ed25519.PublicKey:
+		return publicKey, nil
+	case *ecdsa.PublicKey:
+		return publicKey, nil
+	}
+	return nil, helpers.CreateErrorf("public key is %T, not Ed25519 or ECDSA", key)
+}
+
+// Sign signs message with an Ed25519 or ECDSA private key. ECDSA signatures are
+// ASN.1 DER over the SHA-256 digest of message.
+func Sign(privateKey crypto.Signer, message []byte) ([]byte, error) {
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, message), nil
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(message)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			return nil, helpers.WrapError(err, "ECDSA signing failed")
+		}
+		return signature, nil
+	}
+	return nil, helpers.CreateErrorf("unsupported signing key %T", privateKey)
+}
+
+// Verify reports whether signature is a valid Sign signature of message for an
+// Ed25519 or ECDSA public key. Unsupported key types never verify.
+func Verify(publicKey crypto.PublicKey, message, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return len(key) == ed25519.PublicKeySize && ed25519.Verify(key, message, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	}
+	return false
+}
+
+// SignMessage signs message with a PEM private key and returns the signature as
+// standard base64.
+//
+// Example:
+//
+//	signature, err := encryption.SignMessage(servicePrivateKeyPEM, body)
+//	req.Header.Set("X-Signature", signature)
+func SignMessage(privateKeyPEM string, message []byte) (string, error) {
+	privateKey, err := ParseSigningPrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		logger.Error("❌ Invalid signing private key: " + err.Error())
+		return "", err
+	}
+
+	signature, err := Sign(privateKey, message)
+	if err != nil {
+		logger.Error("❌ " + err.Error())
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyMessage reports whether a base64 signature from SignMessage is valid for
+// message and a PEM public key, e.g. a mobile app's challenge response.
+//
+// Example:
+//
+//	if !encryption.VerifyMessage(devicePublicKeyPEM, []byte(challenge), response.Signature) {
+//	    return helpers.CreateError("invalid device signature")
+//	}
+func VerifyMessage(publicKeyPEM string, message []byte, signature string) bool {
+	publicKey, err := ParseSigningPublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		logger.Warning("⚠️ Invalid signing public key: " + err.Error())
+		return false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return Verify(publicKey, message, decoded)
+}
@@ -1,18 +1,19 @@
 package encryption
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"strings"
 
-	"github.com/hekimapro/utils/helpers"
+	"github.com/hekimapro/utils/config"
 )
 
 // GenerateRefreshToken generates a cryptographically secure random token
 func GenerateRefreshToken() (string, error) {
-	refreshTokenLength := helpers.GetENVIntValue("REFRESH_TOKEN_LENGTH", 12)
-	b := make([]byte, refreshTokenLength)
+	b := make([]byte, config.Get().Encryption.RefreshTokenLength)
 	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
@@ -25,7 +26,36 @@ func HashToken(token string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-// ValidateTokenHash compares a token with its hash
+// ValidateTokenHash compares a token with its hash in constant time
 func ValidateTokenHash(token, hash string) bool {
-	return HashToken(token) == hash
+	return SecureCompareString(HashToken(token), hash)
+}
+
+// SignToken appends an HMAC-SHA256 signature to a token as "token.signature"
+func SignToken(token, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(token))
+	return token + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignedToken checks a value produced by SignToken and returns the original token
+func VerifySignedToken(signed, secret string) (string, bool) {
+	separator := strings.LastIndex(signed, ".")
+	if separator <= 0 {
+		return "", false
+	}
+	token := signed[:separator]
+	return token, SecureCompareString(SignToken(token, secret), signed)
+}
+
+// SignHMAC returns the hex-encoded HMAC-SHA256 of a message, e.g. for webhook signatures
+func SignHMAC(message []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(message)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMAC checks a signature produced by SignHMAC in constant time
+func VerifyHMAC(message []byte, secret, signature string) bool {
+	return SecureCompareString(SignHMAC(message, secret), strings.ToLower(signature))
 }
@@ -0,0 +1,255 @@
+package env
+
+import (
+	"encoding" // encoding provides the TextUnmarshaler interface for custom field types.
+	"fmt"      // fmt provides formatting and printing functions.
+	"reflect"  // reflect provides runtime inspection of struct fields.
+	"strconv"  // strconv provides string conversion utilities.
+	"strings"  // strings provides string manipulation utilities.
+	"time"     // time provides functionality for handling durations.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error creation utilities.
+)
+
+// Struct tags understood by Bind.
+const (
+	tagName     = "env"      // tagName names the environment variable for a field
+	tagDefault  = "default"  // tagDefault supplies a value when the variable is unset
+	tagRequired = "required" // tagRequired marks a field whose variable must be set
+	tagPrefix   = "prefix"   // tagPrefix adds a prefix for every variable in a nested struct
+	tagUnit     = "unit"     // tagUnit sets the unit for bare integer durations (ms, s, m, h)
+)
+
+// durationType is used to recognise time.Duration fields, which are int64 underneath.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// textUnmarshalerType is used to recognise fields that parse themselves.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// FieldError describes an environment variable whose value could not be applied to a field.
+type FieldError struct {
+	Key   string // Key is the full environment variable name
+	Field string // Field is the Go struct field path
+	Err   error  // Err is the underlying parse error
+}
+
+// Error returns the string representation of the field error.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("invalid value for %s (%s): %v", e.Key, e.Field, e.Err)
+}
+
+// BindError aggregates every problem found while binding a struct so that
+// all misconfigurations can be reported at once instead of one per restart.
+type BindError struct {
+	Missing []string     // Missing lists required variables that were not set
+	Invalid []FieldError // Invalid lists variables whose values could not be parsed
+}
+
+// Error returns the string representation of the bind error.
+func (e *BindError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing required environment variable(s): "+strings.Join(e.Missing, ", "))
+	}
+	for _, invalid := range e.Invalid {
+		parts = append(parts, invalid.Error())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// hasErrors reports whether any problem was recorded.
+func (e *BindError) hasErrors() bool {
+	return len(e.Missing) > 0 || len(e.Invalid) > 0
+}
+
+// Bind populates the fields of the struct pointed to by target from environment variables.
+//
+// Supported tags:
+//   - env:"PORT"          the variable name (fields without it are left untouched)
+//   - default:"8080"      value used when the variable is unset
+//   - required:"true"     report the variable as missing when unset and no default exists
+//   - prefix:"DATABASE_"  on a nested struct field, prefixes every variable inside it
+//   - unit:"m"            unit for bare integer durations (ms, s, m, h; default s)
+//
+// Supported field types are strings, booleans, integers, floats, time.Duration,
+// []string (comma separated), and any type implementing encoding.TextUnmarshaler.
+// Every problem is collected into a single *BindError. Fields with an invalid
+// value fall back to their default, if any, so the target is still usable.
+//
+// Example:
+//
+//	type Config struct {
+//	    Port     string `env:"PORT" default:"8080"`
+//	    Database struct {
+//	        Host string `env:"HOST" required:"true"`
+//	    } `prefix:"DATABASE_"`
+//	}
+//	var cfg Config
+//	err := env.Bind(&cfg)
+func Bind(target interface{}) error {
+	return BindWithPrefix("", target)
+}
+
+// BindWithPrefix behaves like Bind but prefixes every variable name with prefix.
+func BindWithPrefix(prefix string, target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return helpers.CreateError("env: bind target must be a non-nil pointer to a struct")
+	}
+
+	bindErr := &BindError{}
+	bindStruct(value.Elem(), prefix, "", bindErr)
+
+	if bindErr.hasErrors() {
+		return bindErr
+	}
+	return nil
+}
+
+// bindStruct walks the fields of a struct value, binding tagged fields and recursing into nested structs.
+func bindStruct(structValue reflect.Value, prefix, path string, bindErr *BindError) {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		// Skip unexported fields; they cannot be set through reflection.
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		name, hasName := field.Tag.Lookup(tagName)
+
+		// Recurse into nested structs that are not bound as a single value.
+		if !hasName && isNestedStruct(field.Type) {
+			if field.Type.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					fieldValue.Set(reflect.New(field.Type.Elem()))
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			bindStruct(fieldValue, prefix+field.Tag.Get(tagPrefix), fieldPath, bindErr)
+			continue
+		}
+
+		if !hasName || name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		raw := GetValue(key)
+		if raw == "" {
+			if defaultValue, ok := field.Tag.Lookup(tagDefault); ok {
+				raw = defaultValue
+			} else if required, _ := strconv.ParseBool(field.Tag.Get(tagRequired)); required {
+				bindErr.Missing = append(bindErr.Missing, key)
+				continue
+			} else {
+				continue
+			}
+		}
+
+		if err := setField(fieldValue, raw, field.Tag.Get(tagUnit)); err != nil {
+			bindErr.Invalid = append(bindErr.Invalid, FieldError{Key: key, Field: fieldPath, Err: err})
+
+			// Fall back to the default so callers that only log the error still get a usable value.
+			if defaultValue, ok := field.Tag.Lookup(tagDefault); ok && defaultValue != raw {
+				_ = setField(fieldValue, defaultValue, field.Tag.Get(tagUnit))
+			}
+		}
+	}
+}
+
+// isNestedStruct reports whether a field type should be walked rather than parsed.
+func isNestedStruct(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return false
+	}
+	// Types that parse themselves (time.Time, custom types) are values, not nested configs.
+	return !reflect.PointerTo(fieldType).Implements(textUnmarshalerType)
+}
+
+// setField parses raw into the field according to its type.
+func setField(fieldValue reflect.Value, raw string, unit string) error {
+	// Allocate pointer fields so the underlying value can be set.
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+		}
+		fieldValue = fieldValue.Elem()
+	}
+
+	if fieldValue.CanAddr() && fieldValue.Addr().Type().Implements(textUnmarshalerType) {
+		return fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if fieldValue.Type() == durationType {
+		duration, err := parseDuration(raw, durationUnit(unit))
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(int64(duration))
+		return nil
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		fieldValue.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(raw), 10, fieldValue.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		fieldValue.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(strings.TrimSpace(raw), 10, fieldValue.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+		fieldValue.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), fieldValue.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		fieldValue.SetFloat(parsed)
+	case reflect.Slice:
+		if fieldValue.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", fieldValue.Type())
+		}
+		fieldValue.Set(reflect.ValueOf(splitList(raw)).Convert(fieldValue.Type()))
+	default:
+		return fmt.Errorf("unsupported field type %s", fieldValue.Type())
+	}
+
+	return nil
+}
+
+// durationUnit maps a unit tag to a duration, defaulting to seconds.
+func durationUnit(unit string) time.Duration {
+	switch strings.ToLower(unit) {
+	case "ms", "millisecond", "milliseconds":
+		return time.Millisecond
+	case "m", "minute", "minutes":
+		return time.Minute
+	case "h", "hour", "hours":
+		return time.Hour
+	default:
+		return time.Second
+	}
+}
@@ -0,0 +1,85 @@
+package env
+
+import (
+	"context"         // context provides support for cancellation and timeouts.
+	"encoding/base64" // base64 provides decoding of Consul KV values.
+	"encoding/json"   // json provides JSON decoding of Consul responses.
+	"fmt"             // fmt provides formatting and printing functions.
+	"net/url"         // url provides query string escaping.
+	"strings"         // strings provides string manipulation utilities.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/request" // request provides HTTP requests with retries.
+)
+
+// ConsulSource loads every key under a prefix from the Consul KV store.
+// A key such as "myapp/smtp/password" under prefix "myapp/" becomes SMTP_PASSWORD.
+type ConsulSource struct {
+	Address    string // Address is the Consul HTTP API URL (e.g., http://127.0.0.1:8500)
+	Token      string // Token is the optional ACL token
+	Prefix     string // Prefix is the KV prefix to read recursively (e.g., "myapp/production/")
+	Datacenter string // Datacenter is the optional datacenter to query
+}
+
+// NewConsulSourceFromEnv builds a ConsulSource from CONSUL_HTTP_ADDR, CONSUL_HTTP_TOKEN,
+// CONSUL_KV_PREFIX, and CONSUL_DATACENTER.
+func NewConsulSourceFromEnv() *ConsulSource {
+	return &ConsulSource{
+		Address:    GetValueWithDefault("CONSUL_HTTP_ADDR", "http://127.0.0.1:8500"),
+		Token:      GetValue("CONSUL_HTTP_TOKEN"),
+		Prefix:     GetValue("CONSUL_KV_PREFIX"),
+		Datacenter: GetValue("CONSUL_DATACENTER"),
+	}
+}
+
+// Name identifies the source in logs and errors.
+func (c *ConsulSource) Name() string {
+	return "consul:" + c.Prefix
+}
+
+// Load reads all keys under Prefix and returns them normalized to UPPER_SNAKE_CASE.
+func (c *ConsulSource) Load(ctx context.Context) (map[string]string, error) {
+	if c.Address == "" {
+		return nil, helpers.CreateError("consul source requires an address")
+	}
+
+	prefix := strings.TrimLeft(c.Prefix, "/")
+	query := url.Values{"recurse": []string{"true"}}
+	if c.Datacenter != "" {
+		query.Set("dc", c.Datacenter)
+	}
+	endpoint := fmt.Sprintf("%s/v1/kv/%s?%s", strings.TrimRight(c.Address, "/"), prefix, query.Encode())
+
+	headers := &request.Headers{}
+	if c.Token != "" {
+		(*headers)["X-Consul-Token"] = c.Token
+	}
+
+	raw, err := request.GetWithContext(ctx, endpoint, headers)
+	if err != nil {
+		return nil, helpers.WrapError(err, "consul request failed")
+	}
+
+	var entries []struct {
+		Key   string `json:"Key"`
+		Value string `json:"Value"` // Value is base64 encoded; null for folders
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode consul response")
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		// Skip folder placeholders
+		if strings.HasSuffix(entry.Key, "/") {
+			continue
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(entry.Value)
+		if err != nil {
+			return nil, helpers.WrapErrorf(err, "failed to decode consul value for %s", entry.Key)
+		}
+		values[normalizeKey(strings.TrimPrefix(entry.Key, prefix))] = string(decoded)
+	}
+	return values, nil
+}
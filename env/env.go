@@ -0,0 +1,87 @@
+// Package env provides typed access to environment variables and binding of
+// environment values onto configuration structs.
+package env
+
+import (
+	"fmt"     // fmt provides formatting and printing functions.
+	"strconv" // strconv provides string conversion utilities.
+	"strings" // strings provides string manipulation utilities.
+	"time"    // time provides functionality for handling durations.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides environment variable loading.
+)
+
+// GetValue returns the value of an environment variable.
+// The key is converted to UPPER_SNAKE_CASE, so "database host" and "DATABASE_HOST" are equivalent.
+// If the variable is unset, the contents of the file named by KEY_FILE are used instead,
+// which allows secrets to be mounted as files (Docker/Kubernetes secrets).
+func GetValue(key string) string {
+	return helpers.GetENVValue(key)
+}
+
+// GetValueWithDefault returns the value of an environment variable or the default if unset.
+func GetValueWithDefault(key string, defaultValue string) string {
+	if value := GetValue(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// GetInt returns an environment variable parsed as an integer, or the default if unset or invalid.
+func GetInt(key string, defaultValue int) int {
+	return helpers.GetENVIntValue(key, defaultValue)
+}
+
+// GetBool returns an environment variable parsed as a boolean, or the default if unset or invalid.
+func GetBool(key string, defaultValue bool) bool {
+	return helpers.GetENVBoolValue(key, defaultValue)
+}
+
+// GetDuration returns an environment variable parsed as a duration, or the default if unset or invalid.
+// Values may use Go duration syntax ("30s", "5m") or be bare integers interpreted in the given unit.
+func GetDuration(key string, unit time.Duration, defaultValue time.Duration) time.Duration {
+	value := GetValue(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := parseDuration(value, unit)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
+// GetList returns an environment variable split on commas with surrounding whitespace trimmed.
+// Empty items are dropped. Returns nil if the variable is unset.
+func GetList(key string) []string {
+	return splitList(GetValue(key))
+}
+
+// splitList splits a comma-separated value into trimmed, non-empty items.
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseDuration parses Go duration syntax, falling back to a bare integer multiplied by unit.
+func parseDuration(value string, unit time.Duration) (time.Duration, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return duration, nil
+	}
+
+	number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(number) * unit, nil
+}
@@ -0,0 +1,128 @@
+package env
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"fmt"     // fmt provides formatting and printing functions.
+	"os"      // os provides access to the process environment.
+	"sort"    // sort provides deterministic ordering of loaded keys.
+	"strings" // strings provides string manipulation utilities.
+	"sync"    // sync provides synchronization primitives for the source registry.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides key normalization and error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Source is a remote provider of configuration values (Vault, Consul, SSM, ...).
+// Load returns variable names mapped to values; names are normalized to UPPER_SNAKE_CASE.
+type Source interface {
+	Name() string                                        // Name identifies the source in logs and errors
+	Load(ctx context.Context) (map[string]string, error) // Load fetches the current values
+}
+
+// sourceRegistry tracks registered sources and the variables they populated.
+type sourceRegistry struct {
+	mu         sync.Mutex
+	sources    []Source
+	remoteKeys map[string]bool // remoteKeys records variables set by a source rather than locally
+}
+
+// registry is the package-level source registry.
+var registry = &sourceRegistry{remoteKeys: make(map[string]bool)}
+
+// AddSource registers one or more sources to be merged by LoadSources.
+// Sources are applied in registration order, so later sources override earlier ones.
+func AddSource(sources ...Source) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.sources = append(registry.sources, sources...)
+}
+
+// LoadSources fetches every registered source and merges the values into the
+// process environment, so GetValue, Bind, and helpers.GetENVValue all see them.
+// Variables set locally (process env or .env file) always take precedence over remote values.
+// All source failures are collected into a single error; values from healthy sources are still applied.
+func LoadSources(ctx context.Context) error {
+	registry.mu.Lock()
+	sources := append([]Source(nil), registry.sources...)
+	registry.mu.Unlock()
+
+	_, err := loadSources(ctx, sources)
+	return err
+}
+
+// loadSources merges the given sources and returns the variables whose values changed.
+func loadSources(ctx context.Context, sources []Source) (map[string]string, error) {
+	changed := make(map[string]string)
+	var failures []string
+
+	for _, source := range sources {
+		values, err := source.Load(ctx)
+		if err != nil {
+			log.Error(fmt.Sprintf("❌ Failed to load configuration from %s: %v", source.Name(), err))
+			failures = append(failures, fmt.Sprintf("%s: %v", source.Name(), err))
+			continue
+		}
+
+		for _, key := range sortedKeys(values) {
+			if registry.apply(key, values[key]) {
+				changed[key] = values[key]
+			}
+		}
+		log.Info(fmt.Sprintf("🔑 Loaded %d configuration value(s) from %s", len(values), source.Name()))
+	}
+
+	if len(failures) > 0 {
+		return changed, helpers.CreateErrorf("failed to load configuration source(s): %s", strings.Join(failures, "; "))
+	}
+	return changed, nil
+}
+
+// apply sets a remote value unless the variable was defined locally. Returns true if the value changed.
+func (r *sourceRegistry) apply(key, value string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, exists := os.LookupEnv(key)
+	if exists && !r.remoteKeys[key] {
+		// Local values win over remote ones.
+		return false
+	}
+	if exists && current == value {
+		return false
+	}
+
+	os.Setenv(key, value)
+	r.remoteKeys[key] = true
+	return true
+}
+
+// normalizeKey converts a remote key such as "smtp/password" or "smtpPassword" to SMTP_PASSWORD.
+func normalizeKey(key string) string {
+	key = strings.Trim(key, "/")
+	key = strings.NewReplacer("/", "_", ".", "_").Replace(key)
+	return strings.ToUpper(helpers.ToSnakeCase(key))
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isRemote reports whether a variable was last set by a remote source.
+func (r *sourceRegistry) isRemote(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.remoteKeys[key]
+}
+
+// markLocal records that a variable is now owned by a local file, so remote sources no longer override it.
+func (r *sourceRegistry) markLocal(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.remoteKeys, key)
+}
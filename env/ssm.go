@@ -0,0 +1,104 @@
+package env
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides JSON encoding and decoding of SSM payloads.
+	"fmt"           // fmt provides formatting and printing functions.
+	"strings"       // strings provides string manipulation utilities.
+	"time"          // time provides the signing timestamp.
+
+	"github.com/hekimapro/utils/helpers"        // helpers provides error utilities.
+	"github.com/hekimapro/utils/internal/sigv4" // sigv4 signs AWS API requests.
+	"github.com/hekimapro/utils/request"        // request provides HTTP requests with retries.
+)
+
+// SSMSource loads parameters under a path from AWS Systems Manager Parameter Store.
+// A parameter such as "/myapp/production/SMTP_PASSWORD" under path "/myapp/production" becomes SMTP_PASSWORD.
+// SecureString parameters are decrypted.
+type SSMSource struct {
+	Region      string            // Region is the AWS region (e.g., eu-west-1)
+	Path        string            // Path is the parameter hierarchy to read recursively
+	Credentials sigv4.Credentials // Credentials are the static AWS credentials used to sign requests
+	Endpoint    string            // Endpoint optionally overrides the SSM endpoint URL
+}
+
+// NewSSMSourceFromEnv builds an SSMSource from AWS_REGION, AWS_SSM_PARAMETER_PATH,
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN.
+func NewSSMSourceFromEnv() *SSMSource {
+	return &SSMSource{
+		Region:      sigv4.RegionFromEnv(),
+		Path:        GetValue("AWS_SSM_PARAMETER_PATH"),
+		Credentials: sigv4.CredentialsFromEnv(),
+	}
+}
+
+// Name identifies the source in logs and errors.
+func (s *SSMSource) Name() string {
+	return "ssm:" + s.Path
+}
+
+// Load reads all parameters under Path, following pagination, and returns them normalized to UPPER_SNAKE_CASE.
+func (s *SSMSource) Load(ctx context.Context) (map[string]string, error) {
+	if s.Region == "" || s.Path == "" || !s.Credentials.Valid() {
+		return nil, helpers.CreateError("ssm source requires region, parameter path, and AWS credentials")
+	}
+
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://ssm.%s.amazonaws.com/", s.Region)
+	}
+
+	values := make(map[string]string)
+	nextToken := ""
+
+	for {
+		payload := map[string]interface{}{
+			"Path":           s.Path,
+			"Recursive":      true,
+			"WithDecryption": true,
+		}
+		if nextToken != "" {
+			payload["NextToken"] = nextToken
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to encode SSM request")
+		}
+
+		headers, err := sigv4.SignHeaders("POST", endpoint, map[string]string{
+			"Content-Type": "application/x-amz-json-1.1",
+			"X-Amz-Target": "AmazonSSM.GetParametersByPath",
+		}, body, s.Credentials, s.Region, "ssm", time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		requestHeaders := request.Headers(headers)
+		raw, err := request.PostWithContext(ctx, endpoint, json.RawMessage(body), &requestHeaders)
+		if err != nil {
+			return nil, helpers.WrapError(err, "SSM request failed")
+		}
+
+		var response struct {
+			Parameters []struct {
+				Name  string `json:"Name"`
+				Value string `json:"Value"`
+			} `json:"Parameters"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := json.Unmarshal(raw, &response); err != nil {
+			return nil, helpers.WrapError(err, "failed to decode SSM response")
+		}
+
+		for _, parameter := range response.Parameters {
+			name := strings.TrimPrefix(parameter.Name, strings.TrimRight(s.Path, "/"))
+			values[normalizeKey(name)] = parameter.Value
+		}
+
+		if response.NextToken == "" {
+			return values, nil
+		}
+		nextToken = response.NextToken
+	}
+}
@@ -0,0 +1,94 @@
+package env
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides JSON decoding of Vault responses.
+	"fmt"           // fmt provides formatting and printing functions.
+	"strings"       // strings provides string manipulation utilities.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/request" // request provides HTTP requests with retries.
+)
+
+// VaultSource loads key/value secrets from a HashiCorp Vault KV secrets engine.
+type VaultSource struct {
+	Address   string // Address is the Vault server URL (e.g., https://vault.example.com:8200)
+	Token     string // Token is the Vault token used for authentication
+	Mount     string // Mount is the KV engine mount path (default "secret")
+	Path      string // Path is the secret path inside the mount (e.g., "myapp/production")
+	KVVersion int    // KVVersion is the KV engine version, 1 or 2 (default 2)
+	Namespace string // Namespace is the optional Vault Enterprise namespace
+}
+
+// NewVaultSourceFromEnv builds a VaultSource from VAULT_ADDR, VAULT_TOKEN, VAULT_KV_MOUNT,
+// VAULT_SECRET_PATH, VAULT_KV_VERSION, and VAULT_NAMESPACE.
+func NewVaultSourceFromEnv() *VaultSource {
+	return &VaultSource{
+		Address:   GetValue("VAULT_ADDR"),
+		Token:     GetValue("VAULT_TOKEN"),
+		Mount:     GetValueWithDefault("VAULT_KV_MOUNT", "secret"),
+		Path:      GetValue("VAULT_SECRET_PATH"),
+		KVVersion: GetInt("VAULT_KV_VERSION", 2),
+		Namespace: GetValue("VAULT_NAMESPACE"),
+	}
+}
+
+// Name identifies the source in logs and errors.
+func (v *VaultSource) Name() string {
+	return "vault:" + v.Path
+}
+
+// Load reads the secret at Path and returns its keys normalized to UPPER_SNAKE_CASE.
+func (v *VaultSource) Load(ctx context.Context) (map[string]string, error) {
+	if v.Address == "" || v.Token == "" || v.Path == "" {
+		return nil, helpers.CreateError("vault source requires address, token, and secret path")
+	}
+
+	mount := strings.Trim(helpers.DefaultIfEmpty(v.Mount, "secret"), "/")
+	path := strings.Trim(v.Path, "/")
+
+	// KV v2 nests secrets under /data/ and wraps them in a second "data" object.
+	url := fmt.Sprintf("%s/v1/%s/%s", strings.TrimRight(v.Address, "/"), mount, path)
+	if v.KVVersion != 1 {
+		url = fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(v.Address, "/"), mount, path)
+	}
+
+	headers := &request.Headers{"X-Vault-Token": v.Token}
+	if v.Namespace != "" {
+		(*headers)["X-Vault-Namespace"] = v.Namespace
+	}
+
+	raw, err := request.GetWithContext(ctx, url, headers)
+	if err != nil {
+		return nil, helpers.WrapError(err, "vault request failed")
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode vault response")
+	}
+
+	data := response.Data
+	if v.KVVersion != 1 {
+		var nested struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(response.Data, &nested); err != nil {
+			return nil, helpers.WrapError(err, "failed to decode vault KV v2 payload")
+		}
+		data = nested.Data
+	}
+
+	var secrets map[string]interface{}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode vault secret data")
+	}
+
+	values := make(map[string]string, len(secrets))
+	for key, value := range secrets {
+		values[normalizeKey(key)] = fmt.Sprint(value)
+	}
+	return values, nil
+}
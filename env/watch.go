@@ -0,0 +1,256 @@
+package env
+
+import (
+	"context"   // context provides support for cancellation of the watcher.
+	"fmt"       // fmt provides formatting and printing functions.
+	"os"        // os provides access to the process environment and file metadata.
+	"os/signal" // signal provides SIGHUP notifications.
+	"strings"   // strings provides matching of the logger variables.
+	"sync"      // sync provides synchronization primitives for listeners.
+	"syscall"   // syscall provides the SIGHUP constant.
+	"time"      // time provides the polling interval.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+	"github.com/joho/godotenv"       // godotenv provides .env file parsing.
+)
+
+// Listener is notified with the variables whose values changed during a reload.
+// Removed variables are reported with an empty value.
+type Listener func(changed map[string]string)
+
+// WatchConfig holds configuration for the configuration watcher.
+type WatchConfig struct {
+	Files        []string      // Files lists the .env files to watch (default [".env"])
+	Interval     time.Duration // Interval is how often file modification times are checked (default 5s)
+	WatchSignals bool          // WatchSignals reloads on SIGHUP when true
+}
+
+// LoadWatchConfig returns a WatchConfig with defaults.
+func LoadWatchConfig() WatchConfig {
+	return WatchConfig{
+		Files:        []string{".env"},
+		Interval:     5 * time.Second,
+		WatchSignals: true,
+	}
+}
+
+// reloadState tracks listeners and the values last read from .env files.
+type reloadState struct {
+	mu         sync.Mutex
+	listeners  []Listener
+	files      []string
+	fileValues map[string]string // fileValues holds the values last loaded from the watched files
+}
+
+// reloader is the package-level reload state.
+var reloader = &reloadState{files: []string{".env"}}
+
+// OnChange registers a listener called after every reload that changed at least one value.
+func OnChange(listener Listener) {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+	reloader.listeners = append(reloader.listeners, listener)
+}
+
+// Reload re-reads the watched .env files and all registered remote sources, applies
+// the changes to the process environment, and notifies listeners.
+// Values that were set by the real process environment (not by a file) are never overwritten.
+// Returns the changed variables.
+func Reload(ctx context.Context) (map[string]string, error) {
+	changed := reloadFiles()
+
+	registry.mu.Lock()
+	sources := append([]Source(nil), registry.sources...)
+	registry.mu.Unlock()
+
+	remoteChanged, err := loadSources(ctx, sources)
+	for key, value := range remoteChanged {
+		changed[key] = value
+	}
+
+	if len(changed) > 0 {
+		log.Info(fmt.Sprintf("🔄 Configuration reloaded: %d value(s) changed", len(changed)))
+		for key := range changed {
+			if strings.HasPrefix(key, "LOG_") {
+				log.Reload()
+				break
+			}
+		}
+		notifyListeners(changed)
+	}
+
+	return changed, err
+}
+
+// reloadFiles applies the current contents of the watched files and returns the changed variables.
+func reloadFiles() map[string]string {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	changed := make(map[string]string)
+	current := readFiles(reloader.files)
+
+	// Take a baseline on first use so values from the initial .env load are attributed to the file.
+	previous := reloader.fileValues
+	if previous == nil {
+		previous = current
+	}
+
+	for key, value := range current {
+		existing, exists := os.LookupEnv(key)
+		oldFileValue, fromFile := previous[key]
+
+		// Only touch variables that are unset or still hold the value the file gave them.
+		if exists && (!fromFile || existing != oldFileValue) && !registry.isRemote(key) {
+			continue
+		}
+		if exists && existing == value {
+			continue
+		}
+
+		os.Setenv(key, value)
+		registry.markLocal(key)
+		changed[key] = value
+	}
+
+	// Unset variables that were removed from the file and were not overridden since.
+	for key, oldValue := range previous {
+		if _, stillPresent := current[key]; stillPresent {
+			continue
+		}
+		if existing, exists := os.LookupEnv(key); exists && existing == oldValue {
+			os.Unsetenv(key)
+			changed[key] = ""
+		}
+	}
+
+	reloader.fileValues = current
+	return changed
+}
+
+// readFiles parses the given .env files, later files overriding earlier ones. Missing files are skipped.
+func readFiles(files []string) map[string]string {
+	values := make(map[string]string)
+	for _, file := range files {
+		fileValues, err := godotenv.Read(file)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Warning(fmt.Sprintf("⚠️ Failed to read %s during reload: %v", file, err))
+			}
+			continue
+		}
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// notifyListeners calls every registered listener, recovering from listener panics.
+func notifyListeners(changed map[string]string) {
+	reloader.mu.Lock()
+	listeners := append([]Listener(nil), reloader.listeners...)
+	reloader.mu.Unlock()
+
+	for _, listener := range listeners {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Error(fmt.Sprintf("🚨 PANIC in configuration change listener: %v", r))
+				}
+			}()
+			listener(changed)
+		}()
+	}
+}
+
+// Watch starts a background watcher that reloads configuration when a watched file
+// changes or, if enabled, when the process receives SIGHUP. It returns immediately;
+// the watcher stops when ctx is cancelled.
+//
+// Example:
+//
+//	env.OnChange(func(changed map[string]string) {
+//	    if level, ok := changed["LOG_LEVEL"]; ok {
+//	        applyLogLevel(level)
+//	    }
+//	})
+//	env.Watch(ctx, env.LoadWatchConfig())
+func Watch(ctx context.Context, config WatchConfig) {
+	if len(config.Files) == 0 {
+		config.Files = []string{".env"}
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+
+	reloader.mu.Lock()
+	reloader.files = append([]string(nil), config.Files...)
+	reloader.mu.Unlock()
+
+	// Establish the baseline before watching so the first change is detected correctly.
+	reloadFiles()
+
+	var hangup chan os.Signal
+	if config.WatchSignals {
+		hangup = make(chan os.Signal, 1)
+		signal.Notify(hangup, syscall.SIGHUP)
+	}
+
+	log.Info(fmt.Sprintf("👀 Watching configuration files %v every %v (SIGHUP reload: %v)",
+		config.Files, config.Interval, config.WatchSignals))
+
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		if hangup != nil {
+			defer signal.Stop(hangup)
+		}
+
+		lastModified := modificationTimes(config.Files)
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("🛑 Configuration watcher stopped")
+				return
+
+			case <-ticker.C:
+				modified := modificationTimes(config.Files)
+				if !sameTimes(lastModified, modified) {
+					lastModified = modified
+					log.Info("📝 Configuration file change detected, reloading")
+					Reload(ctx)
+				}
+
+			case <-hangup:
+				log.Info("📶 SIGHUP received, reloading configuration")
+				Reload(ctx)
+			}
+		}
+	}()
+}
+
+// modificationTimes returns the modification time of each file (zero for missing files).
+func modificationTimes(files []string) []time.Time {
+	times := make([]time.Time, len(files))
+	for i, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			times[i] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// sameTimes reports whether two modification time lists are equal.
+func sameTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package features
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"database/sql"  // sql provides database access.
+	"encoding/json" // json provides encoding of target lists.
+	"fmt"           // fmt provides query formatting.
+
+	"github.com/hekimapro/utils/database" // database provides context-aware query helpers.
+	"github.com/hekimapro/utils/helpers"  // helpers provides error utilities.
+)
+
+// DatabaseProvider stores flags in a PostgreSQL table so they can be toggled at
+// runtime, e.g. from an admin panel, with Set and Delete.
+type DatabaseProvider struct {
+	db    *sql.DB
+	table string
+}
+
+// NewDatabaseProvider creates a provider backed by the given table (default "feature_flags").
+//
+// Example:
+//
+//	store := features.NewDatabaseProvider(db, "")
+//	store.CreateTable(ctx)
+//	store.Set(ctx, features.Flag{Name: "new_checkout", Enabled: true, Percentage: 25})
+func NewDatabaseProvider(db *sql.DB, table string) *DatabaseProvider {
+	return &DatabaseProvider{db: db, table: helpers.DefaultIfEmpty(table, "feature_flags")}
+}
+
+// Name identifies the provider in logs and errors.
+func (p *DatabaseProvider) Name() string {
+	return "database:" + p.table
+}
+
+// CreateTable creates the flag table if it does not exist.
+func (p *DatabaseProvider) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			name TEXT PRIMARY KEY,
+			enabled BOOLEAN NOT NULL DEFAULT FALSE,
+			percentage INTEGER NOT NULL DEFAULT 100 CHECK (percentage BETWEEN 0 AND 100),
+			users JSONB NOT NULL DEFAULT '[]',
+			tenants JSONB NOT NULL DEFAULT '[]',
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`, p.table)
+
+	if _, err := database.ExecWithContext(ctx, p.db, query); err != nil {
+		return helpers.WrapError(err, "failed to create feature flag table")
+	}
+	return nil
+}
+
+// Load reads every flag from the table.
+func (p *DatabaseProvider) Load(ctx context.Context) (map[string]Flag, error) {
+	query := fmt.Sprintf("SELECT name, enabled, percentage, users, tenants FROM %s", p.table)
+
+	rows, err := database.QueryWithContext(ctx, p.db, query)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to query feature flags")
+	}
+	defer rows.Close()
+
+	flags := make(map[string]Flag)
+	for rows.Next() {
+		var flag Flag
+		var users, tenants []byte
+		if err := rows.Scan(&flag.Name, &flag.Enabled, &flag.Percentage, &users, &tenants); err != nil {
+			return nil, helpers.WrapError(err, "failed to read feature flag")
+		}
+		if err := json.Unmarshal(users, &flag.Users); err != nil {
+			return nil, helpers.WrapErrorf(err, "invalid users for feature flag %s", flag.Name)
+		}
+		if err := json.Unmarshal(tenants, &flag.Tenants); err != nil {
+			return nil, helpers.WrapErrorf(err, "invalid tenants for feature flag %s", flag.Name)
+		}
+		flags[flag.Name] = flag
+	}
+	return flags, rows.Err()
+}
+
+// Set creates or replaces a flag. Managers pick up the change on their next refresh.
+func (p *DatabaseProvider) Set(ctx context.Context, flag Flag) error {
+	if flag.Name == "" {
+		return helpers.CreateError("feature flag name cannot be empty")
+	}
+	if flag.Percentage < 0 || flag.Percentage > 100 {
+		return helpers.CreateErrorf("feature flag percentage must be between 0 and 100, got %d", flag.Percentage)
+	}
+
+	users, err := json.Marshal(nonNil(flag.Users))
+	if err != nil {
+		return helpers.WrapError(err, "failed to encode feature flag users")
+	}
+	tenants, err := json.Marshal(nonNil(flag.Tenants))
+	if err != nil {
+		return helpers.WrapError(err, "failed to encode feature flag tenants")
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (name, enabled, percentage, users, tenants, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			percentage = EXCLUDED.percentage,
+			users = EXCLUDED.users,
+			tenants = EXCLUDED.tenants,
+			updated_at = NOW()`, p.table)
+
+	if _, err := database.ExecWithContext(ctx, p.db, query, flag.Name, flag.Enabled, flag.Percentage, users, tenants); err != nil {
+		return helpers.WrapErrorf(err, "failed to save feature flag %s", flag.Name)
+	}
+	return nil
+}
+
+// Delete removes a flag, which turns it off for everyone.
+func (p *DatabaseProvider) Delete(ctx context.Context, name string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE name = $1", p.table)
+
+	if _, err := database.ExecWithContext(ctx, p.db, query, name); err != nil {
+		return helpers.WrapErrorf(err, "failed to delete feature flag %s", name)
+	}
+	return nil
+}
+
+// nonNil returns an empty slice for nil so target lists are stored as [] rather than null.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,300 @@
+// Package features provides boolean and percentage-rollout feature flags loaded
+// from environment variables, a database table, or a remote HTTP provider.
+// Flags are evaluated per request against the user and tenant in the context and
+// can be toggled at runtime without a redeploy.
+package features
+
+import (
+	"context"  // context provides user and tenant targeting.
+	"errors"   // errors provides joining of provider failures.
+	"fmt"      // fmt provides formatting and printing functions.
+	"hash/fnv" // fnv provides stable rollout bucketing.
+	"slices"   // slices provides target lookups and flag comparison.
+	"sort"     // sort provides stable change ordering.
+	"sync"     // sync protects the flag set and listeners.
+	"time"     // time provides the refresh interval.
+
+	"github.com/hekimapro/utils/env"     // env provides configuration change notifications.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models"  // models provides the ContextKey type.
+	"github.com/hekimapro/utils/server"  // server provides the authenticated user context key.
+)
+
+// Context keys for flag targeting.
+const (
+	ContextKeyUserID   models.ContextKey = "feature_user_id"   // ContextKeyUserID holds the user evaluated against flags as a string
+	ContextKeyTenantID models.ContextKey = "feature_tenant_id" // ContextKeyTenantID holds the tenant evaluated against flags as a string
+)
+
+// Flag is a feature flag. A flag that is not Enabled is off for everyone. An enabled
+// flag is on for the listed users and tenants and for Percentage percent of the rest,
+// so a plain boolean flag is Enabled with Percentage 100.
+type Flag struct {
+	Name       string   `json:"name"`              // Name identifies the flag, e.g. "new_checkout"
+	Enabled    bool     `json:"enabled"`           // Enabled is the master switch
+	Percentage int      `json:"percentage"`        // Percentage is the share (0-100) of users or tenants the flag is on for
+	Users      []string `json:"users,omitempty"`   // Users always get the flag while it is enabled
+	Tenants    []string `json:"tenants,omitempty"` // Tenants always get the flag while it is enabled
+}
+
+// Provider is a source of flags (environment, database, remote service, ...).
+type Provider interface {
+	Name() string                                      // Name identifies the provider in logs and errors
+	Load(ctx context.Context) (map[string]Flag, error) // Load fetches the current flags keyed by name
+}
+
+// Listener is notified with the flags that were added, changed, or removed during a
+// refresh. Removed flags are reported with only their Name set.
+type Listener func(changed []Flag)
+
+// Manager holds the current flags merged from its providers.
+type Manager struct {
+	providers []Provider
+	loaded    []map[string]Flag // loaded holds the flags each provider returned last
+	refreshMu sync.Mutex        // refreshMu serializes refreshes
+
+	mu        sync.RWMutex
+	flags     map[string]Flag
+	listeners []Listener
+	loadOnce  sync.Once
+}
+
+// NewManager creates a manager merging the given providers in order, so later
+// providers override flags of the same name from earlier ones. Flags are loaded on
+// the first evaluation or by Refresh.
+//
+// Example:
+//
+//	flags := features.NewManager(features.NewEnvProvider(), features.NewDatabaseProvider(db, "feature_flags"))
+//	flags.OnChange(func(changed []features.Flag) { log.Info("flags changed") })
+//	go flags.Watch(ctx, time.Minute)
+func NewManager(providers ...Provider) *Manager {
+	return &Manager{providers: providers, loaded: make([]map[string]Flag, len(providers)), flags: make(map[string]Flag)}
+}
+
+// Default is the manager used by the package-level functions. It reads FEATURE_*
+// environment variables and refreshes whenever the environment is reloaded.
+var Default = newDefault()
+
+// newDefault creates the Default manager and subscribes it to environment reloads.
+func newDefault() *Manager {
+	manager := NewManager(NewEnvProvider())
+	env.OnChange(func(map[string]string) {
+		manager.Refresh(context.Background())
+	})
+	return manager
+}
+
+// WithUser returns a context whose flag evaluations target the given user.
+func WithUser(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, ContextKeyUserID, userID)
+}
+
+// WithTenant returns a context whose flag evaluations target the given tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, ContextKeyTenantID, tenantID)
+}
+
+// userFromContext returns the user set by WithUser, falling back to the user set by
+// the server's JWT middleware.
+func userFromContext(ctx context.Context) string {
+	if userID, ok := ctx.Value(ContextKeyUserID).(string); ok && userID != "" {
+		return userID
+	}
+	userID, _ := ctx.Value(server.ContextKeyUserID).(string)
+	return userID
+}
+
+// tenantFromContext returns the tenant set by WithTenant.
+func tenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(ContextKeyTenantID).(string)
+	return tenantID
+}
+
+// Refresh reloads every provider, replaces the flag set, and notifies listeners of
+// changes. A failing provider keeps the flags it loaded last, so an outage never
+// flips flags; failures are returned as one error.
+func (m *Manager) Refresh(ctx context.Context) error {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+
+	var failures []error
+	for i, provider := range m.providers {
+		flags, err := provider.Load(ctx)
+		if err != nil {
+			log.Error(fmt.Sprintf("❌ Failed to load feature flags from %s: %v", provider.Name(), err))
+			failures = append(failures, helpers.WrapError(err, provider.Name()))
+			continue
+		}
+		m.loaded[i] = flags
+	}
+
+	merged := make(map[string]Flag)
+	for _, flags := range m.loaded {
+		for name, flag := range flags {
+			flag.Name = name
+			merged[name] = flag
+		}
+	}
+
+	m.mu.Lock()
+	changed := diffFlags(m.flags, merged)
+	m.flags = merged
+	listeners := append([]Listener(nil), m.listeners...)
+	m.mu.Unlock()
+
+	if len(changed) > 0 {
+		log.Info(fmt.Sprintf("🚩 Feature flags refreshed: %d flag(s) changed", len(changed)))
+		for _, listener := range listeners {
+			listener(changed)
+		}
+	}
+
+	if len(failures) > 0 {
+		return helpers.WrapError(errors.Join(failures...), "failed to load feature flags")
+	}
+	return nil
+}
+
+// diffFlags returns the flags that differ between two sets, sorted by name.
+func diffFlags(before, after map[string]Flag) []Flag {
+	var changed []Flag
+	for name, flag := range after {
+		if old, exists := before[name]; !exists || !equalFlags(old, flag) {
+			changed = append(changed, flag)
+		}
+	}
+	for name := range before {
+		if _, exists := after[name]; !exists {
+			changed = append(changed, Flag{Name: name})
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+	return changed
+}
+
+// equalFlags reports whether two flags evaluate identically.
+func equalFlags(a, b Flag) bool {
+	return a.Enabled == b.Enabled && a.Percentage == b.Percentage &&
+		slices.Equal(a.Users, b.Users) && slices.Equal(a.Tenants, b.Tenants)
+}
+
+// Watch refreshes the flags every interval until ctx is cancelled, so changes made in
+// the database or remote provider apply without a redeploy.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	m.ensureLoaded()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Refresh(ctx)
+		}
+	}
+}
+
+// OnChange registers a listener called after every refresh that changed at least one flag.
+func (m *Manager) OnChange(listener Listener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// ensureLoaded loads the flags once before the first evaluation.
+func (m *Manager) ensureLoaded() {
+	m.loadOnce.Do(func() {
+		m.Refresh(context.Background())
+	})
+}
+
+// Flag returns the named flag and whether it exists.
+func (m *Manager) Flag(name string) (Flag, bool) {
+	m.ensureLoaded()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	flag, exists := m.flags[name]
+	return flag, exists
+}
+
+// Flags returns every flag sorted by name.
+func (m *Manager) Flags() []Flag {
+	m.ensureLoaded()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	flags := make([]Flag, 0, len(m.flags))
+	for _, flag := range m.flags {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// Enabled reports whether the named flag is on for the user and tenant in ctx.
+// Unknown flags are off.
+//
+// Example:
+//
+//	if flags.Enabled(r.Context(), "new_checkout") {
+//	    newCheckout(w, r)
+//	    return
+//	}
+func (m *Manager) Enabled(ctx context.Context, name string) bool {
+	flag, exists := m.Flag(name)
+	if !exists {
+		return false
+	}
+	return flag.evaluate(userFromContext(ctx), tenantFromContext(ctx))
+}
+
+// evaluate applies the flag's targeting to one user and tenant.
+func (f Flag) evaluate(userID, tenantID string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if userID != "" && slices.Contains(f.Users, userID) {
+		return true
+	}
+	if tenantID != "" && slices.Contains(f.Tenants, tenantID) {
+		return true
+	}
+	if f.Percentage >= 100 {
+		return true
+	}
+	if f.Percentage <= 0 {
+		return false
+	}
+
+	// Bucket by user, or by tenant when there is no user, so a subject keeps the same
+	// result across requests and rollouts only ever add subjects as they grow.
+	subject := helpers.DefaultIfEmpty(userID, tenantID)
+	if subject == "" {
+		return false
+	}
+	return bucket(f.Name, subject) < f.Percentage
+}
+
+// bucket maps a flag and subject to a stable value in [0, 100).
+func bucket(name, subject string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(name + ":" + subject))
+	return int(hash.Sum32() % 100)
+}
+
+// Enabled reports whether the named flag of the Default manager is on for the user
+// and tenant in ctx.
+func Enabled(ctx context.Context, name string) bool {
+	return Default.Enabled(ctx, name)
+}
+
+// OnChange registers a listener on the Default manager.
+func OnChange(listener Listener) {
+	Default.OnChange(listener)
+}
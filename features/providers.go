@@ -0,0 +1,187 @@
+package features
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides decoding of remote flag documents.
+	"os"            // os provides access to the process environment.
+	"strconv"       // strconv provides percentage parsing.
+	"strings"       // strings provides flag value parsing.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/request" // request provides HTTP requests with retries.
+)
+
+// EnvProvider reads flags from environment variables named <Prefix><FLAG_NAME>.
+//
+// A value is "true"/"false", a rollout percentage such as "25%", optionally followed
+// by targets separated by semicolons:
+//
+//	FEATURE_NEW_CHECKOUT=true
+//	FEATURE_BULK_EXPORT=10%
+//	FEATURE_BETA_REPORTS=0%;users=u-1,u-2;tenants=acme
+//
+// Flag names are the lower-cased remainder of the variable name, e.g. "new_checkout".
+type EnvProvider struct {
+	Prefix string // Prefix selects the flag variables (default "FEATURE_")
+}
+
+// NewEnvProvider creates an EnvProvider reading FEATURE_* variables.
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{Prefix: "FEATURE_"}
+}
+
+// Name identifies the provider in logs and errors.
+func (p *EnvProvider) Name() string {
+	return "env"
+}
+
+// Load parses every variable with the provider's prefix. Invalid values are reported
+// together; valid flags are still returned.
+func (p *EnvProvider) Load(ctx context.Context) (map[string]Flag, error) {
+	prefix := helpers.DefaultIfEmpty(p.Prefix, "FEATURE_")
+	flags := make(map[string]Flag)
+	var invalid []string
+
+	for _, variable := range os.Environ() {
+		key, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(key, prefix) || key == prefix {
+			continue
+		}
+
+		flag, err := ParseFlag(value)
+		if err != nil {
+			invalid = append(invalid, key+": "+err.Error())
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(key, prefix))
+		flag.Name = name
+		flags[name] = flag
+	}
+
+	if len(invalid) > 0 {
+		return flags, helpers.CreateErrorf("invalid feature flag(s): %s", strings.Join(invalid, "; "))
+	}
+	return flags, nil
+}
+
+// ParseFlag parses the EnvProvider value syntax, e.g. "true", "25%", or
+// "10%;users=u-1,u-2;tenants=acme".
+func ParseFlag(value string) (Flag, error) {
+	parts := strings.Split(value, ";")
+	state := strings.ToLower(strings.TrimSpace(parts[0]))
+
+	var flag Flag
+	switch {
+	case state == "true" || state == "on" || state == "1":
+		flag.Enabled, flag.Percentage = true, 100
+	case state == "false" || state == "off" || state == "0" || state == "":
+		flag.Enabled = false
+	case strings.HasSuffix(state, "%"):
+		percentage, err := strconv.Atoi(strings.TrimSuffix(state, "%"))
+		if err != nil || percentage < 0 || percentage > 100 {
+			return flag, helpers.CreateErrorf("invalid rollout percentage %q", state)
+		}
+		flag.Enabled, flag.Percentage = true, percentage
+	default:
+		return flag, helpers.CreateErrorf("invalid flag value %q", state)
+	}
+
+	for _, option := range parts[1:] {
+		name, list, found := strings.Cut(strings.TrimSpace(option), "=")
+		if !found {
+			return flag, helpers.CreateErrorf("invalid flag option %q", option)
+		}
+		targets := splitTargets(list)
+		switch strings.ToLower(name) {
+		case "users":
+			flag.Users = targets
+		case "tenants":
+			flag.Tenants = targets
+		default:
+			return flag, helpers.CreateErrorf("unknown flag option %q", name)
+		}
+	}
+	return flag, nil
+}
+
+// splitTargets splits a comma-separated list, dropping empty entries.
+func splitTargets(list string) []string {
+	var targets []string
+	for _, target := range strings.Split(list, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// HTTPProvider loads flags from a remote service returning a JSON array of flags or an
+// object mapping flag names to flags. Flags without a "percentage" default to 100.
+type HTTPProvider struct {
+	URL     string           // URL is the flag document endpoint
+	Headers *request.Headers // Headers are sent with every request, e.g. an Authorization header
+}
+
+// NewHTTPProvider creates a provider fetching flags from url.
+//
+// Example:
+//
+//	remote := features.NewHTTPProvider("https://flags.example.com/api/flags",
+//	    &request.Headers{"Authorization": "Bearer " + token})
+func NewHTTPProvider(url string, headers *request.Headers) *HTTPProvider {
+	return &HTTPProvider{URL: url, Headers: headers}
+}
+
+// Name identifies the provider in logs and errors.
+func (p *HTTPProvider) Name() string {
+	return "http:" + p.URL
+}
+
+// Load fetches and decodes the flag document.
+func (p *HTTPProvider) Load(ctx context.Context) (map[string]Flag, error) {
+	raw, err := request.GetWithContext(ctx, p.URL, p.Headers)
+	if err != nil {
+		return nil, helpers.WrapError(err, "feature flag request failed")
+	}
+	return decodeFlags(raw)
+}
+
+// remoteFlag decodes a flag whose percentage defaults to 100 when omitted.
+type remoteFlag Flag
+
+// UnmarshalJSON applies the percentage default before decoding.
+func (f *remoteFlag) UnmarshalJSON(data []byte) error {
+	type plain remoteFlag
+	decoded := plain{Percentage: 100}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*f = remoteFlag(decoded)
+	return nil
+}
+
+// decodeFlags decodes either a JSON array of flags or an object keyed by flag name.
+func decodeFlags(raw []byte) (map[string]Flag, error) {
+	flags := make(map[string]Flag)
+
+	var list []remoteFlag
+	if err := json.Unmarshal(raw, &list); err == nil {
+		for _, flag := range list {
+			if flag.Name == "" {
+				return nil, helpers.CreateError("feature flag without a name")
+			}
+			flags[flag.Name] = Flag(flag)
+		}
+		return flags, nil
+	}
+
+	var keyed map[string]remoteFlag
+	if err := json.Unmarshal(raw, &keyed); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode feature flags")
+	}
+	for name, flag := range keyed {
+		flag.Name = name
+		flags[name] = Flag(flag)
+	}
+	return flags, nil
+}
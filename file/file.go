@@ -14,8 +14,12 @@ import (
 	"github.com/google/uuid"             // uuid provides UUID generation.
 	"github.com/hekimapro/utils/helpers" // helpers provides utility functions.
 	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/pool"    // pool provides concurrent batch uploads.
 )
 
+// uploadWorkers is how many files UploadMultipleFiles writes at once.
+const uploadWorkers = 4
+
 // UploadResult represents the result of a file upload operation.
 type UploadResult struct {
 	Filename     string    // Filename is the unique generated filename
@@ -219,8 +223,9 @@ func DeleteFile(filename, uploadDirectory string) error {
 	return nil
 }
 
-// UploadMultipleFiles uploads multiple files and rolls back if any fail.
-// Returns a list of uploaded filenames or an error if any upload fails.
+// UploadMultipleFiles uploads multiple files concurrently on a bounded worker pool
+// and rolls back if any fail.
+// Returns a list of uploaded filenames in input order or an error if any upload fails.
 func UploadMultipleFiles(files []io.Reader, fileNames []string, uploadDirectory string, convertToWebP bool) ([]string, error) {
 	// Create context with timeout for batch upload operation
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -236,32 +241,33 @@ func UploadMultipleFiles(files []io.Reader, fileNames []string, uploadDirectory
 	// Log the start of the batch upload process
 	log.Info("📦 Starting batch file upload for " + fmt.Sprintf("%d", len(files)) + " files")
 
-	// Initialize a slice to store uploaded filenames
-	uploadedFiles := make([]string, 0, len(files))
-
-	// Upload each file individually
-	for i, file := range files {
-		// Check context cancellation before each upload
-		select {
-		case <-ctx.Done():
-			// Rollback uploaded files on cancellation
-			rollbackUploads(uploadedFiles, uploadDirectory)
-			return nil, helpers.WrapError(ctx.Err(), "batch upload cancelled")
-		default:
-			// Continue with upload
-		}
+	// Upload the files concurrently, keeping results in input order
+	indexes := make([]int, len(files))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	results := pool.Run(ctx, uploadWorkers, "file-upload", indexes, func(ctx context.Context, i int) (string, error) {
+		return UploadFile(files[i], fileNames[i], uploadDirectory, convertToWebP)
+	})
 
-		// Attempt to upload the file
-		uniqueFilename, err := UploadFile(file, fileNames[i], uploadDirectory, convertToWebP)
-		if err != nil {
-			// Log the failure and initiate rollback of previously uploaded files
-			log.Error("❌ Upload failed for file: " + fileNames[i] + " — initiating rollback")
-			rollbackUploads(uploadedFiles, uploadDirectory)
-			return nil, helpers.WrapErrorf(err, "failed to upload file %s", fileNames[i])
+	// Collect the uploaded filenames and the first failure
+	uploadedFiles := make([]string, 0, len(files))
+	var failure error
+	for _, result := range results {
+		if result.Err != nil {
+			if failure == nil {
+				log.Error("❌ Upload failed for file: " + fileNames[result.Index] + " — initiating rollback")
+				failure = helpers.WrapErrorf(result.Err, "failed to upload file %s", fileNames[result.Index])
+			}
+			continue
 		}
+		uploadedFiles = append(uploadedFiles, result.Value)
+	}
 
-		// Add the uploaded filename to the list
-		uploadedFiles = append(uploadedFiles, uniqueFilename)
+	// Roll back the files that were uploaded if any upload failed
+	if failure != nil {
+		rollbackUploads(uploadedFiles, uploadDirectory)
+		return nil, failure
 	}
 
 	// Log successful batch upload
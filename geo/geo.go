@@ -0,0 +1,189 @@
+// Package geo resolves the real client IP behind trusted proxies, looks it up in a
+// pluggable GeoIP database, parses the user agent, and derives a client fingerprint.
+// Its middleware stores the result in the request context for audit logs, fraud
+// checks, and localization.
+package geo
+
+import (
+	"context"   // context provides client propagation and lookup cancellation.
+	"errors"    // errors provides sentinel errors.
+	"fmt"       // fmt provides formatting and printing functions.
+	"net/http"  // http provides the middleware types.
+	"net/netip" // netip provides IP address handling.
+	"time"      // time provides the lookup cache TTL.
+
+	"github.com/hekimapro/utils/cache"  // cache provides caching of GeoIP lookups.
+	"github.com/hekimapro/utils/env"    // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/log"    // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models" // models provides the ContextKey type.
+)
+
+// ContextKeyClient holds the resolved Client.
+const ContextKeyClient models.ContextKey = "geo_client"
+
+// ErrNotFound is returned by locators when an address is not in the database.
+var ErrNotFound = errors.New("ip address not found in geoip database")
+
+// Config holds client resolution configuration.
+type Config struct {
+	TrustedProxies []string      `env:"TRUSTED_PROXIES"`                         // TrustedProxies lists proxy IPs or CIDRs whose forwarding headers are believed
+	GeoIPDatabase  string        `env:"GEOIP_DATABASE"`                          // GeoIPDatabase is the path of a CSV range database used by NewResolverFromConfig
+	GeoIPURL       string        `env:"GEOIP_URL"`                               // GeoIPURL is an HTTP lookup URL with an {ip} placeholder, used when no database is set
+	CacheTTL       time.Duration `env:"GEOIP_CACHE_TTL" default:"3600" unit:"s"` // CacheTTL is how long lookups are cached
+	CacheSize      int           `env:"GEOIP_CACHE_SIZE" default:"10000"`        // CacheSize bounds the number of cached lookups
+}
+
+// LoadConfig loads client resolution configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid geo configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// Location is the geographic location of an IP address. Fields the database does
+// not provide are left empty.
+type Location struct {
+	CountryCode string  `json:"country_code,omitempty"` // CountryCode is the ISO 3166-1 alpha-2 code, e.g. "TZ"
+	Country     string  `json:"country,omitempty"`      // Country is the country name
+	Region      string  `json:"region,omitempty"`       // Region is the state or province
+	City        string  `json:"city,omitempty"`         // City is the city name
+	Latitude    float64 `json:"latitude,omitempty"`     // Latitude is the approximate latitude
+	Longitude   float64 `json:"longitude,omitempty"`    // Longitude is the approximate longitude
+	Timezone    string  `json:"timezone,omitempty"`     // Timezone is the IANA time zone, e.g. "Africa/Dar_es_Salaam"
+}
+
+// Locator looks up the location of an IP address. Implementations return
+// ErrNotFound for unknown addresses; MaxMind or other databases can be plugged in
+// by implementing this interface.
+type Locator interface {
+	Lookup(ctx context.Context, ip netip.Addr) (Location, error)
+}
+
+// Client describes the client of a request.
+type Client struct {
+	IP          string    `json:"ip"`                 // IP is the resolved client address
+	Location    *Location `json:"location,omitempty"` // Location is nil when the address could not be located
+	UserAgent   UserAgent `json:"user_agent"`         // UserAgent is the parsed User-Agent header
+	Fingerprint string    `json:"fingerprint"`        // Fingerprint is a stable hash of the client's IP network and headers
+}
+
+// FromContext returns the client stored by Middleware.
+func FromContext(ctx context.Context) (Client, bool) {
+	client, ok := ctx.Value(ContextKeyClient).(Client)
+	return client, ok
+}
+
+// Resolver resolves request clients.
+type Resolver struct {
+	trusted []netip.Prefix
+	locator Locator
+	lookups *cache.Cache
+}
+
+// NewResolver creates a resolver trusting config.TrustedProxies and locating clients
+// with locator, which may be nil to skip geolocation.
+//
+// Example:
+//
+//	locator, err := geo.NewCSVLocator("dbip-city-lite.csv")
+//	resolver, err := geo.NewResolver(geo.LoadConfig(), locator)
+//	handler := resolver.Middleware()(mux)
+func NewResolver(config Config, locator Locator) (*Resolver, error) {
+	trusted, err := ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	return &Resolver{
+		trusted: trusted,
+		locator: locator,
+		lookups: cache.New(cache.Config{MaxEntries: config.CacheSize, DefaultTTL: config.CacheTTL}),
+	}, nil
+}
+
+// NewResolverFromConfig creates a resolver using the CSV database at
+// config.GeoIPDatabase, or the HTTP lookup at config.GeoIPURL, or no locator.
+func NewResolverFromConfig(config Config) (*Resolver, error) {
+	var locator Locator
+	switch {
+	case config.GeoIPDatabase != "":
+		csvLocator, err := NewCSVLocator(config.GeoIPDatabase)
+		if err != nil {
+			return nil, err
+		}
+		locator = csvLocator
+	case config.GeoIPURL != "":
+		locator = NewHTTPLocator(config.GeoIPURL, nil)
+	}
+	return NewResolver(config, locator)
+}
+
+// Locate returns the location of ip, using cached lookups. Private, loopback, and
+// other non-public addresses are never looked up.
+func (r *Resolver) Locate(ctx context.Context, ip netip.Addr) (*Location, error) {
+	if r.locator == nil || !isPublic(ip) {
+		return nil, nil
+	}
+
+	value, err := r.lookups.GetOrLoad(ctx, ip.String(), func(ctx context.Context) (interface{}, error) {
+		location, err := r.locator.Lookup(ctx, ip)
+		if errors.Is(err, ErrNotFound) {
+			// Cache misses too, so unknown addresses do not hit the database every request.
+			return (*Location)(nil), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &location, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*Location), nil
+}
+
+// Resolve returns the client of a request. Geolocation failures are logged and
+// leave Location nil.
+func (r *Resolver) Resolve(req *http.Request) Client {
+	ip := ClientIP(req, r.trusted)
+	userAgent := req.UserAgent()
+
+	client := Client{
+		UserAgent:   ParseUserAgent(userAgent),
+		Fingerprint: Fingerprint(req, ip),
+	}
+	if ip.IsValid() {
+		client.IP = ip.String()
+		location, err := r.Locate(req.Context(), ip)
+		if err != nil {
+			log.Warning(fmt.Sprintf("⚠️ GeoIP lookup failed for %s: %v", client.IP, err))
+		}
+		client.Location = location
+	}
+	return client
+}
+
+// Middleware stores the resolved Client in the request context.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    client, _ := geo.FromContext(r.Context())
+//	    if client.Location != nil && client.Location.CountryCode != "TZ" {
+//	        // flag for review
+//	    }
+//	}
+func (r *Resolver) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			client := r.Resolve(req)
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), ContextKeyClient, client)))
+		})
+	}
+}
+
+// isPublic reports whether ip is a globally routable address worth locating.
+func isPublic(ip netip.Addr) bool {
+	return ip.IsValid() && ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
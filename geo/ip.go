@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"net"       // net provides remote address parsing.
+	"net/http"  // http provides the request type.
+	"net/netip" // netip provides IP address and prefix handling.
+	"strings"   // strings provides header parsing.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// ParseTrustedProxies parses proxy IPs and CIDRs such as "10.0.0.0/8" or "203.0.113.7".
+func ParseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, helpers.WrapErrorf(err, "invalid trusted proxy %q", proxy)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, helpers.WrapErrorf(err, "invalid trusted proxy %q", proxy)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ClientIP returns the address of the client that sent a request. Forwarding headers
+// are only believed when the connection comes from a trusted proxy: X-Forwarded-For
+// is walked from the right, skipping trusted proxies, and the first untrusted address
+// is the client. X-Real-IP is used when X-Forwarded-For is absent. Without trusted
+// proxies the connection address is returned, so clients cannot spoof their IP.
+func ClientIP(r *http.Request, trusted []netip.Prefix) netip.Addr {
+	remote := parseIP(r.RemoteAddr)
+	if !isTrusted(remote, trusted) {
+		return remote
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := parseIP(strings.TrimSpace(hops[i]))
+			if !hop.IsValid() {
+				break
+			}
+			if !isTrusted(hop, trusted) {
+				return hop
+			}
+			remote = hop
+		}
+		return remote
+	}
+
+	if realIP := parseIP(r.Header.Get("X-Real-IP")); realIP.IsValid() {
+		return realIP
+	}
+	return remote
+}
+
+// isTrusted reports whether ip belongs to a trusted proxy.
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP parses an address with or without a port, returning the zero Addr when invalid.
+func parseIP(value string) netip.Addr {
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(strings.Trim(value, "[]"))
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap()
+}
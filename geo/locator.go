@@ -0,0 +1,227 @@
+package geo
+
+import (
+	"context"       // context provides lookup cancellation.
+	"encoding/csv"  // csv provides range database parsing.
+	"encoding/json" // json provides HTTP lookup decoding.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io"            // io provides reader-based loading.
+	"math/big"      // big provides decimal IP conversion.
+	"net/netip"     // netip provides IP address handling.
+	"os"            // os provides database file access.
+	"sort"          // sort provides range ordering and binary search.
+	"strconv"       // strconv provides coordinate parsing.
+	"strings"       // strings provides URL templating.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/request" // request provides HTTP requests with retries.
+)
+
+// ipRange maps an inclusive address range to a location.
+type ipRange struct {
+	start    netip.Addr
+	end      netip.Addr
+	location Location
+}
+
+// CSVLocator looks up addresses in an in-memory copy of a CSV range database.
+// Supported layouts, detected by column count:
+//
+//	start,end,country_code                                       (DB-IP country lite)
+//	start,end,country_code,country                               (IP2Location LITE DB1)
+//	start,end,continent,country_code,region,city,latitude,longitude (DB-IP city lite)
+//
+// Range boundaries are IP addresses or, as in IP2Location files, decimal integers.
+type CSVLocator struct {
+	ranges []ipRange
+}
+
+// NewCSVLocator loads a CSV range database from path.
+func NewCSVLocator(path string) (*CSVLocator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to open geoip database %s", path)
+	}
+	defer file.Close()
+
+	locator, err := ReadCSVLocator(file)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to load geoip database %s", path)
+	}
+	log.Info(fmt.Sprintf("🌍 Loaded %d geoip range(s) from %s", len(locator.ranges), path))
+	return locator, nil
+}
+
+// ReadCSVLocator loads a CSV range database from a reader, e.g. an embedded file.
+func ReadCSVLocator(reader io.Reader) (*CSVLocator, error) {
+	records := csv.NewReader(reader)
+	records.FieldsPerRecord = -1
+	records.ReuseRecord = true
+
+	var ranges []ipRange
+	for line := 1; ; line++ {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, helpers.WrapErrorf(err, "line %d", line)
+		}
+
+		entry, ok, err := parseRange(record)
+		if err != nil {
+			if line == 1 {
+				// Tolerate a header row.
+				continue
+			}
+			return nil, helpers.WrapErrorf(err, "line %d", line)
+		}
+		if ok {
+			ranges = append(ranges, entry)
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start.Less(ranges[j].start) })
+	return &CSVLocator{ranges: ranges}, nil
+}
+
+// parseRange parses one CSV record. Rows without a country (e.g. "ZZ") are skipped.
+func parseRange(record []string) (ipRange, bool, error) {
+	if len(record) < 3 {
+		return ipRange{}, false, helpers.CreateErrorf("expected at least 3 columns, got %d", len(record))
+	}
+	start, err := parseBoundary(record[0])
+	if err != nil {
+		return ipRange{}, false, err
+	}
+	end, err := parseBoundary(record[1])
+	if err != nil {
+		return ipRange{}, false, err
+	}
+
+	var location Location
+	switch {
+	case len(record) >= 8:
+		location.CountryCode = record[3]
+		location.Region = record[4]
+		location.City = record[5]
+		location.Latitude, _ = strconv.ParseFloat(record[6], 64)
+		location.Longitude, _ = strconv.ParseFloat(record[7], 64)
+	case len(record) >= 4:
+		location.CountryCode = record[2]
+		location.Country = record[3]
+	default:
+		location.CountryCode = record[2]
+	}
+
+	if location.CountryCode == "" || location.CountryCode == "-" || location.CountryCode == "ZZ" {
+		return ipRange{}, false, nil
+	}
+	return ipRange{start: start, end: end, location: location}, true, nil
+}
+
+// parseBoundary parses an address or a decimal integer address.
+func parseBoundary(value string) (netip.Addr, error) {
+	value = strings.TrimSpace(value)
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return addr.Unmap(), nil
+	}
+
+	number, ok := new(big.Int).SetString(value, 10)
+	if !ok || number.Sign() < 0 {
+		return netip.Addr{}, helpers.CreateErrorf("invalid range boundary %q", value)
+	}
+	if number.BitLen() <= 32 {
+		var bytes [4]byte
+		number.FillBytes(bytes[:])
+		return netip.AddrFrom4(bytes), nil
+	}
+	if number.BitLen() <= 128 {
+		var bytes [16]byte
+		number.FillBytes(bytes[:])
+		return netip.AddrFrom16(bytes).Unmap(), nil
+	}
+	return netip.Addr{}, helpers.CreateErrorf("invalid range boundary %q", value)
+}
+
+// Lookup finds the range containing ip.
+func (l *CSVLocator) Lookup(ctx context.Context, ip netip.Addr) (Location, error) {
+	ip = ip.Unmap()
+	// Find the last range starting at or before ip.
+	index := sort.Search(len(l.ranges), func(i int) bool { return ip.Less(l.ranges[i].start) }) - 1
+	if index < 0 {
+		return Location{}, ErrNotFound
+	}
+	found := l.ranges[index]
+	if found.start.Is4() != ip.Is4() || found.end.Less(ip) {
+		return Location{}, ErrNotFound
+	}
+	return found.location, nil
+}
+
+// HTTPLocator looks up addresses with a remote GeoIP service. The response is
+// decoded from the field names used by common providers (ipapi.co, ip-api.com,
+// ipinfo-style services); set Decode for other formats.
+type HTTPLocator struct {
+	URL     string                              // URL is the lookup URL with an {ip} placeholder, e.g. "https://ipapi.co/{ip}/json/"
+	Headers *request.Headers                    // Headers are sent with every request, e.g. an API key
+	Decode  func(data []byte) (Location, error) // Decode overrides the default response decoding
+}
+
+// NewHTTPLocator creates a locator querying url, which must contain an {ip} placeholder.
+func NewHTTPLocator(url string, headers *request.Headers) *HTTPLocator {
+	return &HTTPLocator{URL: url, Headers: headers}
+}
+
+// Lookup queries the service for ip.
+func (l *HTTPLocator) Lookup(ctx context.Context, ip netip.Addr) (Location, error) {
+	url := strings.ReplaceAll(l.URL, "{ip}", ip.String())
+	raw, err := request.GetWithContext(ctx, url, l.Headers)
+	if err != nil {
+		return Location{}, helpers.WrapError(err, "geoip request failed")
+	}
+	if l.Decode != nil {
+		return l.Decode(raw)
+	}
+	return decodeLocation(raw)
+}
+
+// decodeLocation reads the location fields used by common GeoIP services.
+func decodeLocation(raw []byte) (Location, error) {
+	var response struct {
+		CountryCode  string  `json:"country_code"`
+		CountryCode2 string  `json:"countryCode"`
+		Country      string  `json:"country"`
+		CountryName  string  `json:"country_name"`
+		Region       string  `json:"region"`
+		RegionName   string  `json:"regionName"`
+		City         string  `json:"city"`
+		Latitude     float64 `json:"latitude"`
+		Lat          float64 `json:"lat"`
+		Longitude    float64 `json:"longitude"`
+		Lon          float64 `json:"lon"`
+		Timezone     string  `json:"timezone"`
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return Location{}, helpers.WrapError(err, "failed to decode geoip response")
+	}
+
+	location := Location{
+		CountryCode: helpers.DefaultIfEmpty(response.CountryCode, response.CountryCode2),
+		Country:     helpers.DefaultIfEmpty(response.CountryName, response.Country),
+		Region:      helpers.DefaultIfEmpty(response.RegionName, response.Region),
+		City:        response.City,
+		Latitude:    response.Latitude + response.Lat,
+		Longitude:   response.Longitude + response.Lon,
+		Timezone:    response.Timezone,
+	}
+	// Services such as ipinfo return the two-letter code in "country".
+	if location.CountryCode == "" && len(location.Country) == 2 {
+		location.CountryCode, location.Country = location.Country, ""
+	}
+	if location.CountryCode == "" && location.Country == "" {
+		return Location{}, ErrNotFound
+	}
+	return location, nil
+}
@@ -0,0 +1,143 @@
+package geo
+
+import (
+	"crypto/sha256" // sha256 provides fingerprint hashing.
+	"encoding/hex"  // hex provides fingerprint encoding.
+	"net/http"      // http provides the request type.
+	"net/netip"     // netip provides network prefix derivation.
+	"regexp"        // regexp provides version extraction.
+	"strings"       // strings provides user agent matching.
+)
+
+// Device types reported by ParseUserAgent.
+const (
+	DeviceDesktop = "desktop" // DeviceDesktop is a desktop or laptop browser
+	DeviceMobile  = "mobile"  // DeviceMobile is a phone
+	DeviceTablet  = "tablet"  // DeviceTablet is a tablet
+	DeviceBot     = "bot"     // DeviceBot is a crawler, monitor, or HTTP library
+	DeviceUnknown = "unknown" // DeviceUnknown is an empty or unrecognized user agent
+)
+
+// UserAgent is a parsed User-Agent header.
+type UserAgent struct {
+	Raw            string `json:"raw"`                       // Raw is the original header
+	Browser        string `json:"browser,omitempty"`         // Browser is the browser or client name, e.g. "Chrome"
+	BrowserVersion string `json:"browser_version,omitempty"` // BrowserVersion is the major.minor version when known
+	OS             string `json:"os,omitempty"`              // OS is the operating system, e.g. "Android"
+	OSVersion      string `json:"os_version,omitempty"`      // OSVersion is the operating system version when known
+	Device         string `json:"device"`                    // Device is one of the Device* constants
+	Bot            bool   `json:"bot"`                       // Bot reports whether the client is automated
+}
+
+// agentPattern matches a product token and captures its version.
+type agentPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// browsers are checked in order; more specific products come before the engines they embed.
+var browsers = []agentPattern{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/([\d.]+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+	{"Internet Explorer", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)([\d.]+)`)},
+	{"okhttp", regexp.MustCompile(`okhttp/([\d.]+)`)},
+	{"Dart", regexp.MustCompile(`Dart/([\d.]+)`)},
+}
+
+// operatingSystems are checked in order.
+var operatingSystems = []agentPattern{
+	{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*? OS ([\d_]+)`)},
+	{"Android", regexp.MustCompile(`Android ?([\d.]*)`)},
+	{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+	{"macOS", regexp.MustCompile(`Mac OS X ([\d_.]+)`)},
+	{"Chrome OS", regexp.MustCompile(`CrOS \S+ ([\d.]+)`)},
+	{"Linux", regexp.MustCompile(`Linux()`)},
+}
+
+// botMarkers identify automated clients.
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "curl/", "wget/", "python-requests", "go-http-client", "postman", "headless", "monitor", "uptime"}
+
+// ParseUserAgent extracts the browser, operating system, and device type from a
+// User-Agent header. It recognizes the common browsers, mobile apps' HTTP clients,
+// and bots; anything else keeps the raw header with DeviceUnknown.
+func ParseUserAgent(header string) UserAgent {
+	agent := UserAgent{Raw: header, Device: DeviceUnknown}
+	if header == "" {
+		return agent
+	}
+
+	lower := strings.ToLower(header)
+	for _, marker := range botMarkers {
+		if strings.Contains(lower, marker) {
+			agent.Bot = true
+			agent.Device = DeviceBot
+			break
+		}
+	}
+
+	for _, browser := range browsers {
+		if match := browser.pattern.FindStringSubmatch(header); match != nil {
+			agent.Browser = browser.name
+			agent.BrowserVersion = majorMinor(match[1])
+			break
+		}
+	}
+	for _, system := range operatingSystems {
+		if match := system.pattern.FindStringSubmatch(header); match != nil {
+			agent.OS = system.name
+			agent.OSVersion = majorMinor(strings.ReplaceAll(match[1], "_", "."))
+			break
+		}
+	}
+
+	if agent.Bot {
+		return agent
+	}
+	switch {
+	case strings.Contains(header, "iPad") || strings.Contains(header, "Tablet") ||
+		(agent.OS == "Android" && !strings.Contains(header, "Mobile")):
+		agent.Device = DeviceTablet
+	case strings.Contains(header, "Mobi") || strings.Contains(header, "iPhone") || agent.Browser == "okhttp" || agent.Browser == "Dart":
+		agent.Device = DeviceMobile
+	case agent.OS != "":
+		agent.Device = DeviceDesktop
+	}
+	return agent
+}
+
+// majorMinor trims a version to its first two components.
+func majorMinor(version string) string {
+	parts := strings.SplitN(strings.Trim(version, "."), ".", 3)
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
+
+// Fingerprint returns a hash identifying a client across requests: the client's
+// network (/24 for IPv4, /48 for IPv6, so address churn within a network keeps the
+// fingerprint) combined with the User-Agent, Accept-Language, and Accept-Encoding
+// headers. It is a fraud signal, not an identity: different clients can share one.
+func Fingerprint(r *http.Request, ip netip.Addr) string {
+	network := ""
+	if ip.IsValid() {
+		bits := 48
+		if ip.Is4() {
+			bits = 24
+		}
+		if prefix, err := ip.Prefix(bits); err == nil {
+			network = prefix.String()
+		}
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{network, r.UserAgent(), r.Header.Get("Accept-Language"), r.Header.Get("Accept-Encoding")} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
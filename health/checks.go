@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"  // context provides check cancellation.
+	"net"      // net provides TCP dialing.
+	"net/http" // http provides HTTP checks.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error creation utilities.
+)
+
+// HTTPCheck returns a check that succeeds when a GET request to url answers with a
+// status below 500, e.g. for an external API's status endpoint.
+//
+// Example:
+//
+//	health.Register(health.Check{Name: "payments-api", Check: health.HTTPCheck("https://api.example.com/ping")})
+func HTTPCheck(url string) CheckFunc {
+	return func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return helpers.WrapError(err, "invalid health check URL")
+		}
+
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode >= http.StatusInternalServerError {
+			return helpers.CreateErrorf("%s answered with status %d", url, response.StatusCode)
+		}
+		return nil
+	}
+}
+
+// TCPCheck returns a check that succeeds when a TCP connection to address
+// ("host:port") can be opened, e.g. for an SMTP relay or message broker.
+func TCPCheck(address string) CheckFunc {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		connection, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return connection.Close()
+	}
+}
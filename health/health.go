@@ -0,0 +1,278 @@
+// Package health provides a registry of named health checks shared by the utils
+// modules and applications. Checks run concurrently with individual timeouts and
+// produce a single report consumed by the server's /health endpoint, command line
+// tools, and readiness probes.
+package health
+
+import (
+	"context"       // context provides check timeouts and cancellation.
+	"encoding/json" // json provides report encoding.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io"            // io provides the text report writer.
+	"net/http"      // http provides the report handler.
+	"sort"          // sort provides stable check ordering.
+	"sync"          // sync protects the registry and collects concurrent results.
+	"time"          // time provides timeouts and durations.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error creation utilities.
+	"github.com/hekimapro/utils/metrics" // metrics provides the per-check status gauge.
+)
+
+// Status is the outcome of a check or of a whole report.
+type Status string
+
+// Report and check statuses.
+const (
+	StatusHealthy   Status = "healthy"   // StatusHealthy means every check passed
+	StatusDegraded  Status = "degraded"  // StatusDegraded means only non-critical checks failed
+	StatusUnhealthy Status = "unhealthy" // StatusUnhealthy means a critical check failed
+)
+
+// DefaultTimeout bounds checks registered without a timeout.
+const DefaultTimeout = 5 * time.Second
+
+// CheckFunc reports a problem by returning an error. It should honour ctx.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named health check.
+type Check struct {
+	Name     string        // Name identifies the check in reports (e.g. "database", "redis")
+	Check    CheckFunc     // Check performs the check
+	Timeout  time.Duration // Timeout bounds one run (default DefaultTimeout)
+	Critical bool          // Critical makes a failure mark the whole report unhealthy instead of degraded
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Name     string `json:"name"`            // Name is the check name
+	Status   Status `json:"status"`          // Status is healthy or unhealthy
+	Critical bool   `json:"critical"`        // Critical reports whether the check is critical
+	Duration string `json:"duration"`        // Duration is how long the check took
+	Error    string `json:"error,omitempty"` // Error is the failure message, if any
+}
+
+// Report aggregates the results of every check.
+type Report struct {
+	Status    Status    `json:"status"`    // Status is the overall status
+	Timestamp time.Time `json:"timestamp"` // Timestamp is when the checks started
+	Duration  string    `json:"duration"`  // Duration is how long all checks took
+	Checks    []Result  `json:"checks"`    // Checks holds the results sorted by name
+}
+
+// OK reports whether the service can serve traffic, i.e. no critical check failed.
+func (r Report) OK() bool {
+	return r.Status != StatusUnhealthy
+}
+
+// WriteText writes a human-readable report, one line per check, for command line tools.
+func (r Report) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s (%s)\n", r.Status, r.Duration); err != nil {
+		return err
+	}
+	for _, result := range r.Checks {
+		line := fmt.Sprintf("  %-10s %-20s %s", result.Status, result.Name, result.Duration)
+		if result.Critical {
+			line += " [critical]"
+		}
+		if result.Error != "" {
+			line += " - " + result.Error
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStatus exposes the latest result of each check as 1 (healthy) or 0 (unhealthy).
+var checkStatus = metrics.NewGauge("health_check_status", "Latest health check result, 1 when healthy and 0 when failing.", "check")
+
+// Registry holds a set of checks. Most code uses the Default registry through
+// the package-level functions.
+type Registry struct {
+	mu     sync.RWMutex
+	checks map[string]Check
+}
+
+// Default is the registry used by the package-level functions, the utils modules, and the server.
+var Default = NewRegistry()
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]Check)}
+}
+
+// Register adds a check, replacing any check with the same name.
+func (r *Registry) Register(check Check) error {
+	if check.Name == "" {
+		return helpers.CreateError("health check name cannot be empty")
+	}
+	if check.Check == nil {
+		return helpers.CreateErrorf("health check %q has no function", check.Name)
+	}
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[check.Name] = check
+	return nil
+}
+
+// Unregister removes a check by name. It reports whether the check existed.
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.checks[name]
+	delete(r.checks, name)
+	return exists
+}
+
+// Names returns the registered check names in order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes every check concurrently and aggregates the results. A registry
+// without checks reports healthy.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make([]Check, 0, len(r.checks))
+	for _, check := range r.checks {
+		checks = append(checks, check)
+	}
+	r.mu.RUnlock()
+
+	start := time.Now()
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{
+		Status:    StatusHealthy,
+		Timestamp: start,
+		Duration:  time.Since(start).Round(time.Millisecond).String(),
+		Checks:    results,
+	}
+	for _, result := range results {
+		if result.Status == StatusHealthy {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusUnhealthy
+		} else if report.Status == StatusHealthy {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// runCheck runs one check with its timeout, converting a panic into a failure.
+func runCheck(ctx context.Context, check Check) (result Result) {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	result = Result{Name: check.Name, Status: StatusHealthy, Critical: check.Critical}
+
+	errs := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				errs <- helpers.CreateErrorf("check panicked: %v", recovered)
+			}
+		}()
+		errs <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = helpers.CreateErrorf("check timed out after %s", check.Timeout)
+	}
+
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+		checkStatus.Set(0, check.Name)
+	} else {
+		checkStatus.Set(1, check.Name)
+	}
+	return result
+}
+
+// Handler returns an HTTP handler serving the report as JSON, with status 200 when
+// the service is healthy or degraded and 503 when a critical check failed.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Run(req.Context())
+
+		status := http.StatusOK
+		if !report.OK() {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// Register adds a check to the Default registry.
+//
+// Example:
+//
+//	health.Register(health.Check{
+//	    Name:     "database",
+//	    Check:    db.PingContext,
+//	    Timeout:  2 * time.Second,
+//	    Critical: true,
+//	})
+func Register(check Check) error {
+	return Default.Register(check)
+}
+
+// Unregister removes a check from the Default registry.
+func Unregister(name string) bool {
+	return Default.Unregister(name)
+}
+
+// Run executes the checks of the Default registry, e.g. from a command line health probe.
+//
+// Example:
+//
+//	report := health.Run(ctx)
+//	report.WriteText(os.Stdout)
+//	if !report.OK() {
+//	    os.Exit(1)
+//	}
+func Run(ctx context.Context) Report {
+	return Default.Run(ctx)
+}
+
+// Handler returns an HTTP handler serving the Default registry report.
+func Handler() http.Handler {
+	return Default.Handler()
+}
@@ -31,14 +31,43 @@ func init() {
 	if err := godotenv.Load(); err != nil {
 		log.Warning("⚠️ .env file not found or failed to load")
 		log.Error(err.Error())
+		return
 	}
+
+	// Apply LOG_LEVEL, LOG_FORMAT, and LOG_COLORS from the .env file
+	log.Reload()
 }
 
 // GetENVValue loads the environment variable value for a given key (case insensitive,
 // converts input key to UPPER_SNAKE_CASE), including those loaded from .env file.
+// When the variable is unset but KEY_FILE points to a file (e.g., a Docker or
+// Kubernetes secret mounted at /run/secrets/key), the file contents are returned
+// with surrounding whitespace trimmed.
 func GetENVValue(key string) string {
 	snakeKey := strings.ToUpper(ToSnakeCase(key))
-	return os.Getenv(snakeKey)
+	if value := os.Getenv(snakeKey); value != "" {
+		return value
+	}
+	return readSecretFile(snakeKey)
+}
+
+// secretFileSuffix is appended to a variable name to locate a secret file path.
+const secretFileSuffix = "_FILE"
+
+// readSecretFile returns the trimmed contents of the file named by KEY_FILE, or an empty string.
+func readSecretFile(snakeKey string) string {
+	path := os.Getenv(snakeKey + secretFileSuffix)
+	if path == "" {
+		return ""
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Failed to read secret file for %s from %s: %v", snakeKey, path, err))
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
 }
 
 // GetENVValueWithDefault loads an environment variable with a default value if not set.
@@ -0,0 +1,192 @@
+package i18n
+
+import (
+	"encoding/json" // json provides decoding of JSON catalogs.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io/fs"         // fs provides catalog loading from embedded and disk file systems.
+	"os"            // os provides catalog loading from disk.
+	"path"          // path provides slash-separated paths inside file systems.
+	"path/filepath" // filepath provides file name handling on disk.
+	"strings"       // strings provides locale and extension handling.
+
+	"github.com/BurntSushi/toml"         // toml provides decoding of TOML catalogs.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"golang.org/x/text/language"         // language provides BCP 47 locale normalization.
+)
+
+// pluralForms lists the keys that mark an object as a plural message.
+var pluralForms = map[string]bool{"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true}
+
+// AddMessages merges messages into the catalog of locale. Nested objects are
+// flattened into dotted keys ("errors.not_found"); objects made only of plural forms
+// with an "other" form become plural messages.
+//
+// Example:
+//
+//	bundle.AddMessages("sw", map[string]interface{}{
+//	    "greeting": "Habari, {name}!",
+//	    "cart": map[string]interface{}{"items": map[string]interface{}{"one": "kipengee {count}", "other": "vipengee {count}"}},
+//	})
+func (b *Bundle) AddMessages(locale string, messages map[string]interface{}) error {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return helpers.CreateError("locale cannot be empty")
+	}
+
+	flattened := make(map[string]message)
+	if err := flatten("", messages, flattened); err != nil {
+		return helpers.WrapErrorf(err, "invalid messages for locale %s", locale)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	catalog, exists := b.catalogs[locale]
+	if !exists {
+		catalog = make(map[string]message)
+		b.catalogs[locale] = catalog
+	}
+	for key, msg := range flattened {
+		catalog[key] = msg
+	}
+	return nil
+}
+
+// flatten converts nested catalog objects into dotted message keys.
+func flatten(prefix string, values map[string]interface{}, out map[string]message) error {
+	for key, value := range values {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+
+		switch typed := value.(type) {
+		case string:
+			out[fullKey] = message{"other": typed}
+		case map[string]interface{}:
+			if forms, ok := pluralMessage(typed); ok {
+				out[fullKey] = forms
+				continue
+			}
+			if err := flatten(fullKey, typed, out); err != nil {
+				return err
+			}
+		default:
+			return helpers.CreateErrorf("message %s must be a string or an object, got %T", fullKey, value)
+		}
+	}
+	return nil
+}
+
+// pluralMessage returns the plural forms of an object whose keys are all plural
+// categories with string values and which has an "other" form.
+func pluralMessage(values map[string]interface{}) (message, bool) {
+	if _, hasOther := values["other"]; !hasOther {
+		return nil, false
+	}
+	forms := make(message, len(values))
+	for key, value := range values {
+		text, isString := value.(string)
+		if !pluralForms[key] || !isString {
+			return nil, false
+		}
+		forms[key] = text
+	}
+	return forms, true
+}
+
+// LoadFile loads a .json or .toml catalog. The locale is the last dot-separated part
+// of the file name before the extension, so "sw.json" and "messages.sw-TZ.toml"
+// load Swahili and Tanzanian Swahili.
+func (b *Bundle) LoadFile(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to read translation file %s", filePath)
+	}
+	return b.loadData(filepath.Base(filePath), data)
+}
+
+// LoadDir loads every .json and .toml catalog in a directory on disk.
+func (b *Bundle) LoadDir(dir string) error {
+	return b.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS loads every .json and .toml catalog in dir of an embedded or disk file system.
+//
+// Example:
+//
+//	//go:embed locales/*.json
+//	var locales embed.FS
+//
+//	err := i18n.Default.LoadFS(locales, "locales")
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to read translation directory %s", dir)
+	}
+
+	loaded := 0
+	for _, entry := range entries {
+		if entry.IsDir() || catalogFormat(entry.Name()) == "" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return helpers.WrapErrorf(err, "failed to read translation file %s", entry.Name())
+		}
+		if err := b.loadData(entry.Name(), data); err != nil {
+			return err
+		}
+		loaded++
+	}
+
+	log.Info(fmt.Sprintf("🌍 Loaded %d translation file(s) from %s", loaded, dir))
+	return nil
+}
+
+// loadData decodes a catalog named like "sw.json" and adds it to the bundle.
+func (b *Bundle) loadData(name string, data []byte) error {
+	format := catalogFormat(name)
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	locale := stem[strings.LastIndex(stem, ".")+1:]
+
+	messages := make(map[string]interface{})
+	var err error
+	switch format {
+	case "json":
+		err = json.Unmarshal(data, &messages)
+	case "toml":
+		err = toml.Unmarshal(data, &messages)
+	default:
+		return helpers.CreateErrorf("unsupported translation file %s, expected .json or .toml", name)
+	}
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to decode translation file %s", name)
+	}
+
+	return b.AddMessages(locale, messages)
+}
+
+// catalogFormat returns "json" or "toml" for supported catalog files, or an empty string.
+func catalogFormat(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	}
+	return ""
+}
+
+// normalizeLocale canonicalizes a locale tag, e.g. "sw_tz" to "sw-TZ".
+func normalizeLocale(locale string) string {
+	locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		return ""
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return strings.ToLower(locale)
+	}
+	return tag.String()
+}
@@ -0,0 +1,257 @@
+// Package i18n provides translation catalogs with locale resolution, pluralization,
+// and variable interpolation. Catalogs are JSON or TOML files, loaded from disk or an
+// embedded file system, and the built-in catalogs translate the common HTTP error
+// messages into English and Swahili.
+//
+// Messages use {name} placeholders. A message with plural forms is an object with
+// "one" and "other" (and optionally "zero") keys:
+//
+//	{
+//	  "greeting": "Hello, {name}!",
+//	  "cart": {"items": {"one": "{count} item", "other": "{count} items"}}
+//	}
+package i18n
+
+import (
+	"context" // context provides locale propagation.
+	"embed"   // embed provides the built-in catalogs.
+	"fmt"     // fmt provides formatting of interpolated values.
+	"sort"    // sort provides stable locale ordering.
+	"strings" // strings provides placeholder replacement.
+	"sync"    // sync protects the catalogs.
+
+	"github.com/hekimapro/utils/env"    // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/log"    // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models" // models provides the ContextKey type.
+)
+
+// Supported built-in locales.
+const (
+	English = "en" // English is the English locale
+	Swahili = "sw" // Swahili is the Swahili locale
+)
+
+// ContextKeyLocale holds the request locale as a string.
+const ContextKeyLocale models.ContextKey = "locale"
+
+// builtin holds the English and Swahili catalogs loaded into every bundle created by New.
+//
+//go:embed locales
+var builtin embed.FS
+
+// Vars holds the values substituted for {name} placeholders.
+type Vars map[string]interface{}
+
+// Config holds i18n configuration.
+type Config struct {
+	DefaultLocale string `env:"I18N_DEFAULT_LOCALE" default:"en"` // DefaultLocale is used when no requested locale is available
+}
+
+// LoadConfig loads i18n configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid i18n configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// message is a translation with its plural forms; simple messages only have "other".
+type message map[string]string
+
+// Bundle holds the catalogs of every loaded locale.
+type Bundle struct {
+	defaultLocale string
+
+	mu       sync.RWMutex
+	catalogs map[string]map[string]message // catalogs maps locale to message key to message
+}
+
+// NewBundle creates an empty bundle falling back to defaultLocale (default "en").
+func NewBundle(defaultLocale string) *Bundle {
+	if defaultLocale == "" {
+		defaultLocale = English
+	}
+	return &Bundle{
+		defaultLocale: normalizeLocale(defaultLocale),
+		catalogs:      make(map[string]map[string]message),
+	}
+}
+
+// New creates a bundle containing the built-in English and Swahili catalogs.
+// Application catalogs loaded afterwards override built-in messages with the same key.
+//
+// Example:
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	bundle := i18n.New(i18n.LoadConfig())
+//	if err := bundle.LoadFS(locales, "locales"); err != nil {
+//	    log.Fatal(err.Error())
+//	}
+func New(config Config) *Bundle {
+	bundle := NewBundle(config.DefaultLocale)
+	if err := bundle.LoadFS(builtin, "locales"); err != nil {
+		log.Error(fmt.Sprintf("❌ Failed to load built-in translations: %v", err))
+	}
+	return bundle
+}
+
+// Default is the bundle used by the package-level functions.
+var Default = New(LoadConfig())
+
+// DefaultLocale returns the fallback locale.
+func (b *Bundle) DefaultLocale() string {
+	return b.defaultLocale
+}
+
+// Locales returns the loaded locales, sorted.
+func (b *Bundle) Locales() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	locales := make([]string, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// WithLocale returns a context carrying the locale used by T and N.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ContextKeyLocale, normalizeLocale(locale))
+}
+
+// LocaleFromContext returns the locale stored by WithLocale or Middleware, or an empty string.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(ContextKeyLocale).(string)
+	return locale
+}
+
+// Translate returns the message for key in locale with vars substituted. It falls
+// back from a regional locale ("sw-TZ") to its language ("sw") and then to the
+// default locale. Missing keys return the key itself so gaps are visible.
+//
+// Example:
+//
+//	bundle.Translate("sw", "greeting", i18n.Vars{"name": "Asha"}) // "Habari, Asha!"
+func (b *Bundle) Translate(locale, key string, vars Vars) string {
+	msg, _, found := b.lookup(locale, key)
+	if !found {
+		return key
+	}
+	return interpolate(msg["other"], vars)
+}
+
+// Plural returns the plural form of key matching count, with count and vars substituted.
+//
+// Example:
+//
+//	bundle.Plural("en", "cart.items", 3, nil) // "3 items"
+func (b *Bundle) Plural(locale, key string, count int, vars Vars) string {
+	msg, resolved, found := b.lookup(locale, key)
+	if !found {
+		return key
+	}
+
+	withCount := Vars{"count": count}
+	for name, value := range vars {
+		withCount[name] = value
+	}
+
+	form := msg[pluralCategory(resolved, count)]
+	if count == 0 && msg["zero"] != "" {
+		form = msg["zero"]
+	}
+	if form == "" {
+		form = msg["other"]
+	}
+	return interpolate(form, withCount)
+}
+
+// T translates key into the locale of ctx.
+func (b *Bundle) T(ctx context.Context, key string, vars Vars) string {
+	return b.Translate(LocaleFromContext(ctx), key, vars)
+}
+
+// N returns the plural form of key for count in the locale of ctx.
+func (b *Bundle) N(ctx context.Context, key string, count int, vars Vars) string {
+	return b.Plural(LocaleFromContext(ctx), key, count, vars)
+}
+
+// lookup finds a message following the locale fallback chain and returns the locale it was found in.
+func (b *Bundle) lookup(locale, key string) (message, string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, candidate := range b.fallbacks(locale) {
+		if msg, exists := b.catalogs[candidate][key]; exists {
+			return msg, candidate, true
+		}
+	}
+	return nil, "", false
+}
+
+// fallbacks returns the locales to try for a requested locale, most specific first.
+func (b *Bundle) fallbacks(locale string) []string {
+	locale = normalizeLocale(locale)
+	var chain []string
+	if locale != "" {
+		chain = append(chain, locale)
+		if base, _, found := strings.Cut(locale, "-"); found {
+			chain = append(chain, base)
+		}
+	}
+	chain = append(chain, b.defaultLocale)
+	if base, _, found := strings.Cut(b.defaultLocale, "-"); found {
+		chain = append(chain, base)
+	}
+	return chain
+}
+
+// interpolate replaces {name} placeholders with vars. Unknown placeholders are kept.
+func interpolate(text string, vars Vars) string {
+	if len(vars) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	replacements := make([]string, 0, len(vars)*2)
+	for name, value := range vars {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// pluralCategory returns the CLDR plural category of count. English and Swahili
+// (and the default rule) use "one" for exactly 1 and "other" otherwise; French and
+// Portuguese also treat 0 as "one".
+func pluralCategory(locale string, count int) string {
+	base, _, _ := strings.Cut(locale, "-")
+	switch base {
+	case "fr", "pt":
+		if count == 0 || count == 1 {
+			return "one"
+		}
+	default:
+		if count == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+// Translate translates key into locale using the Default bundle.
+func Translate(locale, key string, vars Vars) string {
+	return Default.Translate(locale, key, vars)
+}
+
+// T translates key into the locale of ctx using the Default bundle.
+func T(ctx context.Context, key string, vars Vars) string {
+	return Default.T(ctx, key, vars)
+}
+
+// N returns the plural form of key for count in the locale of ctx using the Default bundle.
+func N(ctx context.Context, key string, count int, vars Vars) string {
+	return Default.N(ctx, key, count, vars)
+}
@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"net/http" // http provides the middleware types.
+	"strings"  // strings provides locale prefix handling.
+
+	"golang.org/x/text/language" // language provides Accept-Language parsing.
+)
+
+// LocaleQueryParam overrides Accept-Language when present, e.g. "?lang=sw".
+const LocaleQueryParam = "lang"
+
+// MatchLocale returns the best loaded locale for an Accept-Language header such as
+// "sw-TZ,sw;q=0.9,en;q=0.8", trying each language in preference order, first exactly
+// and then by its base language. Returns the default locale when nothing matches.
+func (b *Bundle) MatchLocale(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return b.defaultLocale
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, tag := range tags {
+		if locale := b.loadedLocale(tag.String()); locale != "" {
+			return locale
+		}
+	}
+	return b.defaultLocale
+}
+
+// loadedLocale returns locale or its base language if either has a catalog.
+// The caller must hold the read lock.
+func (b *Bundle) loadedLocale(locale string) string {
+	if _, exists := b.catalogs[locale]; exists {
+		return locale
+	}
+	base, _, _ := strings.Cut(locale, "-")
+	if _, exists := b.catalogs[base]; exists {
+		return base
+	}
+	return ""
+}
+
+// ResolveLocale returns the locale for a request: the "lang" query parameter if it
+// names a loaded locale, otherwise the best match for the Accept-Language header.
+func (b *Bundle) ResolveLocale(r *http.Request) string {
+	if requested := r.URL.Query().Get(LocaleQueryParam); requested != "" {
+		b.mu.RLock()
+		locale := b.loadedLocale(normalizeLocale(requested))
+		b.mu.RUnlock()
+		if locale != "" {
+			return locale
+		}
+	}
+	return b.MatchLocale(r.Header.Get("Accept-Language"))
+}
+
+// Middleware stores the resolved request locale in the context for T, N, and
+// RespondWithError, and sets the Content-Language response header.
+//
+// Example:
+//
+//	handler := i18n.Default.Middleware()(mux)
+func (b *Bundle) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := b.ResolveLocale(r)
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+		})
+	}
+}
+
+// Middleware resolves request locales with the Default bundle.
+func Middleware() func(http.Handler) http.Handler {
+	return Default.Middleware()
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"bytes"         // bytes provides template rendering buffers.
+	"context"       // context provides the request locale.
+	"html/template" // template provides localized email templates.
+	"net/http"      // http provides the response writer.
+	"strconv"       // strconv provides status code keys.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides standardized JSON responses.
+	"github.com/hekimapro/utils/models"  // models provides the EmailDetails type.
+)
+
+// StatusKey returns the catalog key of the generic message for an HTTP status, e.g. "http.404".
+func StatusKey(statusCode int) string {
+	return "http." + strconv.Itoa(statusCode)
+}
+
+// RespondWithError writes a standardized JSON error response whose message is key
+// translated into the request locale. An empty key uses the generic message for
+// the status code.
+//
+// Example:
+//
+//	i18n.Default.RespondWithError(w, r, http.StatusNotFound, "errors.invoice_not_found", i18n.Vars{"id": id})
+//	// {"success": false, "message": "Ankara 42 haikupatikana"} for Accept-Language: sw
+func (b *Bundle) RespondWithError(w http.ResponseWriter, r *http.Request, statusCode int, key string, vars Vars) {
+	if key == "" {
+		key = StatusKey(statusCode)
+	}
+	helpers.RespondWithJSON(w, statusCode, b.T(r.Context(), key, vars))
+}
+
+// RespondWithError writes a translated JSON error response using the Default bundle.
+func RespondWithError(w http.ResponseWriter, r *http.Request, statusCode int, key string, vars Vars) {
+	Default.RespondWithError(w, r, statusCode, key, vars)
+}
+
+// TemplateFuncs returns template functions translating into locale:
+//
+//	{{t "email.welcome.greeting" "name" .Name}}
+//	{{tn "email.invoices.pending" .Count}}
+//
+// Arguments after the key (and count) are name/value pairs for interpolation.
+func (b *Bundle) TemplateFuncs(locale string) template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string, pairs ...interface{}) string {
+			return b.Translate(locale, key, pairVars(pairs))
+		},
+		"tn": func(key string, count int, pairs ...interface{}) string {
+			return b.Plural(locale, key, count, pairVars(pairs))
+		},
+	}
+}
+
+// pairVars converts alternating name/value template arguments into Vars.
+func pairVars(pairs []interface{}) Vars {
+	vars := make(Vars, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if name, ok := pairs[i].(string); ok {
+			vars[name] = pairs[i+1]
+		}
+	}
+	return vars
+}
+
+// EmailDetails renders a localized email for the locale of ctx: the subject is
+// subjectKey translated with data's vars and the HTML body is tmpl executed with the
+// t and tn functions bound to the locale. tmpl must be parsed with TemplateFuncs so
+// the functions are defined. The result can be passed to the communication send functions.
+//
+// Example:
+//
+//	welcome := template.Must(template.New("welcome").Funcs(i18n.Default.TemplateFuncs("")).ParseFiles("welcome.html"))
+//	details, err := i18n.Default.EmailDetails(i18n.WithLocale(ctx, user.Locale), from, []string{user.Email},
+//	    "email.welcome.subject", i18n.Vars{"name": user.Name}, welcome, user)
+//	err = communication.SendEmailWithConfig(communication.LoadEmailConfig(), details)
+func (b *Bundle) EmailDetails(ctx context.Context, from string, to []string, subjectKey string, subjectVars Vars, tmpl *template.Template, data interface{}) (models.EmailDetails, error) {
+	locale := LocaleFromContext(ctx)
+
+	localized, err := tmpl.Clone()
+	if err != nil {
+		return models.EmailDetails{}, helpers.WrapError(err, "failed to clone email template")
+	}
+
+	var body bytes.Buffer
+	if err := localized.Funcs(b.TemplateFuncs(locale)).Execute(&body, data); err != nil {
+		return models.EmailDetails{}, helpers.WrapErrorf(err, "failed to render email template %s", tmpl.Name())
+	}
+
+	return models.EmailDetails{
+		From:    from,
+		To:      to,
+		Subject: b.Translate(locale, subjectKey, subjectVars),
+		HTML:    body.String(),
+	}, nil
+}
@@ -0,0 +1,78 @@
+// Package recorder provides the ResponseWriter wrapper shared by the logging, access
+// log, metrics, and audit middlewares to capture the status and size of a response.
+package recorder
+
+import (
+	"bufio"    // bufio provides the hijacked connection reader/writer.
+	"errors"   // errors provides the unsupported hijack error.
+	"net"      // net provides the hijacked connection type.
+	"net/http" // http provides the wrapped ResponseWriter.
+)
+
+// Recorder captures the status code and body size written by a handler while passing
+// flushes, hijacks, and http.ResponseController calls through to the wrapped writer.
+type Recorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// New wraps w in a Recorder.
+func New(w http.ResponseWriter) *Recorder {
+	return &Recorder{ResponseWriter: w}
+}
+
+// Status returns the status code written, or 200 when the handler wrote nothing.
+func (r *Recorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Bytes returns the number of body bytes written.
+func (r *Recorder) Bytes() int64 {
+	return r.bytes
+}
+
+// WriteHeader records the status code.
+func (r *Recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records an implicit 200 status and counts the bytes written.
+func (r *Recorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush supports streaming responses.
+func (r *Recorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack supports WebSocket upgrades.
+func (r *Recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("recorder: response writer does not support hijacking")
+	}
+	if r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (r *Recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,125 @@
+// Package sigv4 implements AWS Signature Version 4 request signing for the
+// JSON APIs (SSM, KMS) used by the env and encryption packages, so those
+// integrations can go through the request package instead of the AWS SDK.
+package sigv4
+
+import (
+	"crypto/hmac"   // hmac provides keyed-hash message authentication codes.
+	"crypto/sha256" // sha256 provides the SHA-256 hash used by SigV4.
+	"encoding/hex"  // hex provides hexadecimal encoding.
+	"fmt"           // fmt provides formatting and printing functions.
+	"net/url"       // url provides URL parsing.
+	"sort"          // sort provides sorting of header names.
+	"strings"       // strings provides string manipulation utilities.
+	"time"          // time provides the signing timestamp.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides environment variable loading.
+)
+
+// Credentials holds the AWS access key pair and optional session token.
+type Credentials struct {
+	AccessKeyID     string // AccessKeyID is the AWS access key ID
+	SecretAccessKey string // SecretAccessKey is the AWS secret access key
+	SessionToken    string // SessionToken is the optional STS session token
+}
+
+// CredentialsFromEnv loads credentials from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		AccessKeyID:     helpers.GetENVValue("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: helpers.GetENVValue("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    helpers.GetENVValue("AWS_SESSION_TOKEN"),
+	}
+}
+
+// RegionFromEnv returns AWS_REGION, falling back to AWS_DEFAULT_REGION.
+func RegionFromEnv() string {
+	return helpers.Coalesce(helpers.GetENVValue("AWS_REGION"), helpers.GetENVValue("AWS_DEFAULT_REGION"))
+}
+
+// Valid reports whether the access key pair is present.
+func (c Credentials) Valid() bool {
+	return c.AccessKeyID != "" && c.SecretAccessKey != ""
+}
+
+// SignHeaders computes the SigV4 headers for a request and returns them merged with the given headers.
+// The returned map contains X-Amz-Date, Authorization, and X-Amz-Security-Token when a session token is set.
+func SignHeaders(method, rawURL string, headers map[string]string, body []byte, credentials Credentials, region, service string, now time.Time) (map[string]string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, helpers.WrapError(err, "invalid URL for signing")
+	}
+
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	signed := make(map[string]string, len(headers)+3)
+	for key, value := range headers {
+		signed[key] = value
+	}
+	signed["X-Amz-Date"] = amzDate
+	if credentials.SessionToken != "" {
+		signed["X-Amz-Security-Token"] = credentials.SessionToken
+	}
+
+	// Canonical headers must be lowercase, sorted, and include the host.
+	canonical := map[string]string{"host": parsedURL.Host}
+	for key, value := range signed {
+		canonical[strings.ToLower(key)] = strings.TrimSpace(value)
+	}
+	names := make([]string, 0, len(canonical))
+	for name := range canonical {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + canonical[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := parsedURL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		method,
+		path,
+		parsedURL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, region, service)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	// Derive the signing key: kSecret -> kDate -> kRegion -> kService -> kSigning.
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), dateStamp)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	signed["Authorization"] = fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature)
+
+	return signed, nil
+}
+
+// hmacSHA256 returns HMAC-SHA256(key, data).
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
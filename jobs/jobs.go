@@ -0,0 +1,162 @@
+// Package jobs provides a persistent background job queue stored in PostgreSQL.
+// Jobs survive restarts, are claimed by workers with a visibility timeout, and are
+// retried with exponential backoff — unlike the in-memory scheduler package.
+package jobs
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"database/sql"  // sql provides database access.
+	"encoding/json" // json provides payload serialization.
+	"fmt"           // fmt provides formatting and printing functions.
+	"sync"          // sync provides synchronization primitives.
+	"time"          // time provides scheduling and backoff.
+
+	"github.com/hekimapro/utils/env"     // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+// Job statuses.
+const (
+	StatusPending   Status = "pending"   // StatusPending jobs wait for run_at
+	StatusRunning   Status = "running"   // StatusRunning jobs are claimed by a worker
+	StatusCompleted Status = "completed" // StatusCompleted jobs finished successfully
+	StatusFailed    Status = "failed"    // StatusFailed jobs exhausted their attempts
+)
+
+// Job is a unit of persistent background work.
+type Job struct {
+	ID          int64           `json:"id"`           // ID is the job's primary key
+	Queue       string          `json:"queue"`        // Queue is the logical queue the job belongs to
+	Type        string          `json:"type"`         // Type selects the registered handler
+	Payload     json.RawMessage `json:"payload"`      // Payload holds the JSON-encoded job arguments
+	Status      Status          `json:"status"`       // Status is the current lifecycle state
+	Attempts    int             `json:"attempts"`     // Attempts counts how often the job was claimed
+	MaxAttempts int             `json:"max_attempts"` // MaxAttempts is the limit before the job fails permanently
+	LastError   string          `json:"last_error"`   // LastError is the error from the latest failed attempt
+	RunAt       time.Time       `json:"run_at"`       // RunAt is the earliest time the job may run
+	CreatedAt   time.Time       `json:"created_at"`   // CreatedAt is when the job was enqueued
+}
+
+// Decode unmarshals the job payload into target.
+func (j *Job) Decode(target interface{}) error {
+	if err := json.Unmarshal(j.Payload, target); err != nil {
+		return helpers.WrapErrorf(err, "failed to decode payload of job %d", j.ID)
+	}
+	return nil
+}
+
+// Handler processes a job. Returning an error schedules a retry with backoff.
+type Handler func(ctx context.Context, job *Job) error
+
+// Config holds job queue configuration.
+type Config struct {
+	Table             string        `env:"JOBS_TABLE" default:"jobs"`                      // Table is the jobs table name
+	Queue             string        `env:"JOBS_QUEUE" default:"default"`                   // Queue is the queue workers consume and Enqueue uses
+	Concurrency       int           `env:"JOBS_CONCURRENCY" default:"4"`                   // Concurrency is the number of workers
+	PollInterval      time.Duration `env:"JOBS_POLL_INTERVAL" default:"1" unit:"s"`        // PollInterval is how often idle workers look for jobs
+	VisibilityTimeout time.Duration `env:"JOBS_VISIBILITY_TIMEOUT" default:"300" unit:"s"` // VisibilityTimeout is how long a claim lasts without a heartbeat
+	MaxAttempts       int           `env:"JOBS_MAX_ATTEMPTS" default:"5"`                  // MaxAttempts is the default attempt limit for new jobs
+	BaseBackoff       time.Duration `env:"JOBS_BASE_BACKOFF" default:"10" unit:"s"`        // BaseBackoff is the delay before the first retry
+	MaxBackoff        time.Duration `env:"JOBS_MAX_BACKOFF" default:"3600" unit:"s"`       // MaxBackoff caps the retry delay
+}
+
+// LoadConfig loads job queue configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid jobs configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// Manager enqueues jobs and runs workers for registered handlers.
+type Manager struct {
+	db       *sql.DB
+	config   Config
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	workers  sync.WaitGroup
+}
+
+// NewManager creates a job manager using the given database connection.
+//
+// Example:
+//
+//	manager := jobs.NewManager(db, jobs.LoadConfig())
+//	manager.CreateTable(ctx)
+//	manager.Register("send_invoice", func(ctx context.Context, job *jobs.Job) error {
+//	    var invoice InvoiceJob
+//	    if err := job.Decode(&invoice); err != nil {
+//	        return err
+//	    }
+//	    return sendInvoice(ctx, invoice)
+//	})
+//	manager.Start(ctx)
+//	manager.Enqueue(ctx, "send_invoice", InvoiceJob{ID: 42})
+func NewManager(db *sql.DB, config Config) *Manager {
+	config.Table = helpers.DefaultIfEmpty(config.Table, "jobs")
+	config.Queue = helpers.DefaultIfEmpty(config.Queue, "default")
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.VisibilityTimeout <= 0 {
+		config.VisibilityTimeout = 5 * time.Minute
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 10 * time.Second
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = time.Hour
+	}
+
+	return &Manager{db: db, config: config, handlers: make(map[string]Handler)}
+}
+
+// Register associates a handler with a job type. Workers only claim registered types.
+func (m *Manager) Register(jobType string, handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[jobType] = handler
+}
+
+// registeredTypes returns the job types that have handlers.
+func (m *Manager) registeredTypes() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	types := make([]string, 0, len(m.handlers))
+	for jobType := range m.handlers {
+		types = append(types, jobType)
+	}
+	return types
+}
+
+// handler returns the handler for a job type.
+func (m *Manager) handler(jobType string) (Handler, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	handler, exists := m.handlers[jobType]
+	return handler, exists
+}
+
+// backoff returns the retry delay after the given number of attempts.
+func (m *Manager) backoff(attempts int) time.Duration {
+	delay := m.config.BaseBackoff
+	for i := 1; i < attempts && delay < m.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > m.config.MaxBackoff {
+		delay = m.config.MaxBackoff
+	}
+	return delay
+}
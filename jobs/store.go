@@ -0,0 +1,257 @@
+package jobs
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"database/sql"  // sql provides database access.
+	"encoding/json" // json provides payload serialization.
+	"errors"        // errors provides detection of sql.ErrNoRows.
+	"fmt"           // fmt provides query formatting.
+	"time"          // time provides scheduling.
+
+	"github.com/hekimapro/utils/database" // database provides context-aware query helpers.
+	"github.com/hekimapro/utils/helpers"  // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"      // log provides colored logging utilities.
+	"github.com/lib/pq"                   // pq provides PostgreSQL array parameters.
+)
+
+// queryRower is satisfied by *sql.DB and *sql.Tx so jobs can be enqueued inside a transaction.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// jobColumns lists the columns scanned into a Job.
+const jobColumns = "id, queue, type, payload, status, attempts, max_attempts, COALESCE(last_error, ''), run_at, created_at"
+
+// scanJob reads a row selected with jobColumns.
+func scanJob(row *sql.Row) (*Job, error) {
+	var job Job
+	var payload []byte
+	err := row.Scan(&job.ID, &job.Queue, &job.Type, &payload, &job.Status, &job.Attempts,
+		&job.MaxAttempts, &job.LastError, &job.RunAt, &job.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	job.Payload = json.RawMessage(payload)
+	return &job, nil
+}
+
+// CreateTable creates the jobs table and its index if they do not exist.
+func (m *Manager) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %[1]s (
+			id BIGSERIAL PRIMARY KEY,
+			queue TEXT NOT NULL DEFAULT 'default',
+			type TEXT NOT NULL,
+			payload JSONB NOT NULL DEFAULT '{}',
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INT NOT NULL DEFAULT 0,
+			max_attempts INT NOT NULL DEFAULT 5,
+			last_error TEXT,
+			run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			locked_until TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			completed_at TIMESTAMPTZ
+		);
+		CREATE INDEX IF NOT EXISTS %[1]s_fetch_idx ON %[1]s (queue, status, run_at);`, m.config.Table)
+
+	if _, err := database.ExecWithContext(ctx, m.db, query); err != nil {
+		return helpers.WrapError(err, "failed to create jobs table")
+	}
+	return nil
+}
+
+// Enqueue adds a job of the given type to run as soon as possible and returns its ID.
+func (m *Manager) Enqueue(ctx context.Context, jobType string, payload interface{}) (int64, error) {
+	return m.enqueue(ctx, m.db, jobType, payload, time.Now())
+}
+
+// EnqueueAt adds a job that runs no earlier than runAt.
+func (m *Manager) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) (int64, error) {
+	return m.enqueue(ctx, m.db, jobType, payload, runAt)
+}
+
+// EnqueueTx adds a job inside an existing transaction, so it is only persisted if the transaction commits.
+func (m *Manager) EnqueueTx(ctx context.Context, transaction *sql.Tx, jobType string, payload interface{}) (int64, error) {
+	return m.enqueue(ctx, transaction, jobType, payload, time.Now())
+}
+
+// enqueue inserts a pending job.
+func (m *Manager) enqueue(ctx context.Context, db queryRower, jobType string, payload interface{}, runAt time.Time) (int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to marshal job payload")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (queue, type, payload, max_attempts, run_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		m.config.Table,
+	)
+
+	var id int64
+	if err := db.QueryRowContext(ctx, query, m.config.Queue, jobType, data, m.config.MaxAttempts, runAt).Scan(&id); err != nil {
+		return 0, helpers.WrapErrorf(err, "failed to enqueue job %s", jobType)
+	}
+	return id, nil
+}
+
+// claim atomically takes the next due job of a registered type, or an abandoned
+// running job whose visibility timeout passed and that has attempts left. Abandoned
+// jobs without attempts left are failed instead. Returns nil when nothing is due.
+func (m *Manager) claim(ctx context.Context, types []string) (*Job, error) {
+	if err := m.failAbandoned(ctx, types); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s SET status = 'running', attempts = attempts + 1,
+			locked_until = NOW() + $3 * INTERVAL '1 millisecond', updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM %[1]s
+			WHERE queue = $1 AND type = ANY($2)
+				AND ((status = 'pending' AND run_at <= NOW()) OR (status = 'running' AND locked_until < NOW() AND attempts < max_attempts))
+			ORDER BY run_at, id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING %[2]s`, m.config.Table, jobColumns)
+
+	job, err := scanJob(database.QueryRowWithContext(ctx, m.db, query,
+		m.config.Queue, pq.Array(types), m.config.VisibilityTimeout.Milliseconds()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to claim job")
+	}
+	return job, nil
+}
+
+// failAbandoned permanently fails running jobs whose visibility timeout passed on
+// their last attempt, so they stop showing as running.
+func (m *Manager) failAbandoned(ctx context.Context, types []string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = 'failed', last_error = 'abandoned during final attempt', locked_until = NULL, updated_at = NOW()
+		WHERE queue = $1 AND type = ANY($2)
+			AND status = 'running' AND locked_until < NOW() AND attempts >= max_attempts`, m.config.Table)
+
+	result, err := database.ExecWithContext(ctx, m.db, query, m.config.Queue, pq.Array(types))
+	if err != nil {
+		return helpers.WrapError(err, "failed to fail abandoned jobs")
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		log.Error(fmt.Sprintf("❌ %d abandoned job(s) failed permanently after their last attempt", affected))
+	}
+	return nil
+}
+
+// extend pushes the visibility timeout of a running job forward.
+func (m *Manager) extend(ctx context.Context, id int64) error {
+	query := fmt.Sprintf(
+		"UPDATE %s SET locked_until = NOW() + $2 * INTERVAL '1 millisecond' WHERE id = $1 AND status = 'running'",
+		m.config.Table,
+	)
+	_, err := database.ExecWithContext(ctx, m.db, query, id, m.config.VisibilityTimeout.Milliseconds())
+	return err
+}
+
+// complete marks a job as completed.
+func (m *Manager) complete(ctx context.Context, id int64) error {
+	query := fmt.Sprintf(
+		"UPDATE %s SET status = 'completed', locked_until = NULL, completed_at = NOW(), updated_at = NOW() WHERE id = $1",
+		m.config.Table,
+	)
+	_, err := database.ExecWithContext(ctx, m.db, query, id)
+	return err
+}
+
+// fail records a failed attempt, scheduling a retry or failing the job permanently.
+func (m *Manager) fail(ctx context.Context, job *Job, cause error) (Status, error) {
+	status := StatusPending
+	if job.Attempts >= job.MaxAttempts {
+		status = StatusFailed
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = $2, last_error = $3, locked_until = NULL,
+			run_at = NOW() + $4 * INTERVAL '1 millisecond', updated_at = NOW()
+		WHERE id = $1`, m.config.Table)
+
+	_, err := database.ExecWithContext(ctx, m.db, query, job.ID, status, cause.Error(), m.backoff(job.Attempts).Milliseconds())
+	return status, err
+}
+
+// failPermanently fails a job without further retries, e.g. when it cannot run at all.
+func (m *Manager) failPermanently(ctx context.Context, id int64, cause error) error {
+	query := fmt.Sprintf(
+		"UPDATE %s SET status = 'failed', last_error = $2, locked_until = NULL, updated_at = NOW() WHERE id = $1",
+		m.config.Table,
+	)
+	_, err := database.ExecWithContext(ctx, m.db, query, id, cause.Error())
+	return err
+}
+
+// Get returns a job by ID, or nil if it does not exist.
+func (m *Manager) Get(ctx context.Context, id int64) (*Job, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1", jobColumns, m.config.Table)
+
+	job, err := scanJob(database.QueryRowWithContext(ctx, m.db, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to load job %d", id)
+	}
+	return job, nil
+}
+
+// Retry resets a failed job so it runs again with a fresh attempt budget.
+func (m *Manager) Retry(ctx context.Context, id int64) error {
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = 'pending', attempts = 0, run_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'failed'`, m.config.Table)
+
+	result, err := database.ExecWithContext(ctx, m.db, query, id)
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to retry job %d", id)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return helpers.CreateErrorf("job %d is not in failed state", id)
+	}
+	return nil
+}
+
+// Stats returns the number of jobs per status in the configured queue.
+func (m *Manager) Stats(ctx context.Context) (map[Status]int64, error) {
+	query := fmt.Sprintf("SELECT status, COUNT(*) FROM %s WHERE queue = $1 GROUP BY status", m.config.Table)
+
+	rows, err := database.QueryWithContext(ctx, m.db, query, m.config.Queue)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to load job stats")
+	}
+	defer rows.Close()
+
+	stats := map[Status]int64{StatusPending: 0, StatusRunning: 0, StatusCompleted: 0, StatusFailed: 0}
+	for rows.Next() {
+		var status Status
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, helpers.WrapError(err, "failed to read job stats")
+		}
+		stats[status] = count
+	}
+	return stats, rows.Err()
+}
+
+// DeleteCompleted removes completed jobs older than the given age and returns how many were deleted.
+func (m *Manager) DeleteCompleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE status = 'completed' AND completed_at < NOW() - $1 * INTERVAL '1 millisecond'",
+		m.config.Table,
+	)
+	result, err := database.ExecWithContext(ctx, m.db, query, olderThan.Milliseconds())
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to delete completed jobs")
+	}
+	return result.RowsAffected()
+}
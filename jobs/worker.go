@@ -0,0 +1,177 @@
+package jobs
+
+import (
+	"context"  // context provides support for cancellation and timeouts.
+	"fmt"      // fmt provides formatting and printing functions.
+	"net/http" // http provides the status endpoint.
+	"strconv"  // strconv provides parsing of job IDs.
+	"time"     // time provides polling and heartbeats.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides JSON responses and error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Start launches Concurrency workers in the background. Workers stop claiming new
+// jobs when ctx is cancelled; use Wait to block until in-flight jobs finish.
+func (m *Manager) Start(ctx context.Context) {
+	log.Info(fmt.Sprintf("🚀 Starting %d job worker(s) on queue %s", m.config.Concurrency, m.config.Queue))
+
+	for i := 0; i < m.config.Concurrency; i++ {
+		m.workers.Add(1)
+		go func(worker int) {
+			defer m.workers.Done()
+			m.work(ctx, worker)
+		}(i + 1)
+	}
+}
+
+// Wait blocks until all workers have stopped.
+func (m *Manager) Wait() {
+	m.workers.Wait()
+	log.Info("🛑 Job workers stopped")
+}
+
+// work claims and runs jobs until ctx is cancelled, sleeping PollInterval when idle.
+func (m *Manager) work(ctx context.Context, worker int) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		ran, err := m.runNext(ctx)
+		if err != nil {
+			log.Error(fmt.Sprintf("❌ Job worker %d: %v", worker, err))
+		}
+		if ran {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(m.config.PollInterval):
+		}
+	}
+}
+
+// runNext claims one job and runs it. Returns true if a job was processed.
+func (m *Manager) runNext(ctx context.Context) (bool, error) {
+	types := m.registeredTypes()
+	if len(types) == 0 {
+		return false, nil
+	}
+
+	job, err := m.claim(ctx, types)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	// Results are recorded even if ctx is cancelled mid-job, so the job is not retried needlessly.
+	recordCtx := context.WithoutCancel(ctx)
+
+	handler, exists := m.handler(job.Type)
+	if !exists {
+		// Leaving the claim in place would keep the job running forever.
+		cause := helpers.CreateErrorf("no handler registered for job type %s", job.Type)
+		if err := m.failPermanently(recordCtx, job.ID, cause); err != nil {
+			return true, helpers.WrapErrorf(err, "failed to mark job %d failed", job.ID)
+		}
+		return true, helpers.WrapErrorf(cause, "job %d failed", job.ID)
+	}
+	started := time.Now()
+
+	jobErr := m.execute(ctx, handler, job)
+	if jobErr == nil {
+		log.Success(fmt.Sprintf("✅ Job %d (%s) completed in %v", job.ID, job.Type, time.Since(started)))
+		if err := m.complete(recordCtx, job.ID); err != nil {
+			return true, helpers.WrapErrorf(err, "failed to mark job %d completed", job.ID)
+		}
+		return true, nil
+	}
+
+	status, err := m.fail(recordCtx, job, jobErr)
+	if err != nil {
+		return true, helpers.WrapErrorf(err, "failed to record failure of job %d", job.ID)
+	}
+
+	if status == StatusFailed {
+		log.Error(fmt.Sprintf("❌ Job %d (%s) failed permanently after %d attempt(s): %v", job.ID, job.Type, job.Attempts, jobErr))
+	} else {
+		log.Warning(fmt.Sprintf("⚠️ Job %d (%s) failed (attempt %d/%d), retrying in %v: %v",
+			job.ID, job.Type, job.Attempts, job.MaxAttempts, m.backoff(job.Attempts), jobErr))
+	}
+	return true, nil
+}
+
+// execute runs the handler with panic recovery while a heartbeat keeps the claim alive.
+func (m *Manager) execute(ctx context.Context, handler Handler, job *Job) (err error) {
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+
+	go func() {
+		ticker := time.NewTicker(m.config.VisibilityTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-heartbeatCtx.Done():
+				return
+			case <-ticker.C:
+				if err := m.extend(heartbeatCtx, job.ID); err != nil {
+					log.Warning(fmt.Sprintf("⚠️ Failed to extend claim on job %d: %v", job.ID, err))
+				}
+			}
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = helpers.CreateErrorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// StatusHandler returns an HTTP handler reporting job counts per status,
+// or a single job when called with ?id=<job id>.
+//
+// Example:
+//
+//	router.Handle("/jobs/status", server.ChainMiddlewares(manager.StatusHandler(), server.RequireRoles("admin")))
+func (m *Manager) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if idParam := r.URL.Query().Get("id"); idParam != "" {
+			id, err := strconv.ParseInt(idParam, 10, 64)
+			if err != nil {
+				helpers.RespondWithJSON(w, http.StatusBadRequest, "invalid job id")
+				return
+			}
+
+			job, err := m.Get(r.Context(), id)
+			if err != nil {
+				log.Error("❌ " + err.Error())
+				helpers.RespondWithJSON(w, http.StatusInternalServerError, "failed to load job")
+				return
+			}
+			if job == nil {
+				helpers.RespondWithJSON(w, http.StatusNotFound, "job not found")
+				return
+			}
+			helpers.RespondWithJSON(w, http.StatusOK, job)
+			return
+		}
+
+		stats, err := m.Stats(r.Context())
+		if err != nil {
+			log.Error("❌ " + err.Error())
+			helpers.RespondWithJSON(w, http.StatusInternalServerError, "failed to load job stats")
+			return
+		}
+		helpers.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"queue":  m.config.Queue,
+			"counts": stats,
+		})
+	})
+}
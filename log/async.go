@@ -0,0 +1,123 @@
+package log
+
+import (
+	"io"   // io provides the output writer type.
+	"sync" // sync provides guarding of the queue.
+)
+
+// defaultAsyncBufferSize is the queue length used when EnableAsync is given none.
+const defaultAsyncBufferSize = 4096
+
+// asyncLine is a formatted line waiting to be written, or a flush marker when done is set.
+type asyncLine struct {
+	output io.Writer
+	level  LogLevel
+	line   []byte
+	done   chan struct{}
+}
+
+var (
+	asyncQueue   chan asyncLine // asyncQueue is nil while logging is synchronous
+	asyncStopped chan struct{}  // asyncStopped is closed when the writer goroutine exits
+	asyncMutex   sync.RWMutex   // asyncMutex guards asyncQueue against sends after Close
+)
+
+// EnableAsync makes logging asynchronous: lines are formatted by the caller and
+// queued for a background writer, so slow outputs stay off the request path. When
+// the queue of bufferSize lines (default 4096) is full, callers wait rather than
+// lose lines. Call Flush or Close before the process exits; Fatal and Panic flush
+// on their own.
+//
+// Example:
+//
+//	log.EnableAsync(8192)
+//	defer log.Close()
+func EnableAsync(bufferSize int) {
+	Close()
+
+	if bufferSize <= 0 {
+		bufferSize = defaultAsyncBufferSize
+	}
+
+	asyncMutex.Lock()
+	defer asyncMutex.Unlock()
+
+	asyncQueue = make(chan asyncLine, bufferSize)
+	asyncStopped = make(chan struct{})
+	go drainAsync(asyncQueue, asyncStopped)
+}
+
+// drainAsync writes queued lines until the queue is closed.
+func drainAsync(queue chan asyncLine, stopped chan struct{}) {
+	defer close(stopped)
+	for item := range queue {
+		if item.done != nil {
+			close(item.done)
+			continue
+		}
+		write(item.output, item.level, item.line)
+	}
+}
+
+// writeOutput writes a formatted line, or queues it when logging is asynchronous.
+func writeOutput(output io.Writer, level LogLevel, line []byte) {
+	asyncMutex.RLock()
+	defer asyncMutex.RUnlock()
+
+	if asyncQueue != nil {
+		asyncQueue <- asyncLine{output: output, level: level, line: line}
+		return
+	}
+	write(output, level, line)
+}
+
+// write sends a line to output, passing the level to a LevelWriter.
+func write(output io.Writer, level LogLevel, line []byte) {
+	if levelWriter, ok := output.(LevelWriter); ok {
+		levelWriter.WriteLevel(level, line)
+		return
+	}
+	output.Write(line)
+}
+
+// Flush waits until queued lines are written and hooks have fired (up to 5 seconds),
+// then flushes or syncs the output. Use it before exiting, e.g. in a shutdown hook.
+//
+// Example:
+//
+//	server.OnShutdown(func(ctx context.Context) error {
+//	    log.Flush()
+//	    return nil
+//	})
+func Flush() {
+	asyncMutex.RLock()
+	queue := asyncQueue
+	var done chan struct{}
+	if queue != nil {
+		done = make(chan struct{})
+		queue <- asyncLine{done: done}
+	}
+	asyncMutex.RUnlock()
+
+	if done != nil {
+		<-done
+	}
+	flushHooks(hookFlushTimeout)
+	flushOutput()
+}
+
+// Close flushes the logger and stops the background writer started by EnableAsync;
+// later lines are written synchronously. The output itself is left open.
+func Close() {
+	Flush()
+
+	asyncMutex.Lock()
+	queue, stopped := asyncQueue, asyncStopped
+	asyncQueue, asyncStopped = nil, nil
+	asyncMutex.Unlock()
+
+	if queue != nil {
+		close(queue)
+		<-stopped
+	}
+}
@@ -0,0 +1,44 @@
+package log
+
+import (
+	"io" // io provides the output writer type.
+	"os" // os provides terminal detection and the NO_COLOR variable.
+)
+
+// colorsActive is whether lines are colored, derived from the configuration and the
+// output by updateColors; guarded by configMutex.
+var colorsActive = colorsEnabled(globalConfig)
+
+// colorsEnabled reports whether config should produce colored lines: colors must be
+// enabled, NO_COLOR unset, and the output a terminal unless ForceColors is set.
+func colorsEnabled(config LoggerConfig) bool {
+	if !config.EnableColors || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return config.ForceColors || isTerminal(config.Output)
+}
+
+// isTerminal reports whether writer is a terminal rather than a file or pipe.
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// updateColors recomputes colorsActive; callers hold configMutex.
+func updateColors() {
+	colorsActive = colorsEnabled(globalConfig)
+	updateDestinationColors()
+}
+
+// ForceColors keeps colors when Output is not a terminal, e.g. for a log viewer that
+// renders ANSI codes. NO_COLOR still disables them.
+func ForceColors() {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	globalConfig.ForceColors = true
+	updateColors()
+}
@@ -0,0 +1,139 @@
+package log
+
+import (
+	"context" // context provides the request-scoped values.
+	"fmt"     // fmt provides formatted messages.
+	"sync"    // sync provides thread-safe extractor registration.
+
+	"github.com/hekimapro/utils/models" // models provides the shared context key type.
+)
+
+// Context keys read by the default context fields.
+const (
+	ContextKeyRequestID models.ContextKey = "request_id" // ContextKeyRequestID holds the request ID as a string
+	ContextKeyUserID    models.ContextKey = "user_id"    // ContextKeyUserID holds the user ID, as set by server.JWTMiddleware
+	ContextKeyTraceID   models.ContextKey = "trace_id"   // ContextKeyTraceID holds the distributed trace ID as a string
+	ContextKeySpanID    models.ContextKey = "span_id"    // ContextKeySpanID holds the span ID as a string
+)
+
+// contextField is a named value extracted from a context for every context-aware log line.
+type contextField struct {
+	name    string
+	extract func(ctx context.Context) any
+}
+
+var (
+	// contextFields are appended to context-aware log lines in registration order.
+	contextFields = []contextField{
+		{name: "request_id", extract: contextValue(ContextKeyRequestID)},
+		{name: "user_id", extract: contextValue(ContextKeyUserID)},
+		{name: "trace_id", extract: traceValue(ContextKeyTraceID, false)},
+		{name: "span_id", extract: traceValue(ContextKeySpanID, true)},
+	}
+	contextFieldsMutex sync.RWMutex // contextFieldsMutex guards contextFields
+)
+
+// contextValue returns an extractor reading key from the context.
+func contextValue(key models.ContextKey) func(ctx context.Context) any {
+	return func(ctx context.Context) any {
+		return ctx.Value(key)
+	}
+}
+
+// RegisterContextField adds a field extracted from the context of every InfoCtx,
+// ErrorCtx, and similar call, so logs from one request can be correlated. Nil or
+// empty values are omitted. Registering an existing name replaces its extractor.
+// request_id, user_id, trace_id, and span_id are registered by default; the trace
+// fields come from the active span when SetTracing is configured.
+//
+// Example:
+//
+//	log.RegisterContextField("tenant", func(ctx context.Context) any {
+//	    return ctx.Value(tenantKey)
+//	})
+//	log.InfoCtx(r.Context(), "Invoice created") // ... Invoice created [request_id:abc] [tenant:acme]
+func RegisterContextField(name string, extract func(ctx context.Context) any) {
+	contextFieldsMutex.Lock()
+	defer contextFieldsMutex.Unlock()
+
+	// Copy on write so contextFieldValues can iterate without holding the lock
+	fields := make([]contextField, 0, len(contextFields)+1)
+	for _, field := range contextFields {
+		if field.name != name {
+			fields = append(fields, field)
+		}
+	}
+	contextFields = append(fields, contextField{name: name, extract: extract})
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID logged by context-aware calls.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ContextKeyRequestID, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID or Middleware,
+// or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(ContextKeyRequestID).(string)
+	return requestID
+}
+
+// InfoCtx logs an informational message with the fields extracted from ctx.
+func InfoCtx(ctx context.Context, message string) {
+	logInternal(ctx, LevelInfo, message, nil)
+}
+
+// InfofCtx logs a formatted informational message with the fields extracted from ctx.
+func InfofCtx(ctx context.Context, format string, args ...interface{}) {
+	logInternal(ctx, LevelInfo, fmt.Sprintf(format, args...), nil)
+}
+
+// SuccessCtx logs a success message with the fields extracted from ctx.
+func SuccessCtx(ctx context.Context, message string) {
+	logInternal(ctx, LevelSuccess, message, nil)
+}
+
+// SuccessfCtx logs a formatted success message with the fields extracted from ctx.
+func SuccessfCtx(ctx context.Context, format string, args ...interface{}) {
+	logInternal(ctx, LevelSuccess, fmt.Sprintf(format, args...), nil)
+}
+
+// WarningCtx logs a warning message with the fields extracted from ctx.
+func WarningCtx(ctx context.Context, message string) {
+	logInternal(ctx, LevelWarning, message, nil)
+}
+
+// WarningfCtx logs a formatted warning message with the fields extracted from ctx.
+func WarningfCtx(ctx context.Context, format string, args ...interface{}) {
+	logInternal(ctx, LevelWarning, fmt.Sprintf(format, args...), nil)
+}
+
+// ErrorCtx logs an error message with the fields extracted from ctx.
+func ErrorCtx(ctx context.Context, message string) {
+	logInternal(ctx, LevelError, message, nil)
+}
+
+// ErrorfCtx logs a formatted error message with the fields extracted from ctx.
+func ErrorfCtx(ctx context.Context, format string, args ...interface{}) {
+	logInternal(ctx, LevelError, fmt.Sprintf(format, args...), nil)
+}
+
+// DebugCtx logs a debug message with the fields extracted from ctx.
+func DebugCtx(ctx context.Context, message string) {
+	logInternal(ctx, LevelDebug, message, nil)
+}
+
+// DebugfCtx logs a formatted debug message with the fields extracted from ctx.
+func DebugfCtx(ctx context.Context, format string, args ...interface{}) {
+	logInternal(ctx, LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+// TraceCtx logs a trace message with the fields extracted from ctx.
+func TraceCtx(ctx context.Context, message string) {
+	logInternal(ctx, LevelTrace, message, nil)
+}
+
+// TracefCtx logs a formatted trace message with the fields extracted from ctx.
+func TracefCtx(ctx context.Context, format string, args ...interface{}) {
+	logInternal(ctx, LevelTrace, fmt.Sprintf(format, args...), nil)
+}
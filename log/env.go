@@ -0,0 +1,63 @@
+package log
+
+import (
+	"fmt"     // fmt provides the invalid value warnings.
+	"os"      // os provides the LOG_* environment variables.
+	"strings" // strings provides parsing of the variable values.
+)
+
+func init() {
+	Reload()
+}
+
+// Reload applies LOG_LEVEL (trace, debug, info, success, warning, error), LOG_FORMAT
+// ("text", "json", or a NewTemplateFormatter template), LOG_COLORS ("auto" or "true"
+// to color terminals, "always", or "false"), and LOG_LEVEL_<NAME> for named loggers
+// from the environment. Unset variables leave the current settings alone. It runs at
+// startup, again once helpers has loaded the .env file, and after env.Reload changes a
+// LOG_ variable, which env.Watch also runs on SIGHUP after re-reading .env.
+//
+// Example:
+//
+//	os.Setenv("LOG_LEVEL", "debug")
+//	log.Reload()
+func Reload() {
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		if level, err := ParseLevel(value); err != nil {
+			fmt.Fprintf(os.Stderr, "log: LOG_LEVEL: %v\n", err)
+		} else {
+			SetMinLevel(level)
+		}
+	}
+
+	if value := os.Getenv("LOG_FORMAT"); value != "" {
+		switch {
+		case strings.EqualFold(value, "text"):
+			SetFormatter(nil)
+		case strings.EqualFold(value, "json"):
+			SetFormatter(NewJSONFormatter())
+		case strings.Contains(value, "{"):
+			SetFormatter(NewTemplateFormatter(value))
+		default:
+			fmt.Fprintf(os.Stderr, "log: LOG_FORMAT: invalid format %q\n", value)
+		}
+	}
+
+	if value := os.Getenv("LOG_COLORS"); value != "" {
+		configMutex.Lock()
+		switch strings.ToLower(value) {
+		case "auto", "true", "1", "yes":
+			globalConfig.EnableColors, globalConfig.ForceColors = true, false
+		case "always", "force":
+			globalConfig.EnableColors, globalConfig.ForceColors = true, true
+		case "false", "0", "no", "never":
+			globalConfig.EnableColors, globalConfig.ForceColors = false, false
+		default:
+			fmt.Fprintf(os.Stderr, "log: LOG_COLORS: invalid value %q\n", value)
+		}
+		updateColors()
+		configMutex.Unlock()
+	}
+
+	resetNamedLevels()
+}
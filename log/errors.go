@@ -0,0 +1,93 @@
+package log
+
+import (
+	"context" // context provides the context of error lines.
+	"errors"  // errors provides unwrapping of error chains.
+	"fmt"     // fmt provides formatting of error types and stack frames.
+	"runtime" // runtime provides stack capture.
+	"strings" // strings provides building of stack traces.
+)
+
+// maxStackDepth limits the number of frames captured for a stack trace.
+const maxStackDepth = 32
+
+// errorFields returns the fields describing err: its message, the message of each
+// wrapped layer (as produced by helpers.WrapError or fmt.Errorf with %w), and the
+// type of the innermost error.
+func errorFields(err error) map[string]interface{} {
+	if err == nil {
+		return map[string]interface{}{}
+	}
+
+	fields := map[string]interface{}{"error": err.Error()}
+
+	var chain []string
+	cause := err
+	for next := errors.Unwrap(cause); next != nil; next = errors.Unwrap(cause) {
+		chain = append(chain, next.Error())
+		cause = next
+	}
+	if len(chain) > 0 {
+		fields["error_chain"] = chain
+	}
+	fields["error_type"] = fmt.Sprintf("%T", cause)
+	return fields
+}
+
+// captureStack returns the stack of the caller skip frames above captureStack's caller,
+// one "function\n\tfile:line" entry per frame.
+func captureStack(skip int) string {
+	pcs := make([]uintptr, maxStackDepth)
+	count := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:count])
+
+	var stack strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&stack, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return stack.String()
+}
+
+// WithError creates a FieldLogger recording err: "error" holds its message,
+// "error_chain" the messages of the errors it wraps, and "error_type" the type of the
+// innermost one. A nil err adds no fields.
+//
+// Example:
+//
+//	if err := repository.Save(order); err != nil {
+//	    log.WithError(err).Error("Failed to save order")
+//	    // ... Failed to save order error=save order: connection refused error_chain=[connection refused] error_type=*net.OpError
+//	}
+func WithError(err error) *FieldLogger {
+	return &FieldLogger{fields: errorFields(err)}
+}
+
+// WithError returns a copy of the FieldLogger that also records err, as the
+// package-level WithError does.
+func (f *FieldLogger) WithError(err error) *FieldLogger {
+	return f.withFields(errorFields(err))
+}
+
+// WithStack returns a copy of the FieldLogger with the stack trace of its caller in a
+// "stack" field.
+func (f *FieldLogger) WithStack() *FieldLogger {
+	return f.withFields(map[string]interface{}{"stack": captureStack(1)})
+}
+
+// ErrorWithStack logs message at ERROR level with the fields of WithError and the
+// stack trace of the caller, for failures that must be debuggable in production.
+//
+// Example:
+//
+//	if err := payments.Charge(order); err != nil {
+//	    log.ErrorWithStack(helpers.WrapError(err, "charge failed"), "Checkout aborted")
+//	}
+func ErrorWithStack(err error, message string) {
+	fields := errorFields(err)
+	fields["stack"] = captureStack(1)
+	logInternal(context.Background(), LevelError, message, fields)
+}
@@ -0,0 +1,178 @@
+package log
+
+import (
+	"context" // context provides the context carried by child loggers.
+	"fmt"     // fmt provides formatted messages and invalid key names.
+	"time"    // time provides the duration and time fields.
+)
+
+// Field is a typed structured field, built with String, Int, Duration, Err, and the
+// other constructors and passed to With.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a string field.
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int creates an integer field.
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 creates a 64-bit integer field.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 creates a floating-point field.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool creates a boolean field.
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration creates a duration field, written like "1.5s".
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time creates a time field.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err creates an "error" field holding err; use WithError to also record its chain.
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// Any creates a field of any type.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// With creates a FieldLogger from typed fields or alternating keys and values; see
+// FieldLogger.With.
+//
+// Example:
+//
+//	log.With("user", userID).With(log.Int("order", orderID)).Info("Order placed")
+func With(args ...interface{}) *FieldLogger {
+	return (&FieldLogger{}).With(args...)
+}
+
+// With returns a child logger with the fields of f plus args, which are Field values
+// or alternating keys and values, so it can be stored and reused, e.g. for the
+// lifetime of a request. f itself is unchanged.
+//
+// Example:
+//
+//	logger := log.With(log.String("request_id", requestID), log.String("user", userID))
+//	logger.With("order", orderID).Info("Order placed")
+//	logger.With(log.Duration("elapsed", time.Since(start))).Info("Request finished")
+func (f *FieldLogger) With(args ...interface{}) *FieldLogger {
+	fields := make(map[string]interface{}, len(args))
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case Field:
+			fields[arg.Key] = arg.Value
+		case string:
+			if i+1 < len(args) {
+				fields[arg] = args[i+1]
+				i++
+			} else {
+				fields[arg] = "(missing)"
+			}
+		default:
+			fields[fmt.Sprintf("!badkey%d", i)] = arg
+		}
+	}
+	return f.withFields(fields)
+}
+
+// WithFields returns a child logger with the fields of f plus fields.
+func (f *FieldLogger) WithFields(fields map[string]interface{}) *FieldLogger {
+	return f.withFields(fields)
+}
+
+// WithContext returns a child logger whose lines also carry the fields registered
+// with RegisterContextField, such as the request ID, extracted from ctx.
+//
+// Example:
+//
+//	logger := log.With("user", userID).WithContext(r.Context())
+//	logger.Info("Profile updated") // ... Profile updated user=42 [request_id:abc]
+func (f *FieldLogger) WithContext(ctx context.Context) *FieldLogger {
+	child := f.withFields(nil)
+	child.ctx = ctx
+	return child
+}
+
+// withFields copies the logger with extra fields merged in.
+func (f *FieldLogger) withFields(extra map[string]interface{}) *FieldLogger {
+	fields := make(map[string]interface{}, len(f.fields)+len(extra))
+	for key, value := range f.fields {
+		fields[key] = value
+	}
+	for key, value := range extra {
+		fields[key] = value
+	}
+	return &FieldLogger{fields: fields, name: f.name, ctx: f.ctx, callerSkip: f.callerSkip}
+}
+
+// WithCallerSkip returns a child logger reporting the caller skip frames further up,
+// for application helpers that wrap the logger, so lines point at the helper's caller.
+//
+// Example:
+//
+//	func logFailure(err error, message string) {
+//	    log.WithCallerSkip(1).WithError(err).Error(message) // reports logFailure's caller
+//	}
+func (f *FieldLogger) WithCallerSkip(skip int) *FieldLogger {
+	child := f.withFields(nil)
+	child.callerSkip += skip
+	return child
+}
+
+// WithCallerSkip creates a FieldLogger reporting the caller skip frames further up;
+// see FieldLogger.WithCallerSkip.
+func WithCallerSkip(skip int) *FieldLogger {
+	return (&FieldLogger{}).WithCallerSkip(skip)
+}
+
+// Infof logs a formatted info message with structured fields.
+func (f *FieldLogger) Infof(format string, args ...interface{}) {
+	f.logWithFields(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs a formatted error message with structured fields.
+func (f *FieldLogger) Errorf(format string, args ...interface{}) {
+	f.logWithFields(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Warningf logs a formatted warning message with structured fields.
+func (f *FieldLogger) Warningf(format string, args ...interface{}) {
+	f.logWithFields(LevelWarning, fmt.Sprintf(format, args...))
+}
+
+// Successf logs a formatted success message with structured fields.
+func (f *FieldLogger) Successf(format string, args ...interface{}) {
+	f.logWithFields(LevelSuccess, fmt.Sprintf(format, args...))
+}
+
+// Debugf logs a formatted debug message with structured fields.
+func (f *FieldLogger) Debugf(format string, args ...interface{}) {
+	f.logWithFields(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Tracef logs a formatted trace message with structured fields.
+func (f *FieldLogger) Tracef(format string, args ...interface{}) {
+	f.logWithFields(LevelTrace, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"encoding/json" // json provides the JSON formatter.
+	"fmt"           // fmt provides formatting of field values.
+	"sort"          // sort provides a stable field order.
+	"strings"       // strings provides template expansion.
+	"time"          // time provides the JSON timestamp format.
+)
+
+// Formatter renders an entry as a log line, for teams matching an existing log
+// convention. The line is colored by level when colors are active, and a trailing
+// newline is added when missing.
+type Formatter interface {
+	Format(entry Entry) string
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(entry Entry) string
+
+// Format calls f(entry).
+func (f FormatterFunc) Format(entry Entry) string {
+	return f(entry)
+}
+
+// templateFormatter expands placeholders in a template string.
+type templateFormatter struct {
+	template string
+}
+
+// NewTemplateFormatter creates a Formatter from a template with the placeholders
+// {level}, {time} (in the logger's TimeFormat), {caller}, {message}, and {fields}
+// (sorted "key=value" pairs, including context fields).
+//
+// Example:
+//
+//	log.SetFormatter(log.NewTemplateFormatter("{time} {level} {caller} - {message} {fields}"))
+//	log.WithFields(map[string]interface{}{"order": 42}).Info("Created")
+//	// Mon Jan 2026 15:04:05.000 INFO  - Created order=42
+func NewTemplateFormatter(template string) Formatter {
+	return &templateFormatter{template: template}
+}
+
+// Format expands the template for entry.
+func (f *templateFormatter) Format(entry Entry) string {
+	configMutex.RLock()
+	timeFormat := globalConfig.TimeFormat
+	configMutex.RUnlock()
+
+	return strings.NewReplacer(
+		"{level}", entry.Level.String(),
+		"{time}", entry.Time.Format(timeFormat),
+		"{caller}", entry.Caller,
+		"{message}", entry.Message,
+		"{fields}", formatFields(entry.Fields),
+	).Replace(f.template)
+}
+
+// formatFields renders fields as "key=value" pairs sorted by key.
+func formatFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", key, fields[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// NewJSONFormatter creates a Formatter writing one JSON object per line with level,
+// time (RFC 3339), message, caller when enabled, and the fields at the top level.
+//
+// Example:
+//
+//	log.SetFormatter(log.NewJSONFormatter())
+//	// {"level":"INFO","message":"Created","order":42,"time":"2026-01-02T15:04:05.000Z"}
+func NewJSONFormatter() Formatter {
+	return FormatterFunc(func(entry Entry) string {
+		line := make(map[string]interface{}, len(entry.Fields)+4)
+		for key, value := range entry.Fields {
+			if err, ok := value.(error); ok {
+				value = err.Error()
+			}
+			line[key] = value
+		}
+		line["level"] = entry.Level.String()
+		line["time"] = entry.Time.Format(time.RFC3339Nano)
+		line["message"] = entry.Message
+		if entry.Caller != "" {
+			line["caller"] = entry.Caller
+		}
+
+		encoded, err := json.Marshal(line)
+		if err != nil {
+			encoded, _ = json.Marshal(map[string]interface{}{
+				"level":   entry.Level.String(),
+				"time":    entry.Time.Format(time.RFC3339Nano),
+				"message": entry.Message,
+				"error":   "failed to encode log fields: " + err.Error(),
+			})
+		}
+		return string(encoded)
+	})
+}
+
+// SetFormatter replaces the default "[LEVEL] time message" line format; nil restores it.
+func SetFormatter(formatter Formatter) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	globalConfig.Formatter = formatter
+}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"fmt"         // fmt provides hook error reporting.
+	"os"          // os provides stderr for hook errors.
+	"sync"        // sync provides thread-safe hook registration.
+	"sync/atomic" // atomic provides the registered flag and pending count.
+	"time"        // time provides entry timestamps and the flush timeout.
+)
+
+const (
+	hookQueueSize    = 1024            // hookQueueSize is the number of entries buffered per hook before new ones are dropped
+	hookFlushTimeout = 5 * time.Second // hookFlushTimeout bounds waiting for hooks before Panic and Fatal
+)
+
+// Entry is a log line as passed to hooks.
+type Entry struct {
+	Level   LogLevel               // Level is the entry's severity
+	Time    time.Time              // Time is when the entry was logged
+	Message string                 // Message is the logged message, without fields
+	Fields  map[string]interface{} // Fields holds the FieldLogger and context fields
+	Caller  string                 // Caller is "file.go:line" when caller info is enabled
+}
+
+// Hook receives log entries, e.g. to forward errors to Slack or Sentry.
+type Hook interface {
+	Fire(entry Entry) error
+}
+
+// HookFunc adapts a function to the Hook interface.
+type HookFunc func(entry Entry) error
+
+// Fire calls f(entry).
+func (f HookFunc) Fire(entry Entry) error {
+	return f(entry)
+}
+
+// hookRunner delivers entries to one hook from its own goroutine.
+type hookRunner struct {
+	hook   Hook
+	levels map[LogLevel]bool // levels is empty for every level
+	queue  chan Entry
+}
+
+var (
+	hooks           []*hookRunner // hooks are the registered runners
+	hooksMutex      sync.RWMutex  // hooksMutex guards hooks
+	hooksRegistered atomic.Bool   // hooksRegistered skips entry building while no hook exists
+	hooksPending    atomic.Int64  // hooksPending counts queued entries not yet fired
+)
+
+// AddHook registers a hook for the given levels, or for every level when none are
+// given. Hooks run asynchronously on their own goroutine, so a slow webhook never
+// blocks logging; entries are dropped when a hook falls 1024 entries behind. Hook
+// errors are written to stderr, and entries logged while a failing Fire was running
+// are discarded for that hook, so a broken webhook cannot alert about itself forever.
+//
+// Example:
+//
+//	log.AddHook(log.HookFunc(func(entry log.Entry) error {
+//	    return sentry.Capture(entry.Message, entry.Fields)
+//	}), log.LevelError, log.LevelFatal)
+func AddHook(hook Hook, levels ...LogLevel) {
+	runner := &hookRunner{
+		hook:   hook,
+		levels: make(map[LogLevel]bool, len(levels)),
+		queue:  make(chan Entry, hookQueueSize),
+	}
+	for _, level := range levels {
+		runner.levels[level] = true
+	}
+	go runner.run()
+
+	hooksMutex.Lock()
+	hooks = append(hooks, runner)
+	hooksMutex.Unlock()
+	hooksRegistered.Store(true)
+}
+
+// fireHooks queues entry for every hook registered for its level.
+func fireHooks(entry Entry) {
+	hooksMutex.RLock()
+	defer hooksMutex.RUnlock()
+
+	for _, runner := range hooks {
+		if len(runner.levels) > 0 && !runner.levels[entry.Level] {
+			continue
+		}
+		hooksPending.Add(1)
+		select {
+		case runner.queue <- entry:
+		default:
+			hooksPending.Add(-1)
+			fmt.Fprintf(os.Stderr, "log: hook queue full, dropping %s entry\n", entry.Level)
+		}
+	}
+}
+
+// run fires queued entries until the process exits.
+func (runner *hookRunner) run() {
+	var discardFrom, discardUntil time.Time
+	for entry := range runner.queue {
+		// Skip what was logged during the last failed Fire, e.g. the failing request's own errors
+		if !entry.Time.Before(discardFrom) && entry.Time.Before(discardUntil) {
+			hooksPending.Add(-1)
+			continue
+		}
+
+		started := time.Now()
+		if err := runner.fire(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "log: hook failed: %v\n", err)
+			discardFrom, discardUntil = started, time.Now()
+		}
+		hooksPending.Add(-1)
+	}
+}
+
+// fire calls the hook, turning a panic into an error.
+func (runner *hookRunner) fire(entry Entry) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("hook panicked: %v", recovered)
+		}
+	}()
+	return runner.hook.Fire(entry)
+}
+
+// flushHooks waits until queued entries have been fired or timeout passes.
+func flushHooks(timeout time.Duration) {
+	if !hooksRegistered.Load() {
+		return
+	}
+
+	deadline := time.Now().Add(timeout)
+	for hooksPending.Load() > 0 {
+		if time.Now().After(deadline) {
+			fmt.Fprintln(os.Stderr, "log: timed out waiting for hooks")
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
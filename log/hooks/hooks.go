@@ -0,0 +1,114 @@
+// Package hooks provides log hooks that forward entries to external services,
+// such as a Slack incoming webhook or any HTTP endpoint accepting JSON.
+package hooks
+
+import (
+	"bytes"         // bytes provides the request body reader.
+	"encoding/json" // json provides encoding of delivered entries.
+	"fmt"           // fmt provides formatting of Slack messages and delivery errors.
+	"io"            // io provides draining of response bodies.
+	"net/http"      // http provides the non-logging delivery client.
+	"sort"          // sort provides a stable field order in Slack messages.
+	"strings"       // strings provides building of Slack messages.
+	"time"          // time provides the delivery timeout and timestamps.
+
+	"github.com/hekimapro/utils/log"     // log provides the Hook interface and entries.
+	"github.com/hekimapro/utils/request" // request provides the Headers type.
+)
+
+// deliveryTimeout bounds a single delivery.
+const deliveryTimeout = 10 * time.Second
+
+// client delivers entries. It is a plain http.Client rather than the request
+// package, whose own log lines would fire the hook again and feed back into it.
+var client = &http.Client{Timeout: deliveryTimeout}
+
+// deliver posts body as JSON to url with the given headers.
+func deliver(url string, body any, headers request.Headers) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook payload: %w", err)
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create hook request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpRequest.Header.Set(key, value)
+	}
+
+	response, err := client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("hook delivery failed: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("hook delivery failed with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// payload is the JSON body sent by the HTTP hook.
+type payload struct {
+	Level   string                 `json:"level"`
+	Time    time.Time              `json:"time"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Caller  string                 `json:"caller,omitempty"`
+}
+
+// NewSlackHook creates a hook posting entries to a Slack incoming webhook as
+// "[LEVEL] message key=value ...".
+//
+// Example:
+//
+//	log.AddHook(hooks.NewSlackHook(os.Getenv("SLACK_WEBHOOK_URL")), log.LevelError, log.LevelFatal)
+func NewSlackHook(webhookURL string) log.Hook {
+	return log.HookFunc(func(entry log.Entry) error {
+		return deliver(webhookURL, map[string]string{"text": slackText(entry)}, nil)
+	})
+}
+
+// slackText formats an entry as a single Slack message line.
+func slackText(entry log.Entry) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "[%s] %s", entry.Level, entry.Message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&builder, " %s=%v", key, entry.Fields[key])
+	}
+	if entry.Caller != "" {
+		fmt.Fprintf(&builder, " (%s)", entry.Caller)
+	}
+	return builder.String()
+}
+
+// NewHTTPHook creates a hook posting each entry as JSON to url, with level, time,
+// message, fields, and caller. Headers are added to the request, e.g. for an API key.
+// Deliveries do not log, so the hook can be registered for every level.
+//
+// Example:
+//
+//	log.AddHook(hooks.NewHTTPHook("https://alerts.example.com/logs", request.Headers{
+//	    "X-API-Key": os.Getenv("ALERTS_API_KEY"),
+//	}), log.LevelError)
+func NewHTTPHook(url string, headers request.Headers) log.Hook {
+	return log.HookFunc(func(entry log.Entry) error {
+		body := payload{
+			Level:   entry.Level.String(),
+			Time:    entry.Time,
+			Message: entry.Message,
+			Fields:  entry.Fields,
+			Caller:  entry.Caller,
+		}
+		return deliver(url, body, headers)
+	})
+}
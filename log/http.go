@@ -0,0 +1,124 @@
+package log
+
+import (
+	"context"      // context provides the trace IDs of the request context.
+	"crypto/rand"  // rand provides generation of request IDs.
+	"encoding/hex" // hex provides encoding of request IDs.
+	"fmt"          // fmt provides the request message.
+	"net"          // net provides parsing of the remote address.
+	"net/http"     // http provides the middleware types.
+	"time"         // time provides the request duration.
+
+	"github.com/hekimapro/utils/internal/recorder" // recorder provides the shared response status recorder.
+)
+
+// HeaderRequestID is read from incoming requests and set on responses by Middleware.
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength caps client-supplied request IDs.
+const maxRequestIDLength = 128
+
+// newRequestID returns a random 16-byte hex request ID.
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// validRequestID reports whether a client-supplied request ID is short and made of
+// letters, digits, and "-", "_", ".", or ":", so it is safe to log and store.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, char := range requestID {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case char == '-', char == '_', char == '.', char == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// AssignRequestID gives a request its ID once, for every middleware that needs one:
+// an ID already in the context is kept, otherwise a valid X-Request-ID header is used
+// or a new ID generated, echoed in the response header, and stored in the context.
+//
+// Example:
+//
+//	r = log.AssignRequestID(w, r)
+//	requestID := log.RequestIDFromContext(r.Context())
+func AssignRequestID(w http.ResponseWriter, r *http.Request) *http.Request {
+	if RequestIDFromContext(r.Context()) != "" {
+		return r
+	}
+	requestID := r.Header.Get(HeaderRequestID)
+	if !validRequestID(requestID) {
+		requestID = newRequestID()
+	}
+	w.Header().Set(HeaderRequestID, requestID)
+	return r.WithContext(WithRequestID(r.Context(), requestID))
+}
+
+// Middleware assigns every request an ID with AssignRequestID, stores it
+// in the request context for InfoCtx and the other context-aware functions, and logs
+// one entry per request with method, path, status, bytes, duration, remote IP, and
+// the context fields such as the request ID: 5xx as errors, 4xx as warnings, and the
+// rest as info. The trace and span IDs of a W3C traceparent header are stored in the
+// context too, for services without a tracing library. It replaces
+// middleware.AccessLog rather than running next to it, which would log every
+// request twice.
+//
+// Example:
+//
+//	handler := server.ChainMiddlewares(router, log.Middleware)
+//
+//	// In a handler, lines carry the same request ID:
+//	log.InfoCtx(r.Context(), "Order created") // ... Order created [request_id:3f2a...]
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = AssignRequestID(w, r)
+		ctx := r.Context()
+
+		// Correlate with the caller's trace when no tracing library is configured
+		traceID, spanID, traced := parseTraceparent(r.Header.Get("traceparent"))
+		if traced {
+			ctx = context.WithValue(ctx, ContextKeyTraceID, traceID)
+			ctx = context.WithValue(ctx, ContextKeySpanID, spanID)
+		}
+		r = r.WithContext(ctx)
+
+		start := time.Now()
+		writer := recorder.New(w)
+		next.ServeHTTP(writer, r)
+		duration := time.Since(start)
+
+		status := writer.Status()
+		remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			remoteIP = r.RemoteAddr
+		}
+
+		logger := WithFields(map[string]interface{}{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"status":      status,
+			"bytes":       writer.Bytes(),
+			"duration_ms": float64(duration.Microseconds()) / 1000,
+			"remote_ip":   remoteIP,
+		}).WithContext(ctx)
+		message := fmt.Sprintf("%s %s %d", r.Method, r.URL.Path, status)
+
+		// The public methods keep the reported caller at this middleware
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error(message)
+		case status >= http.StatusBadRequest:
+			logger.Warning(message)
+		default:
+			logger.Info(message)
+		}
+	})
+}
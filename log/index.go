@@ -2,14 +2,15 @@
 package log
 
 import (
-	"context" // context provides support for contextual logging.
-	"fmt"     // fmt provides formatting and printing functions.
-	"io"      // io provides I/O interfaces for output redirection.
-	"os"      // os provides access to standard output and environment variables.
-	"runtime" // runtime provides access to stack trace information.
-	"strings" // strings provides string manipulation utilities.
-	"sync"    // sync provides synchronization primitives for thread safety.
-	"time"    // time provides functionality for handling time and timestamps.
+	"context"       // context provides support for contextual logging.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io"            // io provides I/O interfaces for output redirection.
+	"os"            // os provides access to standard output and the working directory.
+	"path/filepath" // filepath provides caller paths relative to the working directory.
+	"runtime"       // runtime provides access to stack trace information.
+	"strings"       // strings provides string manipulation utilities.
+	"sync"          // sync provides synchronization primitives for thread safety.
+	"time"          // time provides functionality for handling time and timestamps.
 )
 
 // Constants for ANSI color codes used in log output formatting.
@@ -19,24 +20,31 @@ const (
 	brightGreen  = "\033[92m" // brightGreen is the ANSI code for bright green text.
 	brightYellow = "\033[93m" // brightYellow is the ANSI code for bright yellow text.
 	brightBlue   = "\033[94m" // brightBlue is the ANSI code for bright blue text.
+	brightPurple = "\033[95m" // brightPurple is the ANSI code for bright magenta text.
 	brightCyan   = "\033[96m" // brightCyan is the ANSI code for bright cyan text.
 	brightWhite  = "\033[97m" // brightWhite is the ANSI code for bright white text.
+	gray         = "\033[90m" // gray is the ANSI code for dim gray text.
 )
 
 // LogLevel represents the severity level of log messages.
 type LogLevel int
 
 const (
-	LevelDebug   LogLevel = iota // LevelDebug represents debug-level messages
-	LevelInfo                    // LevelInfo represents informational messages
-	LevelSuccess                 // LevelSuccess represents success messages
-	LevelWarning                 // LevelWarning represents warning messages
-	LevelError                   // LevelError represents error messages
+	LevelTrace   LogLevel = iota - 1 // LevelTrace represents very verbose messages such as wire payloads, below debug
+	LevelDebug                       // LevelDebug represents debug-level messages
+	LevelInfo                        // LevelInfo represents informational messages
+	LevelSuccess                     // LevelSuccess represents success messages
+	LevelWarning                     // LevelWarning represents warning messages
+	LevelError                       // LevelError represents error messages
+	LevelPanic                       // LevelPanic represents messages logged before panicking
+	LevelFatal                       // LevelFatal represents messages logged before exiting the process
 )
 
 // String returns the string representation of the log level.
 func (l LogLevel) String() string {
 	switch l {
+	case LevelTrace:
+		return "TRACE"
 	case LevelDebug:
 		return "DEBUG"
 	case LevelInfo:
@@ -47,6 +55,10 @@ func (l LogLevel) String() string {
 		return "WARNING"
 	case LevelError:
 		return "ERROR"
+	case LevelPanic:
+		return "PANIC"
+	case LevelFatal:
+		return "FATAL"
 	default:
 		return "UNKNOWN"
 	}
@@ -54,11 +66,18 @@ func (l LogLevel) String() string {
 
 // LoggerConfig holds configuration for the logger.
 type LoggerConfig struct {
-	MinLevel     LogLevel  // MinLevel specifies the minimum log level to output
-	EnableColors bool      // EnableColors specifies whether to use colored output
-	Output       io.Writer // Output specifies the output writer for logs
-	EnableCaller bool      // EnableCaller specifies whether to include caller information
-	TimeFormat   string    // TimeFormat specifies the timestamp format
+	MinLevel       LogLevel       // MinLevel specifies the minimum log level to output
+	EnableColors   bool           // EnableColors specifies whether to use colored output when Output is a terminal
+	ForceColors    bool           // ForceColors keeps colors when Output is a file or pipe (NO_COLOR still wins)
+	Output         io.Writer      // Output specifies the output writer for logs, e.g. a RotatingFile, SyslogWriter, or JournalWriter
+	EnableCaller   bool           // EnableCaller specifies whether to include caller information
+	CallerSkip     int            // CallerSkip skips extra frames when every log call goes through an application helper
+	CallerFullPath bool           // CallerFullPath reports the path relative to the working directory instead of the file name
+	CallerFunction bool           // CallerFunction appends the calling function's name
+	TimeFormat     string         // TimeFormat specifies the timestamp format
+	Sampling       SamplingConfig // Sampling limits identical messages (zero Interval disables)
+	Formatter      Formatter      // Formatter replaces the default line format, e.g. NewTemplateFormatter (nil = default)
+	Outputs        []Destination  // Outputs are written alongside Output, each with its own level and format
 }
 
 // globalConfig holds the global logger configuration.
@@ -77,24 +96,32 @@ func SetConfig(config LoggerConfig) {
 	configMutex.Lock()
 	defer configMutex.Unlock()
 
-	if config.MinLevel >= LevelDebug && config.MinLevel <= LevelError {
+	if config.MinLevel >= LevelTrace && config.MinLevel <= LevelFatal {
 		globalConfig.MinLevel = config.MinLevel
 	}
 	if config.Output != nil {
 		globalConfig.Output = config.Output
 	}
 	globalConfig.EnableColors = config.EnableColors
+	globalConfig.ForceColors = config.ForceColors
 	globalConfig.EnableCaller = config.EnableCaller
+	globalConfig.CallerSkip = config.CallerSkip
+	globalConfig.CallerFullPath = config.CallerFullPath
+	globalConfig.CallerFunction = config.CallerFunction
 	if config.TimeFormat != "" {
 		globalConfig.TimeFormat = config.TimeFormat
 	}
+	updateColors()
+	globalConfig.Formatter = config.Formatter
+	setDestinations(config.Outputs)
+	SetSampling(config.Sampling)
 }
 
 // SetMinLevel sets the minimum log level for output.
 func SetMinLevel(level LogLevel) {
 	configMutex.Lock()
 	defer configMutex.Unlock()
-	if level >= LevelDebug && level <= LevelError {
+	if level >= LevelTrace && level <= LevelFatal {
 		globalConfig.MinLevel = level
 	}
 }
@@ -105,6 +132,7 @@ func SetOutput(writer io.Writer) {
 	defer configMutex.Unlock()
 	if writer != nil {
 		globalConfig.Output = writer
+		updateColors()
 	}
 }
 
@@ -113,6 +141,7 @@ func DisableColors() {
 	configMutex.Lock()
 	defer configMutex.Unlock()
 	globalConfig.EnableColors = false
+	updateColors()
 }
 
 // EnableCallerInfo enables including caller information in logs.
@@ -122,43 +151,50 @@ func EnableCallerInfo() {
 	globalConfig.EnableCaller = true
 }
 
-// getCallerInfo returns the caller file and line number for logging.
-func getCallerInfo() string {
-	// Skip 3 callers: getCallerInfo -> logInternal -> public log function
-	_, file, line, ok := runtime.Caller(3)
+// getCallerInfo returns the caller file and line number for logging, skipping extra
+// frames for application helpers that wrap the logger. The file is reduced to its
+// name unless fullPath is set, and function appends the calling function's name.
+func getCallerInfo(skip int, fullPath, function bool) string {
+	// Skip 4 callers: getCallerInfo -> writeEntry -> logInternal or logWithFields -> public log function
+	pc, file, line, ok := runtime.Caller(4 + skip)
 	if !ok {
 		return ""
 	}
 
-	// Shorten file path to just the file name
-	parts := strings.Split(file, "/")
-	if len(parts) > 0 {
-		file = parts[len(parts)-1]
+	if fullPath {
+		// Prefer a path relative to the working directory, e.g. "internal/orders/service.go"
+		if workingDirectory, err := os.Getwd(); err == nil {
+			if relative, err := filepath.Rel(workingDirectory, file); err == nil && !strings.HasPrefix(relative, "..") {
+				file = filepath.ToSlash(relative)
+			}
+		}
+	} else {
+		// Shorten file path to just the file name
+		parts := strings.Split(file, "/")
+		if len(parts) > 0 {
+			file = parts[len(parts)-1]
+		}
 	}
 
-	return fmt.Sprintf("%s:%d", file, line)
-}
-
-// shouldLog checks if the given log level should be logged based on configuration.
-func shouldLog(level LogLevel) bool {
-	configMutex.RLock()
-	minLevel := globalConfig.MinLevel
-	configMutex.RUnlock()
-
-	return level >= minLevel
-}
-
-// getColor returns the ANSI color code for the given log level.
-func getColor(level LogLevel) string {
-	configMutex.RLock()
-	enableColors := globalConfig.EnableColors
-	configMutex.RUnlock()
-
-	if !enableColors {
-		return ""
+	caller := fmt.Sprintf("%s:%d", file, line)
+	if function {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			// Trim the import path, keeping e.g. "orders.(*Service).Create"
+			name := fn.Name()
+			if slash := strings.LastIndex(name, "/"); slash >= 0 {
+				name = name[slash+1:]
+			}
+			caller += " " + name
+		}
 	}
+	return caller
+}
 
+// levelColor returns the ANSI color code for the given log level.
+func levelColor(level LogLevel) string {
 	switch level {
+	case LevelTrace:
+		return gray
 	case LevelDebug:
 		return brightCyan
 	case LevelInfo:
@@ -169,147 +205,307 @@ func getColor(level LogLevel) string {
 		return brightYellow
 	case LevelError:
 		return brightRed
+	case LevelPanic, LevelFatal:
+		return brightPurple
 	default:
 		return brightWhite
 	}
 }
 
-// extractContextFields extracts relevant fields from context for logging.
-// This provides a hook for context-aware logging without changing the function signature.
-func extractContextFields(ctx context.Context) string {
+// contextFieldValues returns the fields registered with RegisterContextField that are
+// present in ctx, in registration order.
+func contextFieldValues(ctx context.Context) []fieldValue {
 	if ctx == nil {
-		return ""
+		return nil
 	}
 
-	// This is an extensible hook - you can add more context value extraction here
-	// For example, you might extract request ID, user ID, correlation ID, etc.
+	contextFieldsMutex.RLock()
+	fields := contextFields
+	contextFieldsMutex.RUnlock()
 
-	// Example implementation that would work with common context patterns:
-	// if requestID, ok := ctx.Value("request_id").(string); ok {
-	//     return fmt.Sprintf(" [request_id:%s]", requestID)
-	// }
+	var values []fieldValue
+	for _, field := range fields {
+		value := field.extract(ctx)
+		if value == nil || value == "" {
+			continue
+		}
+		values = append(values, fieldValue{key: field.name, value: value})
+	}
+	return values
+}
 
-	return ""
+// fieldValue is one extracted context field.
+type fieldValue struct {
+	key   string
+	value interface{}
 }
 
 // logInternal is the internal logging function that handles all log output with context support.
-func logInternal(level LogLevel, message string) {
-	if !shouldLog(level) {
+// ctx is context.Background() for the functions without a context, and fields are the
+// structured fields of a FieldLogger.
+func logInternal(ctx context.Context, level LogLevel, message string, fields map[string]interface{}) {
+	threshold, floor := levelThresholds("")
+	if !passesLevels(level, threshold, floor) {
+		return
+	}
+	writeEntry(ctx, level, message, fields, threshold, floor, 0)
+}
+
+// writeEntry formats and writes a message that passed the level check: to the main
+// output and the hooks when level reaches threshold, and to each destination whose
+// MinLevel it reaches, unless it is below floor. callerSkip is added to the caller depth.
+func writeEntry(ctx context.Context, level LogLevel, message string, fields map[string]interface{}, threshold, floor LogLevel, callerSkip int) {
+	if !sampleAllows(level, message) {
 		return
 	}
 
+	// Mask secrets before anything is written or handed to hooks
+	if redact := currentRedactor(); redact != nil {
+		message = redact.text(message)
+		fields = redact.fields(fields)
+	}
+
 	// Get configuration values
 	configMutex.RLock()
 	output := globalConfig.Output
-	enableColors := globalConfig.EnableColors
+	enableColors := colorsActive
 	enableCaller := globalConfig.EnableCaller
+	callerSkip += globalConfig.CallerSkip
+	callerFullPath := globalConfig.CallerFullPath
+	callerFunction := globalConfig.CallerFunction
 	timeFormat := globalConfig.TimeFormat
+	formatter := globalConfig.Formatter
+	outputs := destinations
 	configMutex.RUnlock()
 
 	// Prepare log components
-	timestamp := time.Now().Format(timeFormat)
-	levelStr := level.String()
-	color := getColor(level)
+	now := time.Now()
+	timestamp := now.Format(timeFormat)
+	toMain := level >= threshold
+
+	// Append structured fields to the message, sorted by key
+	text := message
+	if len(fields) > 0 {
+		text += " " + formatFields(fields)
+	}
 
 	// Build additional information string
 	var extraInfo strings.Builder
 
 	// Add caller information if enabled
+	callerInfo := ""
 	if enableCaller {
-		if callerInfo := getCallerInfo(); callerInfo != "" {
+		if callerInfo = getCallerInfo(callerSkip, callerFullPath, callerFunction); callerInfo != "" {
 			extraInfo.WriteString(" [")
 			extraInfo.WriteString(callerInfo)
 			extraInfo.WriteString("]")
 		}
 	}
 
-	// Add context information (using background context for now)
-	// This provides the infrastructure for context-aware logging
-	if ctxFields := extractContextFields(context.Background()); ctxFields != "" {
-		extraInfo.WriteString(ctxFields)
+	// Add the request metadata carried by the context
+	ctxValues := contextFieldValues(ctx)
+	for _, field := range ctxValues {
+		fmt.Fprintf(&extraInfo, " [%s:%v]", field.key, field.value)
 	}
 
-	// Format the log message
-	var logLine string
-	if enableColors {
-		logLine = fmt.Sprintf("%s[%s] %s %s%s%s\n",
-			color, levelStr, timestamp, message, extraInfo.String(), reset)
-	} else {
-		logLine = fmt.Sprintf("[%s] %s %s%s\n",
-			levelStr, timestamp, message, extraInfo.String())
+	// Collect the entry for custom formatters and the hooks
+	var entry Entry
+	hasHooks := toMain && hooksRegistered.Load()
+	spanEvents := toMain && spanEventsEnabled()
+	needEntry := (toMain && formatter != nil) || hasHooks || spanEvents
+	for _, output := range outputs {
+		needEntry = needEntry || output.Formatter != nil
+	}
+	if needEntry {
+		entryFields := make(map[string]interface{}, len(fields)+len(ctxValues))
+		for key, value := range fields {
+			entryFields[key] = value
+		}
+		for _, field := range ctxValues {
+			entryFields[field.key] = field.value
+		}
+		entry = Entry{Level: level, Time: now, Message: message, Fields: entryFields, Caller: callerInfo}
+	}
+
+	// Format the log message for one output
+	formatLine := func(output io.Writer, formatter Formatter, colors bool) string {
+		_, isLevelWriter := output.(LevelWriter)
+		color := levelColor(level)
+		switch {
+		case formatter != nil:
+			logLine := strings.TrimSuffix(formatter.Format(entry), "\n")
+			if colors && !isLevelWriter {
+				logLine = color + logLine + reset
+			}
+			return logLine + "\n"
+		case isLevelWriter:
+			// Syslog and journald record the level and time themselves
+			return text + extraInfo.String()
+		case colors:
+			return fmt.Sprintf("%s[%s] %s %s%s%s\n",
+				color, level.String(), timestamp, text, extraInfo.String(), reset)
+		default:
+			return fmt.Sprintf("[%s] %s %s%s\n",
+				level.String(), timestamp, text, extraInfo.String())
+		}
+	}
+
+	// Write to the outputs, or queue the lines when logging is asynchronous
+	if toMain {
+		writeOutput(output, level, []byte(formatLine(output, formatter, enableColors)))
+	}
+	if level >= floor {
+		for _, destination := range outputs {
+			if level >= destination.MinLevel {
+				writeOutput(destination.Output, level, []byte(formatLine(destination.Output, destination.Formatter, destination.colors)))
+			}
+		}
 	}
 
-	// Write to output
-	fmt.Fprint(output, logLine)
+	// Hand the entry to the registered hooks
+	if hasHooks {
+		fireHooks(entry)
+	}
+
+	// Record the entry on the active span
+	if spanEvents {
+		recordSpanEvent(ctx, entry)
+	}
 }
 
 // Info logs an informational message with a blue [INFO] prefix and timestamp.
 // Now includes internal context support infrastructure.
 func Info(message string) {
-	logInternal(LevelInfo, message)
+	logInternal(context.Background(), LevelInfo, message, nil)
 }
 
 // Infof logs a formatted informational message.
 func Infof(format string, args ...interface{}) {
-	logInternal(LevelInfo, fmt.Sprintf(format, args...))
+	logInternal(context.Background(), LevelInfo, fmt.Sprintf(format, args...), nil)
 }
 
 // Success logs a success message with a green [SUCCESS] prefix and timestamp.
 // Now includes internal context support infrastructure.
 func Success(message string) {
-	logInternal(LevelSuccess, message)
+	logInternal(context.Background(), LevelSuccess, message, nil)
 }
 
 // Successf logs a formatted success message.
 func Successf(format string, args ...interface{}) {
-	logInternal(LevelSuccess, fmt.Sprintf(format, args...))
+	logInternal(context.Background(), LevelSuccess, fmt.Sprintf(format, args...), nil)
 }
 
 // Warning logs a warning message with a yellow [WARNING] prefix and timestamp.
 // Now includes internal context support infrastructure.
 func Warning(message string) {
-	logInternal(LevelWarning, message)
+	logInternal(context.Background(), LevelWarning, message, nil)
 }
 
 // Warningf logs a formatted warning message.
 func Warningf(format string, args ...interface{}) {
-	logInternal(LevelWarning, fmt.Sprintf(format, args...))
+	logInternal(context.Background(), LevelWarning, fmt.Sprintf(format, args...), nil)
 }
 
 // Error logs an error message with a red [ERROR] prefix and timestamp.
 // Now includes internal context support infrastructure.
 func Error(message string) {
-	logInternal(LevelError, message)
+	logInternal(context.Background(), LevelError, message, nil)
 }
 
 // Errorf logs a formatted error message.
 func Errorf(format string, args ...interface{}) {
-	logInternal(LevelError, fmt.Sprintf(format, args...))
+	logInternal(context.Background(), LevelError, fmt.Sprintf(format, args...), nil)
 }
 
 // Debug logs a debug message with a cyan [DEBUG] prefix and timestamp.
 // Debug messages are only shown when log level is set to LevelDebug.
 // Now includes internal context support infrastructure.
 func Debug(message string) {
-	logInternal(LevelDebug, message)
+	logInternal(context.Background(), LevelDebug, message, nil)
 }
 
 // Debugf logs a formatted debug message.
 func Debugf(format string, args ...interface{}) {
-	logInternal(LevelDebug, fmt.Sprintf(format, args...))
+	logInternal(context.Background(), LevelDebug, fmt.Sprintf(format, args...), nil)
 }
 
-// WithFields creates a structured log entry with additional fields.
-// This provides a foundation for structured logging while maintaining simplicity.
-func WithFields(fields map[string]interface{}) *FieldLogger {
-	return &FieldLogger{
-		fields: fields,
+// Trace logs a very verbose message with a gray [TRACE] prefix and timestamp, e.g.
+// wire payloads or SQL with its arguments. Trace messages are only shown when the log
+// level is set to LevelTrace, not at LevelDebug.
+func Trace(message string) {
+	logInternal(context.Background(), LevelTrace, message, nil)
+}
+
+// Tracef logs a formatted trace message.
+func Tracef(format string, args ...interface{}) {
+	logInternal(context.Background(), LevelTrace, fmt.Sprintf(format, args...), nil)
+}
+
+// Panic logs a message at PANIC level, flushes the logger, then panics with it, so
+// deferred functions and recovery middleware still run.
+func Panic(message string) {
+	logInternal(context.Background(), LevelPanic, message, nil)
+	Flush()
+	panic(message)
+}
+
+// Panicf logs a formatted message at PANIC level, then panics with it.
+func Panicf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logInternal(context.Background(), LevelPanic, message, nil)
+	Flush()
+	panic(message)
+}
+
+// Fatal logs a message at FATAL level, flushes the logger (queued lines, hooks, and
+// the output), and exits with status 1. Deferred functions do not run.
+func Fatal(message string) {
+	logInternal(context.Background(), LevelFatal, message, nil)
+	Flush()
+	os.Exit(1)
+}
+
+// Fatalf logs a formatted message at FATAL level, flushes the logger, and exits with status 1.
+func Fatalf(format string, args ...interface{}) {
+	logInternal(context.Background(), LevelFatal, fmt.Sprintf(format, args...), nil)
+	Flush()
+	os.Exit(1)
+}
+
+// flushOutput flushes an output that buffers writes (Flush) or caches them in the
+// OS (Sync, e.g. *os.File), so the last lines survive an exit.
+func flushOutput() {
+	configMutex.RLock()
+	outputs := []io.Writer{globalConfig.Output}
+	for _, destination := range destinations {
+		outputs = append(outputs, destination.Output)
+	}
+	configMutex.RUnlock()
+
+	for _, output := range outputs {
+		switch writer := output.(type) {
+		case interface{ Flush() error }:
+			writer.Flush()
+		case interface{ Sync() error }:
+			writer.Sync()
+		}
 	}
 }
 
-// FieldLogger provides structured logging with additional fields.
+// WithFields creates a structured log entry with additional fields. The map is
+// copied, so later changes to it do not affect the logger.
+func WithFields(fields map[string]interface{}) *FieldLogger {
+	return (&FieldLogger{}).withFields(fields)
+}
+
+// FieldLogger provides structured logging with additional fields. It is immutable:
+// With, WithFields, WithError, and WithContext return child loggers, so one can be
+// stored and shared across goroutines.
 type FieldLogger struct {
-	fields map[string]interface{}
+	fields     map[string]interface{}
+	name       string          // name is set for loggers created by Named
+	ctx        context.Context // ctx is set by WithContext for context fields
+	callerSkip int             // callerSkip is set by WithCallerSkip for wrapped loggers
 }
 
 // Info logs an info message with structured fields.
@@ -337,20 +533,23 @@ func (f *FieldLogger) Debug(message string) {
 	f.logWithFields(LevelDebug, message)
 }
 
+// Trace logs a trace message with structured fields.
+func (f *FieldLogger) Trace(message string) {
+	f.logWithFields(LevelTrace, message)
+}
+
 // logWithFields handles the actual logging with structured fields.
 func (f *FieldLogger) logWithFields(level LogLevel, message string) {
-	if !shouldLog(level) {
+	threshold, floor := levelThresholds(f.name)
+	if !passesLevels(level, threshold, floor) {
 		return
 	}
 
-	// Build fields string
-	fieldsStr := ""
-	if len(f.fields) > 0 {
-		for key, value := range f.fields {
-			fieldsStr += fmt.Sprintf(" %s=%v", key, value)
-		}
+	ctx := f.ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
 	// Log the message with fields
-	logInternal(level, message+fieldsStr)
+	writeEntry(ctx, level, message, f.fields, threshold, floor, f.callerSkip)
 }
@@ -0,0 +1,127 @@
+package log
+
+import (
+	"fmt"     // fmt provides the invalid level error.
+	"os"      // os provides the LOG_LEVEL_<NAME> variables.
+	"strings" // strings provides level and variable name normalization.
+	"sync"    // sync provides thread-safe level registration.
+)
+
+var (
+	namedLevels      = map[string]LogLevel{} // namedLevels are the minimum levels of named loggers
+	namedResolved    = map[string]bool{}     // namedResolved marks names whose environment variable was read
+	namedExplicit    = map[string]bool{}     // namedExplicit marks names whose level was set with SetNamedLevel
+	namedLevelsMutex sync.RWMutex            // namedLevelsMutex guards namedLevels, namedResolved, and namedExplicit
+)
+
+// ParseLevel parses a level name such as "debug" or "WARNING" (case-insensitive;
+// "warn" is accepted for warning).
+func ParseLevel(name string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "success":
+		return LevelSuccess, nil
+	case "warning", "warn":
+		return LevelWarning, nil
+	case "error":
+		return LevelError, nil
+	case "panic":
+		return LevelPanic, nil
+	case "fatal":
+		return LevelFatal, nil
+	default:
+		return LevelInfo, fmt.Errorf("invalid log level %q", name)
+	}
+}
+
+// Named creates a logger for a module whose minimum level can be set apart from the
+// global one, with SetNamedLevel or the LOG_LEVEL_<NAME> environment variable (e.g.
+// LOG_LEVEL_DATABASE=warning), read on first use. Without either it follows the
+// global level. Lines carry a logger=<name> field.
+//
+// Example:
+//
+//	var logger = log.Named("database")
+//
+//	logger.Info("Connected") // ... Connected logger=database
+func Named(name string) *FieldLogger {
+	return &FieldLogger{fields: map[string]interface{}{"logger": name}, name: name}
+}
+
+// namedLevel returns the minimum level of a named logger, reading its environment
+// variable the first time.
+func namedLevel(name string) (LogLevel, bool) {
+	namedLevelsMutex.RLock()
+	level, exists := namedLevels[name]
+	resolved := namedResolved[name]
+	namedLevelsMutex.RUnlock()
+	if resolved {
+		return level, exists
+	}
+
+	namedLevelsMutex.Lock()
+	defer namedLevelsMutex.Unlock()
+
+	if !namedResolved[name] {
+		namedResolved[name] = true
+		if value := os.Getenv(namedLevelVariable(name)); value != "" {
+			if parsed, err := ParseLevel(value); err != nil {
+				fmt.Fprintf(os.Stderr, "log: %s: %v\n", namedLevelVariable(name), err)
+			} else {
+				namedLevels[name] = parsed
+			}
+		}
+	}
+	level, exists = namedLevels[name]
+	return level, exists
+}
+
+// namedLevelVariable returns the environment variable holding the level of a named
+// logger, e.g. LOG_LEVEL_HTTP_CLIENT for "http-client".
+func namedLevelVariable(name string) string {
+	return "LOG_LEVEL_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// SetNamedLevel sets the minimum level of the loggers created by Named(name),
+// overriding LOG_LEVEL_<NAME>.
+//
+// Example:
+//
+//	log.SetNamedLevel("encryption", log.LevelWarning) // silence key-loading chatter
+func SetNamedLevel(name string, level LogLevel) {
+	if level < LevelTrace || level > LevelFatal {
+		return
+	}
+	namedLevelsMutex.Lock()
+	defer namedLevelsMutex.Unlock()
+	namedLevels[name] = level
+	namedResolved[name] = true
+	namedExplicit[name] = true
+}
+
+// resetNamedLevels makes named loggers re-read LOG_LEVEL_<NAME> on next use, except
+// those set with SetNamedLevel.
+func resetNamedLevels() {
+	namedLevelsMutex.Lock()
+	defer namedLevelsMutex.Unlock()
+
+	for name := range namedResolved {
+		if !namedExplicit[name] {
+			delete(namedResolved, name)
+			delete(namedLevels, name)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"io" // io provides the destination writer type.
+)
+
+// Destination is an output written alongside LoggerConfig.Output, with its own
+// minimum level and format, e.g. JSON errors to a file while stdout gets colored text.
+type Destination struct {
+	Output    io.Writer // Output receives the lines, e.g. a RotatingFile or SyslogWriter
+	MinLevel  LogLevel  // MinLevel is the minimum level written to Output (zero value: debug)
+	Formatter Formatter // Formatter formats lines (nil = the default text format, colored on terminals)
+}
+
+// destination is a registered Destination with its color setting resolved.
+type destination struct {
+	Destination
+	colors bool
+}
+
+var (
+	destinations     []destination    // destinations are the extra outputs; guarded by configMutex
+	destinationFloor = LevelFatal + 1 // destinationFloor is the lowest MinLevel of destinations; guarded by configMutex
+)
+
+// AddOutput writes every line at or above destination.MinLevel to destination.Output
+// as well, formatted by its own Formatter. Levels below the global MinLevel still
+// reach the destination; named loggers with their own level stay filtered by it.
+//
+// Example:
+//
+//	file, _ := log.NewRotatingFile(log.RotatingFileConfig{Path: "logs/app.json"})
+//	log.AddOutput(log.Destination{Output: file, MinLevel: log.LevelDebug, Formatter: log.NewJSONFormatter()})
+//	log.AddOutput(log.Destination{Output: os.Stderr, MinLevel: log.LevelError})
+func AddOutput(destination Destination) {
+	if destination.Output == nil {
+		return
+	}
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	setDestinations(append(outputsLocked(), destination))
+}
+
+// outputsLocked returns the registered destinations; callers hold configMutex.
+func outputsLocked() []Destination {
+	outputs := make([]Destination, len(destinations))
+	for i, registered := range destinations {
+		outputs[i] = registered.Destination
+	}
+	return outputs
+}
+
+// setDestinations replaces the destinations; callers hold configMutex.
+func setDestinations(outputs []Destination) {
+	registered := make([]destination, 0, len(outputs))
+	floor := LevelFatal + 1
+	for _, output := range outputs {
+		if output.Output == nil {
+			continue
+		}
+		registered = append(registered, destination{Destination: output})
+		if output.MinLevel < floor {
+			floor = output.MinLevel
+		}
+	}
+	destinations = registered
+	destinationFloor = floor
+	updateColors()
+}
+
+// updateDestinationColors resolves colors for each destination; callers hold configMutex.
+func updateDestinationColors() {
+	for i := range destinations {
+		config := globalConfig
+		config.Output = destinations[i].Output
+		destinations[i].colors = colorsEnabled(config)
+	}
+}
+
+// levelThresholds returns the minimum level for the main output and the floor below
+// which destinations are skipped too: the named level for named loggers that have one,
+// or the global level and no floor.
+func levelThresholds(name string) (threshold, floor LogLevel) {
+	if name != "" {
+		if level, exists := namedLevel(name); exists {
+			return level, level
+		}
+	}
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return globalConfig.MinLevel, LevelTrace
+}
+
+// passesLevels reports whether level reaches the main output or any destination.
+func passesLevels(level, threshold, floor LogLevel) bool {
+	if level >= threshold {
+		return true
+	}
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return level >= floor && level >= destinationFloor
+}
@@ -0,0 +1,217 @@
+package log
+
+import (
+	"regexp"  // regexp provides matching of secrets in messages.
+	"strings" // strings provides key matching.
+	"sync"    // sync provides thread-safe registration.
+)
+
+// redactedValue replaces masked secrets.
+const redactedValue = "[REDACTED]"
+
+var (
+	// redactKeys are the key names masked in fields and "key=value" text.
+	redactKeys = []string{
+		"password", "passwd", "secret", "token", "authorization", "api_key", "apikey",
+		"cookie", "pin", "otp", "cvv", "card_number",
+	}
+	redactPatterns   []*regexp.Regexp                // redactPatterns are extra patterns registered with RedactPattern
+	redactKeyPattern = compileKeyPattern(redactKeys) // redactKeyPattern matches "key=value" pairs of redactKeys in text
+	redactionEnabled = true                          // redactionEnabled is cleared by DisableRedaction
+	redactMutex      sync.RWMutex                    // redactMutex guards the redaction settings
+)
+
+var (
+	// credentialPattern matches HTTP authorization credentials in text.
+	credentialPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+	// cardPattern matches candidate card numbers, checked with the Luhn algorithm.
+	cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// compileKeyPattern builds the pattern matching key=value, key: value, and
+// "key":"value" for keys containing one of names as a word, e.g. "password",
+// "db_password", "x-api-key", or "accessToken".
+func compileKeyPattern(names []string) *regexp.Regexp {
+	plain := make([]string, len(names))
+	camel := make([]string, len(names))
+	for i, name := range names {
+		plain[i] = regexp.QuoteMeta(name)
+		for _, word := range strings.Split(name, "_") {
+			if word != "" {
+				camel[i] += strings.ToUpper(word[:1]) + "(?i:" + regexp.QuoteMeta(word[1:]) + ")"
+			}
+		}
+	}
+	key := `(?:(?i:[\w-]*[_-])?(?i:` + strings.Join(plain, "|") + `)|[A-Za-z0-9]*[a-z0-9](?:` + strings.Join(camel, "|") + `))(?i:[_-][\w-]*)?`
+	return regexp.MustCompile(`\b(` + key + `"?\s*[:=]\s*"?)((?i:(?:bearer|basic)\s+)?[^\s"',;&]+)`)
+}
+
+// RedactKeys adds key names whose values are masked as [REDACTED] before writing:
+// fields whose key contains a name as a word (case-insensitive, so "db_password" and
+// "accessToken" match, but "shipping" does not match "pin"), and "key=value",
+// "key: value", and "key":"value" pairs in messages.
+// Passwords, secrets, tokens, authorization, API keys, cookies, PINs, OTPs, and card
+// numbers are masked by default, as are Bearer and Basic credentials.
+//
+// Example:
+//
+//	log.RedactKeys("national_id", "iban")
+//	log.Infof("Customer iban=%s", iban) // ... Customer iban=[REDACTED]
+func RedactKeys(names ...string) {
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			redactKeys = append(redactKeys, name)
+		}
+	}
+	redactKeyPattern = compileKeyPattern(redactKeys)
+}
+
+// RedactPattern masks every match of pattern in messages and string fields.
+//
+// Example:
+//
+//	log.RedactPattern(regexp.MustCompile(`sk_live_[A-Za-z0-9]+`))
+func RedactPattern(pattern *regexp.Regexp) {
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+	redactPatterns = append(redactPatterns, pattern)
+}
+
+// DisableRedaction turns off masking, e.g. for local debugging.
+func DisableRedaction() {
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+	redactionEnabled = false
+}
+
+// redactor holds a snapshot of the redaction settings for one entry.
+type redactor struct {
+	keys       []string
+	keyPattern *regexp.Regexp
+	patterns   []*regexp.Regexp
+}
+
+// currentRedactor returns the redaction settings, or nil when redaction is disabled.
+func currentRedactor() *redactor {
+	redactMutex.RLock()
+	defer redactMutex.RUnlock()
+
+	if !redactionEnabled {
+		return nil
+	}
+	return &redactor{keys: redactKeys, keyPattern: redactKeyPattern, patterns: redactPatterns}
+}
+
+// text masks secrets in a message or string value.
+func (r *redactor) text(value string) string {
+	value = r.keyPattern.ReplaceAllString(value, "${1}"+redactedValue)
+	value = credentialPattern.ReplaceAllString(value, "${1} "+redactedValue)
+	value = cardPattern.ReplaceAllStringFunc(value, maskCard)
+	for _, pattern := range r.patterns {
+		value = pattern.ReplaceAllString(value, redactedValue)
+	}
+	return value
+}
+
+// sensitiveKey reports whether a field key contains a registered name as a word.
+func (r *redactor) sensitiveKey(key string) bool {
+	words := "_" + strings.Join(keyWords(key), "_") + "_"
+	for _, name := range r.keys {
+		if strings.Contains(words, "_"+name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// keyWords splits a key into lowercase words at separators and camelCase boundaries.
+func keyWords(key string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, strings.ToLower(word.String()))
+			word.Reset()
+		}
+	}
+
+	previousLower := false
+	for _, r := range key {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if previousLower {
+				flush()
+			}
+			word.WriteRune(r)
+			previousLower = false
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			word.WriteRune(r)
+			previousLower = true
+		default:
+			flush()
+			previousLower = false
+		}
+	}
+	flush()
+	return words
+}
+
+// fields returns a copy of fields with sensitive values masked, at any depth.
+func (r *redactor) fields(fields map[string]interface{}) map[string]interface{} {
+	if len(fields) == 0 {
+		return fields
+	}
+
+	redacted := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if r.sensitiveKey(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		switch typed := value.(type) {
+		case string:
+			redacted[key] = r.text(typed)
+		case error:
+			if message := r.text(typed.Error()); message != typed.Error() {
+				redacted[key] = message
+			} else {
+				redacted[key] = typed
+			}
+		case map[string]interface{}:
+			redacted[key] = r.fields(typed)
+		default:
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// maskCard masks a card number that passes the Luhn check, keeping the last four digits.
+func maskCard(candidate string) string {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(candidate)
+	if !luhnValid(digits) {
+		return candidate
+	}
+	return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+}
+
+// luhnValid reports whether digits pass the Luhn checksum used by card numbers.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,281 @@
+package log
+
+import (
+	"compress/gzip" // gzip provides compression of rotated files.
+	"errors"        // errors provides the missing path error.
+	"fmt"           // fmt provides formatting of errors.
+	"io"            // io provides copying into compressed backups.
+	"os"            // os provides file creation, renaming, and removal.
+	"path/filepath" // filepath provides backup file name handling.
+	"sort"          // sort provides ordering of backups by age.
+	"strings"       // strings provides backup file name matching.
+	"sync"          // sync provides thread-safe writes and cleanup tracking.
+	"time"          // time provides rotation timestamps and ages.
+)
+
+// backupTimeFormat is the timestamp inserted into rotated file names; it sorts by age.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFileConfig holds configuration for a RotatingFile.
+type RotatingFileConfig struct {
+	Path        string        // Path is the active log file, e.g. "logs/app.log"
+	MaxSize     int64         // MaxSize rotates the file once it would exceed this many bytes (default 100MB)
+	RotateEvery time.Duration // RotateEvery also rotates files older than this, e.g. 24h (0 = size only)
+	MaxAge      time.Duration // MaxAge deletes backups older than this (0 = keep)
+	MaxBackups  int           // MaxBackups keeps at most this many backups (0 = keep all)
+	Compress    bool          // Compress gzips backups after rotation
+}
+
+// RotatingFile is an io.Writer for LoggerConfig.Output that rotates the log file by
+// size or age and prunes old backups, so services do not need logrotate. Backups are
+// named after the file with a timestamp, e.g. "app-2026-01-02T15-04-05.000.log".
+type RotatingFile struct {
+	config   RotatingFileConfig
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	mutex    sync.Mutex
+	cleanup  sync.WaitGroup // cleanup tracks background compression and pruning
+	pruning  sync.Mutex     // pruning runs one compression and pruning pass at a time
+}
+
+// NewRotatingFile opens (or creates) the log file, appending to an existing one.
+//
+// Example:
+//
+//	file, err := log.NewRotatingFile(log.RotatingFileConfig{
+//	    Path:       "logs/app.log",
+//	    MaxSize:    50 << 20, // 50MB
+//	    MaxAge:     14 * 24 * time.Hour,
+//	    MaxBackups: 10,
+//	    Compress:   true,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer file.Close()
+//	log.SetOutput(file)
+func NewRotatingFile(config RotatingFileConfig) (*RotatingFile, error) {
+	if config.Path == "" {
+		return nil, errors.New("rotating log file path is required")
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = 100 << 20
+	}
+
+	rotating := &RotatingFile{config: config}
+	if err := rotating.open(); err != nil {
+		return nil, err
+	}
+	return rotating, nil
+}
+
+// open opens the active file for appending and records its size and age.
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.config.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(f.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = info.ModTime()
+	if f.size == 0 {
+		f.openedAt = time.Now()
+	}
+	return nil
+}
+
+// Write appends data to the active file, rotating first when it would exceed MaxSize
+// or is older than RotateEvery.
+func (f *RotatingFile) Write(data []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	tooBig := f.size > 0 && f.size+int64(len(data)) > f.config.MaxSize
+	tooOld := f.config.RotateEvery > 0 && time.Since(f.openedAt) >= f.config.RotateEvery
+	if tooBig || tooOld {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the active file, renames it to a timestamped backup, and starts a new one.
+func (f *RotatingFile) Rotate() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// rotate performs the rotation with the lock held, then compresses and prunes
+// backups in the background.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+
+	backup := f.backupName(time.Now())
+	if err := os.Rename(f.config.Path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rename log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.cleanup.Add(1)
+	go func() {
+		defer f.cleanup.Done()
+		f.pruning.Lock()
+		defer f.pruning.Unlock()
+		f.compressAndPrune(backup)
+	}()
+	return nil
+}
+
+// backupName returns the backup path for a rotation at t.
+func (f *RotatingFile) backupName(t time.Time) string {
+	extension := filepath.Ext(f.config.Path)
+	base := strings.TrimSuffix(f.config.Path, extension)
+	return base + "-" + t.Format(backupTimeFormat) + extension
+}
+
+// compressAndPrune gzips the new backup when enabled, then removes backups beyond
+// MaxBackups or older than MaxAge. Errors go to stderr, since the logger may be
+// writing to this very file.
+func (f *RotatingFile) compressAndPrune(backup string) {
+	if f.config.Compress {
+		if err := compressFile(backup); err != nil {
+			fmt.Fprintf(os.Stderr, "log: failed to compress %s: %v\n", backup, err)
+		}
+	}
+
+	if f.config.MaxBackups <= 0 && f.config.MaxAge <= 0 {
+		return
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "log: failed to list log backups: %v\n", err)
+		return
+	}
+	for i, path := range backups {
+		expired := f.config.MaxBackups > 0 && i >= f.config.MaxBackups
+		if !expired && f.config.MaxAge > 0 {
+			if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > f.config.MaxAge {
+				expired = true
+			}
+		}
+		if expired {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "log: failed to remove %s: %v\n", path, err)
+			}
+		}
+	}
+}
+
+// backups lists the backup files of this log, newest first.
+func (f *RotatingFile) backups() ([]string, error) {
+	extension := filepath.Ext(f.config.Path)
+	prefix := filepath.Base(strings.TrimSuffix(f.config.Path, extension)) + "-"
+	directory := filepath.Dir(f.config.Path)
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".gz"), extension)
+		if _, err := time.Parse(backupTimeFormat, stamp); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(directory, name))
+	}
+
+	// Timestamps sort chronologically, so reverse name order is newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// compressFile replaces path with path.gz.
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(target)
+	if _, err := io.Copy(writer, source); err != nil {
+		target.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		target.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := target.Close(); err != nil {
+		return err
+	}
+	source.Close()
+	return os.Remove(path)
+}
+
+// Sync commits the active file to disk; Fatal calls it before exiting.
+func (f *RotatingFile) Sync() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Close waits for background compression and pruning, then closes the active file.
+func (f *RotatingFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.cleanup.Wait()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
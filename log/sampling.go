@@ -0,0 +1,110 @@
+package log
+
+import (
+	"context" // context provides the context of summary lines.
+	"fmt"     // fmt provides the summary message.
+	"sync"    // sync provides thread-safe counting.
+	"time"    // time provides the sampling interval.
+)
+
+// SamplingConfig limits how often an identical message (same level and text) is
+// written, so a flapping dependency cannot flood the output and the hooks.
+type SamplingConfig struct {
+	Interval   time.Duration // Interval is the window identical messages are counted in (0 disables sampling)
+	First      int           // First is how many identical messages are written per interval (default 1)
+	Thereafter int           // Thereafter writes every Nth message after First (0 = suppress the rest)
+}
+
+// sampleCount tracks one message within its interval.
+type sampleCount struct {
+	seen       int
+	suppressed int
+}
+
+// sampler counts identical messages and reports the suppressed ones when their interval ends.
+type sampler struct {
+	config SamplingConfig
+	counts map[string]*sampleCount
+	mutex  sync.Mutex
+}
+
+var (
+	activeSampler *sampler     // activeSampler is nil while sampling is disabled
+	samplerMutex  sync.RWMutex // samplerMutex guards activeSampler
+)
+
+// SetSampling enables sampling of identical messages, or disables it with a zero
+// Interval. When an interval ends with messages suppressed, one summary line such as
+// "Database unreachable (repeated 4211 more times in 1s)" is written. Panic and Fatal
+// messages are never sampled.
+//
+// Example:
+//
+//	// Write an identical message once per second, plus every 1000th occurrence
+//	log.SetSampling(log.SamplingConfig{Interval: time.Second, First: 1, Thereafter: 1000})
+func SetSampling(config SamplingConfig) {
+	samplerMutex.Lock()
+	defer samplerMutex.Unlock()
+
+	if config.Interval <= 0 {
+		activeSampler = nil
+		return
+	}
+	if config.First <= 0 {
+		config.First = 1
+	}
+	activeSampler = &sampler{config: config, counts: make(map[string]*sampleCount)}
+}
+
+// sampleAllows reports whether the message should be written under the current sampling.
+func sampleAllows(level LogLevel, message string) bool {
+	if level >= LevelPanic {
+		return true
+	}
+
+	samplerMutex.RLock()
+	current := activeSampler
+	samplerMutex.RUnlock()
+
+	if current == nil {
+		return true
+	}
+	return current.allow(level, message)
+}
+
+// allow counts the message, starting its interval on the first occurrence.
+func (s *sampler) allow(level LogLevel, message string) bool {
+	key := level.String() + "|" + message
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	count, exists := s.counts[key]
+	if !exists {
+		count = &sampleCount{}
+		s.counts[key] = count
+		time.AfterFunc(s.config.Interval, func() { s.summarize(key, level, message) })
+	}
+	count.seen++
+
+	if count.seen <= s.config.First {
+		return true
+	}
+	if s.config.Thereafter > 0 && (count.seen-s.config.First)%s.config.Thereafter == 0 {
+		return true
+	}
+	count.suppressed++
+	return false
+}
+
+// summarize ends the message's interval and writes how many occurrences were suppressed.
+func (s *sampler) summarize(key string, level LogLevel, message string) {
+	s.mutex.Lock()
+	count := s.counts[key]
+	delete(s.counts, key)
+	s.mutex.Unlock()
+
+	if count != nil && count.suppressed > 0 {
+		logInternal(context.Background(), level, fmt.Sprintf("%s (repeated %d more times in %s)", message, count.suppressed, s.config.Interval), nil)
+	}
+}
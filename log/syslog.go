@@ -0,0 +1,207 @@
+package log
+
+import (
+	"errors"        // errors provides the missing socket error.
+	"fmt"           // fmt provides syslog message framing.
+	"net"           // net provides the syslog connections.
+	"os"            // os provides the process ID, hostname, and program name.
+	"path/filepath" // filepath provides the default tag from the program name.
+	"strings"       // strings provides trimming of log lines.
+	"sync"          // sync provides thread-safe writes and reconnects.
+	"time"          // time provides message timestamps and the dial timeout.
+)
+
+// SyslogFacility is the syslog facility messages are filed under.
+type SyslogFacility int
+
+// Syslog facilities commonly used by applications.
+const (
+	FacilityUser   SyslogFacility = 1  // FacilityUser is for user-level messages (the default)
+	FacilityDaemon SyslogFacility = 3  // FacilityDaemon is for system daemons
+	FacilityLocal0 SyslogFacility = 16 // FacilityLocal0 is reserved for local use
+	FacilityLocal1 SyslogFacility = 17 // FacilityLocal1 is reserved for local use
+	FacilityLocal2 SyslogFacility = 18 // FacilityLocal2 is reserved for local use
+	FacilityLocal3 SyslogFacility = 19 // FacilityLocal3 is reserved for local use
+	FacilityLocal4 SyslogFacility = 20 // FacilityLocal4 is reserved for local use
+	FacilityLocal5 SyslogFacility = 21 // FacilityLocal5 is reserved for local use
+	FacilityLocal6 SyslogFacility = 22 // FacilityLocal6 is reserved for local use
+	FacilityLocal7 SyslogFacility = 23 // FacilityLocal7 is reserved for local use
+)
+
+// localSyslogSockets are the usual local syslog socket paths.
+var localSyslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// LevelWriter is an Output that receives the level of each line, such as a
+// SyslogWriter or JournalWriter. The logger passes it the message with its fields,
+// without colors, level prefix, or timestamp, since the target records those itself.
+type LevelWriter interface {
+	WriteLevel(level LogLevel, message []byte) (int, error)
+}
+
+// syslogSeverity maps a log level to a syslog severity.
+func syslogSeverity(level LogLevel) int {
+	switch level {
+	case LevelTrace, LevelDebug:
+		return 7 // debug
+	case LevelInfo:
+		return 6 // informational
+	case LevelSuccess:
+		return 5 // notice
+	case LevelWarning:
+		return 4 // warning
+	case LevelError:
+		return 3 // error
+	default:
+		return 2 // critical, for panic and fatal
+	}
+}
+
+// defaultTag returns the program name used when no tag is configured.
+func defaultTag() string {
+	return filepath.Base(os.Args[0])
+}
+
+// SyslogConfig holds configuration for a SyslogWriter.
+type SyslogConfig struct {
+	Network  string         // Network is "" for the local syslog socket, or "udp", "tcp", "unix", or "unixgram"
+	Address  string         // Address is the remote "host:port" or socket path (ignored for the local socket)
+	Tag      string         // Tag is the application name (default: program name)
+	Facility SyslogFacility // Facility files messages under a facility (default: FacilityUser)
+}
+
+// SyslogWriter is an Output writing to the local syslog daemon, or to a remote
+// collector over UDP or TCP with RFC 5424 messages (octet-counted on TCP). Lines
+// written with Write, rather than by the logger, are sent as informational.
+type SyslogWriter struct {
+	config     SyslogConfig
+	hostname   string
+	connection net.Conn
+	local      bool
+	mutex      sync.Mutex
+}
+
+// NewSyslogWriter connects to syslog; the connection is re-established when a
+// write fails, so a restarted collector does not silence the service.
+//
+// Example:
+//
+//	// Local syslog daemon
+//	writer, err := log.NewSyslogWriter(log.SyslogConfig{Tag: "orders"})
+//
+//	// Remote collector
+//	writer, err := log.NewSyslogWriter(log.SyslogConfig{
+//	    Network:  "tcp",
+//	    Address:  "logs.example.com:514",
+//	    Tag:      "orders",
+//	    Facility: log.FacilityLocal0,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer writer.Close()
+//	log.SetOutput(writer)
+func NewSyslogWriter(config SyslogConfig) (*SyslogWriter, error) {
+	if config.Tag == "" {
+		config.Tag = defaultTag()
+	}
+	if config.Facility == 0 {
+		config.Facility = FacilityUser
+	}
+	if config.Network != "" && config.Address == "" {
+		return nil, errors.New("syslog address is required for a remote network")
+	}
+
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+
+	writer := &SyslogWriter{config: config, hostname: hostname, local: config.Network == ""}
+	if err := writer.connect(); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// connect dials the configured network, trying the usual socket paths for local syslog.
+func (w *SyslogWriter) connect() error {
+	if !w.local {
+		connection, err := net.DialTimeout(w.config.Network, w.config.Address, 5*time.Second)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog at %s: %w", w.config.Address, err)
+		}
+		w.connection = connection
+		return nil
+	}
+
+	for _, path := range localSyslogSockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			if connection, err := net.Dial(network, path); err == nil {
+				w.connection = connection
+				return nil
+			}
+		}
+	}
+	return errors.New("no local syslog socket found")
+}
+
+// Write sends p as an informational message.
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(LevelInfo, p)
+}
+
+// WriteLevel sends message with the severity of level, reconnecting once on failure.
+func (w *SyslogWriter) WriteLevel(level LogLevel, message []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.connection == nil {
+		if err := w.connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	frame := w.format(level, strings.TrimRight(string(message), "\n"))
+	if _, err := w.connection.Write(frame); err != nil {
+		w.connection.Close()
+		w.connection = nil
+		if err := w.connect(); err != nil {
+			return 0, err
+		}
+		if _, err := w.connection.Write(frame); err != nil {
+			return 0, err
+		}
+	}
+	return len(message), nil
+}
+
+// format frames a message: the traditional local format for the syslog socket, and
+// RFC 5424 for remote collectors, with an octet count prefix on stream connections.
+func (w *SyslogWriter) format(level LogLevel, message string) []byte {
+	priority := int(w.config.Facility)*8 + syslogSeverity(level)
+
+	if w.local {
+		return []byte(fmt.Sprintf("<%d>%s %s[%d]: %s\n",
+			priority, time.Now().Format(time.Stamp), w.config.Tag, os.Getpid(), message))
+	}
+
+	frame := fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+		priority, time.Now().Format(time.RFC3339Nano), w.hostname, w.config.Tag, os.Getpid(), message)
+	if w.config.Network == "tcp" || w.config.Network == "tcp4" || w.config.Network == "tcp6" || w.config.Network == "unix" {
+		frame = fmt.Sprintf("%d %s", len(frame), frame)
+	}
+	return []byte(frame)
+}
+
+// Close closes the syslog connection.
+func (w *SyslogWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.connection == nil {
+		return nil
+	}
+	err := w.connection.Close()
+	w.connection = nil
+	return err
+}
@@ -0,0 +1,86 @@
+package log
+
+import (
+	"context"     // context provides the active span.
+	"strings"     // strings provides parsing of traceparent headers.
+	"sync/atomic" // atomic provides lock-free access to the tracing configuration.
+
+	"github.com/hekimapro/utils/models" // models provides the shared context key type.
+)
+
+// TracingConfig connects the logger to a tracing library such as OpenTelemetry
+// without the log package depending on it.
+type TracingConfig struct {
+	SpanIDs    func(ctx context.Context) (traceID, spanID string, ok bool) // SpanIDs returns the IDs of the active span in ctx
+	SpanEvent  func(ctx context.Context, entry Entry)                      // SpanEvent optionally records an entry on the active span
+	EventLevel LogLevel                                                    // EventLevel is the minimum level passed to SpanEvent
+}
+
+// tracing is the configuration set by SetTracing, nil until then.
+var tracing atomic.Pointer[TracingConfig]
+
+// SetTracing makes context-aware log calls attach the trace_id and span_id of the
+// active span and, with SpanEvent, record entries as span events.
+//
+// Example:
+//
+//	log.SetTracing(log.TracingConfig{
+//	    SpanIDs: func(ctx context.Context) (string, string, bool) {
+//	        span := trace.SpanContextFromContext(ctx)
+//	        return span.TraceID().String(), span.SpanID().String(), span.IsValid()
+//	    },
+//	    SpanEvent: func(ctx context.Context, entry log.Entry) {
+//	        trace.SpanFromContext(ctx).AddEvent(entry.Message, trace.WithAttributes(
+//	            attribute.String("log.severity", entry.Level.String()),
+//	        ))
+//	    },
+//	    EventLevel: log.LevelWarning,
+//	})
+//	log.InfoCtx(ctx, "Charging card") // ... Charging card [trace_id:4bf9...] [span_id:00f0...]
+func SetTracing(config TracingConfig) {
+	tracing.Store(&config)
+}
+
+// traceValue returns an extractor for the trace or span ID, preferring the active span
+// and falling back to the value stored in the context under key.
+func traceValue(key models.ContextKey, span bool) func(ctx context.Context) any {
+	return func(ctx context.Context) any {
+		if config := tracing.Load(); config != nil && config.SpanIDs != nil {
+			if traceID, spanID, ok := config.SpanIDs(ctx); ok {
+				if span {
+					return spanID
+				}
+				return traceID
+			}
+		}
+		return ctx.Value(key)
+	}
+}
+
+// recordSpanEvent passes the entry to SpanEvent when configured and the level qualifies.
+func recordSpanEvent(ctx context.Context, entry Entry) {
+	config := tracing.Load()
+	if config == nil || config.SpanEvent == nil || entry.Level < config.EventLevel {
+		return
+	}
+	config.SpanEvent(ctx, entry)
+}
+
+// spanEventsEnabled reports whether entries must be built for SpanEvent.
+func spanEventsEnabled() bool {
+	config := tracing.Load()
+	return config != nil && config.SpanEvent != nil
+}
+
+// parseTraceparent returns the trace and parent span IDs of a W3C traceparent header
+// such as "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func parseTraceparent(header string) (traceID, spanID string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", "", false
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"net/http" // http provides handler instrumentation.
+	"strconv"  // strconv provides status code formatting.
+	"time"     // time provides request durations.
+
+	"github.com/hekimapro/utils/internal/recorder" // recorder provides the shared response status recorder.
+)
+
+var (
+	httpRequests = NewCounter("http_requests_total",
+		"HTTP requests served, by method, route pattern, and status code.", "method", "route", "code")
+	httpDuration = NewHistogram("http_request_duration_seconds",
+		"HTTP request latency in seconds, by method and route pattern.", nil, "method", "route")
+	httpInFlight = NewGauge("http_requests_in_flight",
+		"HTTP requests currently being served.")
+)
+
+// InstrumentHandler records request counts, latencies, and in-flight requests for next.
+// The route label is the matched http.ServeMux pattern (e.g. "GET /users/{id}"), so
+// path parameters do not create a series per URL; unmatched requests use "other".
+//
+// Example:
+//
+//	handler := metrics.InstrumentHandler(mux)
+func InstrumentHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		writer := recorder.New(w)
+		next.ServeHTTP(writer, r)
+		status := writer.Status()
+
+		// ServeMux sets Pattern on the request it routes, which is this same request.
+		route := r.Pattern
+		if route == "" || route == "/" {
+			route = "other"
+		}
+
+		httpRequests.Inc(r.Method, route, strconv.Itoa(status))
+		httpDuration.ObserveDuration(start, r.Method, route)
+	})
+}
@@ -0,0 +1,284 @@
+// Package metrics provides counters, gauges, and histograms with labels, and an
+// HTTP handler that exposes them in the Prometheus text format.
+//
+// The utils modules record their own metrics (HTTP requests, database queries,
+// scheduler runs, messages sent) in the Default registry, and applications can
+// add custom metrics alongside them.
+package metrics
+
+import (
+	"bytes"    // bytes provides the exposition buffer.
+	"fmt"      // fmt provides formatting and printing functions.
+	"io"       // io provides the output writer.
+	"math"     // math provides float bit conversion and infinity.
+	"net/http" // http provides the exposition handler.
+	"regexp"   // regexp provides metric and label name validation.
+	"sort"     // sort provides stable output ordering.
+	"strconv"  // strconv provides number formatting.
+	"strings"  // strings provides label escaping and series keys.
+	"sync"     // sync protects registry and series maps.
+)
+
+// kind is the Prometheus metric type.
+type kind string
+
+const (
+	kindCounter   kind = "counter"
+	kindGauge     kind = "gauge"
+	kindHistogram kind = "histogram"
+)
+
+// namePattern matches valid metric and label names.
+var namePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// collector is a registered metric family.
+type collector interface {
+	describe() (name, help string, metricKind kind, labels []string)
+	write(buffer *bytes.Buffer)
+}
+
+// Registry holds a set of metrics. Most code uses the Default registry through
+// the package-level functions.
+type Registry struct {
+	mu         sync.RWMutex
+	collectors map[string]collector
+}
+
+// Default is the registry used by the package-level functions and by the utils modules.
+var Default = NewRegistry()
+
+// NewRegistry creates an empty registry, e.g. for tests or a separate endpoint.
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]collector)}
+}
+
+// register adds a collector, or returns the existing one when a metric with the
+// same name, type, and labels is already registered. Registering a conflicting
+// metric or an invalid name is a programming error and panics.
+func (r *Registry) register(name, help string, metricKind kind, labels []string, create func() collector) collector {
+	if !namePattern.MatchString(name) {
+		panic(fmt.Sprintf("metrics: invalid metric name %q", name))
+	}
+	for _, label := range labels {
+		if !namePattern.MatchString(label) || strings.HasPrefix(label, "__") || label == "le" {
+			panic(fmt.Sprintf("metrics: invalid label name %q for %s", label, name))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.collectors[name]; exists {
+		_, _, existingKind, existingLabels := existing.describe()
+		if existingKind != metricKind || strings.Join(existingLabels, ",") != strings.Join(labels, ",") {
+			panic(fmt.Sprintf("metrics: %s is already registered as a %s with labels %v", name, existingKind, existingLabels))
+		}
+		return existing
+	}
+
+	created := create()
+	r.collectors[name] = created
+	return created
+}
+
+// Unregister removes a metric by name. It reports whether the metric existed.
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.collectors[name]
+	delete(r.collectors, name)
+	return exists
+}
+
+// WriteText writes every metric in the Prometheus text exposition format, sorted by name.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := make([]collector, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		collectors = append(collectors, r.collectors[name])
+	}
+	r.mu.RUnlock()
+
+	var buffer bytes.Buffer
+	for _, metric := range collectors {
+		name, help, metricKind, _ := metric.describe()
+		if help != "" {
+			fmt.Fprintf(&buffer, "# HELP %s %s\n", name, escapeHelp(help))
+		}
+		fmt.Fprintf(&buffer, "# TYPE %s %s\n", name, metricKind)
+		metric.write(&buffer)
+	}
+
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+// Handler returns an HTTP handler serving the registry in the Prometheus text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WriteText(w)
+	})
+}
+
+// Handler returns an HTTP handler serving the Default registry, typically mounted at /metrics.
+//
+// Example:
+//
+//	mux.Handle("/metrics", metrics.Handler())
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+// escapeHelp escapes a HELP line.
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// escapeLabel escapes a label value.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
+
+// formatFloat formats a sample value.
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// writeSample writes one sample line with its labels plus any extra label (used for histogram buckets).
+func writeSample(buffer *bytes.Buffer, name string, labels, values []string, extraName, extraValue string, value float64) {
+	buffer.WriteString(name)
+	if len(labels) > 0 || extraName != "" {
+		buffer.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			fmt.Fprintf(buffer, `%s="%s"`, label, escapeLabel(values[i]))
+		}
+		if extraName != "" {
+			if len(labels) > 0 {
+				buffer.WriteByte(',')
+			}
+			fmt.Fprintf(buffer, `%s="%s"`, extraName, extraValue)
+		}
+		buffer.WriteByte('}')
+	}
+	buffer.WriteByte(' ')
+	buffer.WriteString(formatFloat(value))
+	buffer.WriteByte('\n')
+}
+
+// labelSet normalizes label values to the declared label count:
+// missing values become empty and extra values are ignored, so a mistake never panics on a hot path.
+func labelSet(labels []string, values []string) []string {
+	if len(values) == len(labels) {
+		return values
+	}
+	normalized := make([]string, len(labels))
+	copy(normalized, values)
+	return normalized
+}
+
+// seriesKey joins label values into a map key.
+func seriesKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+// series holds the label values and state of one labeled time series.
+type series[T any] struct {
+	values []string
+	state  *T
+}
+
+// family stores the series of one metric, keyed by label values.
+type family[T any] struct {
+	name   string
+	help   string
+	kind   kind
+	labels []string
+	mu     sync.RWMutex
+	series map[string]series[T]
+	create func() *T
+}
+
+// newFamily creates an empty family.
+func newFamily[T any](name, help string, metricKind kind, labels []string, create func() *T) *family[T] {
+	return &family[T]{
+		name:   name,
+		help:   help,
+		kind:   metricKind,
+		labels: append([]string{}, labels...),
+		series: make(map[string]series[T]),
+		create: create,
+	}
+}
+
+// describe returns the family metadata.
+func (f *family[T]) describe() (string, string, kind, []string) {
+	return f.name, f.help, f.kind, f.labels
+}
+
+// get returns the state for the given label values, creating it on first use.
+func (f *family[T]) get(values []string) *T {
+	values = labelSet(f.labels, values)
+	key := seriesKey(values)
+
+	f.mu.RLock()
+	existing, exists := f.series[key]
+	f.mu.RUnlock()
+	if exists {
+		return existing.state
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if existing, exists := f.series[key]; exists {
+		return existing.state
+	}
+	state := f.create()
+	f.series[key] = series[T]{values: append([]string{}, values...), state: state}
+	return state
+}
+
+// sorted returns the series ordered by label values for stable output.
+func (f *family[T]) sorted() []series[T] {
+	f.mu.RLock()
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]series[T], len(keys))
+	for i, key := range keys {
+		result[i] = f.series[key]
+	}
+	f.mu.RUnlock()
+	return result
+}
+
+// Reset removes every series, e.g. when labeled objects disappear.
+func (f *family[T]) Reset() {
+	f.mu.Lock()
+	f.series = make(map[string]series[T])
+	f.mu.Unlock()
+}
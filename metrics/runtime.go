@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"runtime" // runtime provides goroutine and memory statistics.
+	"sync"    // sync limits memory statistics collection.
+	"time"    // time provides process uptime.
+)
+
+// startTime is when the process loaded this package.
+var startTime = time.Now()
+
+// memStats caches runtime.ReadMemStats, which briefly stops the world, for one second.
+var memStats struct {
+	mu      sync.Mutex
+	stats   runtime.MemStats
+	updated time.Time
+}
+
+// readMemStats returns recent memory statistics.
+func readMemStats() runtime.MemStats {
+	memStats.mu.Lock()
+	defer memStats.mu.Unlock()
+	if time.Since(memStats.updated) > time.Second {
+		runtime.ReadMemStats(&memStats.stats)
+		memStats.updated = time.Now()
+	}
+	return memStats.stats
+}
+
+// RegisterRuntimeMetrics adds Go runtime gauges (goroutines, heap, GC runs, uptime)
+// to the Default registry. It is safe to call more than once.
+func RegisterRuntimeMetrics() {
+	NewGaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	NewGaugeFunc("go_memstats_heap_alloc_bytes", "Heap bytes allocated and still in use.", func() float64 {
+		return float64(readMemStats().HeapAlloc)
+	})
+	NewGaugeFunc("go_memstats_sys_bytes", "Bytes obtained from the operating system.", func() float64 {
+		return float64(readMemStats().Sys)
+	})
+	NewGaugeFunc("go_gc_runs", "Completed garbage collection cycles.", func() float64 {
+		return float64(readMemStats().NumGC)
+	})
+	NewGaugeFunc("process_uptime_seconds", "Seconds since the process started.", func() float64 {
+		return time.Since(startTime).Seconds()
+	})
+}
@@ -0,0 +1,262 @@
+package metrics
+
+import (
+	"bytes"       // bytes provides the exposition buffer.
+	"math"        // math provides float bit conversion and infinity.
+	"sort"        // sort provides bucket ordering.
+	"strconv"     // strconv provides bucket bound formatting.
+	"sync"        // sync protects histogram state.
+	"sync/atomic" // atomic provides lock-free counter and gauge updates.
+	"time"        // time provides duration observations.
+)
+
+// value is a float64 updated atomically.
+type value struct {
+	bits atomic.Uint64
+}
+
+// add adds delta with a compare-and-swap loop.
+func (v *value) add(delta float64) {
+	for {
+		old := v.bits.Load()
+		if v.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// set replaces the value.
+func (v *value) set(newValue float64) {
+	v.bits.Store(math.Float64bits(newValue))
+}
+
+// get returns the value.
+func (v *value) get() float64 {
+	return math.Float64frombits(v.bits.Load())
+}
+
+// Counter is a value that only increases, such as requests served or errors seen.
+// Label values are passed to each call in the order the labels were declared.
+type Counter struct {
+	*family[value]
+}
+
+// NewCounter registers a counter in the Default registry.
+// Registering the same name twice returns the existing counter.
+//
+// Example:
+//
+//	var ordersCreated = metrics.NewCounter("orders_created_total", "Orders created.", "channel")
+//	ordersCreated.Inc("mobile")
+func NewCounter(name, help string, labels ...string) *Counter {
+	return Default.NewCounter(name, help, labels...)
+}
+
+// NewCounter registers a counter in the registry.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return r.register(name, help, kindCounter, labels, func() collector {
+		return &Counter{newFamily(name, help, kindCounter, labels, func() *value { return &value{} })}
+	}).(*Counter)
+}
+
+// Inc increments the counter by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.get(labelValues).add(1)
+}
+
+// Add increases the counter. Negative deltas are ignored because counters never decrease.
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		return
+	}
+	c.get(labelValues).add(delta)
+}
+
+// Value returns the current value for the given labels.
+func (c *Counter) Value(labelValues ...string) float64 {
+	return c.get(labelValues).get()
+}
+
+// write writes the counter samples.
+func (c *Counter) write(buffer *bytes.Buffer) {
+	for _, series := range c.sorted() {
+		writeSample(buffer, c.name, c.labels, series.values, "", "", series.state.get())
+	}
+}
+
+// Gauge is a value that can go up and down, such as queue depth or connections in use.
+type Gauge struct {
+	*family[value]
+}
+
+// NewGauge registers a gauge in the Default registry.
+//
+// Example:
+//
+//	var queueDepth = metrics.NewGauge("queue_depth", "Jobs waiting.", "queue")
+//	queueDepth.Set(float64(pending), "emails")
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return Default.NewGauge(name, help, labels...)
+}
+
+// NewGauge registers a gauge in the registry.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return r.register(name, help, kindGauge, labels, func() collector {
+		return &Gauge{newFamily(name, help, kindGauge, labels, func() *value { return &value{} })}
+	}).(*Gauge)
+}
+
+// Set sets the gauge.
+func (g *Gauge) Set(newValue float64, labelValues ...string) {
+	g.get(labelValues).set(newValue)
+}
+
+// Inc increments the gauge by one.
+func (g *Gauge) Inc(labelValues ...string) {
+	g.get(labelValues).add(1)
+}
+
+// Dec decrements the gauge by one.
+func (g *Gauge) Dec(labelValues ...string) {
+	g.get(labelValues).add(-1)
+}
+
+// Add adds delta, which may be negative.
+func (g *Gauge) Add(delta float64, labelValues ...string) {
+	g.get(labelValues).add(delta)
+}
+
+// Value returns the current value for the given labels.
+func (g *Gauge) Value(labelValues ...string) float64 {
+	return g.get(labelValues).get()
+}
+
+// write writes the gauge samples.
+func (g *Gauge) write(buffer *bytes.Buffer) {
+	for _, series := range g.sorted() {
+		writeSample(buffer, g.name, g.labels, series.values, "", "", series.state.get())
+	}
+}
+
+// gaugeFunc is a gauge whose value is computed when scraped.
+type gaugeFunc struct {
+	name     string
+	help     string
+	function func() float64
+}
+
+// describe returns the gauge metadata.
+func (g *gaugeFunc) describe() (string, string, kind, []string) {
+	return g.name, g.help, kindGauge, nil
+}
+
+// write calls the function and writes its value.
+func (g *gaugeFunc) write(buffer *bytes.Buffer) {
+	writeSample(buffer, g.name, nil, nil, "", "", g.function())
+}
+
+// NewGaugeFunc registers a gauge in the Default registry whose value is read from
+// function at scrape time, e.g. connection pool statistics.
+// Registering the same name again keeps the first function.
+//
+// Example:
+//
+//	metrics.NewGaugeFunc("goroutines", "Running goroutines.", func() float64 {
+//	    return float64(runtime.NumGoroutine())
+//	})
+func NewGaugeFunc(name, help string, function func() float64) {
+	Default.NewGaugeFunc(name, help, function)
+}
+
+// NewGaugeFunc registers a gauge in the registry whose value is read from function at scrape time.
+func (r *Registry) NewGaugeFunc(name, help string, function func() float64) {
+	r.register(name, help, kindGauge, nil, func() collector {
+		return &gaugeFunc{name: name, help: help, function: function}
+	})
+}
+
+// DefaultBuckets suit request latencies in seconds, from 5ms to 10s.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogramState holds bucket counts for one series.
+type histogramState struct {
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Histogram counts observations, such as request durations or payload sizes, into buckets.
+type Histogram struct {
+	*family[histogramState]
+	buckets []float64
+}
+
+// NewHistogram registers a histogram in the Default registry. Buckets are upper
+// bounds; nil uses DefaultBuckets.
+//
+// Example:
+//
+//	var reportDuration = metrics.NewHistogram("report_duration_seconds", "Report generation time.", nil, "report")
+//	start := time.Now()
+//	...
+//	reportDuration.ObserveDuration(start, "monthly-sales")
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return Default.NewHistogram(name, help, buckets, labels...)
+}
+
+// NewHistogram registers a histogram in the registry.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	bounds := append([]float64{}, buckets...)
+	sort.Float64s(bounds)
+
+	return r.register(name, help, kindHistogram, labels, func() collector {
+		return &Histogram{
+			family: newFamily(name, help, kindHistogram, labels, func() *histogramState {
+				return &histogramState{counts: make([]uint64, len(bounds))}
+			}),
+			buckets: bounds,
+		}
+	}).(*Histogram)
+}
+
+// Observe records a value.
+func (h *Histogram) Observe(observed float64, labelValues ...string) {
+	state := h.get(labelValues)
+	index := sort.SearchFloat64s(h.buckets, observed)
+
+	state.mu.Lock()
+	if index < len(state.counts) {
+		state.counts[index]++
+	}
+	state.count++
+	state.sum += observed
+	state.mu.Unlock()
+}
+
+// ObserveDuration records the seconds elapsed since start.
+func (h *Histogram) ObserveDuration(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+// write writes cumulative bucket counts, the sum, and the count.
+func (h *Histogram) write(buffer *bytes.Buffer) {
+	for _, series := range h.sorted() {
+		series.state.mu.Lock()
+		counts := append([]uint64{}, series.state.counts...)
+		count, sum := series.state.count, series.state.sum
+		series.state.mu.Unlock()
+
+		cumulative := uint64(0)
+		for i, bound := range h.buckets {
+			cumulative += counts[i]
+			writeSample(buffer, h.name+"_bucket", h.labels, series.values, "le", strconv.FormatFloat(bound, 'g', -1, 64), float64(cumulative))
+		}
+		writeSample(buffer, h.name+"_bucket", h.labels, series.values, "le", "+Inf", float64(count))
+		writeSample(buffer, h.name+"_sum", h.labels, series.values, "", "", sum)
+		writeSample(buffer, h.name+"_count", h.labels, series.values, "", "", float64(count))
+	}
+}
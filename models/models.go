@@ -3,7 +3,11 @@ package models
 // EncryptReturnType defines the structure for the encryption function’s return value
 // Used to hold the encrypted payload in string format
 type EncryptReturnType struct {
-	Payload string // The encrypted data as a string (base64 or hex encoded)
+	Payload        string // The encrypted data as a string (base64, base64url, or hex encoded)
+	KeyID          string `json:",omitempty"` // The ID of the key that encrypted Payload, empty for unversioned keys
+	Encoding       string `json:",omitempty"` // The encoding of Payload when chosen per call, empty for ENCRYPTION_TYPE
+	Format         string `json:",omitempty"` // "bytes" when the plaintext is raw bytes, empty for JSON
+	KeyFingerprint string `json:",omitempty"` // Truncated SHA-256 of the key, so decryption with the wrong key fails fast
 }
 
 // SMSRecipient represents a single recipient’s details in an SMS response
@@ -56,8 +60,8 @@ type EmailDetails struct {
 // ServerResponse defines the structure for standardized JSON API responses
 // Includes a success flag and a flexible message payload
 type ServerResponse struct {
-	Success    bool        `json:"success"`
-	Message    interface{} `json:"message"`
+	Success bool        `json:"success"`
+	Message interface{} `json:"message"`
 }
 
 type BeemSMSRecipient struct {
@@ -105,19 +109,24 @@ type BeemSMSDeliveryStatusPayload struct {
 	SecretKey   string
 }
 
+// DatabaseOptions holds PostgreSQL connection options.
+// Env tags are relative to the DATABASE_ prefix (e.g., DATABASE_HOST).
 type DatabaseOptions struct {
-	Username     string
-	Password     string
-	Host         string
-	Port         string
-	SSLMode      string // e.g., "disable", "require", "verify-full"
-	DatabaseName string
+	Username     string `env:"USERNAME" required:"true"`
+	Password     string `env:"PASSWORD" required:"true"`
+	Host         string `env:"HOST" required:"true"`
+	Port         string `env:"PORT" required:"true"`
+	SSLMode      string `env:"SSL_MODE" required:"true"` // e.g., "disable", "require", "verify-full"
+	DatabaseName string `env:"NAME" required:"true"`
 }
 
 type ContextKey string
 
+// EncryptionConfig holds the key, cipher algorithm, output encoding, and IV used by the encryption package.
 type EncryptionConfig struct {
-	EncryptionKey        string
-	EncryptionType       string
-	InitializationVector string
+	EncryptionKey        string `env:"ENCRYPTION_KEY" required:"true"`
+	EncryptionType       string `env:"ENCRYPTION_TYPE" required:"true"`        // "base64", "base64url", or "hex"
+	InitializationVector string `env:"INITIALIZATION_VECTOR"`                  // Required by aes-cbc only
+	Algorithm            string `env:"ENCRYPTION_ALGORITHM" default:"aes-cbc"` // "aes-cbc" or "chacha20-poly1305"
+	KeyID                string `env:"ENCRYPTION_KEY_ID"`                      // Version of EncryptionKey, recorded in every payload
 }
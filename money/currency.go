@@ -0,0 +1,100 @@
+// Package money provides an integer-based Money type. Amounts are stored in the
+// currency's smallest unit (e.g. cents) so arithmetic never loses precision.
+package money
+
+import (
+	"strings" // strings provides currency code normalization.
+	"sync"    // sync protects the currency registry.
+)
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+// Commonly used currencies.
+const (
+	TZS Currency = "TZS" // TZS is the Tanzanian shilling
+	KES Currency = "KES" // KES is the Kenyan shilling
+	UGX Currency = "UGX" // UGX is the Ugandan shilling
+	RWF Currency = "RWF" // RWF is the Rwandan franc
+	USD Currency = "USD" // USD is the US dollar
+	EUR Currency = "EUR" // EUR is the euro
+	GBP Currency = "GBP" // GBP is the pound sterling
+)
+
+// currencyInfo describes how a currency is stored and displayed.
+type currencyInfo struct {
+	exponent int    // exponent is the number of decimal places of the minor unit
+	symbol   string // symbol is the display symbol
+}
+
+// currencies is the registry of known currencies.
+var (
+	currenciesMu sync.RWMutex
+	currencies   = map[Currency]currencyInfo{
+		TZS: {exponent: 2, symbol: "TSh"},
+		KES: {exponent: 2, symbol: "KSh"},
+		UGX: {exponent: 0, symbol: "USh"},
+		RWF: {exponent: 0, symbol: "FRw"},
+		USD: {exponent: 2, symbol: "$"},
+		EUR: {exponent: 2, symbol: "€"},
+		GBP: {exponent: 2, symbol: "£"},
+	}
+)
+
+// RegisterCurrency adds or replaces a currency definition.
+//
+// Example:
+//
+//	money.RegisterCurrency("BIF", 0, "FBu")
+func RegisterCurrency(code Currency, exponent int, symbol string) {
+	currenciesMu.Lock()
+	defer currenciesMu.Unlock()
+	currencies[code.Normalize()] = currencyInfo{exponent: exponent, symbol: symbol}
+}
+
+// Normalize returns the upper-case, trimmed currency code.
+func (c Currency) Normalize() Currency {
+	return Currency(strings.ToUpper(strings.TrimSpace(string(c))))
+}
+
+// info returns the registered definition, defaulting to two decimals and the code as symbol.
+func (c Currency) info() currencyInfo {
+	currenciesMu.RLock()
+	defer currenciesMu.RUnlock()
+	if info, exists := currencies[c.Normalize()]; exists {
+		return info
+	}
+	return currencyInfo{exponent: 2, symbol: string(c.Normalize())}
+}
+
+// IsKnown reports whether the currency has been registered.
+func (c Currency) IsKnown() bool {
+	currenciesMu.RLock()
+	defer currenciesMu.RUnlock()
+	_, exists := currencies[c.Normalize()]
+	return exists
+}
+
+// Exponent returns the number of decimal places of the minor unit (2 for TZS, 0 for UGX).
+func (c Currency) Exponent() int {
+	return c.info().exponent
+}
+
+// Symbol returns the display symbol, e.g. "TSh" for TZS.
+func (c Currency) Symbol() string {
+	return c.info().symbol
+}
+
+// String returns the currency code.
+func (c Currency) String() string {
+	return string(c.Normalize())
+}
+
+// scale returns 10^Exponent, the number of minor units in one major unit.
+func (c Currency) scale() int64 {
+	scale := int64(1)
+	for i := 0; i < c.Exponent(); i++ {
+		scale *= 10
+	}
+	return scale
+}
@@ -0,0 +1,409 @@
+package money
+
+import (
+	"encoding/json" // json provides JSON marshaling.
+	"errors"        // errors provides sentinel errors.
+	"math/big"      // big provides overflow-free intermediate arithmetic.
+	"strconv"       // strconv provides amount parsing.
+	"strings"       // strings provides formatting and parsing.
+	"unicode/utf8"  // utf8 provides symbol width checks.
+
+	"github.com/dustin/go-humanize"      // humanize provides thousands separators.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+var (
+	// ErrCurrencyMismatch is returned when combining amounts in different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrOverflow is returned when a result does not fit in an int64.
+	ErrOverflow = errors.New("money amount overflow")
+	// ErrUnknownCurrency is returned by Parse for a currency that has not been registered.
+	ErrUnknownCurrency = errors.New("unknown currency")
+)
+
+// Money is an amount in the smallest unit of a currency. The zero value is zero of no currency.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New creates Money from an amount in minor units, e.g. New(150000, TZS) is TSh 1,500.00.
+func New(amount int64, currency Currency) Money {
+	return Money{amount: amount, currency: currency.Normalize()}
+}
+
+// Zero returns zero in the given currency.
+func Zero(currency Currency) Money {
+	return New(0, currency)
+}
+
+// FromMajor creates Money from whole major units, e.g. FromMajor(1500, TZS) is TSh 1,500.00.
+func FromMajor(major int64, currency Currency) (Money, error) {
+	amount, ok := checkedMul(major, currency.scale())
+	if !ok {
+		return Money{}, ErrOverflow
+	}
+	return New(amount, currency), nil
+}
+
+// Parse creates Money from a decimal string such as "1,500.50" or "-20".
+// Thousands separators are ignored and at most one leading sign is accepted; more
+// decimals than the currency allows is an error. The currency must be known (see
+// RegisterCurrency), since its decimal places decide the amount.
+func Parse(value string, currency Currency) (Money, error) {
+	if !currency.IsKnown() {
+		return Money{}, helpers.WrapErrorf(ErrUnknownCurrency, "cannot parse amount in %q", currency.Normalize())
+	}
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+	negative := strings.HasPrefix(value, "-")
+	unsigned := value
+	if negative || strings.HasPrefix(value, "+") {
+		unsigned = value[1:]
+	}
+	if strings.HasPrefix(unsigned, "-") || strings.HasPrefix(unsigned, "+") {
+		return Money{}, helpers.CreateErrorf("invalid amount %q: more than one sign", value)
+	}
+	value = unsigned
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	exponent := currency.Exponent()
+	if len(fraction) > exponent {
+		return Money{}, helpers.CreateErrorf("amount %q has more than %d decimal places for %s", value, exponent, currency.Normalize())
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, helpers.WrapErrorf(err, "invalid amount %q", value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return New(amount, currency), nil
+}
+
+// Amount returns the amount in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the currency.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive reports whether the amount is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative reports whether the amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Negate returns the amount with the opposite sign.
+func (m Money) Negate() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Abs returns the absolute amount.
+func (m Money) Abs() Money {
+	if m.amount < 0 {
+		return m.Negate()
+	}
+	return m
+}
+
+// sameCurrency returns ErrCurrencyMismatch if other is in a different currency.
+func (m Money) sameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return helpers.WrapErrorf(ErrCurrencyMismatch, "%s and %s", m.currency, other.currency)
+	}
+	return nil
+}
+
+// Add returns m + other.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Subtract returns m - other.
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	difference := m.amount - other.amount
+	if (other.amount > 0 && difference > m.amount) || (other.amount < 0 && difference < m.amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: difference, currency: m.currency}, nil
+}
+
+// Multiply returns m * factor, e.g. a unit price times a quantity.
+func (m Money) Multiply(factor int64) (Money, error) {
+	product, ok := checkedMul(m.amount, factor)
+	if !ok {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: product, currency: m.currency}, nil
+}
+
+// Percent returns the given share of m in basis points (1% = 100), rounded half away from zero.
+// For example, Percent(250) is 2.5% of m.
+func (m Money) Percent(basisPoints int64) (Money, error) {
+	amount, ok := mulDivRound(m.amount, basisPoints, 10000)
+	if !ok {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: amount, currency: m.currency}, nil
+}
+
+// Allocate splits m proportionally to ratios without losing minor units;
+// the remainder is distributed one unit at a time from the first share.
+//
+// Example:
+//
+//	shares, _ := money.New(1000, money.TZS).Allocate(1, 1, 1) // 334, 333, 333
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, helpers.CreateError("at least one ratio is required")
+	}
+
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, helpers.CreateError("ratios must not be negative")
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return nil, helpers.CreateError("ratios must not all be zero")
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, ratio := range ratios {
+		share, ok := mulDiv(m.amount, ratio, total)
+		if !ok {
+			return nil, ErrOverflow
+		}
+		shares[i] = Money{amount: share, currency: m.currency}
+		remainder -= share
+	}
+
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].amount += unit
+		remainder -= unit
+	}
+	return shares, nil
+}
+
+// Split divides m into n equal shares, distributing any remainder from the first share.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, helpers.CreateError("split count must be positive")
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// Compare returns -1, 0, or 1 as m is less than, equal to, or greater than other.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Equals reports whether m and other have the same amount and currency.
+func (m Money) Equals(other Money) bool {
+	return m == other
+}
+
+// Sum adds amounts of the same currency. It returns zero of no currency for no values.
+func Sum(values ...Money) (Money, error) {
+	if len(values) == 0 {
+		return Money{}, nil
+	}
+	total := values[0]
+	for _, value := range values[1:] {
+		var err error
+		if total, err = total.Add(value); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Decimal returns the amount as a plain decimal string, e.g. "1500.00".
+func (m Money) Decimal() string {
+	exponent := m.currency.Exponent()
+	negative := m.amount < 0
+	digits := strconv.FormatUint(absUint(m.amount), 10)
+
+	if exponent > 0 {
+		if len(digits) <= exponent {
+			digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// grouped returns the amount with thousands separators, e.g. "1,500.00".
+func (m Money) grouped() string {
+	decimal := strings.TrimPrefix(m.Decimal(), "-")
+	whole, fraction, hasFraction := strings.Cut(decimal, ".")
+	wholeValue, _ := strconv.ParseUint(whole, 10, 64)
+	grouped := humanize.Comma(int64(wholeValue))
+	if wholeValue > 1<<63-1 {
+		grouped = whole
+	}
+	if hasFraction {
+		grouped += "." + fraction
+	}
+	if m.amount < 0 {
+		return "-" + grouped
+	}
+	return grouped
+}
+
+// String returns the amount with its currency code, e.g. "TZS 1,500.00".
+func (m Money) String() string {
+	return strings.TrimSpace(string(m.currency) + " " + m.grouped())
+}
+
+// Format returns the amount with its display symbol, e.g. "TSh 1,500.00", "USh 20,000", or "$12.50".
+func (m Money) Format() string {
+	symbol := m.currency.Symbol()
+	grouped := m.grouped()
+	negative := strings.HasPrefix(grouped, "-")
+	grouped = strings.TrimPrefix(grouped, "-")
+
+	// Single-character symbols such as $ and € attach directly to the number.
+	formatted := symbol + " " + grouped
+	if utf8.RuneCountInString(symbol) == 1 {
+		formatted = symbol + grouped
+	}
+	if negative {
+		return "-" + formatted
+	}
+	return formatted
+}
+
+// moneyJSON is the JSON representation of Money.
+type moneyJSON struct {
+	Amount    json.Number `json:"amount"`              // Amount is in minor units
+	Currency  Currency    `json:"currency"`            // Currency is the ISO 4217 code
+	Formatted string      `json:"formatted,omitempty"` // Formatted is for display only and ignored when decoding
+}
+
+// MarshalJSON encodes Money as {"amount":150000,"currency":"TZS","formatted":"TSh 1,500.00"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:    json.Number(strconv.FormatInt(m.amount, 10)),
+		Currency:  m.currency,
+		Formatted: m.Format(),
+	})
+}
+
+// UnmarshalJSON decodes {"amount":150000,"currency":"TZS"}. The amount must be an
+// integer in minor units; decimal strings are rejected to avoid float ambiguity.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var decoded moneyJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return helpers.WrapError(err, "invalid money value")
+	}
+	amount, err := decoded.Amount.Int64()
+	if err != nil {
+		return helpers.WrapErrorf(err, "money amount %q must be an integer in minor units", decoded.Amount)
+	}
+	*m = New(amount, decoded.Currency)
+	return nil
+}
+
+// absUint returns the absolute value of n as a uint64, handling math.MinInt64.
+func absUint(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
+
+// checkedMul returns a * b and whether it fits in an int64.
+func checkedMul(a, b int64) (int64, bool) {
+	product := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	if !product.IsInt64() {
+		return 0, false
+	}
+	return product.Int64(), true
+}
+
+// mulDiv returns a * b / c truncated toward zero and whether it fits in an int64.
+func mulDiv(a, b, c int64) (int64, bool) {
+	result := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	result.Quo(result, big.NewInt(c))
+	if !result.IsInt64() {
+		return 0, false
+	}
+	return result.Int64(), true
+}
+
+// mulDivRound returns a * b / c rounded half away from zero and whether it fits in an int64.
+func mulDivRound(a, b, c int64) (int64, bool) {
+	numerator := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	denominator := big.NewInt(c)
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	// Round away from zero when the remainder is at least half the denominator.
+	doubled := new(big.Int).Abs(remainder)
+	doubled.Lsh(doubled, 1)
+	if doubled.Cmp(new(big.Int).Abs(denominator)) >= 0 {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, false
+	}
+	return quotient.Int64(), true
+}
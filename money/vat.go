@@ -0,0 +1,55 @@
+package money
+
+// Standard VAT rates in basis points (1% = 100).
+const (
+	VATRateTanzania int64 = 1800 // VATRateTanzania is the Tanzanian standard rate (18%)
+	VATRateKenya    int64 = 1600 // VATRateKenya is the Kenyan standard rate (16%)
+	VATRateUganda   int64 = 1800 // VATRateUganda is the Ugandan standard rate (18%)
+	VATRateRwanda   int64 = 1800 // VATRateRwanda is the Rwandan standard rate (18%)
+)
+
+// VATBreakdown splits an amount into net, VAT, and gross. Net + VAT always equals Gross exactly.
+type VATBreakdown struct {
+	Net   Money `json:"net"`   // Net is the amount before VAT
+	VAT   Money `json:"vat"`   // VAT is the tax amount
+	Gross Money `json:"gross"` // Gross is the amount including VAT
+	Rate  int64 `json:"rate"`  // Rate is the VAT rate in basis points
+}
+
+// AddVAT treats m as a net (VAT-exclusive) amount and adds VAT at rate basis points.
+//
+// Example:
+//
+//	breakdown, _ := money.New(100000, money.TZS).AddVAT(money.VATRateTanzania)
+//	// Net TZS 1,000.00, VAT TZS 180.00, Gross TZS 1,180.00
+func (m Money) AddVAT(rate int64) (VATBreakdown, error) {
+	vat, err := m.Percent(rate)
+	if err != nil {
+		return VATBreakdown{}, err
+	}
+	gross, err := m.Add(vat)
+	if err != nil {
+		return VATBreakdown{}, err
+	}
+	return VATBreakdown{Net: m, VAT: vat, Gross: gross, Rate: rate}, nil
+}
+
+// ExtractVAT treats m as a gross (VAT-inclusive) amount and extracts the VAT at rate basis points.
+//
+// Example:
+//
+//	breakdown, _ := money.New(118000, money.TZS).ExtractVAT(money.VATRateTanzania)
+//	// Net TZS 1,000.00, VAT TZS 180.00, Gross TZS 1,180.00
+func (m Money) ExtractVAT(rate int64) (VATBreakdown, error) {
+	net, ok := mulDivRound(m.amount, 10000, 10000+rate)
+	if !ok {
+		return VATBreakdown{}, ErrOverflow
+	}
+	netMoney := Money{amount: net, currency: m.currency}
+	return VATBreakdown{
+		Net:   netMoney,
+		VAT:   Money{amount: m.amount - net, currency: m.currency},
+		Gross: m,
+		Rate:  rate,
+	}, nil
+}
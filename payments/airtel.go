@@ -0,0 +1,325 @@
+package payments
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides webhook decoding.
+	"net/http"      // http provides webhook request types.
+	"net/url"       // url provides path escaping.
+	"strings"       // strings provides country code handling.
+	"time"          // time provides timeouts and token lifetimes.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides constant-time comparison of callback tokens.
+	"github.com/hekimapro/utils/env"        // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"    // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+)
+
+// AirtelMoneyConfig holds Airtel Money Open API credentials.
+type AirtelMoneyConfig struct {
+	BaseURL         string        `env:"BASE_URL" default:"https://openapi.airtel.africa"` // BaseURL is the API root; use https://openapiuat.airtel.africa for staging
+	ClientID        string        `env:"CLIENT_ID"`                                        // ClientID is the application client ID
+	ClientSecret    string        `env:"CLIENT_SECRET"`                                    // ClientSecret is the application client secret
+	Country         string        `env:"COUNTRY" default:"TZ"`                             // Country is the ISO 3166 country code sent as X-Country
+	Currency        string        `env:"CURRENCY" default:"TZS"`                           // Currency is the wallet currency sent as X-Currency
+	CountryCode     string        `env:"COUNTRY_CODE" default:"255"`                       // CountryCode is the dialing code stripped from phone numbers
+	DisbursementPIN string        `env:"DISBURSEMENT_PIN"`                                 // DisbursementPIN is the disbursement PIN encrypted with Airtel's public key
+	CallbackToken   string        `env:"CALLBACK_TOKEN"`                                   // CallbackToken must match the callback URL's token query parameter; callbacks are refused without it
+	Timeout         time.Duration `env:"TIMEOUT" default:"30" unit:"s"`                    // Timeout is the HTTP request timeout
+}
+
+// LoadAirtelMoneyConfig loads Airtel Money configuration from AIRTEL_MONEY_* environment variables.
+func LoadAirtelMoneyConfig() AirtelMoneyConfig {
+	var config AirtelMoneyConfig
+	if err := env.BindWithPrefix("AIRTEL_MONEY_", &config); err != nil {
+		log.Warning("⚠️ Invalid Airtel Money configuration: " + err.Error())
+	}
+	return config
+}
+
+// AirtelMoney implements Provider for the Airtel Money Open API.
+type AirtelMoney struct {
+	config AirtelMoneyConfig
+	client *apiClient
+	tokens tokenCache
+}
+
+// NewAirtelMoney creates an Airtel Money provider.
+//
+// Example:
+//
+//	airtel := payments.NewAirtelMoney(payments.LoadAirtelMoneyConfig())
+//	tx, err := airtel.Collect(ctx, payments.CollectionRequest{Reference: "INV-42", Phone: "0682345678", Amount: 500000, Currency: "TZS"})
+func NewAirtelMoney(config AirtelMoneyConfig) *AirtelMoney {
+	config.BaseURL = helpers.DefaultIfEmpty(config.BaseURL, "https://openapi.airtel.africa")
+	config.Country = helpers.DefaultIfEmpty(config.Country, "TZ")
+	config.Currency = helpers.DefaultIfEmpty(config.Currency, "TZS")
+	config.CountryCode = helpers.DefaultIfEmpty(config.CountryCode, "255")
+	return &AirtelMoney{config: config, client: newAPIClient("airtelmoney", config.BaseURL, config.Timeout)}
+}
+
+// Name returns "airtelmoney".
+func (a *AirtelMoney) Name() string {
+	return "airtelmoney"
+}
+
+// airtelStatus is the status block included in every Airtel API response.
+type airtelStatus struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	ResultCode string `json:"result_code"`
+	Success    bool   `json:"success"`
+}
+
+// airtelTransaction is the transaction block included in Airtel API responses and callbacks.
+type airtelTransaction struct {
+	ID            string `json:"id"`
+	AirtelMoneyID string `json:"airtel_money_id"`
+	ReferenceID   string `json:"reference_id"`
+	Status        string `json:"status"`
+	StatusCode    string `json:"status_code"`
+	Message       string `json:"message"`
+}
+
+// airtelResponse is the envelope of Airtel API responses.
+type airtelResponse struct {
+	Data struct {
+		Transaction airtelTransaction `json:"transaction"`
+	} `json:"data"`
+	Status airtelStatus `json:"status"`
+}
+
+// airtelTransactionStatus maps Airtel transaction status codes to Status.
+// TS is success, TF failed, TE expired; TIP (in progress) and TA (ambiguous) stay pending.
+func airtelTransactionStatus(code string) Status {
+	switch strings.ToUpper(code) {
+	case "TS":
+		return StatusSuccessful
+	case "TF", "TE":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+// token returns a cached OAuth access token.
+func (a *AirtelMoney) token(ctx context.Context) (string, error) {
+	return a.tokens.get(ctx, func(ctx context.Context) (string, time.Duration, error) {
+		var response struct {
+			AccessToken string      `json:"access_token"`
+			ExpiresIn   json.Number `json:"expires_in"`
+		}
+		payload := map[string]string{
+			"client_id":     a.config.ClientID,
+			"client_secret": a.config.ClientSecret,
+			"grant_type":    "client_credentials",
+		}
+		if _, err := a.client.doJSON(ctx, http.MethodPost, "/auth/oauth2/token", nil, payload, &response); err != nil {
+			return "", 0, helpers.WrapError(err, "failed to obtain Airtel Money access token")
+		}
+		if response.AccessToken == "" {
+			return "", 0, helpers.CreateError("Airtel Money returned an empty access token")
+		}
+		seconds, _ := response.ExpiresIn.Int64()
+		return response.AccessToken, time.Duration(seconds) * time.Second, nil
+	})
+}
+
+// headers returns the authenticated headers required by every API call.
+func (a *AirtelMoney) headers(ctx context.Context) (map[string]string, error) {
+	token, err := a.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"Authorization": "Bearer " + token,
+		"X-Country":     a.config.Country,
+		"X-Currency":    a.config.Currency,
+	}, nil
+}
+
+// msisdn converts a phone number to the local format Airtel expects (no country code).
+func (a *AirtelMoney) msisdn(phone string) string {
+	return strings.TrimPrefix(NormalizePhone(phone, a.config.CountryCode), a.config.CountryCode)
+}
+
+// Collect sends a USSD push asking the customer to approve the payment.
+// The returned transaction is pending; the final result arrives by callback or Status.
+func (a *AirtelMoney) Collect(ctx context.Context, request CollectionRequest) (*Transaction, error) {
+	currency := helpers.DefaultIfEmpty(request.Currency, a.config.Currency)
+	amount, err := wholeAmount(request.Amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := a.headers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"reference": helpers.DefaultIfEmpty(request.Description, request.Reference),
+		"subscriber": map[string]string{
+			"country":  a.config.Country,
+			"currency": currency,
+			"msisdn":   a.msisdn(request.Phone),
+		},
+		"transaction": map[string]interface{}{
+			"amount":   amount,
+			"country":  a.config.Country,
+			"currency": currency,
+			"id":       request.Reference,
+		},
+	}
+
+	var response airtelResponse
+	raw, err := a.client.doJSON(ctx, http.MethodPost, "/merchant/v1/payments/", headers, payload, &response)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "Airtel Money collection %s failed", request.Reference)
+	}
+
+	status := StatusPending
+	if !response.Status.Success {
+		status = StatusFailed
+	}
+
+	return &Transaction{
+		Provider:  a.Name(),
+		Type:      TypeCollection,
+		Reference: request.Reference,
+		Status:    status,
+		Amount:    request.Amount,
+		Currency:  currency,
+		Phone:     NormalizePhone(request.Phone, a.config.CountryCode),
+		Message:   response.Status.Message,
+		UpdatedAt: time.Now(),
+		Raw:       raw,
+	}, nil
+}
+
+// Disburse transfers money from the merchant wallet to a customer.
+func (a *AirtelMoney) Disburse(ctx context.Context, request DisbursementRequest) (*Transaction, error) {
+	if a.config.DisbursementPIN == "" {
+		return nil, helpers.WrapError(ErrUnsupported, "Airtel Money disbursement PIN is not configured")
+	}
+
+	currency := helpers.DefaultIfEmpty(request.Currency, a.config.Currency)
+	amount, err := wholeAmount(request.Amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := a.headers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"payee":     map[string]string{"msisdn": a.msisdn(request.Phone)},
+		"reference": helpers.DefaultIfEmpty(request.Description, request.Reference),
+		"pin":       a.config.DisbursementPIN,
+		"transaction": map[string]interface{}{
+			"amount": amount,
+			"id":     request.Reference,
+		},
+	}
+
+	var response airtelResponse
+	raw, err := a.client.doJSON(ctx, http.MethodPost, "/standard/v1/disbursements/", headers, payload, &response)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "Airtel Money disbursement %s failed", request.Reference)
+	}
+
+	status := airtelTransactionStatus(response.Data.Transaction.Status)
+	if !response.Status.Success {
+		status = StatusFailed
+	}
+
+	return &Transaction{
+		Provider:          a.Name(),
+		Type:              TypeDisbursement,
+		Reference:         request.Reference,
+		ProviderReference: response.Data.Transaction.AirtelMoneyID,
+		Status:            status,
+		Amount:            request.Amount,
+		Currency:          currency,
+		Phone:             NormalizePhone(request.Phone, a.config.CountryCode),
+		Message:           response.Status.Message,
+		UpdatedAt:         time.Now(),
+		Raw:               raw,
+	}, nil
+}
+
+// Status queries a collection or disbursement by merchant reference.
+func (a *AirtelMoney) Status(ctx context.Context, transactionType Type, reference string) (*Transaction, error) {
+	path := "/standard/v1/payments/"
+	if transactionType == TypeDisbursement {
+		path = "/standard/v1/disbursements/"
+	}
+
+	headers, err := a.headers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var response airtelResponse
+	raw, err := a.client.doJSON(ctx, http.MethodGet, path+url.PathEscape(reference), headers, nil, &response)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "Airtel Money status query for %s failed", reference)
+	}
+	if !response.Status.Success {
+		return nil, helpers.CreateErrorf("Airtel Money status query for %s failed: %s", reference, response.Status.Message)
+	}
+
+	transaction := response.Data.Transaction
+	return &Transaction{
+		Provider:          a.Name(),
+		Type:              transactionType,
+		Reference:         reference,
+		ProviderReference: transaction.AirtelMoneyID,
+		Status:            airtelTransactionStatus(transaction.Status),
+		Currency:          a.config.Currency,
+		Message:           helpers.DefaultIfEmpty(transaction.Message, response.Status.Message),
+		UpdatedAt:         time.Now(),
+		Raw:               raw,
+	}, nil
+}
+
+// ParseWebhook parses a transaction callback. The callback URL must carry a token
+// query parameter matching CallbackToken; without a configured token every callback
+// is refused, since anyone could otherwise mark a payment successful.
+func (a *AirtelMoney) ParseWebhook(r *http.Request) (*Transaction, error) {
+	if a.config.CallbackToken == "" {
+		return nil, helpers.CreateError("Airtel Money callback token is not configured")
+	}
+	token := r.URL.Query().Get("token")
+	if !encryption.SecureCompareString(token, a.config.CallbackToken) {
+		return nil, helpers.CreateError("invalid Airtel Money callback token")
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var callback struct {
+		Transaction airtelTransaction `json:"transaction"`
+	}
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode Airtel Money callback")
+	}
+	if callback.Transaction.ID == "" {
+		return nil, helpers.CreateError("Airtel Money callback is missing transaction id")
+	}
+
+	return &Transaction{
+		Provider:          a.Name(),
+		Type:              TypeCollection,
+		Reference:         callback.Transaction.ID,
+		ProviderReference: callback.Transaction.AirtelMoneyID,
+		Status:            airtelTransactionStatus(callback.Transaction.StatusCode),
+		Currency:          a.config.Currency,
+		Message:           callback.Transaction.Message,
+		UpdatedAt:         time.Now(),
+		Raw:               body,
+	}, nil
+}
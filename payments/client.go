@@ -0,0 +1,181 @@
+package payments
+
+import (
+	"bytes"         // bytes provides request body buffers.
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides request and response encoding.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io"            // io provides request and response bodies.
+	"net/http"      // http provides the HTTP client.
+	"net/url"       // url provides form encoding.
+	"strings"       // strings provides URL and body handling.
+	"sync"          // sync protects cached access tokens.
+	"time"          // time provides timeouts and token expiry.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/money"   // money provides currency exponents and amount parsing.
+)
+
+// maxResponseSize caps provider responses and webhook bodies read into memory.
+const maxResponseSize = 1 << 20
+
+// APIError is returned when a provider responds with a non-2xx status.
+type APIError struct {
+	Provider   string // Provider is the provider name
+	StatusCode int    // StatusCode is the HTTP status returned
+	Body       string // Body is the raw response body
+}
+
+// Error returns the string representation of the API error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API returned HTTP %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// apiClient sends requests to a provider API.
+// Unlike the request package it never retries: repeating a payment call may charge or pay twice.
+type apiClient struct {
+	provider string
+	baseURL  string
+	http     *http.Client
+}
+
+// newAPIClient creates a client for the given provider and base URL.
+func newAPIClient(provider, baseURL string, timeout time.Duration) *apiClient {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &apiClient{
+		provider: provider,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		http:     &http.Client{Timeout: timeout},
+	}
+}
+
+// doJSON sends payload as JSON and decodes the response into target when non-nil.
+func (c *apiClient) doJSON(ctx context.Context, method, path string, headers map[string]string, payload, target interface{}) (json.RawMessage, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to marshal request body")
+		}
+		body = bytes.NewReader(data)
+	}
+	return c.do(ctx, method, path, "application/json", headers, body, target)
+}
+
+// doForm sends form as application/x-www-form-urlencoded and decodes the response into target when non-nil.
+func (c *apiClient) doForm(ctx context.Context, method, path string, headers map[string]string, form url.Values, target interface{}) (json.RawMessage, error) {
+	return c.do(ctx, method, path, "application/x-www-form-urlencoded", headers, strings.NewReader(form.Encode()), target)
+}
+
+// do performs a single request and returns the raw response body.
+func (c *apiClient) do(ctx context.Context, method, path, contentType string, headers map[string]string, body io.Reader, target interface{}) (json.RawMessage, error) {
+	endpoint := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		endpoint = c.baseURL + path
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to create %s request", c.provider)
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", contentType)
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	log.Info(fmt.Sprintf("💳 %s %s %s", c.provider, method, path))
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "%s request failed", c.provider)
+	}
+	defer response.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to read %s response", c.provider)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		log.Warning(fmt.Sprintf("⚠️ %s returned HTTP %d", c.provider, response.StatusCode))
+		return raw, &APIError{Provider: c.provider, StatusCode: response.StatusCode, Body: string(raw)}
+	}
+
+	if target != nil && len(raw) > 0 {
+		if err := json.Unmarshal(raw, target); err != nil {
+			return raw, helpers.WrapErrorf(err, "failed to decode %s response", c.provider)
+		}
+	}
+	return raw, nil
+}
+
+// tokenCache holds an OAuth access token until shortly before it expires.
+type tokenCache struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// get returns the cached token, calling fetch when it is missing or about to expire.
+func (t *tokenCache) get(ctx context.Context, fetch func(ctx context.Context) (string, time.Duration, error)) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	token, lifetime, err := fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Refresh a minute early so tokens do not expire mid-request.
+	if lifetime > 2*time.Minute {
+		lifetime -= time.Minute
+	}
+	t.token = token
+	t.expiresAt = time.Now().Add(lifetime)
+	return token, nil
+}
+
+// wholeAmount converts a minor-unit amount to whole major units.
+// Mobile money wallets do not accept fractional amounts, so those are rejected.
+func wholeAmount(amount int64, currency string) (int64, error) {
+	if amount <= 0 {
+		return 0, helpers.CreateError("amount must be positive")
+	}
+
+	divisor := int64(1)
+	for i := 0; i < money.Currency(currency).Exponent(); i++ {
+		divisor *= 10
+	}
+	if amount%divisor != 0 {
+		return 0, helpers.CreateErrorf("amount %s has a fractional part, which mobile money does not support", money.New(amount, money.Currency(currency)))
+	}
+	return amount / divisor, nil
+}
+
+// parseAmount converts a provider's decimal amount string (e.g. "1000" or "10.50") to minor units.
+func parseAmount(value string, currency string) (int64, error) {
+	parsed, err := money.Parse(value, money.Currency(currency))
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Amount(), nil
+}
+
+// readBody reads a webhook body with a size limit.
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxResponseSize))
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to read webhook body")
+	}
+	return body, nil
+}
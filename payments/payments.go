@@ -0,0 +1,144 @@
+// Package payments provides mobile money and card payment integrations behind a
+// common Provider interface, so checkout code does not depend on a specific provider.
+package payments
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides raw provider payloads.
+	"errors"        // errors provides sentinel errors.
+	"fmt"           // fmt provides formatting and printing functions.
+	"net/http"      // http provides webhook request types.
+	"strings"       // strings provides phone number normalization.
+	"time"          // time provides transaction timestamps.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides JSON responses.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// ErrUnsupported is returned when a provider does not support an operation.
+var ErrUnsupported = errors.New("operation not supported by payment provider")
+
+// ErrIgnoredEvent is returned by ParseWebhook for valid callbacks that do not describe
+// a transaction (e.g. unrelated Stripe events). WebhookHandler acknowledges them without calling handle.
+var ErrIgnoredEvent = errors.New("webhook event does not describe a transaction")
+
+// Type distinguishes money coming in from money going out.
+type Type string
+
+// Transaction types.
+const (
+	TypeCollection   Type = "collection"   // TypeCollection is a payment from a customer to the merchant
+	TypeDisbursement Type = "disbursement" // TypeDisbursement is a payment from the merchant to a customer
+)
+
+// Status is the normalized state of a transaction across providers.
+type Status string
+
+// Transaction statuses.
+const (
+	StatusPending    Status = "pending"    // StatusPending transactions await customer or provider action
+	StatusSuccessful Status = "successful" // StatusSuccessful transactions completed
+	StatusFailed     Status = "failed"     // StatusFailed transactions were rejected, cancelled, or timed out
+)
+
+// CollectionRequest asks a customer to pay, usually via a USSD push to their phone.
+type CollectionRequest struct {
+	Reference   string // Reference is the merchant's unique transaction reference
+	Phone       string // Phone is the customer's phone number in local or international format
+	Amount      int64  // Amount is in the smallest unit of Currency (e.g. cents)
+	Currency    string // Currency is the ISO 4217 code, e.g. TZS
+	Description string // Description is shown to the customer where supported
+	Name        string // Name is the customer's name, required by some aggregators
+	Email       string // Email is the customer's email, required by some aggregators
+}
+
+// DisbursementRequest sends money from the merchant to a customer.
+type DisbursementRequest struct {
+	Reference   string // Reference is the merchant's unique transaction reference
+	Phone       string // Phone is the recipient's phone number in local or international format
+	Amount      int64  // Amount is in the smallest unit of Currency (e.g. cents)
+	Currency    string // Currency is the ISO 4217 code, e.g. TZS
+	Description string // Description is attached to the transfer where supported
+}
+
+// Transaction is the normalized result of a payment operation or webhook.
+type Transaction struct {
+	Provider          string          `json:"provider"`           // Provider is the name of the provider that handled the transaction
+	Type              Type            `json:"type"`               // Type is collection or disbursement
+	Reference         string          `json:"reference"`          // Reference is the merchant's transaction reference
+	ProviderReference string          `json:"provider_reference"` // ProviderReference is the provider's transaction ID
+	Status            Status          `json:"status"`             // Status is the normalized transaction state
+	Amount            int64           `json:"amount"`             // Amount is in the smallest unit of Currency
+	Currency          string          `json:"currency"`           // Currency is the ISO 4217 code
+	Phone             string          `json:"phone,omitempty"`    // Phone is the customer's phone number, if known
+	Message           string          `json:"message,omitempty"`  // Message is the provider's status description
+	UpdatedAt         time.Time       `json:"updated_at"`         // UpdatedAt is when this state was observed
+	Raw               json.RawMessage `json:"raw,omitempty"`      // Raw is the provider's original response or webhook body
+}
+
+// Provider is implemented by every payment integration.
+type Provider interface {
+	// Name returns the provider identifier, e.g. "tigopesa".
+	Name() string
+	// Collect requests a payment from a customer.
+	Collect(ctx context.Context, request CollectionRequest) (*Transaction, error)
+	// Disburse sends money to a customer.
+	Disburse(ctx context.Context, request DisbursementRequest) (*Transaction, error)
+	// Status queries the current state of a transaction by merchant reference.
+	Status(ctx context.Context, transactionType Type, reference string) (*Transaction, error)
+	// ParseWebhook reads and validates a provider callback.
+	ParseWebhook(r *http.Request) (*Transaction, error)
+}
+
+// WebhookHandler returns an HTTP handler that parses provider callbacks and passes
+// the normalized transaction to handle. Invalid callbacks are rejected with 400;
+// errors from handle return 500 so the provider retries delivery.
+//
+// Example:
+//
+//	router.Handle("/webhooks/airtel", payments.WebhookHandler(airtel, func(ctx context.Context, tx *payments.Transaction) error {
+//	    return orders.MarkPaid(ctx, tx.Reference, tx.Status)
+//	}))
+func WebhookHandler(provider Provider, handle func(ctx context.Context, transaction *Transaction) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		transaction, err := provider.ParseWebhook(r)
+		if errors.Is(err, ErrIgnoredEvent) {
+			helpers.RespondWithJSON(w, http.StatusOK, "webhook ignored")
+			return
+		}
+		if err != nil {
+			log.Error(fmt.Sprintf("❌ Invalid %s webhook: %v", provider.Name(), err))
+			helpers.RespondWithJSON(w, http.StatusBadRequest, "invalid webhook")
+			return
+		}
+
+		if err := handle(r.Context(), transaction); err != nil {
+			log.Error(fmt.Sprintf("❌ Failed to handle %s webhook for %s: %v", provider.Name(), transaction.Reference, err))
+			helpers.RespondWithJSON(w, http.StatusInternalServerError, "failed to process webhook")
+			return
+		}
+
+		helpers.RespondWithJSON(w, http.StatusOK, "webhook processed")
+	})
+}
+
+// NormalizePhone converts a phone number to international format without a plus sign,
+// e.g. "0712 345 678" becomes "255712345678" for country code "255".
+func NormalizePhone(phone, countryCode string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	normalized := digits.String()
+
+	switch {
+	case strings.HasPrefix(normalized, countryCode):
+		return normalized
+	case strings.HasPrefix(normalized, "0"):
+		return countryCode + normalized[1:]
+	default:
+		return countryCode + normalized
+	}
+}
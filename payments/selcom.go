@@ -0,0 +1,437 @@
+package payments
+
+import (
+	"context"         // context provides support for cancellation and timeouts.
+	"crypto/hmac"     // hmac provides request signing.
+	"crypto/sha256"   // sha256 provides the signing hash.
+	"encoding/base64" // base64 provides header and URL encoding.
+	"encoding/json"   // json provides webhook decoding.
+	"fmt"             // fmt provides value formatting for signatures.
+	"net/http"        // http provides webhook request types.
+	"net/url"         // url provides query encoding.
+	"strings"         // strings provides signed field handling.
+	"time"            // time provides timestamps and timeouts.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides constant-time comparison of webhook digests.
+	"github.com/hekimapro/utils/env"        // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"    // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+)
+
+// selcomSuccessCode is the result code for a successful Selcom request.
+const selcomSuccessCode = "000"
+
+// SelcomConfig holds Selcom Checkout API credentials.
+type SelcomConfig struct {
+	BaseURL          string        `env:"BASE_URL" default:"https://apigw.selcommobile.com/v1"` // BaseURL is the API gateway root
+	APIKey           string        `env:"API_KEY"`                                              // APIKey is the merchant API key
+	APISecret        string        `env:"API_SECRET"`                                           // APISecret signs requests and verifies webhooks
+	Vendor           string        `env:"VENDOR"`                                               // Vendor is the merchant till/vendor ID
+	WebhookURL       string        `env:"WEBHOOK_URL"`                                          // WebhookURL receives payment notifications
+	RedirectURL      string        `env:"REDIRECT_URL"`                                         // RedirectURL is where card checkout returns on success
+	CancelURL        string        `env:"CANCEL_URL"`                                           // CancelURL is where card checkout returns on cancel
+	OrderExpiry      int           `env:"ORDER_EXPIRY" default:"60"`                            // OrderExpiry is how long orders stay payable, in minutes
+	WebhookTolerance time.Duration `env:"WEBHOOK_TOLERANCE" default:"300" unit:"s"`             // WebhookTolerance is the maximum age of a webhook timestamp
+	CountryCode      string        `env:"COUNTRY_CODE" default:"255"`                           // CountryCode is prefixed to local phone numbers
+	Timeout          time.Duration `env:"TIMEOUT" default:"30" unit:"s"`                        // Timeout is the HTTP request timeout
+}
+
+// LoadSelcomConfig loads Selcom configuration from SELCOM_* environment variables.
+func LoadSelcomConfig() SelcomConfig {
+	var config SelcomConfig
+	if err := env.BindWithPrefix("SELCOM_", &config); err != nil {
+		log.Warning("⚠️ Invalid Selcom configuration: " + err.Error())
+	}
+	return config
+}
+
+// SelcomOrder describes a checkout order.
+type SelcomOrder struct {
+	OrderID     string // OrderID is the merchant's unique order reference
+	Amount      int64  // Amount is in the smallest unit of Currency
+	Currency    string // Currency is the ISO 4217 code, e.g. TZS
+	BuyerName   string // BuyerName is the customer's name
+	BuyerEmail  string // BuyerEmail is the customer's email
+	BuyerPhone  string // BuyerPhone is the customer's phone number
+	Description string // Description is shown to the customer
+}
+
+// SelcomCheckout is the result of creating an order.
+type SelcomCheckout struct {
+	OrderID      string          `json:"order_id"`      // OrderID is the merchant's order reference
+	Reference    string          `json:"reference"`     // Reference is Selcom's request reference
+	PaymentToken string          `json:"payment_token"` // PaymentToken lets customers pay via USSD menus
+	PaymentURL   string          `json:"payment_url"`   // PaymentURL is the hosted card and wallet checkout page
+	QR           string          `json:"qr,omitempty"`  // QR is the payment QR code payload
+	Raw          json.RawMessage `json:"raw,omitempty"` // Raw is the original response
+}
+
+// Selcom implements Provider for the Selcom payment aggregator, which accepts
+// cards and all major Tanzanian wallets through a single integration.
+// Disbursement is not available through the checkout API.
+type Selcom struct {
+	config SelcomConfig
+	client *apiClient
+}
+
+// NewSelcom creates a Selcom provider.
+//
+// Example:
+//
+//	selcom := payments.NewSelcom(payments.LoadSelcomConfig())
+//	checkout, err := selcom.CreateOrder(ctx, payments.SelcomOrder{OrderID: "INV-42", Amount: 500000, Currency: "TZS", ...})
+//	http.Redirect(w, r, checkout.PaymentURL, http.StatusSeeOther) // card checkout
+func NewSelcom(config SelcomConfig) *Selcom {
+	config.BaseURL = helpers.DefaultIfEmpty(config.BaseURL, "https://apigw.selcommobile.com/v1")
+	config.CountryCode = helpers.DefaultIfEmpty(config.CountryCode, "255")
+	if config.OrderExpiry <= 0 {
+		config.OrderExpiry = 60
+	}
+	if config.WebhookTolerance <= 0 {
+		config.WebhookTolerance = 5 * time.Minute
+	}
+	return &Selcom{config: config, client: newAPIClient("selcom", config.BaseURL, config.Timeout)}
+}
+
+// Name returns "selcom".
+func (s *Selcom) Name() string {
+	return "selcom"
+}
+
+// selcomField is a signed request field; order matters for the digest.
+type selcomField struct {
+	key   string
+	value interface{}
+}
+
+// selcomDigest computes the HMAC-SHA256 digest over the timestamp and signed fields.
+func selcomDigest(secret, timestamp string, keys []string, values map[string]interface{}) string {
+	var data strings.Builder
+	data.WriteString("timestamp=" + timestamp)
+	for _, key := range keys {
+		data.WriteString("&" + key + "=" + fmt.Sprint(values[key]))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signedHeaders returns the authentication headers for the given fields.
+func (s *Selcom) signedHeaders(fields []selcomField) (map[string]string, map[string]interface{}) {
+	timestamp := time.Now().Format(time.RFC3339)
+	keys := make([]string, len(fields))
+	values := make(map[string]interface{}, len(fields))
+	for i, field := range fields {
+		keys[i] = field.key
+		values[field.key] = field.value
+	}
+
+	return map[string]string{
+		"Authorization": "SELCOM " + base64.StdEncoding.EncodeToString([]byte(s.config.APIKey)),
+		"Digest-Method": "HS256",
+		"Digest":        selcomDigest(s.config.APISecret, timestamp, keys, values),
+		"Timestamp":     timestamp,
+		"Signed-Fields": strings.Join(keys, ","),
+	}, values
+}
+
+// selcomResponse is the envelope of Selcom API responses.
+type selcomResponse struct {
+	Reference  string            `json:"reference"`
+	ResultCode string            `json:"resultcode"`
+	Result     string            `json:"result"`
+	Message    string            `json:"message"`
+	Data       []json.RawMessage `json:"data"`
+}
+
+// post sends a signed JSON request and checks the result code.
+func (s *Selcom) post(ctx context.Context, path string, fields []selcomField) (*selcomResponse, json.RawMessage, error) {
+	headers, payload := s.signedHeaders(fields)
+
+	var response selcomResponse
+	raw, err := s.client.doJSON(ctx, http.MethodPost, path, headers, payload, &response)
+	if err != nil {
+		return nil, raw, err
+	}
+	if response.ResultCode != selcomSuccessCode {
+		return &response, raw, helpers.CreateErrorf("selcom returned %s: %s", response.ResultCode, response.Message)
+	}
+	return &response, raw, nil
+}
+
+// CreateOrder creates a checkout order. Customers can pay it on the hosted
+// PaymentURL (card or wallet), with the USSD PaymentToken, or via WalletPayment.
+func (s *Selcom) CreateOrder(ctx context.Context, order SelcomOrder) (*SelcomCheckout, error) {
+	currency := helpers.DefaultIfEmpty(order.Currency, "TZS")
+	amount, err := wholeAmount(order.Amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []selcomField{
+		{"vendor", s.config.Vendor},
+		{"order_id", order.OrderID},
+		{"buyer_email", order.BuyerEmail},
+		{"buyer_name", order.BuyerName},
+		{"buyer_phone", NormalizePhone(order.BuyerPhone, s.config.CountryCode)},
+		{"amount", amount},
+		{"currency", currency},
+		{"buyer_remarks", helpers.DefaultIfEmpty(order.Description, order.OrderID)},
+		{"merchant_remarks", order.OrderID},
+		{"no_of_items", 1},
+		{"expiry", s.config.OrderExpiry},
+	}
+	if s.config.WebhookURL != "" {
+		fields = append(fields, selcomField{"webhook", base64.StdEncoding.EncodeToString([]byte(s.config.WebhookURL))})
+	}
+	if s.config.RedirectURL != "" {
+		fields = append(fields, selcomField{"redirect_url", base64.StdEncoding.EncodeToString([]byte(s.config.RedirectURL))})
+	}
+	if s.config.CancelURL != "" {
+		fields = append(fields, selcomField{"cancel_url", base64.StdEncoding.EncodeToString([]byte(s.config.CancelURL))})
+	}
+
+	response, raw, err := s.post(ctx, "/checkout/create-order-minimal", fields)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to create Selcom order %s", order.OrderID)
+	}
+
+	checkout := &SelcomCheckout{OrderID: order.OrderID, Reference: response.Reference, Raw: raw}
+	if len(response.Data) > 0 {
+		var data struct {
+			PaymentToken      string `json:"payment_token"`
+			PaymentGatewayURL string `json:"payment_gateway_url"`
+			QR                string `json:"qr"`
+		}
+		if err := json.Unmarshal(response.Data[0], &data); err != nil {
+			return nil, helpers.WrapError(err, "failed to decode Selcom order response")
+		}
+		checkout.PaymentToken = data.PaymentToken
+		checkout.QR = data.QR
+		// The gateway URL is returned base64 encoded.
+		if decoded, err := base64.StdEncoding.DecodeString(data.PaymentGatewayURL); err == nil {
+			checkout.PaymentURL = string(decoded)
+		} else {
+			checkout.PaymentURL = data.PaymentGatewayURL
+		}
+	}
+
+	log.Success(fmt.Sprintf("✅ Selcom order %s created", order.OrderID))
+	return checkout, nil
+}
+
+// WalletPayment sends a USSD push to the customer's wallet to pay an existing order.
+func (s *Selcom) WalletPayment(ctx context.Context, orderID, phone string) error {
+	fields := []selcomField{
+		{"transid", orderID},
+		{"order_id", orderID},
+		{"msisdn", NormalizePhone(phone, s.config.CountryCode)},
+	}
+	if _, _, err := s.post(ctx, "/checkout/wallet-payment", fields); err != nil {
+		return helpers.WrapErrorf(err, "Selcom wallet payment for order %s failed", orderID)
+	}
+	return nil
+}
+
+// Collect creates an order and pushes a wallet payment request to the customer's phone.
+func (s *Selcom) Collect(ctx context.Context, request CollectionRequest) (*Transaction, error) {
+	checkout, err := s.CreateOrder(ctx, SelcomOrder{
+		OrderID:     request.Reference,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		BuyerName:   helpers.DefaultIfEmpty(request.Name, request.Phone),
+		BuyerEmail:  request.Email,
+		BuyerPhone:  request.Phone,
+		Description: request.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.WalletPayment(ctx, request.Reference, request.Phone); err != nil {
+		return nil, err
+	}
+
+	return &Transaction{
+		Provider:          s.Name(),
+		Type:              TypeCollection,
+		Reference:         request.Reference,
+		ProviderReference: checkout.Reference,
+		Status:            StatusPending,
+		Amount:            request.Amount,
+		Currency:          helpers.DefaultIfEmpty(request.Currency, "TZS"),
+		Phone:             NormalizePhone(request.Phone, s.config.CountryCode),
+		UpdatedAt:         time.Now(),
+		Raw:               checkout.Raw,
+	}, nil
+}
+
+// Disburse is not supported by the Selcom checkout API.
+func (s *Selcom) Disburse(ctx context.Context, request DisbursementRequest) (*Transaction, error) {
+	return nil, ErrUnsupported
+}
+
+// selcomPaymentStatus maps Selcom order payment statuses to Status.
+func selcomPaymentStatus(status string) Status {
+	switch strings.ToUpper(status) {
+	case "COMPLETED":
+		return StatusSuccessful
+	case "CANCELLED", "USERCANCELLED", "REJECTED", "FAILED":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+// selcomOrderStatus is the order record returned by status queries and webhooks.
+type selcomOrderStatus struct {
+	OrderID       string      `json:"order_id"`
+	TransID       string      `json:"transid"`
+	Reference     string      `json:"reference"`
+	Channel       string      `json:"channel"`
+	Amount        json.Number `json:"amount"`
+	Phone         string      `json:"phone"`
+	PaymentStatus string      `json:"payment_status"`
+	Result        string      `json:"result"`
+	ResultCode    string      `json:"resultcode"`
+}
+
+// transaction converts an order status to a Transaction.
+func (o selcomOrderStatus) transaction(provider string, raw json.RawMessage) (*Transaction, error) {
+	transaction := &Transaction{
+		Provider:          provider,
+		Type:              TypeCollection,
+		Reference:         o.OrderID,
+		ProviderReference: helpers.DefaultIfEmpty(o.Reference, o.TransID),
+		Status:            selcomPaymentStatus(o.PaymentStatus),
+		Currency:          "TZS",
+		Phone:             o.Phone,
+		Message:           strings.TrimSpace(o.Channel + " " + o.PaymentStatus),
+		UpdatedAt:         time.Now(),
+		Raw:               raw,
+	}
+	if o.Amount != "" {
+		amount, err := parseAmount(o.Amount.String(), transaction.Currency)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Amount = amount
+	}
+	return transaction, nil
+}
+
+// Status queries an order by merchant reference.
+func (s *Selcom) Status(ctx context.Context, transactionType Type, reference string) (*Transaction, error) {
+	if transactionType != TypeCollection {
+		return nil, ErrUnsupported
+	}
+
+	headers, _ := s.signedHeaders([]selcomField{{"order_id", reference}})
+	path := "/checkout/order-status?" + url.Values{"order_id": {reference}}.Encode()
+
+	var response selcomResponse
+	raw, err := s.client.doJSON(ctx, http.MethodGet, path, headers, nil, &response)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "Selcom status query for %s failed", reference)
+	}
+	if response.ResultCode != selcomSuccessCode || len(response.Data) == 0 {
+		return nil, helpers.CreateErrorf("Selcom status query for %s failed: %s", reference, response.Message)
+	}
+
+	var order selcomOrderStatus
+	if err := json.Unmarshal(response.Data[0], &order); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode Selcom order status")
+	}
+	return order.transaction(s.Name(), raw)
+}
+
+// ParseWebhook verifies the webhook digest and parses the payment notification.
+func (s *Selcom) ParseWebhook(r *http.Request) (*Transaction, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.VerifyWebhook(r.Header, body); err != nil {
+		return nil, err
+	}
+
+	var order selcomOrderStatus
+	if err := json.Unmarshal(body, &order); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode Selcom webhook")
+	}
+	if order.OrderID == "" {
+		return nil, helpers.CreateError("Selcom webhook is missing order_id")
+	}
+
+	transaction, err := order.transaction(s.Name(), body)
+	if err != nil {
+		return nil, err
+	}
+	// Webhooks carry the payment outcome in resultcode rather than payment_status.
+	if order.PaymentStatus == "" {
+		transaction.Status = StatusFailed
+		if order.ResultCode == selcomSuccessCode {
+			transaction.Status = StatusSuccessful
+		}
+		transaction.Message = order.Result
+	}
+	return transaction, nil
+}
+
+// selcomOutcomeFields must be covered by the webhook signature whenever they are in
+// the body, since the Signed-Fields header is chosen by the sender.
+var selcomOutcomeFields = []string{"order_id", "resultcode", "payment_status"}
+
+// VerifyWebhook checks the Digest header of a webhook against the signed body fields.
+// Timestamps older than WebhookTolerance are rejected to prevent replays, and the
+// order ID and payment outcome fields must be among the signed fields.
+func (s *Selcom) VerifyWebhook(header http.Header, body []byte) error {
+	if s.config.APISecret == "" {
+		return helpers.CreateError("Selcom API secret is not configured")
+	}
+
+	digest := header.Get("Digest")
+	timestamp := header.Get("Timestamp")
+	signedFields := header.Get("Signed-Fields")
+	if digest == "" || timestamp == "" || signedFields == "" {
+		return helpers.CreateError("missing Selcom webhook signature headers")
+	}
+
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return helpers.WrapError(err, "invalid Selcom webhook timestamp")
+	}
+	if age := time.Since(signedAt); age > s.config.WebhookTolerance || age < -s.config.WebhookTolerance {
+		return helpers.CreateError("Selcom webhook timestamp outside tolerance")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return helpers.WrapError(err, "failed to decode Selcom webhook")
+	}
+
+	keys := strings.Split(signedFields, ",")
+	signed := make(map[string]bool, len(keys))
+	for i := range keys {
+		keys[i] = strings.TrimSpace(keys[i])
+		signed[keys[i]] = true
+	}
+	for _, field := range selcomOutcomeFields {
+		if _, present := values[field]; present && !signed[field] {
+			return helpers.CreateErrorf("Selcom webhook field %s is not signed", field)
+		}
+	}
+	if !signed["order_id"] {
+		return helpers.CreateError("Selcom webhook order_id is not signed")
+	}
+
+	expected := selcomDigest(s.config.APISecret, timestamp, keys, values)
+	if !encryption.SecureCompareString(expected, digest) {
+		return helpers.CreateError("invalid Selcom webhook signature")
+	}
+	return nil
+}
@@ -0,0 +1,479 @@
+package payments
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"crypto/hmac"   // hmac provides webhook signature computation.
+	"crypto/sha256" // sha256 provides the signature hash.
+	"encoding/hex"  // hex provides signature decoding.
+	"encoding/json" // json provides response and event decoding.
+	"fmt"           // fmt provides formatting and printing functions.
+	"net/http"      // http provides webhook request types.
+	"net/url"       // url provides form encoding.
+	"strconv"       // strconv provides amount and timestamp formatting.
+	"strings"       // strings provides signature header parsing.
+	"time"          // time provides timestamp tolerance and timeouts.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides constant-time comparison of webhook signatures.
+	"github.com/hekimapro/utils/env"        // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"    // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+)
+
+// Stripe webhook event types handled by this package.
+const (
+	StripeEventPaymentIntentSucceeded  = "payment_intent.succeeded"      // StripeEventPaymentIntentSucceeded fires when a payment completes
+	StripeEventPaymentIntentFailed     = "payment_intent.payment_failed" // StripeEventPaymentIntentFailed fires when a payment attempt fails
+	StripeEventPaymentIntentCanceled   = "payment_intent.canceled"       // StripeEventPaymentIntentCanceled fires when a payment intent is cancelled
+	StripeEventPaymentIntentProcessing = "payment_intent.processing"     // StripeEventPaymentIntentProcessing fires while a payment is processing
+	StripeEventChargeRefunded          = "charge.refunded"               // StripeEventChargeRefunded fires when a charge is fully or partially refunded
+	StripeEventChargeDisputeCreated    = "charge.dispute.created"        // StripeEventChargeDisputeCreated fires when a customer disputes a charge
+)
+
+// StripeConfig holds Stripe API credentials.
+type StripeConfig struct {
+	SecretKey        string        `env:"SECRET_KEY"`                                // SecretKey is the API secret key (sk_...)
+	WebhookSecret    string        `env:"WEBHOOK_SECRET"`                            // WebhookSecret verifies webhook signatures (whsec_...)
+	BaseURL          string        `env:"BASE_URL" default:"https://api.stripe.com"` // BaseURL is the API root
+	WebhookTolerance time.Duration `env:"WEBHOOK_TOLERANCE" default:"300" unit:"s"`  // WebhookTolerance is the maximum age of a webhook signature
+	Timeout          time.Duration `env:"TIMEOUT" default:"30" unit:"s"`             // Timeout is the HTTP request timeout
+}
+
+// LoadStripeConfig loads Stripe configuration from STRIPE_* environment variables.
+func LoadStripeConfig() StripeConfig {
+	var config StripeConfig
+	if err := env.BindWithPrefix("STRIPE_", &config); err != nil {
+		log.Warning("⚠️ Invalid Stripe configuration: " + err.Error())
+	}
+	return config
+}
+
+// StripePaymentIntentParams describes a payment intent to create.
+type StripePaymentIntentParams struct {
+	Amount             int64             // Amount is in the smallest unit of Currency
+	Currency           string            // Currency is the ISO 4217 code, e.g. USD
+	Description        string            // Description appears in the Stripe dashboard
+	ReceiptEmail       string            // ReceiptEmail receives Stripe's receipt, if set
+	CustomerID         string            // CustomerID attaches the intent to a Stripe customer, if set
+	PaymentMethodTypes []string          // PaymentMethodTypes restricts methods; empty enables automatic payment methods
+	Metadata           map[string]string // Metadata is stored on the intent and echoed in webhooks
+	IdempotencyKey     string            // IdempotencyKey makes retries of the same request safe
+}
+
+// StripePaymentIntent is a Stripe payment intent.
+type StripePaymentIntent struct {
+	ID               string            `json:"id"`              // ID is the intent ID (pi_...)
+	Amount           int64             `json:"amount"`          // Amount is in the smallest currency unit
+	AmountReceived   int64             `json:"amount_received"` // AmountReceived is the captured amount
+	Currency         string            `json:"currency"`        // Currency is the lowercase ISO 4217 code
+	Status           string            `json:"status"`          // Status is e.g. requires_payment_method, processing, succeeded, canceled
+	ClientSecret     string            `json:"client_secret"`   // ClientSecret is passed to Stripe.js to confirm the payment
+	Description      string            `json:"description"`     // Description is the intent description
+	ReceiptEmail     string            `json:"receipt_email"`   // ReceiptEmail is the receipt address
+	LatestCharge     string            `json:"latest_charge"`   // LatestCharge is the ID of the most recent charge
+	Metadata         map[string]string `json:"metadata"`        // Metadata is the intent metadata
+	Created          int64             `json:"created"`         // Created is a Unix timestamp
+	LastPaymentError *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"last_payment_error"` // LastPaymentError describes the latest failed attempt
+}
+
+// StripeRefundParams describes a refund to create.
+type StripeRefundParams struct {
+	PaymentIntent  string            // PaymentIntent is the intent to refund
+	Amount         int64             // Amount to refund; zero refunds the full amount
+	Reason         string            // Reason is duplicate, fraudulent, or requested_by_customer
+	Metadata       map[string]string // Metadata is stored on the refund
+	IdempotencyKey string            // IdempotencyKey makes retries of the same request safe
+}
+
+// StripeRefund is a Stripe refund.
+type StripeRefund struct {
+	ID            string            `json:"id"`             // ID is the refund ID (re_...)
+	Amount        int64             `json:"amount"`         // Amount is in the smallest currency unit
+	Currency      string            `json:"currency"`       // Currency is the lowercase ISO 4217 code
+	Status        string            `json:"status"`         // Status is pending, succeeded, failed, or canceled
+	PaymentIntent string            `json:"payment_intent"` // PaymentIntent is the refunded intent
+	Reason        string            `json:"reason"`         // Reason is the refund reason
+	Metadata      map[string]string `json:"metadata"`       // Metadata is the refund metadata
+	Created       int64             `json:"created"`        // Created is a Unix timestamp
+}
+
+// StripeCharge is the subset of a Stripe charge carried by charge events.
+type StripeCharge struct {
+	ID             string            `json:"id"`              // ID is the charge ID (ch_...)
+	Amount         int64             `json:"amount"`          // Amount is in the smallest currency unit
+	AmountRefunded int64             `json:"amount_refunded"` // AmountRefunded is the total refunded so far
+	Currency       string            `json:"currency"`        // Currency is the lowercase ISO 4217 code
+	Status         string            `json:"status"`          // Status is succeeded, pending, or failed
+	Refunded       bool              `json:"refunded"`        // Refunded reports whether the charge was fully refunded
+	PaymentIntent  string            `json:"payment_intent"`  // PaymentIntent is the intent that created the charge
+	Metadata       map[string]string `json:"metadata"`        // Metadata is the charge metadata
+}
+
+// StripeEvent is a verified Stripe webhook event.
+type StripeEvent struct {
+	ID       string `json:"id"`       // ID is the event ID (evt_...)
+	Type     string `json:"type"`     // Type is the event type, e.g. payment_intent.succeeded
+	Created  int64  `json:"created"`  // Created is a Unix timestamp
+	Livemode bool   `json:"livemode"` // Livemode is false for test-mode events
+	Data     struct {
+		Object json.RawMessage `json:"object"` // Object is the resource the event describes
+	} `json:"data"` // Data holds the event payload
+}
+
+// PaymentIntent decodes the event object as a payment intent (payment_intent.* events).
+func (e *StripeEvent) PaymentIntent() (*StripePaymentIntent, error) {
+	if !strings.HasPrefix(e.Type, "payment_intent.") {
+		return nil, helpers.CreateErrorf("event %s does not carry a payment intent", e.Type)
+	}
+	var intent StripePaymentIntent
+	if err := json.Unmarshal(e.Data.Object, &intent); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode payment intent")
+	}
+	return &intent, nil
+}
+
+// Charge decodes the event object as a charge (charge.* events other than disputes).
+func (e *StripeEvent) Charge() (*StripeCharge, error) {
+	if !strings.HasPrefix(e.Type, "charge.") || strings.HasPrefix(e.Type, "charge.dispute.") {
+		return nil, helpers.CreateErrorf("event %s does not carry a charge", e.Type)
+	}
+	var charge StripeCharge
+	if err := json.Unmarshal(e.Data.Object, &charge); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode charge")
+	}
+	return &charge, nil
+}
+
+// Stripe implements Provider for Stripe card payments. Collect creates a payment
+// intent that the client confirms with Stripe.js using its client secret.
+// Payouts to customers are not supported, so Disburse returns ErrUnsupported.
+type Stripe struct {
+	config StripeConfig
+	client *apiClient
+}
+
+// NewStripe creates a Stripe provider.
+//
+// Example:
+//
+//	stripe := payments.NewStripe(payments.LoadStripeConfig())
+//	intent, err := stripe.CreatePaymentIntent(ctx, payments.StripePaymentIntentParams{
+//	    Amount: 2500, Currency: "USD", Metadata: map[string]string{"reference": "INV-42"},
+//	})
+//	// return intent.ClientSecret to the frontend
+func NewStripe(config StripeConfig) *Stripe {
+	config.BaseURL = helpers.DefaultIfEmpty(config.BaseURL, "https://api.stripe.com")
+	if config.WebhookTolerance <= 0 {
+		config.WebhookTolerance = 5 * time.Minute
+	}
+	return &Stripe{config: config, client: newAPIClient("stripe", config.BaseURL, config.Timeout)}
+}
+
+// Name returns "stripe".
+func (s *Stripe) Name() string {
+	return "stripe"
+}
+
+// headers returns the authentication headers, plus an idempotency key when given.
+func (s *Stripe) headers(idempotencyKey string) map[string]string {
+	headers := map[string]string{"Authorization": "Bearer " + s.config.SecretKey}
+	if idempotencyKey != "" {
+		headers["Idempotency-Key"] = idempotencyKey
+	}
+	return headers
+}
+
+// addMetadata adds metadata entries to a form using Stripe's bracket notation.
+func addMetadata(form url.Values, metadata map[string]string) {
+	for key, value := range metadata {
+		form.Set("metadata["+key+"]", value)
+	}
+}
+
+// CreatePaymentIntent creates a payment intent.
+func (s *Stripe) CreatePaymentIntent(ctx context.Context, params StripePaymentIntentParams) (*StripePaymentIntent, error) {
+	if params.Amount <= 0 {
+		return nil, helpers.CreateError("amount must be positive")
+	}
+
+	form := url.Values{
+		"amount":   {strconv.FormatInt(params.Amount, 10)},
+		"currency": {strings.ToLower(params.Currency)},
+	}
+	if params.Description != "" {
+		form.Set("description", params.Description)
+	}
+	if params.ReceiptEmail != "" {
+		form.Set("receipt_email", params.ReceiptEmail)
+	}
+	if params.CustomerID != "" {
+		form.Set("customer", params.CustomerID)
+	}
+	if len(params.PaymentMethodTypes) > 0 {
+		for i, methodType := range params.PaymentMethodTypes {
+			form.Set(fmt.Sprintf("payment_method_types[%d]", i), methodType)
+		}
+	} else {
+		form.Set("automatic_payment_methods[enabled]", "true")
+	}
+	addMetadata(form, params.Metadata)
+
+	var intent StripePaymentIntent
+	if _, err := s.client.doForm(ctx, http.MethodPost, "/v1/payment_intents", s.headers(params.IdempotencyKey), form, &intent); err != nil {
+		return nil, helpers.WrapError(err, "failed to create Stripe payment intent")
+	}
+
+	log.Success(fmt.Sprintf("✅ Stripe payment intent %s created", intent.ID))
+	return &intent, nil
+}
+
+// GetPaymentIntent retrieves a payment intent by ID.
+func (s *Stripe) GetPaymentIntent(ctx context.Context, id string) (*StripePaymentIntent, error) {
+	var intent StripePaymentIntent
+	if _, err := s.client.doJSON(ctx, http.MethodGet, "/v1/payment_intents/"+url.PathEscape(id), s.headers(""), nil, &intent); err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to retrieve Stripe payment intent %s", id)
+	}
+	return &intent, nil
+}
+
+// CancelPaymentIntent cancels a payment intent that has not succeeded.
+func (s *Stripe) CancelPaymentIntent(ctx context.Context, id string) (*StripePaymentIntent, error) {
+	var intent StripePaymentIntent
+	if _, err := s.client.doForm(ctx, http.MethodPost, "/v1/payment_intents/"+url.PathEscape(id)+"/cancel", s.headers(""), url.Values{}, &intent); err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to cancel Stripe payment intent %s", id)
+	}
+	return &intent, nil
+}
+
+// CreateRefund refunds a payment intent in full or in part.
+func (s *Stripe) CreateRefund(ctx context.Context, params StripeRefundParams) (*StripeRefund, error) {
+	form := url.Values{"payment_intent": {params.PaymentIntent}}
+	if params.Amount > 0 {
+		form.Set("amount", strconv.FormatInt(params.Amount, 10))
+	}
+	if params.Reason != "" {
+		form.Set("reason", params.Reason)
+	}
+	addMetadata(form, params.Metadata)
+
+	var refund StripeRefund
+	if _, err := s.client.doForm(ctx, http.MethodPost, "/v1/refunds", s.headers(params.IdempotencyKey), form, &refund); err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to refund Stripe payment intent %s", params.PaymentIntent)
+	}
+
+	log.Success(fmt.Sprintf("✅ Stripe refund %s created for %s", refund.ID, params.PaymentIntent))
+	return &refund, nil
+}
+
+// stripeIntentStatus maps payment intent statuses to Status.
+func stripeIntentStatus(status string) Status {
+	switch status {
+	case "succeeded":
+		return StatusSuccessful
+	case "canceled":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+// transaction converts a payment intent to a Transaction. The merchant reference
+// is read from the "reference" metadata key set by Collect.
+func (s *Stripe) transaction(intent *StripePaymentIntent, raw json.RawMessage) *Transaction {
+	message := intent.Status
+	if intent.LastPaymentError != nil {
+		message = intent.LastPaymentError.Message
+	}
+	return &Transaction{
+		Provider:          s.Name(),
+		Type:              TypeCollection,
+		Reference:         helpers.DefaultIfEmpty(intent.Metadata["reference"], intent.ID),
+		ProviderReference: intent.ID,
+		Status:            stripeIntentStatus(intent.Status),
+		Amount:            intent.Amount,
+		Currency:          strings.ToUpper(intent.Currency),
+		Message:           message,
+		UpdatedAt:         time.Now(),
+		Raw:               raw,
+	}
+}
+
+// Collect creates a payment intent tagged with the merchant reference. The raw
+// response contains the client_secret needed to confirm the payment client-side.
+func (s *Stripe) Collect(ctx context.Context, request CollectionRequest) (*Transaction, error) {
+	intent, err := s.CreatePaymentIntent(ctx, StripePaymentIntentParams{
+		Amount:         request.Amount,
+		Currency:       request.Currency,
+		Description:    request.Description,
+		ReceiptEmail:   request.Email,
+		Metadata:       map[string]string{"reference": request.Reference},
+		IdempotencyKey: "collect-" + request.Reference,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := json.Marshal(intent)
+	return s.transaction(intent, raw), nil
+}
+
+// Disburse is not supported for Stripe.
+func (s *Stripe) Disburse(ctx context.Context, request DisbursementRequest) (*Transaction, error) {
+	return nil, ErrUnsupported
+}
+
+// Status looks up a payment by payment intent ID (pi_...) or by merchant reference.
+func (s *Stripe) Status(ctx context.Context, transactionType Type, reference string) (*Transaction, error) {
+	if transactionType != TypeCollection {
+		return nil, ErrUnsupported
+	}
+
+	if strings.HasPrefix(reference, "pi_") {
+		intent, err := s.GetPaymentIntent(ctx, reference)
+		if err != nil {
+			return nil, err
+		}
+		raw, _ := json.Marshal(intent)
+		return s.transaction(intent, raw), nil
+	}
+
+	query := url.Values{"query": {fmt.Sprintf("metadata['reference']:'%s'", strings.ReplaceAll(reference, "'", "\\'"))}}
+	var result struct {
+		Data []StripePaymentIntent `json:"data"`
+	}
+	if _, err := s.client.doJSON(ctx, http.MethodGet, "/v1/payment_intents/search?"+query.Encode(), s.headers(""), nil, &result); err != nil {
+		return nil, helpers.WrapErrorf(err, "Stripe status query for %s failed", reference)
+	}
+	if len(result.Data) == 0 {
+		return nil, helpers.CreateErrorf("no Stripe payment intent found for reference %s", reference)
+	}
+
+	intent := result.Data[0]
+	raw, _ := json.Marshal(intent)
+	return s.transaction(&intent, raw), nil
+}
+
+// VerifyWebhook checks the Stripe-Signature header and decodes the event.
+// Signatures older than WebhookTolerance are rejected to prevent replays.
+func (s *Stripe) VerifyWebhook(header http.Header, body []byte) (*StripeEvent, error) {
+	if s.config.WebhookSecret == "" {
+		return nil, helpers.CreateError("Stripe webhook secret is not configured")
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header.Get("Stripe-Signature"), ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return nil, helpers.CreateError("missing Stripe webhook signature")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, helpers.WrapError(err, "invalid Stripe webhook timestamp")
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > s.config.WebhookTolerance || age < -s.config.WebhookTolerance {
+		return nil, helpers.CreateError("Stripe webhook timestamp outside tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.config.WebhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	valid := false
+	for _, signature := range signatures {
+		decoded, err := hex.DecodeString(signature)
+		if err == nil && encryption.SecureCompare(decoded, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, helpers.CreateError("invalid Stripe webhook signature")
+	}
+
+	var event StripeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode Stripe event")
+	}
+	return &event, nil
+}
+
+// ParseWebhook verifies the webhook and converts payment_intent.* events to a
+// Transaction. Other valid events return ErrIgnoredEvent; use EventHandler for them.
+func (s *Stripe) ParseWebhook(r *http.Request) (*Transaction, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.VerifyWebhook(r.Header, body)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(event.Type, "payment_intent.") {
+		return nil, ErrIgnoredEvent
+	}
+
+	intent, err := event.PaymentIntent()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := s.transaction(intent, event.Data.Object)
+	if event.Type == StripeEventPaymentIntentFailed {
+		transaction.Status = StatusFailed
+	}
+	return transaction, nil
+}
+
+// EventHandler returns an HTTP handler that verifies webhooks and passes every
+// typed event to handle. Errors from handle return 500 so Stripe retries delivery.
+//
+// Example:
+//
+//	router.Handle("/webhooks/stripe", stripe.EventHandler(func(ctx context.Context, event *payments.StripeEvent) error {
+//	    switch event.Type {
+//	    case payments.StripeEventChargeRefunded:
+//	        charge, err := event.Charge()
+//	        ...
+//	    }
+//	    return nil
+//	}))
+func (s *Stripe) EventHandler(handle func(ctx context.Context, event *StripeEvent) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := readBody(r)
+		if err != nil {
+			helpers.RespondWithJSON(w, http.StatusBadRequest, "invalid webhook")
+			return
+		}
+
+		event, err := s.VerifyWebhook(r.Header, body)
+		if err != nil {
+			log.Error("❌ Invalid Stripe webhook: " + err.Error())
+			helpers.RespondWithJSON(w, http.StatusBadRequest, "invalid webhook")
+			return
+		}
+
+		if err := handle(r.Context(), event); err != nil {
+			log.Error(fmt.Sprintf("❌ Failed to handle Stripe event %s (%s): %v", event.ID, event.Type, err))
+			helpers.RespondWithJSON(w, http.StatusInternalServerError, "failed to process webhook")
+			return
+		}
+
+		helpers.RespondWithJSON(w, http.StatusOK, "webhook processed")
+	})
+}
@@ -0,0 +1,282 @@
+package payments
+
+import (
+	"bytes"         // bytes provides request body readers.
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides request and webhook decoding.
+	"encoding/xml"  // xml provides the disbursement command format.
+	"net/http"      // http provides webhook request types.
+	"net/url"       // url provides form encoding for token requests.
+	"strconv"       // strconv provides amount formatting.
+	"time"          // time provides timeouts and token lifetimes.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides constant-time comparison of callback tokens.
+	"github.com/hekimapro/utils/env"        // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"    // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+)
+
+// tigoSuccessCode is the push billing response code for an accepted request.
+const tigoSuccessCode = "BILLER-18-0000-S"
+
+// TigoPesaConfig holds Tigo Pesa API credentials and endpoints.
+// Endpoints and credentials are issued in the merchant integration pack.
+type TigoPesaConfig struct {
+	BaseURL            string        `env:"BASE_URL"`                      // BaseURL is the push billing API root
+	Username           string        `env:"USERNAME"`                      // Username is the push billing API username
+	Password           string        `env:"PASSWORD"`                      // Password is the push billing API password
+	BillerMSISDN       string        `env:"BILLER_MSISDN"`                 // BillerMSISDN is the merchant's biller number
+	CountryCode        string        `env:"COUNTRY_CODE" default:"255"`    // CountryCode is prefixed to local phone numbers
+	CallbackToken      string        `env:"CALLBACK_TOKEN"`                // CallbackToken must match the callback URL's token query parameter; callbacks are refused without it
+	DisbursementURL    string        `env:"DISBURSEMENT_URL"`              // DisbursementURL is the B2C command endpoint
+	DisbursementMSISDN string        `env:"DISBURSEMENT_MSISDN"`           // DisbursementMSISDN is the disbursing agent wallet
+	DisbursementPIN    string        `env:"DISBURSEMENT_PIN"`              // DisbursementPIN is the agent wallet PIN
+	BrandID            string        `env:"BRAND_ID"`                      // BrandID identifies the merchant brand for B2C
+	Timeout            time.Duration `env:"TIMEOUT" default:"30" unit:"s"` // Timeout is the HTTP request timeout
+}
+
+// LoadTigoPesaConfig loads Tigo Pesa configuration from TIGO_PESA_* environment variables.
+func LoadTigoPesaConfig() TigoPesaConfig {
+	var config TigoPesaConfig
+	if err := env.BindWithPrefix("TIGO_PESA_", &config); err != nil {
+		log.Warning("⚠️ Invalid Tigo Pesa configuration: " + err.Error())
+	}
+	return config
+}
+
+// TigoPesa implements Provider for Tigo Pesa (Tanzania).
+// Tigo Pesa reports final results only through callbacks, so Status is unsupported.
+type TigoPesa struct {
+	config TigoPesaConfig
+	client *apiClient
+	tokens tokenCache
+}
+
+// NewTigoPesa creates a Tigo Pesa provider.
+//
+// Example:
+//
+//	tigo := payments.NewTigoPesa(payments.LoadTigoPesaConfig())
+//	tx, err := tigo.Collect(ctx, payments.CollectionRequest{Reference: "INV-42", Phone: "0712345678", Amount: 500000, Currency: "TZS"})
+func NewTigoPesa(config TigoPesaConfig) *TigoPesa {
+	config.CountryCode = helpers.DefaultIfEmpty(config.CountryCode, "255")
+	return &TigoPesa{config: config, client: newAPIClient("tigopesa", config.BaseURL, config.Timeout)}
+}
+
+// Name returns "tigopesa".
+func (t *TigoPesa) Name() string {
+	return "tigopesa"
+}
+
+// token returns a cached push billing access token.
+func (t *TigoPesa) token(ctx context.Context) (string, error) {
+	return t.tokens.get(ctx, func(ctx context.Context) (string, time.Duration, error) {
+		var response struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+		}
+		form := url.Values{
+			"username":   {t.config.Username},
+			"password":   {t.config.Password},
+			"grant_type": {"password"},
+		}
+		if _, err := t.client.doForm(ctx, http.MethodPost, "/token", nil, form, &response); err != nil {
+			return "", 0, helpers.WrapError(err, "failed to obtain Tigo Pesa access token")
+		}
+		if response.AccessToken == "" {
+			return "", 0, helpers.CreateError("Tigo Pesa returned an empty access token")
+		}
+		return response.AccessToken, time.Duration(response.ExpiresIn) * time.Second, nil
+	})
+}
+
+// Collect sends a USSD push asking the customer to approve the payment.
+// The returned transaction is pending; the final result arrives by callback.
+func (t *TigoPesa) Collect(ctx context.Context, request CollectionRequest) (*Transaction, error) {
+	amount, err := wholeAmount(request.Amount, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := t.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phone := NormalizePhone(request.Phone, t.config.CountryCode)
+	payload := map[string]interface{}{
+		"CustomerMSISDN": phone,
+		"BillerMSISDN":   t.config.BillerMSISDN,
+		"Amount":         amount,
+		"Remarks":        helpers.DefaultIfEmpty(request.Description, request.Reference),
+		"ReferenceID":    request.Reference,
+	}
+	headers := map[string]string{
+		"Authorization": "bearer " + token,
+		"Username":      t.config.Username,
+		"Password":      t.config.Password,
+	}
+
+	var response struct {
+		ResponseCode        string `json:"ResponseCode"`
+		ResponseStatus      bool   `json:"ResponseStatus"`
+		ResponseDescription string `json:"ResponseDescription"`
+	}
+	raw, err := t.client.doJSON(ctx, http.MethodPost, "/API/BillerPayment/BillerPay", headers, payload, &response)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "Tigo Pesa collection %s failed", request.Reference)
+	}
+
+	status := StatusPending
+	if !response.ResponseStatus || response.ResponseCode != tigoSuccessCode {
+		status = StatusFailed
+	}
+
+	return &Transaction{
+		Provider:  t.Name(),
+		Type:      TypeCollection,
+		Reference: request.Reference,
+		Status:    status,
+		Amount:    request.Amount,
+		Currency:  request.Currency,
+		Phone:     phone,
+		Message:   response.ResponseDescription,
+		UpdatedAt: time.Now(),
+		Raw:       raw,
+	}, nil
+}
+
+// tigoCommand is the XML envelope used by the B2C disbursement API.
+type tigoCommand struct {
+	XMLName     xml.Name `xml:"COMMAND"`
+	Type        string   `xml:"TYPE"`
+	ReferenceID string   `xml:"REFERENCEID,omitempty"`
+	MSISDN      string   `xml:"MSISDN,omitempty"`
+	PIN         string   `xml:"PIN,omitempty"`
+	MSISDN1     string   `xml:"MSISDN1,omitempty"`
+	Amount      string   `xml:"AMOUNT,omitempty"`
+	SenderName  string   `xml:"SENDERNAME"`
+	BrandID     string   `xml:"BRAND_ID,omitempty"`
+	Language    string   `xml:"LANGUAGE1,omitempty"`
+	TxnID       string   `xml:"TXNID,omitempty"`
+	TxnStatus   string   `xml:"TXNSTATUS,omitempty"`
+	Message     string   `xml:"MESSAGE,omitempty"`
+}
+
+// Disburse transfers money from the agent wallet to a customer via the B2C API.
+func (t *TigoPesa) Disburse(ctx context.Context, request DisbursementRequest) (*Transaction, error) {
+	if t.config.DisbursementURL == "" {
+		return nil, helpers.WrapError(ErrUnsupported, "Tigo Pesa disbursement URL is not configured")
+	}
+
+	amount, err := wholeAmount(request.Amount, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	phone := NormalizePhone(request.Phone, t.config.CountryCode)
+	body, err := xml.Marshal(tigoCommand{
+		Type:        "REQMFCI",
+		ReferenceID: request.Reference,
+		MSISDN:      t.config.DisbursementMSISDN,
+		PIN:         t.config.DisbursementPIN,
+		MSISDN1:     phone,
+		Amount:      strconv.FormatInt(amount, 10),
+		BrandID:     t.config.BrandID,
+		Language:    "en",
+	})
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to encode Tigo Pesa disbursement")
+	}
+
+	raw, err := t.client.do(ctx, http.MethodPost, t.config.DisbursementURL, "text/xml",
+		map[string]string{"Accept": "text/xml"}, bytes.NewReader(body), nil)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "Tigo Pesa disbursement %s failed", request.Reference)
+	}
+
+	var response tigoCommand
+	if err := xml.Unmarshal(raw, &response); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode Tigo Pesa disbursement response")
+	}
+
+	status := StatusSuccessful
+	if response.TxnStatus != "200" {
+		status = StatusFailed
+	}
+
+	rawJSON, _ := json.Marshal(response)
+	return &Transaction{
+		Provider:          t.Name(),
+		Type:              TypeDisbursement,
+		Reference:         request.Reference,
+		ProviderReference: response.TxnID,
+		Status:            status,
+		Amount:            request.Amount,
+		Currency:          request.Currency,
+		Phone:             phone,
+		Message:           response.Message,
+		UpdatedAt:         time.Now(),
+		Raw:               rawJSON,
+	}, nil
+}
+
+// Status is not supported: Tigo Pesa delivers results through callbacks only.
+func (t *TigoPesa) Status(ctx context.Context, transactionType Type, reference string) (*Transaction, error) {
+	return nil, ErrUnsupported
+}
+
+// ParseWebhook parses a push billing callback. The callback URL must carry a token
+// query parameter matching CallbackToken; without a configured token every callback
+// is refused, since anyone could otherwise mark a payment successful.
+func (t *TigoPesa) ParseWebhook(r *http.Request) (*Transaction, error) {
+	if t.config.CallbackToken == "" {
+		return nil, helpers.CreateError("Tigo Pesa callback token is not configured")
+	}
+	token := r.URL.Query().Get("token")
+	if !encryption.SecureCompareString(token, t.config.CallbackToken) {
+		return nil, helpers.CreateError("invalid Tigo Pesa callback token")
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var callback struct {
+		Status           bool        `json:"Status"`
+		Description      string      `json:"Description"`
+		MFSTransactionID string      `json:"MFSTransactionID"`
+		ReferenceID      string      `json:"ReferenceID"`
+		Amount           json.Number `json:"Amount"`
+	}
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode Tigo Pesa callback")
+	}
+	if callback.ReferenceID == "" {
+		return nil, helpers.CreateError("Tigo Pesa callback is missing ReferenceID")
+	}
+
+	status := StatusFailed
+	if callback.Status {
+		status = StatusSuccessful
+	}
+
+	transaction := &Transaction{
+		Provider:          t.Name(),
+		Type:              TypeCollection,
+		Reference:         callback.ReferenceID,
+		ProviderReference: callback.MFSTransactionID,
+		Status:            status,
+		Currency:          "TZS",
+		Message:           callback.Description,
+		UpdatedAt:         time.Now(),
+		Raw:               body,
+	}
+	if callback.Amount != "" {
+		if transaction.Amount, err = parseAmount(callback.Amount.String(), transaction.Currency); err != nil {
+			return nil, err
+		}
+	}
+	return transaction, nil
+}
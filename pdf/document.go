@@ -0,0 +1,343 @@
+// Package pdf renders documents such as invoices, receipts, and reports to PDF
+// in-process, without shelling out to external tools like wkhtmltopdf.
+package pdf
+
+import (
+	"bytes"    // bytes provides image readers.
+	"fmt"      // fmt provides formatting and printing functions.
+	"io"       // io provides the output writer.
+	"net/http" // http provides image content type detection.
+	"strconv"  // strconv provides unique image names.
+	"strings"  // strings provides text handling.
+
+	"github.com/go-pdf/fpdf"             // fpdf provides PDF rendering.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// Text alignment values for table columns.
+const (
+	AlignLeft   = "L" // AlignLeft aligns text to the left
+	AlignCenter = "C" // AlignCenter centers text
+	AlignRight  = "R" // AlignRight aligns text to the right, typically for amounts
+)
+
+// Options controls page layout and the repeating header and footer.
+type Options struct {
+	Orientation string  // Orientation is "P" (portrait, default) or "L" (landscape)
+	PageSize    string  // PageSize is A4 (default), A5, Letter, or Legal
+	Margin      float64 // Margin is the page margin in millimetres (default 15)
+	FontFamily  string  // FontFamily is a core font: Helvetica (default), Times, or Courier
+	Title       string  // Title is stored in the document metadata
+	Author      string  // Author is stored in the document metadata
+	HeaderText  string  // HeaderText is printed at the top right of every page
+	HeaderLogo  []byte  // HeaderLogo is a PNG, JPEG, or GIF printed at the top left of every page
+	FooterText  string  // FooterText is printed at the bottom left of every page
+	PageNumbers bool    // PageNumbers prints "Page X of Y" at the bottom right of every page
+}
+
+// Field is a label and value pair, rendered by KeyValues.
+type Field struct {
+	Label string // Label is printed in bold
+	Value string // Value follows the label
+}
+
+// Column describes a table column.
+type Column struct {
+	Header string  // Header is the column title
+	Width  float64 // Width is a relative weight; columns share the page width proportionally
+	Align  string  // Align is AlignLeft (default), AlignCenter, or AlignRight
+}
+
+// Table is tabular data rendered with a shaded header row that repeats on each page.
+type Table struct {
+	Columns []Column   // Columns define headers, widths, and alignment
+	Rows    [][]string // Rows hold cell text; long text wraps within its cell
+	Footer  [][]string // Footer rows are printed in bold after the data, e.g. totals
+}
+
+// Document is a PDF under construction. Content flows top to bottom and
+// new pages are added automatically.
+type Document struct {
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+	options   Options
+	images    int
+}
+
+// New creates a document with the given options and adds the first page.
+//
+// Example:
+//
+//	document := pdf.New(pdf.Options{Title: "Monthly Sales", PageNumbers: true})
+//	document.Heading("Monthly Sales")
+//	document.Table(pdf.Table{Columns: columns, Rows: rows})
+//	err := document.Write(w)
+func New(options Options) *Document {
+	options.Orientation = helpers.DefaultIfEmpty(options.Orientation, "P")
+	options.PageSize = helpers.DefaultIfEmpty(options.PageSize, "A4")
+	options.FontFamily = helpers.DefaultIfEmpty(options.FontFamily, "Helvetica")
+	if options.Margin <= 0 {
+		options.Margin = 15
+	}
+
+	pdf := fpdf.New(options.Orientation, "mm", options.PageSize, "")
+	document := &Document{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+		options:   options,
+	}
+
+	pdf.SetMargins(options.Margin, options.Margin, options.Margin)
+	pdf.SetAutoPageBreak(true, options.Margin+5)
+	pdf.SetTitle(options.Title, true)
+	pdf.SetAuthor(options.Author, true)
+	pdf.SetCreator("github.com/hekimapro/utils/pdf", true)
+	pdf.AliasNbPages("{nb}")
+
+	var headerLogo string
+	if len(options.HeaderLogo) > 0 {
+		headerLogo = document.registerImage(options.HeaderLogo)
+	}
+
+	if headerLogo != "" || options.HeaderText != "" {
+		pdf.SetHeaderFunc(func() {
+			top := pdf.GetY()
+			if headerLogo != "" {
+				pdf.ImageOptions(headerLogo, options.Margin, top, 0, 12, false, fpdf.ImageOptions{}, 0, "")
+			}
+			if options.HeaderText != "" {
+				pdf.SetFont(options.FontFamily, "", 9)
+				pdf.SetTextColor(100, 100, 100)
+				pdf.SetXY(options.Margin, top)
+				pdf.CellFormat(0, 6, document.translate(options.HeaderText), "", 0, AlignRight, false, 0, "")
+				pdf.SetTextColor(0, 0, 0)
+			}
+			pdf.SetY(top + 16)
+		})
+	}
+
+	if options.FooterText != "" || options.PageNumbers {
+		pdf.SetFooterFunc(func() {
+			pdf.SetY(-options.Margin)
+			pdf.SetFont(options.FontFamily, "", 8)
+			pdf.SetTextColor(120, 120, 120)
+			if options.FooterText != "" {
+				pdf.CellFormat(0, 5, document.translate(options.FooterText), "", 0, AlignLeft, false, 0, "")
+				pdf.SetX(options.Margin)
+			}
+			if options.PageNumbers {
+				pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, AlignRight, false, 0, "")
+			}
+			pdf.SetTextColor(0, 0, 0)
+		})
+	}
+
+	pdf.AddPage()
+	pdf.SetFont(options.FontFamily, "", 10)
+	return document
+}
+
+// contentWidth returns the printable width between the margins.
+func (d *Document) contentWidth() float64 {
+	width, _ := d.pdf.GetPageSize()
+	left, _, right, _ := d.pdf.GetMargins()
+	return width - left - right
+}
+
+// Heading adds a large bold title.
+func (d *Document) Heading(text string) {
+	d.pdf.SetFont(d.options.FontFamily, "B", 16)
+	d.pdf.MultiCell(0, 8, d.translate(text), "", AlignLeft, false)
+	d.pdf.Ln(2)
+	d.pdf.SetFont(d.options.FontFamily, "", 10)
+}
+
+// Subheading adds a smaller bold title.
+func (d *Document) Subheading(text string) {
+	d.pdf.SetFont(d.options.FontFamily, "B", 12)
+	d.pdf.MultiCell(0, 6, d.translate(text), "", AlignLeft, false)
+	d.pdf.Ln(1)
+	d.pdf.SetFont(d.options.FontFamily, "", 10)
+}
+
+// Text adds a wrapped paragraph.
+func (d *Document) Text(text string) {
+	d.pdf.SetFont(d.options.FontFamily, "", 10)
+	d.pdf.MultiCell(0, 5, d.translate(text), "", AlignLeft, false)
+	d.pdf.Ln(2)
+}
+
+// KeyValues adds label and value lines, e.g. invoice number and dates.
+func (d *Document) KeyValues(fields []Field) {
+	labelWidth := 0.0
+	d.pdf.SetFont(d.options.FontFamily, "B", 10)
+	for _, field := range fields {
+		if width := d.pdf.GetStringWidth(d.translate(field.Label)) + 4; width > labelWidth {
+			labelWidth = width
+		}
+	}
+
+	for _, field := range fields {
+		d.pdf.SetFont(d.options.FontFamily, "B", 10)
+		d.pdf.CellFormat(labelWidth, 5, d.translate(field.Label), "", 0, AlignLeft, false, 0, "")
+		d.pdf.SetFont(d.options.FontFamily, "", 10)
+		d.pdf.MultiCell(0, 5, d.translate(field.Value), "", AlignLeft, false)
+	}
+	d.pdf.Ln(2)
+}
+
+// Spacer adds vertical space in millimetres.
+func (d *Document) Spacer(height float64) {
+	d.pdf.Ln(height)
+}
+
+// PageBreak starts a new page.
+func (d *Document) PageBreak() {
+	d.pdf.AddPage()
+}
+
+// registerImage registers image data and returns its name, or "" if the format is unsupported.
+func (d *Document) registerImage(data []byte) string {
+	var imageType string
+	switch http.DetectContentType(data) {
+	case "image/png":
+		imageType = "PNG"
+	case "image/jpeg":
+		imageType = "JPG"
+	case "image/gif":
+		imageType = "GIF"
+	default:
+		return ""
+	}
+
+	d.images++
+	name := "image" + strconv.Itoa(d.images)
+	d.pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(data))
+	return name
+}
+
+// Image adds a PNG, JPEG, or GIF image of the given width in millimetres
+// (height keeps the aspect ratio). Use alignment to position it horizontally.
+func (d *Document) Image(data []byte, width float64, alignment string) error {
+	name := d.registerImage(data)
+	if name == "" {
+		return helpers.CreateError("unsupported image format; use PNG, JPEG, or GIF")
+	}
+	if err := d.pdf.Error(); err != nil {
+		return helpers.WrapError(err, "failed to load image")
+	}
+
+	left, _, _, _ := d.pdf.GetMargins()
+	x := left
+	switch alignment {
+	case AlignCenter:
+		x = left + (d.contentWidth()-width)/2
+	case AlignRight:
+		x = left + d.contentWidth() - width
+	}
+
+	d.pdf.ImageOptions(name, x, d.pdf.GetY(), width, 0, true, fpdf.ImageOptions{}, 0, "")
+	d.pdf.Ln(2)
+	return nil
+}
+
+// Table adds a table. Rows wrap long text, and the header row repeats after page breaks.
+func (d *Document) Table(table Table) {
+	if len(table.Columns) == 0 {
+		return
+	}
+
+	totalWeight := 0.0
+	for _, column := range table.Columns {
+		totalWeight += column.Width
+	}
+	widths := make([]float64, len(table.Columns))
+	for i, column := range table.Columns {
+		if totalWeight > 0 {
+			widths[i] = d.contentWidth() * column.Width / totalWeight
+		} else {
+			widths[i] = d.contentWidth() / float64(len(table.Columns))
+		}
+	}
+
+	header := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column.Header
+	}
+
+	drawHeader := func() {
+		d.pdf.SetFont(d.options.FontFamily, "B", 9)
+		d.pdf.SetFillColor(235, 235, 235)
+		d.tableRow(table.Columns, widths, header, true)
+		d.pdf.SetFont(d.options.FontFamily, "", 9)
+	}
+
+	drawHeader()
+	for _, row := range table.Rows {
+		if d.tableRow(table.Columns, widths, row, false) {
+			drawHeader()
+			d.tableRow(table.Columns, widths, row, false)
+		}
+	}
+
+	d.pdf.SetFont(d.options.FontFamily, "B", 9)
+	for _, row := range table.Footer {
+		if d.tableRow(table.Columns, widths, row, false) {
+			d.tableRow(table.Columns, widths, row, false)
+		}
+	}
+	d.pdf.SetFont(d.options.FontFamily, "", 10)
+	d.pdf.Ln(3)
+}
+
+// tableRow draws one row with wrapped cells. If the row does not fit on the
+// current page, it adds a page instead and returns true so the caller can redraw.
+func (d *Document) tableRow(columns []Column, widths []float64, cells []string, fill bool) bool {
+	const lineHeight = 5.0
+
+	lines := make([][]string, len(columns))
+	maxLines := 1
+	for i := range columns {
+		text := ""
+		if i < len(cells) {
+			text = d.translate(cells[i])
+		}
+		lines[i] = d.pdf.SplitText(text, widths[i]-2)
+		if len(lines[i]) > maxLines {
+			maxLines = len(lines[i])
+		}
+	}
+	rowHeight := float64(maxLines)*lineHeight + 1
+
+	_, pageHeight := d.pdf.GetPageSize()
+	_, _, _, bottom := d.pdf.GetMargins()
+	if d.pdf.GetY()+rowHeight > pageHeight-bottom && !fill {
+		d.pdf.AddPage()
+		return true
+	}
+
+	left, _, _, _ := d.pdf.GetMargins()
+	top := d.pdf.GetY()
+	style := "D"
+	if fill {
+		style = "FD"
+	}
+
+	x := left
+	for i, column := range columns {
+		d.pdf.Rect(x, top, widths[i], rowHeight, style)
+		d.pdf.SetXY(x+1, top+0.5)
+		d.pdf.MultiCell(widths[i]-2, lineHeight, strings.Join(lines[i], "\n"), "", helpers.DefaultIfEmpty(column.Align, AlignLeft), false)
+		x += widths[i]
+	}
+	d.pdf.SetXY(left, top+rowHeight)
+	return false
+}
+
+// Write renders the document to w.
+func (d *Document) Write(w io.Writer) error {
+	if err := d.pdf.Output(w); err != nil {
+		return helpers.WrapError(err, "failed to render PDF")
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package pdf
+
+import (
+	"io"      // io provides the output writer.
+	"strconv" // strconv provides quantity formatting.
+	"strings" // strings provides address formatting.
+	"time"    // time provides document dates.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/money"   // money provides amounts and VAT.
+	"github.com/hekimapro/utils/qrcode"  // qrcode provides receipt QR codes.
+)
+
+// dateLayout is the date format used on invoices and receipts.
+const dateLayout = "02 Jan 2006"
+
+// Party is a seller or customer block.
+type Party struct {
+	Name    string   // Name is printed in bold
+	Address []string // Address lines
+	Phone   string   // Phone is printed when set
+	Email   string   // Email is printed when set
+	TIN     string   // TIN is the tax identification number
+	VRN     string   // VRN is the VAT registration number
+}
+
+// text returns the party block as printable lines.
+func (p Party) text() string {
+	lines := append([]string{}, p.Address...)
+	if p.Phone != "" {
+		lines = append(lines, "Phone: "+p.Phone)
+	}
+	if p.Email != "" {
+		lines = append(lines, "Email: "+p.Email)
+	}
+	if p.TIN != "" {
+		lines = append(lines, "TIN: "+p.TIN)
+	}
+	if p.VRN != "" {
+		lines = append(lines, "VRN: "+p.VRN)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LineItem is a billed product or service.
+type LineItem struct {
+	Description string      // Description of the product or service
+	Quantity    int64       // Quantity billed
+	UnitPrice   money.Money // UnitPrice excludes VAT
+}
+
+// Invoice is the data for RenderInvoice.
+type Invoice struct {
+	Number    string     // Number is the invoice number
+	Title     string     // Title defaults to "INVOICE"; e.g. "PROFORMA INVOICE"
+	IssueDate time.Time  // IssueDate is when the invoice was issued
+	DueDate   time.Time  // DueDate is printed when set
+	From      Party      // From is the seller
+	To        Party      // To is the customer
+	Items     []LineItem // Items are the billed lines
+	VATRate   int64      // VATRate in basis points, e.g. money.VATRateTanzania; zero omits VAT
+	Notes     string     // Notes such as payment instructions
+	Logo      []byte     // Logo is a PNG, JPEG, or GIF for the page header
+	PaymentQR string     // PaymentQR is encoded as a QR code below the totals when set
+}
+
+// Receipt is the data for RenderReceipt.
+type Receipt struct {
+	Number           string      // Number is the receipt number
+	Date             time.Time   // Date of payment
+	Merchant         Party       // Merchant is the seller
+	Customer         string      // Customer name, if known
+	Items            []LineItem  // Items paid for
+	VATRate          int64       // VATRate in basis points; zero omits VAT
+	PaymentMethod    string      // PaymentMethod, e.g. "Airtel Money"
+	PaymentReference string      // PaymentReference is the provider transaction ID
+	AmountPaid       money.Money // AmountPaid defaults to the total when zero
+	Notes            string      // Notes printed at the bottom
+	Logo             []byte      // Logo is a PNG, JPEG, or GIF for the page header
+	QRData           string      // QRData is encoded as a QR code, e.g. a verification URL
+}
+
+// Report is the data for RenderReport.
+type Report struct {
+	Title       string     // Title of the report
+	Subtitle    string     // Subtitle, e.g. the date range
+	GeneratedAt time.Time  // GeneratedAt defaults to now
+	Columns     []Column   // Columns of the data table
+	Rows        [][]string // Rows of the data table
+	Summary     []Field    // Summary fields printed after the table
+	Landscape   bool       // Landscape fits wide tables
+	Logo        []byte     // Logo is a PNG, JPEG, or GIF for the page header
+}
+
+// totals holds computed line totals, subtotal, and VAT.
+type totals struct {
+	rows     [][]string
+	subtotal money.Money
+	vat      money.VATBreakdown
+}
+
+// computeTotals prices the items and applies VAT.
+func computeTotals(items []LineItem, vatRate int64) (totals, error) {
+	if len(items) == 0 {
+		return totals{}, helpers.CreateError("at least one line item is required")
+	}
+
+	var result totals
+	lineTotals := make([]money.Money, len(items))
+	for i, item := range items {
+		lineTotal, err := item.UnitPrice.Multiply(item.Quantity)
+		if err != nil {
+			return totals{}, helpers.WrapErrorf(err, "line %d", i+1)
+		}
+		lineTotals[i] = lineTotal
+		result.rows = append(result.rows, []string{
+			item.Description,
+			strconv.FormatInt(item.Quantity, 10),
+			item.UnitPrice.Format(),
+			lineTotal.Format(),
+		})
+	}
+
+	subtotal, err := money.Sum(lineTotals...)
+	if err != nil {
+		return totals{}, helpers.WrapError(err, "line items must share one currency")
+	}
+	result.subtotal = subtotal
+
+	if result.vat, err = subtotal.AddVAT(vatRate); err != nil {
+		return totals{}, err
+	}
+	return result, nil
+}
+
+// itemColumns are the line item table columns.
+var itemColumns = []Column{
+	{Header: "Description", Width: 50},
+	{Header: "Qty", Width: 10, Align: AlignRight},
+	{Header: "Unit Price", Width: 20, Align: AlignRight},
+	{Header: "Amount", Width: 20, Align: AlignRight},
+}
+
+// totalsFooter returns the subtotal, VAT, and total rows.
+func totalsFooter(computed totals, vatRate int64) [][]string {
+	if vatRate == 0 {
+		return [][]string{{"", "", "Total", computed.vat.Gross.Format()}}
+	}
+	return [][]string{
+		{"", "", "Subtotal", computed.subtotal.Format()},
+		{"", "", "VAT " + strconv.FormatFloat(float64(vatRate)/100, 'f', -1, 64) + "%", computed.vat.VAT.Format()},
+		{"", "", "Total", computed.vat.Gross.Format()},
+	}
+}
+
+// addQR renders data as a QR code image.
+func addQR(document *Document, data string, width float64) error {
+	image, err := qrcode.Generate(data, 512, qrcode.PNG)
+	if err != nil {
+		return err
+	}
+	return document.Image(image, width, AlignRight)
+}
+
+// RenderInvoice renders an invoice with seller and customer blocks, line items,
+// VAT, and totals formatted in the items' currency (e.g. TSh 1,500.00).
+//
+// Example:
+//
+//	err := pdf.RenderInvoice(w, pdf.Invoice{
+//	    Number: "INV-2024-001", IssueDate: time.Now(),
+//	    From: pdf.Party{Name: "Hekima Ltd", TIN: "123-456-789"},
+//	    To:   pdf.Party{Name: "Customer Ltd"},
+//	    Items: []pdf.LineItem{{Description: "Consulting", Quantity: 2, UnitPrice: money.New(5000000, money.TZS)}},
+//	    VATRate: money.VATRateTanzania,
+//	})
+func RenderInvoice(w io.Writer, invoice Invoice) error {
+	computed, err := computeTotals(invoice.Items, invoice.VATRate)
+	if err != nil {
+		return helpers.WrapError(err, "invalid invoice")
+	}
+
+	title := helpers.DefaultIfEmpty(invoice.Title, "INVOICE")
+	document := New(Options{
+		Title:       title + " " + invoice.Number,
+		Author:      invoice.From.Name,
+		HeaderLogo:  invoice.Logo,
+		HeaderText:  invoice.From.Name,
+		FooterText:  title + " " + invoice.Number,
+		PageNumbers: true,
+	})
+
+	document.Heading(title)
+	fields := []Field{{Label: "Invoice No:", Value: invoice.Number}}
+	if !invoice.IssueDate.IsZero() {
+		fields = append(fields, Field{Label: "Date:", Value: invoice.IssueDate.Format(dateLayout)})
+	}
+	if !invoice.DueDate.IsZero() {
+		fields = append(fields, Field{Label: "Due Date:", Value: invoice.DueDate.Format(dateLayout)})
+	}
+	document.KeyValues(fields)
+
+	document.Table(Table{
+		Columns: []Column{{Header: "From", Width: 1}, {Header: "Bill To", Width: 1}},
+		Rows: [][]string{{
+			strings.TrimSpace(invoice.From.Name + "\n" + invoice.From.text()),
+			strings.TrimSpace(invoice.To.Name + "\n" + invoice.To.text()),
+		}},
+	})
+
+	document.Table(Table{Columns: itemColumns, Rows: computed.rows, Footer: totalsFooter(computed, invoice.VATRate)})
+
+	if invoice.PaymentQR != "" {
+		if err := addQR(document, invoice.PaymentQR, 35); err != nil {
+			return err
+		}
+	}
+	if invoice.Notes != "" {
+		document.Subheading("Notes")
+		document.Text(invoice.Notes)
+	}
+
+	return document.Write(w)
+}
+
+// RenderReceipt renders a payment receipt with items, VAT, payment details, and an optional QR code.
+func RenderReceipt(w io.Writer, receipt Receipt) error {
+	computed, err := computeTotals(receipt.Items, receipt.VATRate)
+	if err != nil {
+		return helpers.WrapError(err, "invalid receipt")
+	}
+
+	document := New(Options{
+		Title:      "Receipt " + receipt.Number,
+		Author:     receipt.Merchant.Name,
+		HeaderLogo: receipt.Logo,
+		HeaderText: receipt.Merchant.Name,
+		FooterText: "Receipt " + receipt.Number,
+	})
+
+	document.Heading("RECEIPT")
+	if receipt.Merchant.Name != "" {
+		document.Subheading(receipt.Merchant.Name)
+	}
+	if details := receipt.Merchant.text(); details != "" {
+		document.Text(details)
+	}
+
+	fields := []Field{{Label: "Receipt No:", Value: receipt.Number}}
+	if !receipt.Date.IsZero() {
+		fields = append(fields, Field{Label: "Date:", Value: receipt.Date.Format(dateLayout + " 15:04")})
+	}
+	if receipt.Customer != "" {
+		fields = append(fields, Field{Label: "Customer:", Value: receipt.Customer})
+	}
+	document.KeyValues(fields)
+
+	document.Table(Table{Columns: itemColumns, Rows: computed.rows, Footer: totalsFooter(computed, receipt.VATRate)})
+
+	paid := receipt.AmountPaid
+	if paid.IsZero() {
+		paid = computed.vat.Gross
+	}
+	payment := []Field{{Label: "Amount Paid:", Value: paid.Format()}}
+	if receipt.PaymentMethod != "" {
+		payment = append(payment, Field{Label: "Payment Method:", Value: receipt.PaymentMethod})
+	}
+	if receipt.PaymentReference != "" {
+		payment = append(payment, Field{Label: "Reference:", Value: receipt.PaymentReference})
+	}
+	document.KeyValues(payment)
+
+	if receipt.QRData != "" {
+		if err := addQR(document, receipt.QRData, 30); err != nil {
+			return err
+		}
+	}
+	if receipt.Notes != "" {
+		document.Text(receipt.Notes)
+	}
+
+	return document.Write(w)
+}
+
+// RenderReport renders a tabular report with a title, generation time, and summary.
+func RenderReport(w io.Writer, report Report) error {
+	generatedAt := report.GeneratedAt
+	if generatedAt.IsZero() {
+		generatedAt = time.Now()
+	}
+
+	orientation := "P"
+	if report.Landscape {
+		orientation = "L"
+	}
+
+	document := New(Options{
+		Orientation: orientation,
+		Title:       report.Title,
+		HeaderLogo:  report.Logo,
+		HeaderText:  report.Title,
+		FooterText:  "Generated " + generatedAt.Format(dateLayout+" 15:04"),
+		PageNumbers: true,
+	})
+
+	document.Heading(report.Title)
+	if report.Subtitle != "" {
+		document.Text(report.Subtitle)
+	}
+
+	document.Table(Table{Columns: report.Columns, Rows: report.Rows})
+	if len(report.Summary) > 0 {
+		document.KeyValues(report.Summary)
+	}
+
+	return document.Write(w)
+}
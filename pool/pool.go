@@ -0,0 +1,248 @@
+// Package pool provides a bounded worker pool: a fixed number of workers run
+// submitted tasks from a bounded queue, each with its own context and optional
+// timeout, isolated from panics with the scheduler's recovery logic. Map collects
+// ordered results for batch work, and Shutdown drains the queue gracefully.
+package pool
+
+import (
+	"context" // context provides per-task cancellation and timeouts.
+	"errors"  // errors provides sentinel errors.
+	"fmt"     // fmt provides formatting and printing functions.
+	"sync"    // sync provides worker and submission synchronization.
+	"time"    // time provides task timeouts.
+
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"       // log provides colored logging utilities.
+	"github.com/hekimapro/utils/scheduler" // scheduler provides panic recovery and run metrics.
+)
+
+// Errors returned by the pool.
+var (
+	ErrClosed = errors.New("worker pool is closed") // ErrClosed is returned when submitting after Shutdown
+	ErrPanic  = errors.New("task panicked")         // ErrPanic is the error of a task that panicked
+)
+
+// Config holds worker pool configuration. The pool is used by the request client,
+// which env depends on, so it is configured in code rather than from the environment.
+type Config struct {
+	Workers     int           // Workers is the number of tasks run at once (default 1)
+	QueueSize   int           // QueueSize is how many tasks may wait before Submit blocks
+	TaskTimeout time.Duration // TaskTimeout bounds each task (0 = no limit)
+	Name        string        // Name prefixes task names in logs and scheduler metrics (default "pool")
+}
+
+// DefaultConfig returns a pool of 10 workers with a queue of 100 tasks.
+func DefaultConfig() Config {
+	return Config{Workers: 10, QueueSize: 100, Name: "pool"}
+}
+
+// Task is a unit of work. Its context is cancelled when the submitter's context ends,
+// the task timeout expires, or Shutdown gives up waiting.
+type Task func(ctx context.Context) error
+
+// job is a queued task.
+type job struct {
+	ctx  context.Context
+	name string
+	task Task
+	done func(err error) // done receives the task error; nil logs failures instead
+}
+
+// Pool runs tasks on a fixed number of workers.
+type Pool struct {
+	config Config
+	jobs   chan job
+
+	mu     sync.RWMutex // mu guards closed and the jobs channel against sends after close
+	closed bool
+
+	workers sync.WaitGroup
+	stop    context.Context    // stop is cancelled when Shutdown stops waiting
+	cancel  context.CancelFunc // cancel cancels stop
+}
+
+// New starts a pool with config.Workers workers.
+//
+// Example:
+//
+//	workers := pool.New(pool.DefaultConfig())
+//	runner.Add("pool", nil, workers.Shutdown)
+//
+//	workers.Submit(ctx, "thumbnail", func(ctx context.Context) error {
+//	    return generateThumbnail(ctx, upload)
+//	})
+func New(config Config) *Pool {
+	config.Workers = max(config.Workers, 1)
+	config.QueueSize = max(config.QueueSize, 0)
+	config.Name = helpers.DefaultIfEmpty(config.Name, "pool")
+
+	stop, cancel := context.WithCancel(context.Background())
+	p := &Pool{config: config, jobs: make(chan job, config.QueueSize), stop: stop, cancel: cancel}
+	p.workers.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// work runs queued jobs until the queue is closed and empty.
+func (p *Pool) work() {
+	defer p.workers.Done()
+	for queued := range p.jobs {
+		p.run(queued)
+	}
+}
+
+// run executes one job with its context, timeout, and panic recovery.
+func (p *Pool) run(queued job) {
+	ctx, cancel := context.WithCancel(queued.ctx)
+	defer cancel()
+	defer context.AfterFunc(p.stop, cancel)()
+	if p.config.TaskTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, p.config.TaskTimeout)
+		defer cancelTimeout()
+	}
+
+	name := p.config.Name + ":" + queued.name
+	var err error
+	if err = ctx.Err(); err == nil {
+		if !scheduler.RunWithRecovery(func() { err = queued.task(ctx) }, name) {
+			err = helpers.WrapErrorf(ErrPanic, "task %s", name)
+		}
+	}
+
+	if queued.done != nil {
+		queued.done(err)
+	} else if err != nil {
+		log.Error(fmt.Sprintf("❌ Task %s failed: %v", name, err))
+	}
+}
+
+// Submit queues task, blocking while the queue is full. It returns ErrClosed after
+// Shutdown, or the context error if ctx ends before the task is queued. Task errors
+// are logged; use Map to collect them.
+func (p *Pool) Submit(ctx context.Context, name string, task Task) error {
+	return p.submit(job{ctx: ctx, name: name, task: task})
+}
+
+// submit queues a job unless the pool is closed or the job's context ends first.
+func (p *Pool) submit(queued job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	select {
+	case p.jobs <- queued:
+		return nil
+	case <-queued.ctx.Done():
+		return queued.ctx.Err()
+	}
+}
+
+// Shutdown stops accepting tasks and waits for queued and running tasks to finish.
+// If ctx ends first, the contexts of the remaining tasks are cancelled, tasks still
+// queued fail with the context error, and Shutdown returns ctx.Err().
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.close()
+
+	drained := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(drained)
+	}()
+
+	log.Info(fmt.Sprintf("🛑 Draining worker pool %s (%d queued)", p.config.Name, len(p.jobs)))
+	select {
+	case <-drained:
+		log.Success(fmt.Sprintf("✅ Worker pool %s drained", p.config.Name))
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		log.Warning(fmt.Sprintf("⚠️ Worker pool %s did not drain in time, cancelling remaining tasks", p.config.Name))
+		return helpers.WrapErrorf(ctx.Err(), "worker pool %s shutdown", p.config.Name)
+	}
+}
+
+// close stops accepting tasks; workers exit once the queue is empty.
+func (p *Pool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+}
+
+// Result is the outcome of one Map item.
+type Result[T any] struct {
+	Index int   // Index is the position of the item in the input
+	Value T     // Value is the task's value when Err is nil
+	Err   error // Err is the task error, a panic (ErrPanic), or a submission error
+}
+
+// Map runs fn for every item on p and waits for all of them, returning the results
+// in item order. Items that could not be queued (ctx ended or the pool closed) get
+// the submission error.
+//
+// Example:
+//
+//	results := pool.Map(ctx, workers, "geocode", addresses, func(ctx context.Context, address string) (Location, error) {
+//	    return geocoder.Lookup(ctx, address)
+//	})
+//	for _, result := range results {
+//	    if result.Err != nil { ... }
+//	}
+func Map[T, R any](ctx context.Context, p *Pool, name string, items []T, fn func(ctx context.Context, item T) (R, error)) []Result[R] {
+	results := make([]Result[R], len(items))
+	var pending sync.WaitGroup
+	for i, item := range items {
+		results[i].Index = i
+		pending.Add(1)
+		err := p.submit(job{
+			ctx:  ctx,
+			name: name,
+			task: func(ctx context.Context) error {
+				value, err := fn(ctx, item)
+				results[i].Value = value
+				return err
+			},
+			done: func(err error) {
+				results[i].Err = err
+				pending.Done()
+			},
+		})
+		if err != nil {
+			results[i].Err = err
+			pending.Done()
+		}
+	}
+	pending.Wait()
+	return results
+}
+
+// Run runs fn for every item on a temporary pool of workers and returns the results
+// in item order, for one-off batches that do not share a long-lived pool.
+//
+// Example:
+//
+//	results := pool.Run(ctx, 5, "sms", payloads, func(ctx context.Context, payload *models.ATSMSPayload) (*models.ATSMSResponse, error) {
+//	    return communication.SendAfricasTalkingSMS(payload)
+//	})
+func Run[T, R any](ctx context.Context, workers int, name string, items []T, fn func(ctx context.Context, item T) (R, error)) []Result[R] {
+	p := New(Config{Workers: min(max(workers, 1), max(len(items), 1)), QueueSize: len(items), Name: "batch"})
+	defer p.close()
+	return Map(ctx, p, name, items, fn)
+}
+
+// Errors returns the errors of failed results joined with errors.Join, or nil.
+func Errors[T any](results []Result[T]) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d: %w", result.Index, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
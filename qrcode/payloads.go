@@ -0,0 +1,217 @@
+package qrcode
+
+import (
+	"fmt"          // fmt provides formatting and printing functions.
+	"net/url"      // url provides otpauth URI encoding.
+	"strconv"      // strconv provides numeric formatting.
+	"strings"      // strings provides payload assembly.
+	"unicode/utf8" // utf8 provides rune-safe truncation.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/money"   // money provides payment amounts.
+)
+
+// OTPAuth describes a TOTP key for authenticator app enrollment.
+type OTPAuth struct {
+	Issuer    string // Issuer is the service name shown in the app
+	Account   string // Account is the user identifier, usually an email
+	Secret    string // Secret is the base32-encoded shared secret
+	Algorithm string // Algorithm is SHA1 (default), SHA256, or SHA512
+	Digits    int    // Digits is the code length (default 6)
+	Period    int    // Period is the code lifetime in seconds (default 30)
+}
+
+// URI returns the otpauth:// URI understood by authenticator apps.
+func (o OTPAuth) URI() (string, error) {
+	if o.Secret == "" || o.Account == "" {
+		return "", helpers.CreateError("OTP secret and account are required")
+	}
+
+	label := o.Account
+	if o.Issuer != "" {
+		label = o.Issuer + ":" + o.Account
+	}
+
+	query := url.Values{}
+	query.Set("secret", strings.ToUpper(strings.TrimRight(o.Secret, "=")))
+	if o.Issuer != "" {
+		query.Set("issuer", o.Issuer)
+	}
+	if o.Algorithm != "" {
+		query.Set("algorithm", strings.ToUpper(o.Algorithm))
+	}
+	if o.Digits > 0 {
+		query.Set("digits", strconv.Itoa(o.Digits))
+	}
+	if o.Period > 0 {
+		query.Set("period", strconv.Itoa(o.Period))
+	}
+
+	return "otpauth://totp/" + url.PathEscape(label) + "?" + query.Encode(), nil
+}
+
+// GenerateOTPAuth renders an otpauth URI as a QR code for authenticator enrollment.
+//
+// Example:
+//
+//	png, err := qrcode.GenerateOTPAuth(qrcode.OTPAuth{Issuer: "Hekima", Account: user.Email, Secret: secret}, 256, qrcode.PNG)
+func GenerateOTPAuth(otp OTPAuth, size int, format Format) ([]byte, error) {
+	uri, err := otp.URI()
+	if err != nil {
+		return nil, err
+	}
+	return Generate(uri, size, format)
+}
+
+// currencyNumericCodes maps ISO 4217 alphabetic codes to the numeric codes used in payment QR payloads.
+var currencyNumericCodes = map[money.Currency]string{
+	money.TZS: "834",
+	money.KES: "404",
+	money.UGX: "800",
+	money.RWF: "646",
+	money.USD: "840",
+	money.EUR: "978",
+	money.GBP: "826",
+}
+
+// Payment describes a merchant-presented payment QR code in the EMVCo format
+// used by TANQR and most interoperable mobile money and banking apps.
+type Payment struct {
+	MerchantName         string      // MerchantName is shown to the payer (max 25 characters)
+	MerchantCity         string      // MerchantCity is the merchant's city (max 15 characters)
+	CountryCode          string      // CountryCode is the ISO 3166 country code (default TZ)
+	MerchantCategoryCode string      // MerchantCategoryCode is the ISO 18245 MCC (default 0000)
+	AccountTemplateID    string      // AccountTemplateID is the merchant account template tag, 26-51 (default 26)
+	AccountGUID          string      // AccountGUID identifies the payment network or scheme
+	MerchantID           string      // MerchantID is the merchant's number within the scheme, e.g. a till or lipa number
+	Amount               money.Money // Amount makes the code single-use (dynamic); leave zero for a static code
+	Reference            string      // Reference is echoed back to the merchant, e.g. an invoice number
+}
+
+// maxEMVFieldLength is the largest value a two-digit EMV length can describe.
+const maxEMVFieldLength = 99
+
+// emvBuilder assembles tag-length-value fields, keeping the first value too long to
+// encode as its error instead of writing a corrupt length.
+type emvBuilder struct {
+	strings.Builder
+	err error
+}
+
+// field appends a tag-length-value field.
+func (b *emvBuilder) field(tag, value string) {
+	if b.err != nil {
+		return
+	}
+	if len(value) > maxEMVFieldLength {
+		b.err = helpers.CreateErrorf("payment QR field %s is %d bytes, more than the %d allowed", tag, len(value), maxEMVFieldLength)
+		return
+	}
+	fmt.Fprintf(b, "%s%02d%s", tag, len(value), value)
+}
+
+// Payload returns the EMVCo merchant-presented QR payload, including its CRC.
+func (p Payment) Payload() (string, error) {
+	if p.MerchantName == "" || p.MerchantID == "" {
+		return "", helpers.CreateError("merchant name and merchant ID are required")
+	}
+
+	templateID := helpers.DefaultIfEmpty(p.AccountTemplateID, "26")
+	if id, err := strconv.Atoi(templateID); err != nil || id < 26 || id > 51 {
+		return "", helpers.CreateErrorf("account template ID must be between 26 and 51, got %s", templateID)
+	}
+
+	initiation := "11" // static: reusable, payer enters the amount
+	if !p.Amount.IsZero() {
+		initiation = "12" // dynamic: single use with a fixed amount
+	}
+
+	var account emvBuilder
+	if p.AccountGUID != "" {
+		account.field("00", p.AccountGUID)
+	}
+	account.field("01", p.MerchantID)
+	if account.err != nil {
+		return "", account.err
+	}
+
+	var payload emvBuilder
+	payload.field("00", "01")
+	payload.field("01", initiation)
+	payload.field(templateID, account.String())
+	payload.field("52", helpers.DefaultIfEmpty(p.MerchantCategoryCode, "0000"))
+
+	if !p.Amount.IsZero() {
+		if p.Amount.IsNegative() {
+			return "", helpers.CreateError("payment amount must be positive")
+		}
+		code, exists := currencyNumericCodes[p.Amount.Currency()]
+		if !exists {
+			return "", helpers.CreateErrorf("unsupported payment QR currency: %s", p.Amount.Currency())
+		}
+		payload.field("53", code)
+		payload.field("54", p.Amount.Decimal())
+	} else {
+		payload.field("53", currencyNumericCodes[money.TZS])
+	}
+
+	payload.field("58", helpers.DefaultIfEmpty(p.CountryCode, "TZ"))
+	payload.field("59", truncate(p.MerchantName, 25))
+	payload.field("60", truncate(helpers.DefaultIfEmpty(p.MerchantCity, "Dar es Salaam"), 15))
+	if p.Reference != "" {
+		var additional emvBuilder
+		additional.field("05", truncate(p.Reference, 25))
+		payload.field("62", additional.String())
+	}
+	if payload.err != nil {
+		return "", payload.err
+	}
+
+	// The CRC covers everything including its own tag and length.
+	payload.WriteString("6304")
+	payload.WriteString(fmt.Sprintf("%04X", crc16(payload.String())))
+	return payload.String(), nil
+}
+
+// GeneratePayment renders a payment QR code, e.g. for printing on receipts or invoices.
+//
+// Example:
+//
+//	png, err := qrcode.GeneratePayment(qrcode.Payment{
+//	    MerchantName: "Hekima Shop", MerchantID: "12345678",
+//	    Amount: money.New(1500000, money.TZS), Reference: "INV-42",
+//	}, 256, qrcode.PNG)
+func GeneratePayment(payment Payment, size int, format Format) ([]byte, error) {
+	payload, err := payment.Payload()
+	if err != nil {
+		return nil, err
+	}
+	return Generate(payload, size, format)
+}
+
+// truncate shortens value to at most max bytes without splitting a UTF-8 character.
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	for max > 0 && !utf8.RuneStart(value[max]) {
+		max--
+	}
+	return value[:max]
+}
+
+// crc16 computes the CRC-16/CCITT-FALSE checksum required by EMVCo payloads.
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,139 @@
+// Package qrcode generates QR codes as PNG or SVG, with helpers for payment
+// payloads and otpauth URIs used by receipts and two-factor enrollment.
+package qrcode
+
+import (
+	"bytes"   // bytes provides readers over encoded output.
+	"fmt"     // fmt provides formatting and printing functions.
+	"regexp"  // regexp provides SVG color validation.
+	"strings" // strings provides SVG assembly.
+
+	"github.com/hekimapro/utils/file"     // file provides saving to the upload directory.
+	"github.com/hekimapro/utils/helpers"  // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"      // log provides colored logging utilities.
+	goqrcode "github.com/skip2/go-qrcode" // goqrcode provides QR encoding.
+)
+
+// Format is the output image format.
+type Format string
+
+// Supported output formats.
+const (
+	PNG Format = "png" // PNG produces a raster image
+	SVG Format = "svg" // SVG produces a scalable vector image
+)
+
+// RecoveryLevel controls how much of the code can be damaged and still scan.
+type RecoveryLevel = goqrcode.RecoveryLevel
+
+// Error correction levels.
+const (
+	RecoveryLow     = goqrcode.Low     // RecoveryLow recovers 7% of data
+	RecoveryMedium  = goqrcode.Medium  // RecoveryMedium recovers 15% of data
+	RecoveryHigh    = goqrcode.High    // RecoveryHigh recovers 25% of data
+	RecoveryHighest = goqrcode.Highest // RecoveryHighest recovers 30% of data
+)
+
+// Options customizes QR code output.
+type Options struct {
+	Recovery      RecoveryLevel // Recovery is the error correction level (default medium)
+	DisableBorder bool          // DisableBorder removes the quiet zone around the code
+	Foreground    string        // Foreground is the SVG module color, a hex code or color name (default #000000)
+	Background    string        // Background is the SVG background color, a hex code or color name (default #ffffff)
+}
+
+// svgColorPattern matches the colors accepted for SVG output: #rgb, #rgba, #rrggbb,
+// #rrggbbaa, or a color name such as "white", so options can never break out of
+// the fill attribute.
+var svgColorPattern = regexp.MustCompile(`^(#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})|[a-zA-Z]+)$`)
+
+// Generate encodes data as a QR code of size x size pixels in the given format.
+//
+// Example:
+//
+//	png, err := qrcode.Generate("https://example.com/r/42", 256, qrcode.PNG)
+func Generate(data string, size int, format Format) ([]byte, error) {
+	return GenerateWithOptions(data, size, format, Options{Recovery: RecoveryMedium})
+}
+
+// GenerateWithOptions encodes data as a QR code with custom options.
+func GenerateWithOptions(data string, size int, format Format, options Options) ([]byte, error) {
+	if data == "" {
+		return nil, helpers.CreateError("QR code data cannot be empty")
+	}
+	if size <= 0 {
+		return nil, helpers.CreateError("QR code size must be positive")
+	}
+
+	code, err := goqrcode.New(data, options.Recovery)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to encode QR code")
+	}
+	code.DisableBorder = options.DisableBorder
+
+	switch format {
+	case PNG:
+		png, err := code.PNG(size)
+		if err != nil {
+			return nil, helpers.WrapError(err, "failed to render QR code PNG")
+		}
+		return png, nil
+	case SVG:
+		for _, color := range []string{options.Foreground, options.Background} {
+			if color != "" && !svgColorPattern.MatchString(color) {
+				return nil, helpers.CreateErrorf("invalid SVG color %q: use a hex code such as #1a2b3c or a color name", color)
+			}
+		}
+		return renderSVG(code.Bitmap(), size, options), nil
+	default:
+		return nil, helpers.CreateErrorf("unsupported QR code format: %s", format)
+	}
+}
+
+// renderSVG draws the module bitmap as an SVG, merging horizontal runs into single path segments.
+func renderSVG(bitmap [][]bool, size int, options Options) []byte {
+	modules := len(bitmap)
+	foreground := helpers.DefaultIfEmpty(options.Foreground, "#000000")
+	background := helpers.DefaultIfEmpty(options.Background, "#ffffff")
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, modules, modules)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="%s"/>`, modules, modules, background)
+	fmt.Fprintf(&svg, `<path fill="%s" d="`, foreground)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&svg, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+	svg.WriteString(`"/></svg>`)
+	return []byte(svg.String())
+}
+
+// Save generates a QR code and stores it in uploadDirectory using the file package.
+// Returns the unique filename generated for the image.
+//
+// Example:
+//
+//	filename, err := qrcode.Save(receiptURL, 256, qrcode.PNG, "receipt-42", "./uploads/qrcodes")
+func Save(data string, size int, format Format, name, uploadDirectory string) (string, error) {
+	image, err := Generate(data, size, format)
+	if err != nil {
+		return "", err
+	}
+
+	filename, err := file.UploadFile(bytes.NewReader(image), name+"."+string(format), uploadDirectory, false)
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to save QR code")
+	}
+
+	log.Success("✅ QR code saved: " + filename)
+	return filename, nil
+}
@@ -0,0 +1,191 @@
+package queue
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"fmt"     // fmt provides formatting and printing functions.
+	"sync"    // sync provides synchronization primitives.
+	"time"    // time provides retry delays.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/retry"   // retry provides the retry backoff.
+)
+
+// MemoryConfig holds configuration for the in-process queue.
+type MemoryConfig struct {
+	BufferSize  int // BufferSize is the number of pending messages per topic (default 1000)
+	MaxRetries  int // MaxRetries is the number of retries after the first attempt (default 3, negative disables retries)
+	Concurrency int // Concurrency is the number of workers per subscription (default 1)
+
+	RetryDelay    time.Duration // RetryDelay is the wait before the first retry, doubled for each further one (default 1s)
+	MaxRetryDelay time.Duration // MaxRetryDelay caps the retry delay (default 1m)
+}
+
+// MemoryQueue is an in-process queue. Messages are lost when the process exits,
+// so use it for tests, development, or work that may be dropped.
+type MemoryQueue struct {
+	config      MemoryConfig
+	backoff     retry.Backoff
+	mu          sync.Mutex
+	topics      map[string]chan Message
+	deadLetters map[string][]Message
+	closed      chan struct{}
+	closeOnce   sync.Once
+	workers     sync.WaitGroup
+}
+
+// NewMemoryQueue creates an in-process queue.
+func NewMemoryQueue(config MemoryConfig) *MemoryQueue {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1000
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = time.Second
+	}
+	if config.MaxRetryDelay < config.RetryDelay {
+		config.MaxRetryDelay = max(time.Minute, config.RetryDelay)
+	}
+
+	return &MemoryQueue{
+		config:      config,
+		backoff:     retry.Exponential(config.RetryDelay, config.MaxRetryDelay),
+		topics:      make(map[string]chan Message),
+		deadLetters: make(map[string][]Message),
+		closed:      make(chan struct{}),
+	}
+}
+
+// topic returns the channel for a topic, creating it on first use.
+func (m *MemoryQueue) topic(name string) chan Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	channel, exists := m.topics[name]
+	if !exists {
+		channel = make(chan Message, m.config.BufferSize)
+		m.topics[name] = channel
+	}
+	return channel
+}
+
+// Publish enqueues a message, blocking while the topic buffer is full.
+func (m *MemoryQueue) Publish(ctx context.Context, topic string, message Message) error {
+	return m.enqueue(ctx, topic, prepare(topic, message))
+}
+
+// enqueue delivers a prepared message to the topic channel.
+func (m *MemoryQueue) enqueue(ctx context.Context, topic string, message Message) error {
+	select {
+	case <-m.closed:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case m.topic(topic) <- message:
+		return nil
+	case <-m.closed:
+		return ErrClosed
+	case <-ctx.Done():
+		return helpers.WrapError(ctx.Err(), "publish cancelled")
+	}
+}
+
+// Subscribe starts Concurrency workers that deliver messages from topic to handler.
+func (m *MemoryQueue) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	select {
+	case <-m.closed:
+		return ErrClosed
+	default:
+	}
+
+	channel := m.topic(topic)
+	for i := 0; i < m.config.Concurrency; i++ {
+		m.workers.Add(1)
+		go func() {
+			defer m.workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-m.closed:
+					return
+				case message := <-channel:
+					m.handle(ctx, handler, message)
+				}
+			}
+		}()
+	}
+
+	log.Info(fmt.Sprintf("📥 Subscribed to in-memory topic %s with %d worker(s)", topic, m.config.Concurrency))
+	return nil
+}
+
+// handle runs the handler and retries failed messages after a backoff delay, or
+// dead-letters them once the retries are used up.
+func (m *MemoryQueue) handle(ctx context.Context, handler Handler, message Message) {
+	err := runHandler(ctx, handler, message)
+	if err == nil {
+		return
+	}
+
+	if message.Attempt <= m.config.MaxRetries {
+		delay := m.backoff(message.Attempt)
+		log.Warning(fmt.Sprintf("⚠️ Message %s on %s failed (attempt %d), retrying in %v: %v", message.ID, message.Topic, message.Attempt, delay, err))
+		message.Attempt++
+		// Requeue from a separate goroutine so the delay and a full buffer cannot block the worker.
+		go func() {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-m.closed:
+				return
+			}
+			if err := m.enqueue(context.Background(), message.Topic, message); err != nil {
+				m.deadLetter(message)
+			}
+		}()
+		return
+	}
+
+	log.Error(fmt.Sprintf("❌ Message %s on %s dead-lettered after %d attempt(s): %v", message.ID, message.Topic, message.Attempt, err))
+	m.deadLetter(message)
+}
+
+// deadLetter records a message that exhausted its retries.
+func (m *MemoryQueue) deadLetter(message Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadLetters[message.Topic] = append(m.deadLetters[message.Topic], message)
+}
+
+// DeadLetters returns the messages on topic that exhausted their retries.
+func (m *MemoryQueue) DeadLetters(topic string) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.deadLetters[topic]...)
+}
+
+// Pending returns the number of messages waiting on topic.
+func (m *MemoryQueue) Pending(topic string) int {
+	return len(m.topic(topic))
+}
+
+// Close stops all workers and waits for in-flight handlers to finish.
+// Pending messages are discarded.
+func (m *MemoryQueue) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+	})
+	m.workers.Wait()
+	return nil
+}
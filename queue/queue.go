@@ -0,0 +1,97 @@
+// Package queue provides publish/subscribe message queues with an in-process backend
+// for tests and single instances, and a RabbitMQ backend with reconnects, acks, retries,
+// and dead-lettering, so slow work (email, SMS, reports) can leave the HTTP request path.
+package queue
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides JSON message helpers.
+	"errors"        // errors provides sentinel errors.
+	"time"          // time provides message timestamps.
+
+	"github.com/google/uuid"             // uuid provides message IDs.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// ErrClosed is returned when publishing to or subscribing on a closed queue.
+var ErrClosed = errors.New("queue is closed")
+
+// Message is a unit of work published to a topic.
+type Message struct {
+	ID        string            // ID uniquely identifies the message (generated when empty)
+	Topic     string            // Topic is the queue the message was published to
+	Body      []byte            // Body is the message payload
+	Headers   map[string]string // Headers holds optional metadata
+	Attempt   int               // Attempt is the 1-based delivery attempt
+	Timestamp time.Time         // Timestamp is when the message was first published
+}
+
+// Handler processes a message. Returning nil acknowledges it; returning an error
+// retries it until the backend's retry limit, after which it is dead-lettered.
+type Handler func(ctx context.Context, message Message) error
+
+// Publisher publishes messages to topics.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, message Message) error
+}
+
+// Subscriber consumes messages from topics. Subscribe returns once consumers are
+// running; they stop when ctx is cancelled or the queue is closed. Multiple
+// subscribers on one topic share its messages (each message goes to one of them).
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic string, handler Handler) error
+}
+
+// Queue is a backend that can both publish and subscribe.
+type Queue interface {
+	Publisher
+	Subscriber
+	Close() error // Close stops consumers and releases resources
+}
+
+// PublishJSON marshals value to JSON and publishes it to topic.
+//
+// Example:
+//
+//	err := queue.PublishJSON(ctx, q, "emails", EmailJob{To: "user@example.com"})
+func PublishJSON(ctx context.Context, publisher Publisher, topic string, value interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return helpers.WrapError(err, "failed to marshal message")
+	}
+	return publisher.Publish(ctx, topic, Message{
+		Body:    body,
+		Headers: map[string]string{"Content-Type": "application/json"},
+	})
+}
+
+// DecodeJSON unmarshals a message body into target.
+func DecodeJSON(message Message, target interface{}) error {
+	if err := json.Unmarshal(message.Body, target); err != nil {
+		return helpers.WrapErrorf(err, "failed to decode message %s", message.ID)
+	}
+	return nil
+}
+
+// prepare fills in generated fields before a message is published.
+func prepare(topic string, message Message) Message {
+	if message.ID == "" {
+		message.ID = uuid.NewString()
+	}
+	if message.Timestamp.IsZero() {
+		message.Timestamp = time.Now()
+	}
+	message.Topic = topic
+	message.Attempt = 1
+	return message
+}
+
+// runHandler calls the handler, converting panics into errors.
+func runHandler(ctx context.Context, handler Handler, message Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = helpers.CreateErrorf("message handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, message)
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context" // context provides the Limiter interface signature.
+	"math"    // math provides token rounding.
+	"sync"    // sync protects limiter state.
+	"time"    // time provides refill and window calculations.
+)
+
+// sweepInterval is how often idle keys are removed from memory limiters.
+const sweepInterval = time.Minute
+
+// bucket is the state of one token bucket key.
+type bucket struct {
+	tokens   float64
+	updated  time.Time
+	idleTime time.Duration // idleTime is how long until the bucket is full again and can be forgotten
+}
+
+// TokenBucket is an in-memory token bucket limiter for a single instance.
+type TokenBucket struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewTokenBucket creates an in-memory token bucket limiter.
+func NewTokenBucket() *TokenBucket {
+	return &TokenBucket{buckets: make(map[string]*bucket), lastSweep: time.Now()}
+}
+
+// AllowN refills the bucket for the time elapsed and takes n tokens if available.
+func (l *TokenBucket) AllowN(ctx context.Context, key string, limit Limit, n int) (Result, error) {
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	capacity := float64(limit.capacity())
+	perToken := limit.Period / time.Duration(limit.Rate)
+
+	state, exists := l.buckets[key]
+	if !exists {
+		state = &bucket{tokens: capacity, updated: now}
+		l.buckets[key] = state
+	}
+	state.tokens = math.Min(capacity, state.tokens+float64(now.Sub(state.updated))/float64(perToken))
+	state.updated = now
+
+	result := Result{Limit: limit.capacity()}
+	if state.tokens >= float64(n) {
+		state.tokens -= float64(n)
+		result.Allowed = true
+	} else if float64(n) <= capacity {
+		result.RetryAfter = time.Duration((float64(n) - state.tokens) * float64(perToken))
+	} else {
+		// More than the bucket can ever hold.
+		result.RetryAfter = limit.Period
+	}
+
+	state.idleTime = time.Duration((capacity - state.tokens) * float64(perToken))
+	result.Remaining = int(state.tokens)
+	result.ResetAfter = state.idleTime
+	return result, nil
+}
+
+// Reset forgets the bucket of key, refilling it.
+func (l *TokenBucket) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.buckets, key)
+	return nil
+}
+
+// sweep removes buckets that have refilled completely. The caller must hold the lock.
+func (l *TokenBucket) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, state := range l.buckets {
+		if now.Sub(state.updated) >= state.idleTime {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// window is the state of one sliding-window key.
+type window struct {
+	start    time.Time
+	period   time.Duration
+	previous int
+	current  int
+}
+
+// SlidingWindow is an in-memory sliding-window limiter for a single instance. It
+// approximates a true sliding window by weighting the previous fixed window's count
+// by its overlap, which is accurate to within a few percent and needs two counters per key.
+type SlidingWindow struct {
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// NewSlidingWindow creates an in-memory sliding-window limiter.
+func NewSlidingWindow() *SlidingWindow {
+	return &SlidingWindow{windows: make(map[string]*window), lastSweep: time.Now()}
+}
+
+// AllowN admits n events if the sliding-window count stays within limit.Rate.
+func (l *SlidingWindow) AllowN(ctx context.Context, key string, limit Limit, n int) (Result, error) {
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	state, exists := l.windows[key]
+	if !exists || state.period != limit.Period {
+		state = &window{start: now.Truncate(limit.Period), period: limit.Period}
+		l.windows[key] = state
+	}
+
+	// Advance to the window containing now.
+	if elapsedWindows := now.Sub(state.start) / limit.Period; elapsedWindows > 0 {
+		if elapsedWindows == 1 {
+			state.previous = state.current
+		} else {
+			state.previous = 0
+		}
+		state.current = 0
+		state.start = state.start.Add(elapsedWindows * limit.Period)
+	}
+
+	elapsed := now.Sub(state.start)
+	estimate := slidingEstimate(state.previous, state.current, elapsed, limit.Period)
+
+	result := Result{Limit: limit.Rate, ResetAfter: 2*limit.Period - elapsed}
+	if estimate+float64(n) <= float64(limit.Rate) {
+		state.current += n
+		estimate += float64(n)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = slidingRetryAfter(state.previous, state.current, n, limit.Rate, elapsed, limit.Period)
+	}
+	result.Remaining = max(0, limit.Rate-int(math.Ceil(estimate)))
+	return result, nil
+}
+
+// Reset clears the counters of key.
+func (l *SlidingWindow) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.windows, key)
+	return nil
+}
+
+// sweep removes keys with no events in the last two windows. The caller must hold the lock.
+func (l *SlidingWindow) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, state := range l.windows {
+		if now.Sub(state.start) >= 2*state.period {
+			delete(l.windows, key)
+		}
+	}
+}
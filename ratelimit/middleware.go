@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"fmt"      // fmt provides formatting and printing functions.
+	"math"     // math provides rounding of header values.
+	"net"      // net provides remote address parsing.
+	"net/http" // http provides the middleware types.
+	"strconv"  // strconv provides header formatting.
+	"time"     // time provides duration conversion.
+
+	"github.com/hekimapro/utils/geo"     // geo provides the proxy-aware client IP.
+	"github.com/hekimapro/utils/helpers" // helpers provides JSON responses.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// KeyFunc returns the rate limit key of a request. An empty key skips limiting.
+type KeyFunc func(r *http.Request) string
+
+// KeyByIP keys requests by client IP, using the address resolved by the geo
+// middleware when present and the connection address otherwise.
+func KeyByIP(r *http.Request) string {
+	if client, ok := geo.FromContext(r.Context()); ok && client.IP != "" {
+		return "ip:" + client.IP
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// Middleware rejects requests over limit with a JSON 429 response. Every response
+// carries RateLimit-Limit, RateLimit-Remaining, and RateLimit-Reset headers, and
+// rejections carry Retry-After. If the limiter fails (e.g. Redis is down) the request
+// is allowed and the error logged, so limiting never takes the API down.
+//
+// Example:
+//
+//	limiter := ratelimit.NewTokenBucket()
+//	handler := ratelimit.Middleware(limiter, ratelimit.PerMinute(60), ratelimit.KeyByIP)(mux)
+func Middleware(limiter Limiter, limit Limit, key KeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestKey := key(r)
+			if requestKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := Allow(r.Context(), limiter, requestKey, limit)
+			if err != nil {
+				log.Error(fmt.Sprintf("❌ Rate limiter failed for %s, allowing request: %v", requestKey, err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			SetHeaders(w, result)
+			if !result.Allowed {
+				log.Warning("⚠️ Rate limit exceeded for " + requestKey)
+				helpers.RespondWithJSON(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetHeaders writes the rate limit headers for result, plus Retry-After when the
+// request was rejected.
+func SetHeaders(w http.ResponseWriter, result Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", seconds(result.ResetAfter))
+	if !result.Allowed {
+		w.Header().Set("Retry-After", seconds(result.RetryAfter))
+	}
+}
+
+// seconds formats a duration as whole seconds, rounded up.
+func seconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
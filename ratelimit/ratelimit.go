@@ -0,0 +1,157 @@
+// Package ratelimit provides token bucket and sliding-window rate limiters behind
+// one interface, with in-memory backends for a single instance and Redis backends
+// for limits shared across instances. Limits are passed per call, so one limiter
+// serves keys with different limits (per API key plans, OTP attempts, SMS sends).
+package ratelimit
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"fmt"     // fmt provides formatting and printing functions.
+	"time"    // time provides limit periods and retry delays.
+
+	"github.com/hekimapro/utils/env"       // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"       // log provides colored logging utilities.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// Supported algorithms.
+const (
+	AlgorithmTokenBucket   = "token_bucket"   // AlgorithmTokenBucket allows bursts up to Burst and refills continuously
+	AlgorithmSlidingWindow = "sliding_window" // AlgorithmSlidingWindow allows Rate requests in any Period-long window
+)
+
+// Limit is a rate of Rate events per Period. Burst is the token bucket capacity
+// (default Rate) and is ignored by sliding-window limiters.
+type Limit struct {
+	Rate   int           // Rate is the number of events allowed per Period
+	Period time.Duration // Period is the length of the rate window
+	Burst  int           // Burst is the maximum number of events allowed at once (default Rate)
+}
+
+// PerSecond returns a limit of rate events per second.
+func PerSecond(rate int) Limit {
+	return Limit{Rate: rate, Period: time.Second}
+}
+
+// PerMinute returns a limit of rate events per minute.
+func PerMinute(rate int) Limit {
+	return Limit{Rate: rate, Period: time.Minute}
+}
+
+// PerHour returns a limit of rate events per hour.
+func PerHour(rate int) Limit {
+	return Limit{Rate: rate, Period: time.Hour}
+}
+
+// capacity returns the token bucket capacity.
+func (l Limit) capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// validate reports whether the limit can be enforced.
+func (l Limit) validate() error {
+	if l.Rate <= 0 || l.Period <= 0 {
+		return helpers.CreateErrorf("invalid rate limit %d per %s", l.Rate, l.Period)
+	}
+	return nil
+}
+
+// Result describes the outcome of a rate limit check.
+type Result struct {
+	Allowed    bool          // Allowed reports whether the events were admitted
+	Limit      int           // Limit is the maximum number of events admitted at once
+	Remaining  int           // Remaining is how many more events would be admitted now
+	RetryAfter time.Duration // RetryAfter is how long to wait before retrying when not allowed
+	ResetAfter time.Duration // ResetAfter is how long until the key is back to its full allowance
+}
+
+// Limiter admits or rejects events per key.
+type Limiter interface {
+	// AllowN records n events for key under limit and reports whether they were admitted.
+	// Rejected events are not counted.
+	AllowN(ctx context.Context, key string, limit Limit, n int) (Result, error)
+	// Reset clears the state of key, e.g. after a successful login.
+	Reset(ctx context.Context, key string) error
+}
+
+// Allow records one event for key.
+//
+// Example:
+//
+//	result, err := ratelimit.Allow(ctx, limiter, "otp:"+phone, ratelimit.PerHour(5))
+//	if err == nil && !result.Allowed {
+//	    return helpers.CreateErrorf("too many attempts, retry in %s", result.RetryAfter)
+//	}
+func Allow(ctx context.Context, limiter Limiter, key string, limit Limit) (Result, error) {
+	return limiter.AllowN(ctx, key, limit, 1)
+}
+
+// Config holds rate limiter configuration.
+type Config struct {
+	Algorithm string        `env:"RATE_LIMIT_ALGORITHM" default:"token_bucket"` // Algorithm is token_bucket or sliding_window
+	Rate      int           `env:"RATE_LIMIT_REQUESTS" default:"100"`           // Rate is the default number of requests per Period
+	Period    time.Duration `env:"RATE_LIMIT_PERIOD" default:"60" unit:"s"`     // Period is the default rate window
+	Burst     int           `env:"RATE_LIMIT_BURST" default:"0"`                // Burst is the default token bucket capacity (0 = Rate)
+	KeyPrefix string        `env:"RATE_LIMIT_KEY_PREFIX" default:"ratelimit:"`  // KeyPrefix namespaces Redis keys
+}
+
+// LoadConfig loads rate limiter configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid rate limit configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// Limit returns the default limit of the configuration.
+func (c Config) Limit() Limit {
+	return Limit{Rate: c.Rate, Period: c.Period, Burst: c.Burst}
+}
+
+// New creates a limiter for the configured algorithm, backed by Redis when client is
+// non-nil and by memory otherwise.
+//
+// Example:
+//
+//	config := ratelimit.LoadConfig()
+//	limiter, err := ratelimit.New(config, redisClient)
+//	handler := ratelimit.Middleware(limiter, config.Limit(), ratelimit.KeyByIP)(mux)
+func New(config Config, client goredis.Cmdable) (Limiter, error) {
+	switch config.Algorithm {
+	case AlgorithmTokenBucket, "":
+		if client != nil {
+			return NewRedisTokenBucket(client, config.KeyPrefix), nil
+		}
+		return NewTokenBucket(), nil
+	case AlgorithmSlidingWindow:
+		if client != nil {
+			return NewRedisSlidingWindow(client, config.KeyPrefix), nil
+		}
+		return NewSlidingWindow(), nil
+	}
+	return nil, helpers.CreateErrorf("unknown rate limit algorithm %q", config.Algorithm)
+}
+
+// slidingEstimate weights the previous window's count by how much of it still overlaps
+// the sliding window ending now.
+func slidingEstimate(previous, current int, elapsed, period time.Duration) float64 {
+	overlap := 1 - float64(elapsed)/float64(period)
+	return float64(previous)*overlap + float64(current)
+}
+
+// slidingRetryAfter returns how long until n more events fit under rate.
+func slidingRetryAfter(previous, current, n, rate int, elapsed, period time.Duration) time.Duration {
+	untilNextWindow := period - elapsed
+	if previous == 0 || current+n > rate {
+		// Only the next window can make room.
+		return untilNextWindow
+	}
+	excess := slidingEstimate(previous, current, elapsed, period) + float64(n) - float64(rate)
+	wait := time.Duration(excess / float64(previous) * float64(period))
+	return min(max(wait, time.Millisecond), untilNextWindow)
+}
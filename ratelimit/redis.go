@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"math"    // math provides remaining count rounding.
+	"strconv" // strconv provides float formatting for script arguments.
+	"time"    // time provides duration conversion.
+
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// Both scripts read the Redis server clock so every instance shares one notion of
+// time; replicate_commands allows TIME before writes on Redis versions before 7.
+
+// tokenBucketScript refills and takes tokens atomically.
+// Returns {allowed, tokens, retry ms (-1 when n exceeds the capacity), reset ms}.
+var tokenBucketScript = goredis.NewScript(`
+redis.replicate_commands()
+local per_ms = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local clock = redis.call("TIME")
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = capacity
+	updated = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * per_ms)
+
+local allowed = 0
+local retry = 0
+if tokens >= n then
+	tokens = tokens - n
+	allowed = 1
+elseif n <= capacity then
+	retry = math.ceil((n - tokens) / per_ms)
+else
+	retry = -1
+end
+
+local reset = math.ceil((capacity - tokens) / per_ms)
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], math.max(reset, 1))
+return {allowed, math.floor(tokens), retry, reset}`)
+
+// slidingWindowScript advances the fixed windows and counts n events if they fit.
+// Returns {allowed, previous, current, elapsed ms}.
+var slidingWindowScript = goredis.NewScript(`
+redis.replicate_commands()
+local period = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local clock = redis.call("TIME")
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+local start = now - (now % period)
+
+local state = redis.call("HMGET", KEYS[1], "start", "previous", "current")
+local saved = tonumber(state[1])
+local previous = tonumber(state[2]) or 0
+local current = tonumber(state[3]) or 0
+if saved ~= start then
+	if saved ~= nil and start - saved == period then
+		previous = current
+	else
+		previous = 0
+	end
+	current = 0
+end
+
+local elapsed = now - start
+local allowed = 0
+if previous * (1 - elapsed / period) + current + n <= rate then
+	current = current + n
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "start", start, "previous", previous, "current", current)
+redis.call("PEXPIRE", KEYS[1], 2 * period - elapsed)
+return {allowed, previous, current, elapsed}`)
+
+// RedisTokenBucket is a token bucket limiter whose state lives in Redis, so every
+// instance enforces one shared limit.
+type RedisTokenBucket struct {
+	client goredis.Cmdable
+	prefix string
+}
+
+// NewRedisTokenBucket creates a Redis token bucket limiter storing keys under prefix.
+func NewRedisTokenBucket(client goredis.Cmdable, prefix string) *RedisTokenBucket {
+	return &RedisTokenBucket{client: client, prefix: prefix}
+}
+
+// AllowN refills the bucket and takes n tokens atomically.
+func (l *RedisTokenBucket) AllowN(ctx context.Context, key string, limit Limit, n int) (Result, error) {
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+
+	perMillisecond := float64(limit.Rate) / float64(limit.Period.Milliseconds())
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{l.prefix + key},
+		strconv.FormatFloat(perMillisecond, 'g', -1, 64), limit.capacity(), n).Int64Slice()
+	if err != nil {
+		return Result{}, helpers.WrapErrorf(err, "failed to check rate limit for %s", key)
+	}
+
+	retryAfter := time.Duration(values[2]) * time.Millisecond
+	if values[2] < 0 {
+		retryAfter = limit.Period
+	}
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      limit.capacity(),
+		Remaining:  int(values[1]),
+		RetryAfter: retryAfter,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+// Reset deletes the bucket of key.
+func (l *RedisTokenBucket) Reset(ctx context.Context, key string) error {
+	if err := l.client.Del(ctx, l.prefix+key).Err(); err != nil {
+		return helpers.WrapErrorf(err, "failed to reset rate limit for %s", key)
+	}
+	return nil
+}
+
+// RedisSlidingWindow is a sliding-window limiter whose counters live in Redis, so
+// every instance enforces one shared limit.
+type RedisSlidingWindow struct {
+	client goredis.Cmdable
+	prefix string
+}
+
+// NewRedisSlidingWindow creates a Redis sliding-window limiter storing keys under prefix.
+func NewRedisSlidingWindow(client goredis.Cmdable, prefix string) *RedisSlidingWindow {
+	return &RedisSlidingWindow{client: client, prefix: prefix}
+}
+
+// AllowN admits n events if the sliding-window count stays within limit.Rate.
+func (l *RedisSlidingWindow) AllowN(ctx context.Context, key string, limit Limit, n int) (Result, error) {
+	if err := limit.validate(); err != nil {
+		return Result{}, err
+	}
+
+	values, err := slidingWindowScript.Run(ctx, l.client, []string{l.prefix + key},
+		limit.Period.Milliseconds(), limit.Rate, n).Int64Slice()
+	if err != nil {
+		return Result{}, helpers.WrapErrorf(err, "failed to check rate limit for %s", key)
+	}
+
+	allowed := values[0] == 1
+	previous, current := int(values[1]), int(values[2])
+	elapsed := time.Duration(values[3]) * time.Millisecond
+
+	result := Result{Allowed: allowed, Limit: limit.Rate, ResetAfter: 2*limit.Period - elapsed}
+	if !allowed {
+		result.RetryAfter = slidingRetryAfter(previous, current, n, limit.Rate, elapsed, limit.Period)
+	}
+	estimate := slidingEstimate(previous, current, elapsed, limit.Period)
+	result.Remaining = max(0, limit.Rate-int(math.Ceil(estimate)))
+	return result, nil
+}
+
+// Reset deletes the counters of key.
+func (l *RedisSlidingWindow) Reset(ctx context.Context, key string) error {
+	if err := l.client.Del(ctx, l.prefix+key).Err(); err != nil {
+		return helpers.WrapErrorf(err, "failed to reset rate limit for %s", key)
+	}
+	return nil
+}
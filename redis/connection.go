@@ -0,0 +1,204 @@
+// Package redis provides Redis connection management, JSON values, distributed locks,
+// and rate-limit/counter primitives, mirroring the ergonomics of the database package.
+package redis
+
+import (
+	"context"    // context provides support for cancellation and timeouts.
+	"crypto/tls" // tls provides TLS configuration for secure connections.
+	"fmt"        // fmt provides formatting and printing functions.
+	"time"       // time provides connection timeouts.
+
+	"github.com/hekimapro/utils/env"       // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/health"    // health provides the shared check registry.
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"       // log provides colored logging utilities.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// RedisConfig holds configuration for the Redis connection and connection pooling.
+// Fields are bound from REDIS_-prefixed environment variables.
+type RedisConfig struct {
+	URL            string        `env:"URL"`                                   // URL is a redis:// or rediss:// URL; overrides Address, Password, and DB when set
+	Address        string        `env:"ADDRESS" default:"localhost:6379"`      // Address is the host:port of the Redis server
+	Username       string        `env:"USERNAME"`                              // Username is the ACL username
+	Password       string        `env:"PASSWORD"`                              // Password is the Redis password
+	DB             int           `env:"DB" default:"0"`                        // DB is the database number
+	TLS            bool          `env:"TLS" default:"false"`                   // TLS enables TLS for Address-based connections
+	PoolSize       int           `env:"POOL_SIZE" default:"10"`                // PoolSize is the maximum number of socket connections
+	MinIdleConns   int           `env:"MINIMUM_IDLE_CONNECTIONS" default:"0"`  // MinIdleConns is the minimum number of idle connections
+	DialTimeout    time.Duration `env:"DIAL_TIMEOUT" default:"5" unit:"s"`     // DialTimeout is the timeout for establishing new connections
+	ReadTimeout    time.Duration `env:"READ_TIMEOUT" default:"3" unit:"s"`     // ReadTimeout is the timeout for socket reads
+	WriteTimeout   time.Duration `env:"WRITE_TIMEOUT" default:"3" unit:"s"`    // WriteTimeout is the timeout for socket writes
+	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT" default:"10" unit:"s"` // ConnectTimeout sets the maximum time for establishing the connection
+	PingTimeout    time.Duration `env:"PING_TIMEOUT" default:"5" unit:"s"`     // PingTimeout sets the maximum time for ping operations
+}
+
+// LoadRedisConfig loads Redis configuration with defaults from environment variables.
+// Invalid values are logged and replaced by their defaults.
+func LoadRedisConfig() RedisConfig {
+	var config RedisConfig
+	if err := env.BindWithPrefix("REDIS_", &config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid Redis configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// clientOptions converts the configuration to go-redis options.
+func clientOptions(config RedisConfig) (*goredis.Options, error) {
+	var options *goredis.Options
+	if config.URL != "" {
+		parsed, err := goredis.ParseURL(config.URL)
+		if err != nil {
+			return nil, helpers.WrapError(err, "invalid REDIS_URL")
+		}
+		options = parsed
+	} else {
+		options = &goredis.Options{
+			Addr:     config.Address,
+			Username: config.Username,
+			Password: config.Password,
+			DB:       config.DB,
+		}
+		if config.TLS {
+			options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+	}
+
+	options.PoolSize = config.PoolSize
+	options.MinIdleConns = config.MinIdleConns
+	options.DialTimeout = config.DialTimeout
+	options.ReadTimeout = config.ReadTimeout
+	options.WriteTimeout = config.WriteTimeout
+	return options, nil
+}
+
+// ConnectToRedis establishes a connection to Redis using REDIS_ environment variables.
+// Configures connection pooling and verifies connectivity.
+// Returns the client or an error if the connection fails.
+func ConnectToRedis() (*goredis.Client, error) {
+	config := LoadRedisConfig()
+	ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
+	defer cancel()
+
+	return ConnectToRedisWithConfig(ctx, config)
+}
+
+// ConnectToRedisWithConfig establishes a connection to Redis with an explicit configuration.
+func ConnectToRedisWithConfig(ctx context.Context, config RedisConfig) (*goredis.Client, error) {
+	// Check context cancellation before starting
+	select {
+	case <-ctx.Done():
+		return nil, helpers.WrapError(ctx.Err(), "redis connection cancelled before start")
+	default:
+		// Continue with connection
+	}
+
+	log.Info("🔌 Starting Redis connection process")
+
+	options, err := clientOptions(config)
+	if err != nil {
+		log.Error(fmt.Sprintf("❌ Invalid Redis configuration: %v", err))
+		return nil, err
+	}
+
+	log.Info(fmt.Sprintf("📊 Redis pool settings - PoolSize: %d, MinIdle: %d", options.PoolSize, options.MinIdleConns))
+	client := goredis.NewClient(options)
+
+	// Verify connectivity with context timeout
+	log.Info("🔎 Verifying Redis connectivity with ping")
+	pingTimeout := config.PingTimeout
+	if pingTimeout <= 0 {
+		pingTimeout = 5 * time.Second
+	}
+	pingCtx, pingCancel := context.WithTimeout(ctx, pingTimeout)
+	defer pingCancel()
+
+	if err := client.Ping(pingCtx).Err(); err != nil {
+		log.Error(fmt.Sprintf("❌ Failed to ping Redis: %v", err))
+		client.Close()
+		return nil, helpers.WrapError(err, "unable to connect to Redis")
+	}
+
+	log.Success(fmt.Sprintf("✅ Successfully connected to Redis: %s", options.Addr))
+	return client, nil
+}
+
+// PingRedis pings Redis to verify connectivity.
+func PingRedis(client *goredis.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return PingRedisWithContext(ctx, client)
+}
+
+// PingRedisWithContext pings Redis with context support.
+func PingRedisWithContext(ctx context.Context, client *goredis.Client) error {
+	log.Info("🔍 Pinging Redis to verify connectivity")
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Error(fmt.Sprintf("❌ Redis ping failed: %v", err))
+		return helpers.WrapError(err, "redis ping failed")
+	}
+
+	log.Success("✅ Redis ping successful")
+	return nil
+}
+
+// IsRedisConnected checks if Redis is connected and responsive.
+func IsRedisConnected(client *goredis.Client) bool {
+	if client == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return client.Ping(ctx).Err() == nil
+}
+
+// RegisterHealthCheck registers a "redis" check that pings client. Pass critical
+// when the service cannot work without Redis; otherwise failures report degraded.
+//
+// Example:
+//
+//	client, err := redis.ConnectToRedis()
+//	redis.RegisterHealthCheck(client, false)
+func RegisterHealthCheck(client *goredis.Client, critical bool) {
+	_ = health.Register(health.Check{
+		Name: "redis",
+		Check: func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		},
+		Timeout:  2 * time.Second,
+		Critical: critical,
+	})
+}
+
+// CloseRedis closes the Redis client and its connection pool.
+func CloseRedis(client *goredis.Client) error {
+	log.Info("🔌 Closing Redis connection")
+	if err := client.Close(); err != nil {
+		log.Error(fmt.Sprintf("❌ Failed to close Redis connection: %v", err))
+		return helpers.WrapError(err, "failed to close Redis connection")
+	}
+
+	log.Success("✅ Redis connection closed successfully")
+	return nil
+}
+
+// GetRedisStats returns Redis connection pool statistics.
+func GetRedisStats(client *goredis.Client) *goredis.PoolStats {
+	return client.PoolStats()
+}
+
+// PrintRedisStats logs Redis connection pool statistics.
+func PrintRedisStats(client *goredis.Client) {
+	stats := GetRedisStats(client)
+
+	log.Info("📊 Redis Connection Pool Statistics:")
+	log.Info(fmt.Sprintf("   Total Connections: %d", stats.TotalConns))
+	log.Info(fmt.Sprintf("   Idle: %d", stats.IdleConns))
+	log.Info(fmt.Sprintf("   Stale: %d", stats.StaleConns))
+	log.Info(fmt.Sprintf("   Hits: %d", stats.Hits))
+	log.Info(fmt.Sprintf("   Misses: %d", stats.Misses))
+	log.Info(fmt.Sprintf("   Timeouts: %d", stats.Timeouts))
+}
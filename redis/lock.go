@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"         // context provides support for cancellation and timeouts.
+	"crypto/rand"     // rand provides unique lock tokens.
+	"encoding/base64" // base64 provides encoding of lock tokens.
+	"errors"          // errors provides sentinel errors.
+	"time"            // time provides lock expiry and retry delays.
+
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"       // log provides colored logging utilities.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// ErrLockNotAcquired is returned when a lock is held by someone else.
+var ErrLockNotAcquired = errors.New("lock not acquired")
+
+// ErrLockNotHeld is returned when releasing or extending a lock that expired or was taken over.
+var ErrLockNotHeld = errors.New("lock not held")
+
+// releaseScript deletes the lock only if it still holds our token.
+var releaseScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// extendScript resets the lock expiry only if it still holds our token.
+var extendScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// Lock is a distributed lock held on a Redis key.
+type Lock struct {
+	client goredis.Cmdable
+	key    string
+	token  string
+}
+
+// Key returns the locked key.
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// AcquireLock tries once to take the lock on key for ttl.
+// Returns ErrLockNotAcquired if the lock is held by someone else.
+func AcquireLock(ctx context.Context, client goredis.Cmdable, key string, ttl time.Duration) (*Lock, error) {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return nil, helpers.WrapError(err, "failed to generate lock token")
+	}
+	token := base64.RawURLEncoding.EncodeToString(buffer)
+
+	acquired, err := client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to acquire lock %s", key)
+	}
+	if !acquired {
+		return nil, ErrLockNotAcquired
+	}
+
+	return &Lock{client: client, key: key, token: token}, nil
+}
+
+// WaitForLock retries AcquireLock every retryInterval until it succeeds or ctx is done.
+func WaitForLock(ctx context.Context, client goredis.Cmdable, key string, ttl, retryInterval time.Duration) (*Lock, error) {
+	if retryInterval <= 0 {
+		retryInterval = 100 * time.Millisecond
+	}
+
+	for {
+		lock, err := AcquireLock(ctx, client, key, ttl)
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, helpers.WrapErrorf(ctx.Err(), "timed out waiting for lock %s", key)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// Release frees the lock if it is still held by this holder.
+func (l *Lock) Release(ctx context.Context) error {
+	released, err := releaseScript.Run(ctx, l.client, []string{l.key}, l.token).Int64()
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to release lock %s", l.key)
+	}
+	if released == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Extend resets the lock expiry to ttl if it is still held by this holder.
+func (l *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	extended, err := extendScript.Run(ctx, l.client, []string{l.key}, l.token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to extend lock %s", l.key)
+	}
+	if extended == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// WithLock runs fn while holding the lock on key and releases it afterwards.
+// Returns ErrLockNotAcquired without running fn if the lock is held by someone else.
+//
+// Example:
+//
+//	err := redis.WithLock(ctx, client, "lock:invoice:42", 30*time.Second, func(ctx context.Context) error {
+//	    return generateInvoice(ctx, 42)
+//	})
+func WithLock(ctx context.Context, client goredis.Cmdable, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	lock, err := AcquireLock(ctx, client, key, ttl)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			log.Warning("⚠️ " + err.Error() + ": " + key)
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"time"    // time provides rate limit windows.
+
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// incrementScript increments a counter and sets its expiry when it is first created.
+var incrementScript = goredis.NewScript(`
+local count = redis.call("INCRBY", KEYS[1], ARGV[1])
+if count == tonumber(ARGV[1]) and tonumber(ARGV[2]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return {count, redis.call("PTTL", KEYS[1])}`)
+
+// RateLimitResult describes the outcome of a rate limit check.
+type RateLimitResult struct {
+	Allowed   bool          // Allowed reports whether the request is within the limit
+	Count     int64         // Count is the number of requests in the current window
+	Remaining int64         // Remaining is the number of requests left in the current window
+	ResetIn   time.Duration // ResetIn is the time until the window resets
+}
+
+// Increment adds delta to the counter at key, starting a new expiry of ttl when the
+// counter is created, and returns the new value. A ttl of 0 means no expiry.
+func Increment(ctx context.Context, client goredis.Cmdable, key string, delta int64, ttl time.Duration) (int64, error) {
+	count, _, err := increment(ctx, client, key, delta, ttl)
+	return count, err
+}
+
+// increment runs the increment script and returns the count and remaining ttl.
+func increment(ctx context.Context, client goredis.Cmdable, key string, delta int64, ttl time.Duration) (int64, time.Duration, error) {
+	expiry := ttl.Milliseconds()
+	if ttl <= 0 {
+		expiry = -1
+	}
+
+	result, err := incrementScript.Run(ctx, client, []string{key}, delta, expiry).Int64Slice()
+	if err != nil {
+		return 0, 0, helpers.WrapErrorf(err, "failed to increment counter %s", key)
+	}
+
+	remaining := time.Duration(result[1]) * time.Millisecond
+	if remaining < 0 {
+		remaining = 0
+	}
+	return result[0], remaining, nil
+}
+
+// Allow records a request against a fixed-window rate limit of limit requests per window.
+// All instances sharing the Redis server share the same limit.
+//
+// Example:
+//
+//	result, err := redis.Allow(ctx, client, "ratelimit:"+clientIP, 100, time.Minute)
+//	if err == nil && !result.Allowed {
+//	    helpers.RespondWithJSON(w, http.StatusTooManyRequests, "rate limit exceeded")
+//	}
+func Allow(ctx context.Context, client goredis.Cmdable, key string, limit int64, window time.Duration) (RateLimitResult, error) {
+	count, resetIn, err := increment(ctx, client, key, 1, window)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	remaining := limit - count
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return RateLimitResult{
+		Allowed:   count <= limit,
+		Count:     count,
+		Remaining: remaining,
+		ResetIn:   resetIn,
+	}, nil
+}
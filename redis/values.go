@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"encoding/json" // json provides marshaling of stored values.
+	"errors"        // errors provides detection of missing keys.
+	"time"          // time provides expiry handling.
+
+	"github.com/hekimapro/utils/helpers"   // helpers provides error utilities.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// SetJSON marshals value to JSON and stores it under key. A ttl of 0 means no expiry.
+func SetJSON(ctx context.Context, client goredis.Cmdable, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to marshal value for key %s", key)
+	}
+
+	if err := client.Set(ctx, key, data, ttl).Err(); err != nil {
+		return helpers.WrapErrorf(err, "failed to set key %s", key)
+	}
+	return nil
+}
+
+// GetJSON reads the JSON value stored under key into target.
+// Returns false (and no error) when the key does not exist.
+//
+// Example:
+//
+//	var user User
+//	found, err := redis.GetJSON(ctx, client, "user:42", &user)
+func GetJSON(ctx context.Context, client goredis.Cmdable, key string, target interface{}) (bool, error) {
+	data, err := client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, helpers.WrapErrorf(err, "failed to get key %s", key)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return false, helpers.WrapErrorf(err, "failed to unmarshal value for key %s", key)
+	}
+	return true, nil
+}
+
+// Delete removes the given keys and returns how many existed.
+func Delete(ctx context.Context, client goredis.Cmdable, keys ...string) (int64, error) {
+	deleted, err := client.Del(ctx, keys...).Result()
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to delete keys")
+	}
+	return deleted, nil
+}
+
+// Exists reports whether key exists.
+func Exists(ctx context.Context, client goredis.Cmdable, key string) (bool, error) {
+	count, err := client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, helpers.WrapErrorf(err, "failed to check key %s", key)
+	}
+	return count > 0, nil
+}
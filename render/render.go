@@ -0,0 +1,300 @@
+// Package render renders server-side HTML pages with html/template, for admin pages
+// served alongside the JSON API. Templates are organized as layouts, partials, and
+// pages, loaded from disk or an embedded file system, and reloaded on every render
+// in development mode.
+//
+// Directory layout (relative to the template root):
+//
+//	layouts/base.html      {{define "title"}}…{{end}} … {{block "content" .}}{{end}}
+//	partials/nav.html      included with {{template "partials/nav" .}}
+//	users/list.html        {{define "content"}}…{{end}}
+//
+// A page made only of {{define}} blocks is rendered inside the layout; a page with
+// top-level content is rendered on its own. Templates receive a
+// models.ServerResponse, so pages read their data from .Message and the outcome
+// from .Success, exactly like the JSON responses.
+package render
+
+import (
+	"bytes"         // bytes provides buffered rendering so failures never send partial pages.
+	"fmt"           // fmt provides formatting and printing functions.
+	"html/template" // template provides contextual auto-escaping templates.
+	"io"            // io provides the Execute writer.
+	"io/fs"         // fs provides template loading from embedded and disk file systems.
+	"net/http"      // http provides the response writer.
+	"os"            // os provides the disk file system for development.
+	"path"          // path provides slash-separated template paths.
+	"sort"          // sort provides stable page listing.
+	"strings"       // strings provides template name handling.
+	"sync"          // sync protects the template sets during reloads.
+	"time"          // time provides the formatTime helper.
+
+	"github.com/hekimapro/utils/env"     // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models"  // models provides the ServerResponse envelope.
+)
+
+// Config holds template rendering configuration.
+type Config struct {
+	Directory string `env:"RENDER_TEMPLATES_DIR" default:"templates"` // Directory is the template root used by NewFromConfig
+	Extension string `env:"RENDER_EXTENSION" default:".html"`         // Extension selects template files
+	Layout    string `env:"RENDER_LAYOUT" default:"base"`             // Layout is the default layout under layouts/
+	DevMode   bool   `env:"RENDER_DEV_MODE" default:"false"`          // DevMode reloads templates on every render
+}
+
+// LoadConfig loads rendering configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid render configuration, using defaults where needed: %v", err))
+	}
+	return config
+}
+
+// Renderer holds the parsed template set of every page.
+type Renderer struct {
+	fsys   fs.FS
+	config Config
+	funcs  template.FuncMap
+
+	mu    sync.RWMutex
+	pages map[string]*page
+}
+
+// page is a parsed page with its layouts and partials.
+type page struct {
+	set       *template.Template
+	hasLayout bool // hasLayout is true when the page only defines blocks and renders inside a layout
+}
+
+// New parses the templates in fsys, e.g. an embed.FS or os.DirFS, with extra
+// template functions (which may be nil).
+//
+// Example:
+//
+//	//go:embed templates
+//	var templates embed.FS
+//
+//	root, _ := fs.Sub(templates, "templates")
+//	renderer, err := render.New(root, render.LoadConfig(), template.FuncMap{"money": formatMoney})
+func New(fsys fs.FS, config Config, funcs template.FuncMap) (*Renderer, error) {
+	config.Extension = helpers.DefaultIfEmpty(config.Extension, ".html")
+
+	merged := defaultFuncs()
+	for name, fn := range funcs {
+		merged[name] = fn
+	}
+
+	renderer := &Renderer{fsys: fsys, config: config, funcs: merged}
+	if err := renderer.Reload(); err != nil {
+		return nil, err
+	}
+	return renderer, nil
+}
+
+// NewFromConfig parses the templates in config.Directory on disk. Combined with
+// DevMode, edits show up on the next request without a restart.
+func NewFromConfig(config Config, funcs template.FuncMap) (*Renderer, error) {
+	return New(os.DirFS(helpers.DefaultIfEmpty(config.Directory, "templates")), config, funcs)
+}
+
+// defaultFuncs returns the functions available to every template.
+func defaultFuncs() template.FuncMap {
+	return template.FuncMap{
+		// dict builds a map from name/value pairs for passing several values to a partial.
+		"dict": func(pairs ...interface{}) (map[string]interface{}, error) {
+			if len(pairs)%2 != 0 {
+				return nil, helpers.CreateError("dict requires name/value pairs")
+			}
+			values := make(map[string]interface{}, len(pairs)/2)
+			for i := 0; i < len(pairs); i += 2 {
+				name, ok := pairs[i].(string)
+				if !ok {
+					return nil, helpers.CreateErrorf("dict name must be a string, got %T", pairs[i])
+				}
+				values[name] = pairs[i+1]
+			}
+			return values, nil
+		},
+		// formatTime formats a time with a Go layout, e.g. {{formatTime .CreatedAt "02 Jan 2006"}}.
+		"formatTime": func(value time.Time, layout string) string {
+			if value.IsZero() {
+				return ""
+			}
+			return value.Format(layout)
+		},
+	}
+}
+
+// Reload re-parses every template. It is called automatically before each render in DevMode.
+func (r *Renderer) Reload() error {
+	var layouts, partials, pageFiles []string
+	err := fs.WalkDir(r.fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || path.Ext(filePath) != r.config.Extension {
+			return nil
+		}
+		switch {
+		case strings.HasPrefix(filePath, "layouts/"):
+			layouts = append(layouts, filePath)
+		case strings.HasPrefix(filePath, "partials/"):
+			partials = append(partials, filePath)
+		default:
+			pageFiles = append(pageFiles, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return helpers.WrapError(err, "failed to list templates")
+	}
+
+	// Parse layouts and partials once, then clone the shared set for each page.
+	shared := template.New("").Funcs(r.funcs)
+	for _, filePath := range append(layouts, partials...) {
+		if err := r.parseFile(shared, filePath); err != nil {
+			return err
+		}
+	}
+
+	pages := make(map[string]*page, len(pageFiles))
+	for _, filePath := range pageFiles {
+		set, err := shared.Clone()
+		if err != nil {
+			return helpers.WrapError(err, "failed to clone template set")
+		}
+		if err := r.parseFile(set, filePath); err != nil {
+			return err
+		}
+		name := r.templateName(filePath)
+		pages[name] = &page{set: set, hasLayout: onlyDefinitions(set.Lookup(name))}
+	}
+
+	r.mu.Lock()
+	r.pages = pages
+	r.mu.Unlock()
+
+	if !r.config.DevMode {
+		log.Info(fmt.Sprintf("🖼️ Loaded %d page(s), %d layout(s), and %d partial(s)", len(pageFiles), len(layouts), len(partials)))
+	}
+	return nil
+}
+
+// parseFile adds one file to a set under its name without extension.
+func (r *Renderer) parseFile(set *template.Template, filePath string) error {
+	content, err := fs.ReadFile(r.fsys, filePath)
+	if err != nil {
+		return helpers.WrapErrorf(err, "failed to read template %s", filePath)
+	}
+	if _, err := set.New(r.templateName(filePath)).Parse(string(content)); err != nil {
+		return helpers.WrapErrorf(err, "failed to parse template %s", filePath)
+	}
+	return nil
+}
+
+// templateName converts "users/list.html" to "users/list".
+func (r *Renderer) templateName(filePath string) string {
+	return strings.TrimSuffix(filePath, r.config.Extension)
+}
+
+// onlyDefinitions reports whether a template has no top-level output besides whitespace.
+func onlyDefinitions(tmpl *template.Template) bool {
+	if tmpl == nil || tmpl.Tree == nil || tmpl.Tree.Root == nil {
+		return true
+	}
+	for _, node := range tmpl.Tree.Root.Nodes {
+		if strings.TrimSpace(node.String()) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Pages returns the names of the loaded pages, sorted.
+func (r *Renderer) Pages() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.pages))
+	for name := range r.pages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render writes the named page with the default layout. Data is wrapped in a
+// models.ServerResponse whose Success follows the status code, as with
+// helpers.RespondWithJSON. The page is rendered to a buffer first, so a template
+// error produces a clean 500 instead of a half-written page.
+//
+// Example:
+//
+//	renderer.Render(w, http.StatusOK, "users/list", map[string]interface{}{"Users": users})
+//	// users/list.html: {{range .Message.Users}}<li>{{.Name}}</li>{{end}}
+func (r *Renderer) Render(w http.ResponseWriter, status int, name string, data interface{}) {
+	r.RenderLayout(w, status, r.config.Layout, name, data)
+}
+
+// RenderLayout writes the named page inside the given layout ("" renders the page alone).
+func (r *Renderer) RenderLayout(w http.ResponseWriter, status int, layout, name string, data interface{}) {
+	var body bytes.Buffer
+	if err := r.Execute(&body, layout, name, &models.ServerResponse{Success: status < http.StatusBadRequest, Message: data}); err != nil {
+		log.Error(fmt.Sprintf("❌ Failed to render page %s: %v", name, err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := body.WriteTo(w); err != nil {
+		log.Error(fmt.Sprintf("❌ Failed to write page %s: %v", name, err))
+	}
+}
+
+// Error renders "errors/<status>" or, failing that, "errors/default" with message as
+// the data, and falls back to a plain text response when neither page exists.
+func (r *Renderer) Error(w http.ResponseWriter, status int, message string) {
+	for _, name := range []string{fmt.Sprintf("errors/%d", status), "errors/default"} {
+		if r.has(name) {
+			r.Render(w, status, name, message)
+			return
+		}
+	}
+	http.Error(w, message, status)
+}
+
+// has reports whether a page is loaded.
+func (r *Renderer) has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.pages[name]
+	return exists
+}
+
+// Execute writes the named page to w with data as-is, e.g. to render HTML emails.
+func (r *Renderer) Execute(w io.Writer, layout, name string, data interface{}) error {
+	if r.config.DevMode {
+		if err := r.Reload(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.RLock()
+	selected, exists := r.pages[name]
+	r.mu.RUnlock()
+	if !exists {
+		return helpers.CreateErrorf("page %s not found", name)
+	}
+
+	if layout == "" || !selected.hasLayout {
+		return selected.set.ExecuteTemplate(w, name, data)
+	}
+	layoutName := "layouts/" + layout
+	if selected.set.Lookup(layoutName) == nil {
+		return helpers.CreateErrorf("layout %s not found", layout)
+	}
+	return selected.set.ExecuteTemplate(w, layoutName, data)
+}
@@ -0,0 +1,54 @@
+package request
+
+import (
+	"context"       // context provides cancellation of the batch.
+	"encoding/json" // json provides the raw response type.
+	"fmt"           // fmt provides formatting and printing functions.
+	"net/http"      // http provides the method constants.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/pool"    // pool provides the bounded concurrent requests.
+)
+
+// BatchWorkers is how many requests Batch sends at once.
+var BatchWorkers = 5
+
+// BatchRequest is one request of a Batch.
+type BatchRequest struct {
+	Method  string   // Method is GET, POST, PUT, or DELETE (default GET)
+	URL     string   // URL is the request URL
+	Body    any      // Body is marshaled to JSON for POST and PUT
+	Headers *Headers // Headers are merged with the default headers
+}
+
+// Batch sends requests concurrently, at most BatchWorkers at a time, each with the
+// usual retries. Results are in request order; a failed request does not stop the others.
+//
+// Example:
+//
+//	results := request.Batch(ctx, []request.BatchRequest{
+//	    {URL: "https://api.example.com/users/1"},
+//	    {URL: "https://api.example.com/users/2"},
+//	})
+//	for _, result := range results {
+//	    if result.Err == nil {
+//	        json.Unmarshal(result.Value, &users[result.Index])
+//	    }
+//	}
+func Batch(ctx context.Context, requests []BatchRequest) []pool.Result[json.RawMessage] {
+	log.Info(fmt.Sprintf("📦 Sending batch of %d HTTP requests", len(requests)))
+	return pool.Run(ctx, BatchWorkers, "http-batch", requests, func(ctx context.Context, batchRequest BatchRequest) (json.RawMessage, error) {
+		switch batchRequest.Method {
+		case http.MethodGet, "":
+			return GetWithContext(ctx, batchRequest.URL, batchRequest.Headers)
+		case http.MethodPost:
+			return PostWithContext(ctx, batchRequest.URL, batchRequest.Body, batchRequest.Headers)
+		case http.MethodPut:
+			return PutWithContext(ctx, batchRequest.URL, batchRequest.Body, batchRequest.Headers)
+		case http.MethodDelete:
+			return DeleteWithContext(ctx, batchRequest.URL, batchRequest.Headers)
+		}
+		return nil, helpers.CreateErrorf("unsupported batch request method %s", batchRequest.Method)
+	})
+}
@@ -8,9 +8,13 @@ import (
 	"fmt"           // fmt provides formatting and printing functions.
 	"io"            // io provides interfaces for I/O operations.
 	"net/http"      // http provides utilities for HTTP requests and responses.
+	"strconv"       // strconv provides Retry-After parsing.
+	"strings"       // strings provides header trimming.
 	"time"          // time provides functionality for timeouts and durations.
 
-	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/retry"   // retry provides the attempt loop and backoff.
 )
 
 // Headers type alias for map[string]string to store HTTP headers.
@@ -19,18 +23,23 @@ type Headers map[string]string
 // RequestConfig holds configuration parameters for HTTP requests.
 // This struct centralizes all request settings for better maintainability.
 type RequestConfig struct {
-	Timeout    time.Duration // Timeout specifies the maximum time for the entire request
-	MaxRetries int           // MaxRetries specifies maximum retry attempts for failed requests
-	RetryDelay time.Duration // RetryDelay specifies the delay between retry attempts
+	Timeout       time.Duration // Timeout specifies the maximum time for the entire request
+	MaxRetries    int           // MaxRetries specifies maximum retry attempts for failed requests
+	RetryDelay    time.Duration // RetryDelay specifies the delay between retry attempts
+	MaxRetryAfter time.Duration // MaxRetryAfter caps the wait a server can ask for with Retry-After (default 30s)
 }
 
+// defaultMaxRetryAfter caps Retry-After when RequestConfig.MaxRetryAfter is unset.
+const defaultMaxRetryAfter = 30 * time.Second
+
 // LoadConfig loads request configuration with defaults.
 // Returns a RequestConfig struct with default values.
 func LoadConfig() RequestConfig {
 	return RequestConfig{
-		Timeout:    30 * time.Second,
-		MaxRetries: 3,
-		RetryDelay: 1 * time.Second,
+		Timeout:       30 * time.Second,
+		MaxRetries:    3,
+		RetryDelay:    1 * time.Second,
+		MaxRetryAfter: defaultMaxRetryAfter,
 	}
 }
 
@@ -97,52 +106,73 @@ func shouldRetry(statusCode int, err error) bool {
 }
 
 // executeWithRetry executes an HTTP request with retry logic and context support.
+// The body is rewound before every retry, and a Retry-After header on 429 and 503
+// responses overrides the linear backoff, capped at MaxRetryAfter so a server cannot
+// block the caller for hours.
 // Returns the HTTP response or an error after all retry attempts.
 func executeWithRetry(ctx context.Context, req *http.Request, config RequestConfig) (*http.Response, error) {
-	var lastError error
-	var response *http.Response
-
 	client := createHTTPClient(config.Timeout)
+	maxRetryAfter := config.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = defaultMaxRetryAfter
+	}
 
-	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
-		// Check if context is cancelled before each attempt
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			// Continue with request
-		}
-
-		// Log retry attempt if not the first attempt
-		if attempt > 0 {
-			log.Warning(fmt.Sprintf("🔄 Retry attempt %d/%d for %s %s",
-				attempt, config.MaxRetries, req.Method, req.URL.String()))
-			time.Sleep(config.RetryDelay * time.Duration(attempt)) // Exponential backoff
+	attempt := 0
+	response, err := retry.DoValue(ctx, func(ctx context.Context) (*http.Response, error) {
+		attempt++
+		attemptRequest := req.WithContext(ctx)
+		if attempt > 1 && req.GetBody != nil {
+			// The previous attempt consumed the body, so send a fresh copy.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, retry.Permanent(helpers.WrapError(err, "failed to rewind request body"))
+			}
+			attemptRequest.Body = body
 		}
 
-		// Execute the request with context
-		reqWithContext := req.WithContext(ctx)
-		resp, err := client.Do(reqWithContext)
-
+		resp, err := client.Do(attemptRequest)
 		if err != nil {
-			lastError = err
-			log.Warning(fmt.Sprintf("⚠️  Request attempt %d failed: %v", attempt+1, err))
-			continue
+			log.Warning(fmt.Sprintf("⚠️  Request attempt %d failed: %v", attempt, err))
+			return nil, err
 		}
 
 		// Check if we should retry based on status code
 		if shouldRetry(resp.StatusCode, nil) {
-			lastError = fmt.Errorf("server returned %d status", resp.StatusCode)
+			statusErr := fmt.Errorf("server returned %d status", resp.StatusCode)
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
 			resp.Body.Close()
-			log.Warning(fmt.Sprintf("⚠️  Request attempt %d failed with status: %d", attempt+1, resp.StatusCode))
-			continue
+			log.Warning(fmt.Sprintf("⚠️  Request attempt %d failed with status: %d", attempt, resp.StatusCode))
+			if retryAfter > 0 {
+				return nil, retry.After(statusErr, min(retryAfter, maxRetryAfter))
+			}
+			return nil, statusErr
 		}
 
-		// Success - return the response
 		return resp, nil
+	},
+		retry.WithMaxAttempts(config.MaxRetries+1),
+		retry.WithLinearBackoff(config.RetryDelay, 0),
+		retry.WithOnRetry(func(attempt int, err error, delay time.Duration) {
+			log.Warning(fmt.Sprintf("🔄 Retry attempt %d/%d for %s %s in %v",
+				attempt, config.MaxRetries, req.Method, req.URL.String(), delay))
+		}),
+	)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
 	}
+	return response, nil
+}
 
-	return response, fmt.Errorf("request failed after %d attempts: %w", config.MaxRetries+1, lastError)
+// parseRetryAfter returns the delay of a Retry-After header in seconds, or 0.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 // handleResponse processes an HTTP response.
@@ -192,13 +222,13 @@ func handleResponse(response *http.Response) (json.RawMessage, error) {
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
 func Get(url string, headers *Headers) (json.RawMessage, error) {
-	return getWithContext(context.Background(), url, headers)
+	return GetWithContext(context.Background(), url, headers)
 }
 
-// getWithContext sends an HTTP GET request with context support for cancellation.
+// GetWithContext sends an HTTP GET request with context support for cancellation.
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
-func getWithContext(ctx context.Context, url string, headers *Headers) (json.RawMessage, error) {
+func GetWithContext(ctx context.Context, url string, headers *Headers) (json.RawMessage, error) {
 	// Validate URL
 	if err := validateURL(url); err != nil {
 		log.Error("❌ Invalid URL: " + err.Error())
@@ -247,17 +277,70 @@ func getWithContext(ctx context.Context, url string, headers *Headers) (json.Raw
 	return handleResponse(response)
 }
 
+// GetRawWithContext sends an HTTP GET request like GetWithContext but returns the
+// response body as is, for endpoints that do not return JSON (plain text, CSV, files).
+// User headers should set Accept, which otherwise asks for JSON.
+// Returns an error if the request fails or the status code is not 2xx.
+func GetRawWithContext(ctx context.Context, url string, headers *Headers) ([]byte, error) {
+	// Validate URL
+	if err := validateURL(url); err != nil {
+		log.Error("❌ Invalid URL: " + err.Error())
+		return nil, err
+	}
+
+	config := LoadConfig()
+	log.Info(fmt.Sprintf("🔍 Preparing raw GET request to %s (Timeout: %v, MaxRetries: %d)",
+		url, config.Timeout, config.MaxRetries))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		log.Error("❌ Failed to create GET request: " + err.Error())
+		return nil, err
+	}
+	for headerKey, headerValue := range mergeHeaders(headers) {
+		request.Header.Set(headerKey, headerValue)
+	}
+
+	response, err := executeWithRetry(ctx, request, config)
+	if err != nil {
+		log.Error("❌ GET request failed: " + err.Error())
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return handleRawResponse(response)
+}
+
+// handleRawResponse reads the body of a response that need not be JSON.
+// Returns the body with an error when the status code is not 2xx.
+func handleRawResponse(response *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Error("❌ Failed to read response body: " + err.Error())
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("📥 Response received - Status: %d, Size: %d bytes",
+		response.StatusCode, len(body)))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		log.Warning(fmt.Sprintf("⚠️  HTTP error response: %d %s",
+			response.StatusCode, http.StatusText(response.StatusCode)))
+		return body, fmt.Errorf("HTTP %d: %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+	return body, nil
+}
+
 // Post sends an HTTP POST request with a JSON body to the specified URL with context support.
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
 func Post(url string, body any, headers *Headers) (json.RawMessage, error) {
-	return postWithContext(context.Background(), url, body, headers)
+	return PostWithContext(context.Background(), url, body, headers)
 }
 
-// postWithContext sends an HTTP POST request with context support for cancellation.
+// PostWithContext sends an HTTP POST request with context support for cancellation.
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
-func postWithContext(ctx context.Context, url string, body any, headers *Headers) (json.RawMessage, error) {
+func PostWithContext(ctx context.Context, url string, body any, headers *Headers) (json.RawMessage, error) {
 	// Validate URL
 	if err := validateURL(url); err != nil {
 		log.Error("❌ Invalid URL: " + err.Error())
@@ -323,17 +406,57 @@ func postWithContext(ctx context.Context, url string, body any, headers *Headers
 	return handleResponse(response)
 }
 
+// PostRawWithContext sends an HTTP POST request with a JSON body like PostWithContext
+// but returns the response body as is, for endpoints that answer with plain text
+// (e.g. Slack webhooks reply "ok").
+// Returns an error if the request fails or the status code is not 2xx.
+func PostRawWithContext(ctx context.Context, url string, body any, headers *Headers) ([]byte, error) {
+	// Validate URL
+	if err := validateURL(url); err != nil {
+		log.Error("❌ Invalid URL: " + err.Error())
+		return nil, err
+	}
+
+	config := LoadConfig()
+	log.Info(fmt.Sprintf("📤 Preparing raw POST request to %s (Timeout: %v, MaxRetries: %d)",
+		url, config.Timeout, config.MaxRetries))
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		log.Error("❌ Failed to marshal POST body: " + err.Error())
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
+	if err != nil {
+		log.Error("❌ Failed to create POST request: " + err.Error())
+		return nil, err
+	}
+	for headerKey, headerValue := range mergeHeaders(headers) {
+		request.Header.Set(headerKey, headerValue)
+	}
+
+	response, err := executeWithRetry(ctx, request, config)
+	if err != nil {
+		log.Error("❌ POST request failed: " + err.Error())
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return handleRawResponse(response)
+}
+
 // Put sends an HTTP PUT request with a JSON body to the specified URL with context support.
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
 func Put(url string, body any, headers *Headers) (json.RawMessage, error) {
-	return putWithContext(context.Background(), url, body, headers)
+	return PutWithContext(context.Background(), url, body, headers)
 }
 
-// putWithContext sends an HTTP PUT request with context support for cancellation.
+// PutWithContext sends an HTTP PUT request with context support for cancellation.
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
-func putWithContext(ctx context.Context, url string, body any, headers *Headers) (json.RawMessage, error) {
+func PutWithContext(ctx context.Context, url string, body any, headers *Headers) (json.RawMessage, error) {
 	// Validate URL
 	if err := validateURL(url); err != nil {
 		log.Error("❌ Invalid URL: " + err.Error())
@@ -403,13 +526,13 @@ func putWithContext(ctx context.Context, url string, body any, headers *Headers)
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
 func Delete(url string, headers *Headers) (json.RawMessage, error) {
-	return deleteWithContext(context.Background(), url, headers)
+	return DeleteWithContext(context.Background(), url, headers)
 }
 
-// deleteWithContext sends an HTTP DELETE request with context support for cancellation.
+// DeleteWithContext sends an HTTP DELETE request with context support for cancellation.
 // Applies headers and returns the response body as json.RawMessage.
 // Returns an error if the request or response processing fails.
-func deleteWithContext(ctx context.Context, url string, headers *Headers) (json.RawMessage, error) {
+func DeleteWithContext(ctx context.Context, url string, headers *Headers) (json.RawMessage, error) {
 	// Validate URL
 	if err := validateURL(url); err != nil {
 		log.Error("❌ Invalid URL: " + err.Error())
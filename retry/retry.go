@@ -0,0 +1,308 @@
+// Package retry runs operations again after transient failures, with configurable
+// attempts, backoff, jitter, and retry conditions. It is used by the request client,
+// database startup and transactions, the email sender, and webhook delivery, and is
+// exported so applications stop writing their own retry loops.
+package retry
+
+import (
+	"context"   // context provides cancellation of attempts and backoff waits.
+	"errors"    // errors provides unwrapping of permanent and delayed errors.
+	"fmt"       // fmt provides formatting and printing functions.
+	"math/rand" // rand provides backoff jitter.
+	"time"      // time provides backoff durations and timers.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Backoff returns how long to wait after the given failed attempt (starting at 1).
+type Backoff func(attempt int) time.Duration
+
+// Constant waits delay between every attempt.
+func Constant(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// Linear waits base, 2×base, 3×base, ... capped at maximum (0 = no cap).
+func Linear(base, maximum time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return capDelay(base*time.Duration(attempt), maximum)
+	}
+}
+
+// Exponential waits base, 2×base, 4×base, ... capped at maximum (0 = no cap).
+func Exponential(base, maximum time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && (maximum <= 0 || delay < maximum); i++ {
+			delay *= 2
+		}
+		return capDelay(delay, maximum)
+	}
+}
+
+// capDelay limits delay to maximum when maximum is positive.
+func capDelay(delay, maximum time.Duration) time.Duration {
+	if maximum > 0 && delay > maximum {
+		return maximum
+	}
+	return delay
+}
+
+// config holds the options of one Do call.
+type config struct {
+	maxAttempts    int
+	backoff        Backoff
+	jitter         float64
+	retryIf        func(error) bool
+	onRetry        func(attempt int, err error, delay time.Duration)
+	attemptTimeout time.Duration
+	name           string
+}
+
+// Option configures Do.
+type Option func(*config)
+
+// WithMaxAttempts sets the total number of attempts, including the first (default 3).
+func WithMaxAttempts(attempts int) Option {
+	return func(c *config) {
+		c.maxAttempts = max(attempts, 1)
+	}
+}
+
+// WithBackoff sets the delay between attempts.
+func WithBackoff(backoff Backoff) Option {
+	return func(c *config) {
+		c.backoff = backoff
+	}
+}
+
+// WithExponentialBackoff doubles the delay after each failure, starting at base and
+// capped at maximum. This is the default, with 100ms and 10s.
+func WithExponentialBackoff(base, maximum time.Duration) Option {
+	return WithBackoff(Exponential(base, maximum))
+}
+
+// WithLinearBackoff grows the delay by base after each failure, capped at maximum.
+func WithLinearBackoff(base, maximum time.Duration) Option {
+	return WithBackoff(Linear(base, maximum))
+}
+
+// WithConstantBackoff waits delay between every attempt.
+func WithConstantBackoff(delay time.Duration) Option {
+	return WithBackoff(Constant(delay))
+}
+
+// WithJitter randomizes each delay by up to ±fraction (e.g. 0.2 for ±20%), so many
+// clients failing together do not retry in lockstep.
+func WithJitter(fraction float64) Option {
+	return func(c *config) {
+		c.jitter = min(max(fraction, 0), 1)
+	}
+}
+
+// WithRetryIf retries only errors for which retryable returns true. By default every
+// error is retried except Permanent errors and context cancellation.
+func WithRetryIf(retryable func(err error) bool) Option {
+	return func(c *config) {
+		c.retryIf = retryable
+	}
+}
+
+// WithOnRetry calls fn before waiting to retry, e.g. for logging or metrics.
+// Setting it replaces the default warning log.
+func WithOnRetry(fn func(attempt int, err error, delay time.Duration)) Option {
+	return func(c *config) {
+		c.onRetry = fn
+	}
+}
+
+// WithAttemptTimeout bounds each attempt separately from the overall context.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.attemptTimeout = timeout
+	}
+}
+
+// WithName names the operation in the default retry log.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// permanentError marks an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not retryable, stopping Do immediately. Do returns the
+// original error. Permanent(nil) returns nil.
+//
+// Example:
+//
+//	if response.StatusCode == http.StatusBadRequest {
+//	    return retry.Permanent(helpers.CreateError("invalid payload"))
+//	}
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// delayedError carries a server-requested delay, e.g. from a Retry-After header.
+type delayedError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *delayedError) Error() string { return e.err.Error() }
+func (e *delayedError) Unwrap() error { return e.err }
+
+// After marks err as retryable after delay instead of the backoff delay, e.g. to
+// honor a Retry-After header. Do returns the original error. After(nil, d) returns nil.
+func After(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &delayedError{err: err, delay: delay}
+}
+
+// unwrapMarkers removes the Permanent and After markers from the outside of err.
+func unwrapMarkers(err error) error {
+	for {
+		switch marked := err.(type) {
+		case *permanentError:
+			err = marked.err
+		case *delayedError:
+			err = marked.err
+		default:
+			return err
+		}
+	}
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, or the attempts
+// run out, waiting between attempts according to the backoff. It returns nil on
+// success and otherwise the last error of fn. If ctx ends while waiting, the
+// context error is returned, wrapped with the last error of fn.
+//
+// Example:
+//
+//	err := retry.Do(ctx, func(ctx context.Context) error {
+//	    return client.Publish(ctx, message)
+//	},
+//	    retry.WithMaxAttempts(5),
+//	    retry.WithExponentialBackoff(200*time.Millisecond, 5*time.Second),
+//	    retry.WithRetryIf(func(err error) bool { return !errors.Is(err, ErrInvalidMessage) }),
+//	)
+func Do(ctx context.Context, fn func(ctx context.Context) error, options ...Option) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, options...)
+	return err
+}
+
+// DoValue is Do for operations that return a value.
+//
+// Example:
+//
+//	db, err := retry.DoValue(ctx, openDatabase, retry.WithMaxAttempts(5))
+func DoValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error), options ...Option) (T, error) {
+	settings := config{maxAttempts: 3, backoff: Exponential(100*time.Millisecond, 10*time.Second)}
+	for _, option := range options {
+		option(&settings)
+	}
+
+	var zero T
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		value, err := call(ctx, &settings, fn)
+		if err == nil {
+			return value, nil
+		}
+		if attempt >= settings.maxAttempts || !settings.retryable(ctx, err) {
+			return zero, unwrapMarkers(err)
+		}
+
+		delay := settings.delay(attempt, err)
+		if settings.onRetry != nil {
+			settings.onRetry(attempt, unwrapMarkers(err), delay)
+		} else {
+			log.Warning(fmt.Sprintf("🔄 %s attempt %d/%d failed: %v, retrying in %v",
+				helpers.DefaultIfEmpty(settings.name, "Operation"), attempt, settings.maxAttempts, unwrapMarkers(err), delay))
+		}
+
+		if sleepErr := Sleep(ctx, delay); sleepErr != nil {
+			return zero, helpers.WrapErrorf(sleepErr, "retry stopped after %d attempt(s), last error: %v", attempt, unwrapMarkers(err))
+		}
+	}
+}
+
+// call runs one attempt, bounded by the attempt timeout when set.
+func call[T any](ctx context.Context, settings *config, fn func(ctx context.Context) (T, error)) (T, error) {
+	if settings.attemptTimeout <= 0 {
+		return fn(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, settings.attemptTimeout)
+	defer cancel()
+	return fn(attemptCtx)
+}
+
+// retryable reports whether err should be retried.
+func (c *config) retryable(ctx context.Context, err error) bool {
+	if IsPermanent(err) || ctx.Err() != nil {
+		return false
+	}
+	if c.retryIf != nil {
+		return c.retryIf(unwrapMarkers(err))
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// delay returns the wait after a failed attempt, honoring After and jitter.
+func (c *config) delay(attempt int, err error) time.Duration {
+	var delayed *delayedError
+	if errors.As(err, &delayed) {
+		return delayed.delay
+	}
+
+	delay := time.Duration(0)
+	if c.backoff != nil {
+		delay = c.backoff(attempt)
+	}
+	if c.jitter > 0 && delay > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * c.jitter * float64(delay))
+	}
+	return max(delay, 0)
+}
+
+// Sleep waits for delay or until ctx ends, returning the context error in that case.
+func Sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,399 @@
+package scheduler
+
+import (
+	"fmt"         // fmt provides formatting of log messages.
+	"sync"        // sync provides guarding of the next run time and one-time shutdown.
+	"sync/atomic" // atomic provides the count of runs in progress.
+	"time"        // time provides run timers.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// Job is a handle to a scheduled function, returned by Scheduler.Add and Every. It
+// reports the job's state and lets it be paused, resumed, triggered, or stopped
+// without restarting the service.
+type Job struct {
+	name      string
+	spec      string
+	schedule  Schedule
+	task      func() error
+	state     *SchedulerState
+	config    SchedulerConfig
+	scheduler *Scheduler     // scheduler is the owning Scheduler, or nil for Every
+	location  *time.Location // location is the time zone the schedule follows
+
+	mu       sync.RWMutex
+	nextRun  time.Time
+	paused   bool
+	failures int           // failures counts consecutive failed runs; used only by the run loop
+	panics   int           // panics counts consecutive panics for the circuit breaker
+	runs     int           // runs counts started runs, so a finished schedule is not reported as empty
+	active   atomic.Int32  // active counts runs in progress
+	runNow   chan struct{} // runNow holds a pending RunNow request
+	stop     chan struct{} // stop is closed to end the run loop
+	stopOnce sync.Once
+	done     chan struct{} // done is closed when the run loop has returned
+}
+
+// newJob creates a job that is not yet running.
+func newJob(name, spec string, schedule Schedule, task func() error, config SchedulerConfig) *Job {
+	location := config.Location
+	if location == nil {
+		location = DefaultLocation()
+	}
+	// Report the first run before the run loop has started
+	nextRun := schedule.Next(time.Now().In(location))
+	if config.RunInstant {
+		nextRun = time.Now()
+	}
+	return &Job{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		task:     task,
+		config:   config,
+		location: location,
+		nextRun:  nextRun,
+		state:    NewSchedulerState(),
+		runNow:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Every runs fn at the given interval in the background, optionally once right away,
+// and returns its handle. Unlike RunFunctionAtInterval it does not block or install a
+// signal handler; stop it with Stop during shutdown.
+//
+// Example:
+//
+//	job, err := scheduler.Every("sync-rates", 10*time.Minute, true, syncExchangeRates)
+//	if err != nil {
+//	    return err
+//	}
+//	defer job.Stop()
+//
+//	job.Pause()  // e.g. from an admin endpoint while the rates API is down
+//	job.Resume()
+//	job.RunNow() // refresh immediately without waiting for the next tick
+func Every(name string, interval time.Duration, runInstant bool, fn func()) (*Job, error) {
+	if err := validateInterval(interval); err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("job %s has no function", name)
+	}
+
+	job := newJob(name, interval.String(), intervalSchedule{interval: interval}, withoutError(fn), SchedulerConfig{RunInstant: runInstant})
+	go job.run()
+
+	log.Info(fmt.Sprintf("⏰ Job %s scheduled every %v. Run instantly: %v", name, interval, runInstant))
+	return job, nil
+}
+
+// RunAt runs fn once at t, or right away when t has passed, and returns a handle
+// whose Stop cancels it. The run has the same panic recovery, metrics, and state as
+// scheduled jobs; Done is closed once it has run or been cancelled.
+//
+// Example:
+//
+//	tomorrow := time.Now().AddDate(0, 0, 1)
+//	reminder := scheduler.RunAt(time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, time.Local), sendReminder)
+//	// the appointment was cancelled
+//	reminder.Stop()
+func RunAt(t time.Time, fn func()) *Job {
+	config := SchedulerConfig{RunInstant: !t.After(time.Now())}
+	job := newJob("delayed execution", t.Format(time.RFC3339), onceSchedule{at: t}, withoutError(fn), config)
+	go job.run()
+
+	log.Debug(fmt.Sprintf("⏰ Delayed function scheduled for %s", t.Format(time.RFC3339)))
+	return job
+}
+
+// RunAfter runs fn once after delay and returns a handle whose Stop cancels it.
+//
+// Example:
+//
+//	expiry := scheduler.RunAfter(10*time.Minute, func() { otpStore.Delete(phone) })
+//	// the OTP was verified in time
+//	expiry.Stop()
+func RunAfter(delay time.Duration, fn func()) *Job {
+	return RunAt(time.Now().Add(delay), fn)
+}
+
+// withoutError adapts a function without a result to a task.
+func withoutError(fn func()) func() error {
+	return func() error {
+		fn()
+		return nil
+	}
+}
+
+// Name returns the name the job was added under.
+func (job *Job) Name() string {
+	return job.name
+}
+
+// Spec returns the schedule spec the job was added with.
+func (job *Job) Spec() string {
+	return job.spec
+}
+
+// NextRun returns when the job runs next, or the zero time once it has stopped.
+func (job *Job) NextRun() time.Time {
+	job.mu.RLock()
+	defer job.mu.RUnlock()
+	return job.nextRun
+}
+
+// Location returns the time zone the job's schedule follows.
+func (job *Job) Location() *time.Location {
+	return job.location
+}
+
+// State returns the job's execution state, e.g. for State().HealthCheck.
+func (job *Job) State() *SchedulerState {
+	return job.state
+}
+
+// Status returns the job's SchedulerState status with its name, spec, time zone, next
+// run, and number of runs in progress.
+func (job *Job) Status() map[string]interface{} {
+	status := job.state.GetStatus()
+	status["name"] = job.name
+	status["spec"] = job.spec
+	status["next_run"] = job.NextRun()
+	status["location"] = job.location.String()
+	status["running"] = job.active.Load()
+	return status
+}
+
+// Pause skips the job's scheduled runs until Resume is called. A run in progress
+// finishes normally.
+func (job *Job) Pause() {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if !job.paused {
+		job.paused = true
+		job.state.Pause()
+		log.Warning(fmt.Sprintf("⏸️ Job %s paused", job.name))
+	}
+}
+
+// Resume continues scheduled runs of a paused job from its next scheduled time.
+func (job *Job) Resume() {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	if job.paused {
+		job.paused = false
+		job.state.Resume()
+		log.Info(fmt.Sprintf("▶️ Job %s resumed", job.name))
+	}
+}
+
+// Paused reports whether the job is paused.
+func (job *Job) Paused() bool {
+	job.mu.RLock()
+	defer job.mu.RUnlock()
+	return job.paused
+}
+
+// RunNow runs the job without changing its schedule. It also works while the job is
+// paused. While a run is in progress it follows the overlap policy, except that a run
+// that would be skipped is queued until the current one finishes; requests made while
+// one is already pending are merged into it.
+func (job *Job) RunNow() {
+	select {
+	case job.runNow <- struct{}{}:
+	default:
+	}
+}
+
+// Stop stops the job and removes it from its Scheduler. It does not wait for a run in
+// progress, so a job may stop itself; use Done to wait. Stopping twice is harmless.
+func (job *Job) Stop() {
+	if job.scheduler != nil {
+		job.scheduler.unregister(job)
+	}
+	job.shutdown()
+}
+
+// Done returns a channel closed once the job has stopped and its last run has finished.
+func (job *Job) Done() <-chan struct{} {
+	return job.done
+}
+
+// setNextRun records the upcoming run time.
+func (job *Job) setNextRun(next time.Time) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.nextRun = next
+}
+
+// runOutcome is the result of one run, reported back to the run loop.
+type runOutcome struct {
+	panicked bool
+	err      error
+}
+
+// run starts the job at each scheduled time, applying its overlap policy while a run
+// is in progress, until it is stopped, its schedule ends, or its circuit breaker
+// trips. It returns once every started run has finished.
+func (job *Job) run() {
+	defer close(job.done)
+	defer job.state.Stop()
+	defer job.setNextRun(time.Time{})
+
+	finished := make(chan runOutcome)
+	running, queued := 0, false
+
+	// Runs execute on their own goroutines so a due run can see the previous one
+	start := func() {
+		running++
+		job.runs++
+		job.active.Add(1)
+		go func() {
+			defer job.active.Add(-1)
+			panicked, err := runTask(job.task, job.name)
+			finished <- runOutcome{panicked: panicked, err: err}
+		}()
+	}
+	// trigger starts a due run or applies the overlap policy; a RunNow request is
+	// queued rather than skipped
+	trigger := func(manual bool) {
+		switch {
+		case running == 0:
+			start()
+		case job.config.Overlap == OverlapConcurrent && (job.config.MaxConcurrent <= 0 || running < job.config.MaxConcurrent):
+			start()
+		case manual && queued:
+			// Merged into the run already queued
+		case (job.config.Overlap == OverlapQueue || manual) && !queued:
+			queued = true
+			log.Debug(fmt.Sprintf("⏳ Job %s is still running, queueing run", job.name))
+		default:
+			job.state.RecordSkip()
+			log.Warning(fmt.Sprintf("⏭️ Job %s is still running, skipping run (total skipped: %d)", job.name, job.state.SkippedCount))
+		}
+	}
+	// Wait for runs in progress before returning, so Done means the job is idle
+	defer func() {
+		for ; running > 0; running-- {
+			job.record(<-finished)
+		}
+	}()
+
+	if job.config.RunInstant {
+		start()
+	}
+
+	var slot time.Time
+	var period time.Duration
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	// schedule sets the timer for the next slot and reports whether there is one
+	schedule := func() bool {
+		// Search from the last slot when jitter ran it early, so it is not run twice
+		after := time.Now()
+		if after.Before(slot) {
+			after = slot
+		}
+		slot = job.schedule.Next(after.In(job.location))
+		if slot.IsZero() {
+			if job.runs > 0 {
+				log.Debug(fmt.Sprintf("✅ Job %s has no runs left, stopping it", job.name))
+			} else {
+				log.Warning(fmt.Sprintf("⚠️ Job %s has no upcoming run, stopping it", job.name))
+			}
+			return false
+		}
+		period = job.schedule.Next(slot).Sub(slot)
+		next := slot.Add(job.config.jitterOffset(period))
+		job.setNextRun(next)
+		timer.Reset(time.Until(next))
+		return true
+	}
+
+	if !schedule() {
+		return
+	}
+	for {
+		select {
+		case <-timer.C:
+			if job.Paused() {
+				log.Debug(fmt.Sprintf("⏭️ Job %s is paused, skipping run", job.name))
+			} else {
+				trigger(false)
+			}
+			if !schedule() {
+				return
+			}
+
+		case <-job.runNow:
+			trigger(true)
+
+		case outcome := <-finished:
+			running--
+			if !job.record(outcome) {
+				return
+			}
+			// Back off after consecutive failures instead of keeping the schedule
+			if delay := job.config.backoffDelay(period, job.failures); delay > 0 {
+				slot = time.Now().Add(delay)
+				job.setNextRun(slot)
+				timer.Reset(delay)
+				log.Warning(fmt.Sprintf("⏳ Job %s backing off (consecutive failures: %d), next run in %v", job.name, job.failures, delay))
+			}
+			if queued {
+				queued = false
+				trigger(true)
+			}
+
+		case <-job.stop:
+			// Cancelled one-shot runs are routine, e.g. an OTP verified before it expired
+			if _, once := job.schedule.(onceSchedule); once {
+				log.Debug(fmt.Sprintf("🛑 Job %s cancelled", job.name))
+			} else {
+				log.Info(fmt.Sprintf("🛑 Job %s stopped", job.name))
+			}
+			return
+		}
+	}
+}
+
+// record updates the job's state with the outcome of a run. It returns false when
+// MaxPanicRecovery consecutive panics mean the job should stop.
+func (job *Job) record(outcome runOutcome) bool {
+	switch {
+	case outcome.err == nil:
+		job.state.RecordExecution()
+		job.failures, job.panics = 0, 0
+		return true
+	case outcome.panicked:
+		job.state.RecordPanic(outcome.err)
+		job.failures++
+		job.panics++
+	default:
+		job.state.RecordError(outcome.err)
+		job.failures++
+		job.panics = 0
+		log.Error(fmt.Sprintf("❌ Job %s failed: %v", job.name, outcome.err))
+	}
+
+	// Circuit breaker: stop the job after too many consecutive panics
+	if job.config.MaxPanicRecovery > 0 && job.panics >= job.config.MaxPanicRecovery {
+		log.Error(fmt.Sprintf("❌ Job %s panicked %d times in a row, stopping it for safety", job.name, job.panics))
+		if job.scheduler != nil {
+			job.scheduler.unregister(job)
+		}
+		return false
+	}
+	log.Warning(fmt.Sprintf("⚠️ Job %s encountered issues but remains scheduled", job.name))
+	return true
+}
+
+// shutdown ends the run loop once; the loop closes done when it returns.
+func (job *Job) shutdown() {
+	job.stopOnce.Do(func() { close(job.stop) })
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"fmt"  // fmt provides formatting of log messages and errors.
+	"sort" // sort provides ordering of listed jobs by name.
+	"sync" // sync provides thread-safe job registration.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// Scheduler runs many named jobs, each on its own schedule, and lets them be listed,
+// inspected, and removed while the application runs.
+type Scheduler struct {
+	mu      sync.RWMutex
+	jobs    map[string]*Job
+	stopped bool
+}
+
+// New creates an empty Scheduler. Jobs start running as soon as they are added.
+//
+// Example:
+//
+//	jobs := scheduler.New()
+//	jobs.Add("cleanup-sessions", "15m", cleanupExpiredSessions)
+//	jobs.Add("daily-report", "0 6 * * *", sendDailyReport)
+//	defer jobs.Stop()
+func New() *Scheduler {
+	return &Scheduler{jobs: make(map[string]*Job)}
+}
+
+// Add registers fn under name and starts running it on the schedule described by spec
+// (see ParseSpec). Panics are recovered and recorded in the job's state, so one failing
+// run does not stop the schedule. Names must be unique; remove a job before adding it again.
+//
+// Example:
+//
+//	job, err := jobs.Add("sync-rates", "@every 10m", syncExchangeRates)
+//	if err != nil {
+//	    log.Fatal(err.Error())
+//	}
+//	fmt.Println("First run:", job.NextRun())
+func (s *Scheduler) Add(name, spec string, fn func()) (*Job, error) {
+	return s.AddWithConfig(name, spec, fn, SchedulerConfig{})
+}
+
+// AddWithConfig is Add with per-job settings: RunInstant runs the job once when it is
+// added, Jitter or JitterPercent spread its runs so many instances of a service do
+// not hit shared resources at the same moment, Location sets the time zone of a cron
+// spec, and Overlap decides whether a run due while the previous one is still going
+// is skipped (the default, counted in SkippedCount), queued, or run concurrently up to
+// MaxConcurrent. Interval and EnableGracefulShutdown are not used; the spec sets the
+// schedule and the Scheduler is stopped with Stop.
+//
+// Example:
+//
+//	jobs.AddWithConfig("refresh-cache", "5m", refreshCache, scheduler.SchedulerConfig{
+//	    RunInstant:    true,
+//	    JitterPercent: 10, // each run within ±30s of its slot
+//	})
+//
+//	jobs.AddWithConfig("import-orders", "1m", importOrders, scheduler.SchedulerConfig{
+//	    Overlap:       scheduler.OverlapConcurrent,
+//	    MaxConcurrent: 3, // slow imports may overlap, but never more than three at once
+//	})
+//
+//	dar, _ := time.LoadLocation("Africa/Dar_es_Salaam")
+//	jobs.AddWithConfig("opening-report", "0 8 * * mon-fri", sendOpeningReport, scheduler.SchedulerConfig{
+//	    Location: dar, // 08:00 in Dar es Salaam whatever the server's zone
+//	})
+func (s *Scheduler) AddWithConfig(name, spec string, fn func(), config SchedulerConfig) (*Job, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("job %s has no function", name)
+	}
+	return s.AddTask(name, spec, withoutError(fn), config)
+}
+
+// AddTask is AddWithConfig for a task that reports failure by returning an error.
+// Failed runs are counted in the job's state, and with MaxBackoff set each consecutive
+// failure or panic doubles the wait before the next run, up to MaxBackoff, until a run
+// succeeds. MaxPanicRecovery stops the job after that many consecutive panics.
+//
+// Example:
+//
+//	jobs.AddTask("sync-payments", "1m", syncPayments, scheduler.SchedulerConfig{
+//	    MaxBackoff:       30 * time.Minute, // 2m, 4m, 8m, ... while the provider is down
+//	    MaxPanicRecovery: 3,
+//	})
+func (s *Scheduler) AddTask(name, spec string, task func() error, config SchedulerConfig) (*Job, error) {
+	if name == "" {
+		return nil, fmt.Errorf("job name is required")
+	}
+	if task == nil {
+		return nil, fmt.Errorf("job %s has no function", name)
+	}
+	schedule, err := ParseSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return nil, fmt.Errorf("scheduler is stopped")
+	}
+	if _, exists := s.jobs[name]; exists {
+		return nil, fmt.Errorf("job %s already exists", name)
+	}
+
+	job := newJob(name, spec, schedule, task, config)
+	job.scheduler = s
+	s.jobs[name] = job
+	go job.run()
+
+	log.Info(fmt.Sprintf("⏰ Job %s scheduled (%s)", name, spec))
+	return job, nil
+}
+
+// Remove stops the named job and unregisters it, waiting for a run in progress to
+// finish. It returns false when no job has that name.
+func (s *Scheduler) Remove(name string) bool {
+	s.mu.Lock()
+	job, exists := s.jobs[name]
+	delete(s.jobs, name)
+	s.mu.Unlock()
+
+	if !exists {
+		return false
+	}
+	job.shutdown()
+	<-job.done
+	log.Info(fmt.Sprintf("🗑️ Job %s removed", name))
+	return true
+}
+
+// unregister removes job if it is still registered under its name, so Job.Stop
+// does not remove a newer job added with the same name.
+func (s *Scheduler) unregister(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs[job.name] == job {
+		delete(s.jobs, job.name)
+	}
+}
+
+// Job returns the named job, or false when no job has that name.
+func (s *Scheduler) Job(name string) (*Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, exists := s.jobs[name]
+	return job, exists
+}
+
+// Jobs returns the registered jobs ordered by name.
+//
+// Example:
+//
+//	for _, job := range jobs.Jobs() {
+//	    fmt.Printf("%s (%s) next run %s\n", job.Name(), job.Spec(), job.NextRun())
+//	}
+func (s *Scheduler) Jobs() []*Job {
+	s.mu.RLock()
+	list := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		list = append(list, job)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	return list
+}
+
+// Status returns GetStatus of every job, keyed by job name, for a status endpoint.
+func (s *Scheduler) Status() map[string]map[string]interface{} {
+	jobs := s.Jobs()
+	status := make(map[string]map[string]interface{}, len(jobs))
+	for _, job := range jobs {
+		status[job.name] = job.Status()
+	}
+	return status
+}
+
+// Stop stops every job, waiting for runs in progress to finish. Jobs cannot be added
+// to a stopped Scheduler.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.jobs = make(map[string]*Job)
+	s.stopped = true
+	s.mu.Unlock()
+
+	for _, job := range jobs {
+		job.shutdown()
+	}
+	for _, job := range jobs {
+		<-job.done
+	}
+	log.Info(fmt.Sprintf("🛑 Scheduler stopped (%d jobs)", len(jobs)))
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"   // context provides support for cancellation and timeouts.
 	"fmt"       // fmt provides formatting and printing functions.
+	"math/rand" // rand provides run time jitter.
 	"os"        // os provides file system operations and signal handling.
 	"os/signal" // signal provides system signal handling.
 	"runtime"   // runtime provides access to system resources.
@@ -10,16 +11,36 @@ import (
 	"syscall"   // syscall provides system call constants.
 	"time"      // time provides functionality for handling intervals and sleeping.
 
-	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+	"github.com/hekimapro/utils/health"  // health provides the check function type.
+	"github.com/hekimapro/utils/helpers" // helpers provides environment variable access.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/metrics" // metrics provides run counters and durations.
+)
+
+// OverlapPolicy decides what happens when a job's run is due while its previous run
+// is still in progress.
+type OverlapPolicy int
+
+// Overlap policies.
+const (
+	OverlapSkip       OverlapPolicy = iota // OverlapSkip drops the due run and records it as skipped (default)
+	OverlapQueue                           // OverlapQueue runs the due run once the current one finishes, queueing at most one
+	OverlapConcurrent                      // OverlapConcurrent starts the due run alongside, up to MaxConcurrent runs
 )
 
 // SchedulerConfig holds configuration parameters for the scheduler.
 // This struct centralizes all scheduler settings for better maintainability.
 type SchedulerConfig struct {
-	Interval               time.Duration // Interval specifies the duration between function executions
-	RunInstant             bool          // RunInstant specifies whether to run the function immediately before the first interval
-	EnableGracefulShutdown bool          // EnableGracefulShutdown specifies whether to handle OS signals for graceful shutdown
-	MaxPanicRecovery       int           // MaxPanicRecovery specifies maximum consecutive panics before stopping (0 = unlimited)
+	Interval               time.Duration  // Interval specifies the duration between function executions
+	RunInstant             bool           // RunInstant specifies whether to run the function immediately before the first interval
+	EnableGracefulShutdown bool           // EnableGracefulShutdown specifies whether to handle OS signals for graceful shutdown
+	MaxPanicRecovery       int            // MaxPanicRecovery specifies maximum consecutive panics before stopping (0 = unlimited)
+	Jitter                 time.Duration  // Jitter shifts each run by a random amount within ±Jitter (0 = none)
+	JitterPercent          float64        // JitterPercent shifts each run within ±this percentage of the interval when Jitter is unset, e.g. 10
+	MaxBackoff             time.Duration  // MaxBackoff enables backoff after a failed run: the wait doubles from the interval per consecutive failure, up to this cap (0 = keep the schedule)
+	Location               *time.Location // Location is the time zone cron schedules follow (nil = DefaultLocation)
+	Overlap                OverlapPolicy  // Overlap decides what a job does when a run is due while the previous one is still running
+	MaxConcurrent          int            // MaxConcurrent caps simultaneous runs under OverlapConcurrent (0 = unlimited)
 }
 
 // LoadConfig loads scheduler configuration with defaults.
@@ -33,6 +54,61 @@ func LoadConfig(interval time.Duration, runInstant bool) SchedulerConfig {
 	}
 }
 
+// TimezoneVariable names the environment variable holding the default time zone of
+// cron schedules, e.g. "Africa/Dar_es_Salaam".
+const TimezoneVariable = "SCHEDULER_TIMEZONE"
+
+// DefaultLocation returns the time zone named by SCHEDULER_TIMEZONE, or the server's
+// local zone when it is unset or unknown. Zone names are looked up in the system's
+// time zone database; import _ "time/tzdata" to embed one in minimal images.
+//
+// Example:
+//
+//	// SCHEDULER_TIMEZONE=Africa/Dar_es_Salaam
+//	tomorrow := time.Now().In(scheduler.DefaultLocation()).AddDate(0, 0, 1)
+//	scheduler.RunAt(time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, tomorrow.Location()), sendReminder)
+func DefaultLocation() *time.Location {
+	name := helpers.GetENVValue(TimezoneVariable)
+	if name == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Warning(fmt.Sprintf("⚠️ Invalid %s %q, using the local time zone: %v", TimezoneVariable, name, err))
+		return time.Local
+	}
+	return location
+}
+
+// jitterOffset returns a random offset for one run within ±Jitter, or within
+// ±JitterPercent of period when Jitter is unset. The spread is capped at half the
+// period so a run never passes its neighbours.
+func (config SchedulerConfig) jitterOffset(period time.Duration) time.Duration {
+	spread := config.Jitter
+	if spread <= 0 && config.JitterPercent > 0 {
+		spread = time.Duration(float64(period) * config.JitterPercent / 100)
+	}
+	spread = min(spread, period/2)
+	if spread <= 0 {
+		return 0
+	}
+	return time.Duration((rand.Float64()*2 - 1) * float64(spread))
+}
+
+// backoffDelay returns the wait before the next run after failures consecutive
+// failed runs: period doubled per failure and capped at MaxBackoff. It returns zero
+// when backoff is disabled or the last run succeeded.
+func (config SchedulerConfig) backoffDelay(period time.Duration, failures int) time.Duration {
+	if config.MaxBackoff <= 0 || failures <= 0 || period <= 0 {
+		return 0
+	}
+	delay := period
+	for i := 0; i < failures && delay < config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return max(min(delay, config.MaxBackoff), period)
+}
+
 // validateInterval validates that the interval is a positive duration.
 // Returns an error if the interval is zero or negative.
 func validateInterval(interval time.Duration) error {
@@ -42,9 +118,42 @@ func validateInterval(interval time.Duration) error {
 	return nil
 }
 
-// runWithRecovery executes a function with panic recovery and logging.
+// Scheduler metrics, labelled by operation name.
+var (
+	schedulerRuns = metrics.NewCounter("scheduler_runs_total",
+		"Scheduled function executions, by operation and result (success, error, or panic).", "operation", "result")
+	schedulerDuration = metrics.NewHistogram("scheduler_run_duration_seconds",
+		"Scheduled function execution time in seconds, by operation.", []float64{0.01, 0.1, 0.5, 1, 5, 15, 60, 300, 900}, "operation")
+)
+
+// RunWithRecovery executes a function with panic recovery and logging, recording
+// the run in the scheduler metrics under operationName.
 // Returns true if the function completed successfully, false if it panicked.
-func runWithRecovery(functionToRun func(), operationName string) (success bool) {
+func RunWithRecovery(functionToRun func(), operationName string) (success bool) {
+	panicked, _ := runTask(func() error {
+		functionToRun()
+		return nil
+	}, operationName)
+	return !panicked
+}
+
+// runTask executes a task with panic recovery and logging, recording the run in the
+// scheduler metrics under operationName with result success, error, or panic.
+// Returns the task's error, or an error describing the panic with panicked set.
+func runTask(task func() error, operationName string) (panicked bool, err error) {
+	start := time.Now()
+	defer func() {
+		// Record the run whether it completed, failed, or panicked
+		result := "success"
+		if panicked {
+			result = "panic"
+		} else if err != nil {
+			result = "error"
+		}
+		schedulerRuns.Inc(operationName, result)
+		schedulerDuration.ObserveDuration(start, operationName)
+	}()
+
 	defer func() {
 		if r := recover(); r != nil {
 			// Log the panic with detailed information
@@ -55,13 +164,12 @@ func runWithRecovery(functionToRun func(), operationName string) (success bool)
 			n := runtime.Stack(buf, false)
 			log.Warning(fmt.Sprintf("Stack trace: %s", string(buf[:n])))
 
-			success = false
+			panicked, err = true, fmt.Errorf("panic: %v", r)
 		}
 	}()
 
-	// Execute the function
-	functionToRun()
-	return true
+	// Execute the task
+	return false, task()
 }
 
 // SchedulerState holds the current state of the scheduler for monitoring.
@@ -70,9 +178,14 @@ type SchedulerState struct {
 	StartTime      time.Time // StartTime records when the scheduler started
 	ExecutionCount int64     // ExecutionCount tracks total successful executions
 	PanicCount     int64     // PanicCount tracks total panic recoveries
+	ErrorCount     int64     // ErrorCount tracks total runs that returned an error
+	Failures       int       // Failures counts consecutive failed runs, reset by a successful one
+	SkippedCount   int64     // SkippedCount tracks runs skipped because the previous run was still in progress
+	LastSkipped    time.Time // LastSkipped records when a run was last skipped
 	LastExecution  time.Time // LastExecution records when the function was last run
 	LastError      string    // LastError stores the last error message
 	IsRunning      bool      // IsRunning indicates if the scheduler is active
+	IsPaused       bool      // IsPaused indicates that scheduled runs are being skipped
 }
 
 // NewSchedulerState creates and initializes a new SchedulerState.
@@ -89,6 +202,16 @@ func (s *SchedulerState) RecordExecution() {
 	defer s.mu.Unlock()
 	s.ExecutionCount++
 	s.LastExecution = time.Now()
+	s.Failures = 0
+}
+
+// RecordError records a run that returned an error.
+func (s *SchedulerState) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ErrorCount++
+	s.Failures++
+	s.LastError = err.Error()
 }
 
 // RecordPanic records a panic occurrence.
@@ -96,9 +219,18 @@ func (s *SchedulerState) RecordPanic(err interface{}) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.PanicCount++
+	s.Failures++
 	s.LastError = fmt.Sprintf("%v", err)
 }
 
+// RecordSkip records a run skipped because the previous run was still in progress.
+func (s *SchedulerState) RecordSkip() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.SkippedCount++
+	s.LastSkipped = time.Now()
+}
+
 // Stop marks the scheduler as stopped.
 func (s *SchedulerState) Stop() {
 	s.mu.Lock()
@@ -106,6 +238,20 @@ func (s *SchedulerState) Stop() {
 	s.IsRunning = false
 }
 
+// Pause marks the scheduler as paused.
+func (s *SchedulerState) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.IsPaused = true
+}
+
+// Resume marks the scheduler as no longer paused.
+func (s *SchedulerState) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.IsPaused = false
+}
+
 // GetStatus returns the current scheduler status for monitoring.
 func (s *SchedulerState) GetStatus() map[string]interface{} {
 	s.mu.RLock()
@@ -113,18 +259,54 @@ func (s *SchedulerState) GetStatus() map[string]interface{} {
 
 	return map[string]interface{}{
 		"is_running":      s.IsRunning,
+		"is_paused":       s.IsPaused,
 		"start_time":      s.StartTime,
 		"execution_count": s.ExecutionCount,
 		"panic_count":     s.PanicCount,
+		"error_count":     s.ErrorCount,
+		"failures":        s.Failures,
+		"skipped_count":   s.SkippedCount,
+		"last_skipped":    s.LastSkipped,
 		"last_execution":  s.LastExecution,
 		"last_error":      s.LastError,
 		"uptime":          time.Since(s.StartTime).String(),
 	}
 }
 
+// HealthCheck returns a health check that fails when the scheduler has stopped or
+// has not completed a run within maxSilence, e.g. twice its interval. A paused
+// scheduler was disabled on purpose and passes.
+//
+// Example:
+//
+//	state := scheduler.NewSchedulerState()
+//	health.Register(health.Check{Name: "report-scheduler", Check: state.HealthCheck(10 * time.Minute)})
+func (s *SchedulerState) HealthCheck(maxSilence time.Duration) health.CheckFunc {
+	return func(ctx context.Context) error {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		if !s.IsRunning {
+			return fmt.Errorf("scheduler is stopped")
+		}
+		if s.IsPaused {
+			return nil
+		}
+		lastRun := s.LastExecution
+		if lastRun.IsZero() {
+			lastRun = s.StartTime
+		}
+		if silence := time.Since(lastRun); silence > maxSilence {
+			return fmt.Errorf("no run completed for %s", silence.Round(time.Second))
+		}
+		return nil
+	}
+}
+
 // RunFunctionAtInterval schedules a function to run at regular intervals with graceful shutdown support.
 // Executes the provided function repeatedly after the specified duration.
 // Supports optional immediate execution before the first interval and graceful shutdown on OS signals.
+// Use Every instead for a non-blocking job that can be paused, triggered, or stopped.
 //
 // Parameters:
 //   - functionToRun: The function to execute at each interval
@@ -144,9 +326,6 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 	// Load configuration
 	config := LoadConfig(interval, runInstant)
 
-	// Initialize scheduler state for monitoring
-	state := NewSchedulerState()
-
 	// Set up context for graceful shutdown
 	var ctx context.Context
 	var cancel context.CancelFunc
@@ -162,6 +341,42 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 		defer cancel()
 	}
 
+	runAtInterval(ctx, functionToRun, config)
+}
+
+// RunFunctionAtIntervalCtx runs a function at regular intervals until ctx is cancelled,
+// so the scheduler follows the server's or a test's lifetime instead of installing its
+// own signal handler. RunInstant and MaxPanicRecovery are taken from config, which
+// LoadConfig fills with defaults; interval replaces config.Interval and
+// EnableGracefulShutdown is ignored. Runs never overlap: intervals that pass during a
+// long run are skipped and logged, whatever config.Overlap says.
+// Returns nil once ctx is cancelled, or an error when the interval is invalid or the
+// circuit breaker stopped the scheduler after too many consecutive panics.
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//	go scheduler.RunFunctionAtIntervalCtx(ctx, syncExchangeRates, 10*time.Minute, scheduler.LoadConfig(10*time.Minute, true))
+//	server.Run(ctx, router)
+func RunFunctionAtIntervalCtx(ctx context.Context, functionToRun func(), interval time.Duration, config SchedulerConfig) error {
+	if err := validateInterval(interval); err != nil {
+		log.Error(fmt.Sprintf("❌ Scheduler validation failed: %v", err))
+		return err
+	}
+	config.Interval = interval
+	config.EnableGracefulShutdown = false
+	return runAtInterval(ctx, functionToRun, config)
+}
+
+// runAtInterval is the scheduler loop shared by RunFunctionAtInterval and
+// RunFunctionAtIntervalCtx; it runs until ctx is done or the circuit breaker trips.
+func runAtInterval(ctx context.Context, functionToRun func(), config SchedulerConfig) error {
+	interval, runInstant := config.Interval, config.RunInstant
+
+	// Initialize scheduler state for monitoring
+	state := NewSchedulerState()
+
 	// Log the start of the scheduler with configuration details
 	log.Info(fmt.Sprintf("⏰ Scheduler started: Function will run every %v. Run instantly: %v", interval, runInstant))
 	log.Info(fmt.Sprintf("📊 Configuration - Graceful shutdown: %v, Max panic recovery: %d",
@@ -171,7 +386,7 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 	if runInstant {
 		log.Info("🚀 Executing function immediately before first interval...")
 
-		if success := runWithRecovery(functionToRun, "initial execution"); success {
+		if success := RunWithRecovery(functionToRun, "initial execution"); success {
 			state.RecordExecution()
 			log.Success("✅ Initial execution completed successfully.")
 		} else {
@@ -180,9 +395,11 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 		}
 	}
 
-	// Create a ticker for the specified interval
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	// Create a timer for the first interval; runs stay on the interval grid and
+	// jitter only shifts each run around its slot
+	slot := time.Now().Add(interval)
+	timer := time.NewTimer(max(time.Until(slot)+config.jitterOffset(interval), 0))
+	defer timer.Stop()
 
 	// Track consecutive panics for circuit breaker pattern
 	consecutivePanics := 0
@@ -190,11 +407,11 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 	// Main scheduler loop
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			// Execute the scheduled function with panic recovery
 			log.Warning("⚡ Executing scheduled function...")
 
-			if success := runWithRecovery(functionToRun, "scheduled execution"); success {
+			if success := RunWithRecovery(functionToRun, "scheduled execution"); success {
 				state.RecordExecution()
 				consecutivePanics = 0 // Reset panic counter on success
 				log.Success("✅ Function execution completed successfully.")
@@ -214,14 +431,30 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 				if config.MaxPanicRecovery > 0 && consecutivePanics >= config.MaxPanicRecovery {
 					log.Error(fmt.Sprintf("❌ Too many consecutive panics (%d), stopping scheduler for safety", consecutivePanics))
 					state.Stop()
-					return
+					return fmt.Errorf("scheduler stopped after %d consecutive panics", consecutivePanics)
 				}
 			}
 
+			// Move to the next slot; runs never overlap here, so slots that passed during
+			// a long run are skipped and recorded
+			slot = slot.Add(interval)
+			for !slot.After(time.Now()) {
+				slot = slot.Add(interval)
+				state.RecordSkip()
+				log.Warning(fmt.Sprintf("⏭️  Function still running at its next interval, skipping run (total skipped: %d)", state.SkippedCount))
+			}
+
+			// Back off after consecutive panics instead of keeping the interval
+			if delay := config.backoffDelay(interval, consecutivePanics); delay > 0 {
+				slot = time.Now().Add(delay)
+				log.Warning(fmt.Sprintf("⏳ Backing off after %d consecutive panics, next run in %v", consecutivePanics, delay))
+			}
+			timer.Reset(max(time.Until(slot)+config.jitterOffset(interval), 0))
+
 		case <-ctx.Done():
 			// Handle graceful shutdown
 			state.Stop()
-			log.Info("🛑 Received shutdown signal, stopping scheduler gracefully...")
+			log.Info("🛑 Shutdown requested, stopping scheduler gracefully...")
 
 			// Log final statistics
 			status := state.GetStatus()
@@ -229,7 +462,7 @@ func RunFunctionAtInterval(functionToRun func(), interval time.Duration, runInst
 				status["execution_count"], status["panic_count"], status["uptime"]))
 
 			log.Success("✅ Scheduler shutdown completed successfully")
-			return
+			return nil
 		}
 	}
 }
@@ -0,0 +1,314 @@
+package scheduler
+
+import (
+	"fmt"     // fmt provides formatting of parse errors.
+	"strconv" // strconv provides parsing of cron field values.
+	"strings" // strings provides splitting of specs and fields.
+	"time"    // time provides durations and next-run calculation.
+)
+
+// Schedule computes when a job runs next.
+type Schedule interface {
+	// Next returns the first run time strictly after after, or the zero time if there is none.
+	Next(after time.Time) time.Time
+}
+
+// intervalSchedule runs a job at a fixed interval from the previous run.
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Next returns after plus the interval.
+func (s intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(s.interval)
+}
+
+// onceSchedule runs a job a single time.
+type onceSchedule struct {
+	at time.Time
+}
+
+// Next returns the run time while it is still ahead, and the zero time after it.
+func (s onceSchedule) Next(after time.Time) time.Time {
+	if s.at.After(after) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+// cronField is a bit set of the values a cron field matches.
+type cronField uint64
+
+// has reports whether value is in the set.
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// cronSchedule runs a job at the wall-clock times matched by a five-field cron expression.
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek cronField
+	anyDayOfMonth, anyDayOfWeek                bool // the day fields started with "*" (including "*/N"), which changes how they combine
+	anyHour                                    bool // the hour field was "*", so the job also runs in a repeated DST hour
+}
+
+// cronBounds describes the allowed values and names of one cron field.
+type cronBounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds     = cronBounds{min: 0, max: 59}
+	hourBounds       = cronBounds{min: 0, max: 23}
+	dayOfMonthBounds = cronBounds{min: 1, max: 31}
+	monthBounds      = cronBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dayOfWeekBounds = cronBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors maps the predefined cron schedules to their expressions.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSpec parses a job schedule. A spec is one of:
+//   - a Go duration such as "30s" or "1h30m", or "@every 5m", to run at that interval
+//   - a predefined schedule: "@hourly", "@daily" (or "@midnight"), "@weekly", "@monthly", "@yearly"
+//   - a five-field cron expression "minute hour day-of-month month day-of-week", supporting
+//     "*", lists ("1,15"), ranges ("1-5"), steps ("*/10", "8-18/2"), and month and day names
+//
+// Cron schedules follow the wall clock of the time zone of the time passed to Next;
+// jobs use SchedulerConfig.Location. As in cron, when both day fields are restricted a
+// day matching either one runs the job, and daylight saving changes are handled the
+// way cron handles them: a run in an hour skipped when clocks go forward happens as
+// soon as that hour has passed, and a run at a fixed hour is not repeated when clocks
+// go back, while hourly ("*") schedules run in both passes of the repeated hour.
+//
+// Example:
+//
+//	schedule, err := scheduler.ParseSpec("0 9 * * mon-fri") // 09:00 on weekdays
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println("Next run:", schedule.Next(time.Now()))
+func ParseSpec(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("schedule spec is empty")
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		return parseInterval(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+	}
+	if strings.HasPrefix(spec, "@") {
+		expression, ok := cronDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown schedule descriptor: %s", spec)
+		}
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 1 {
+		return parseInterval(spec)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec %q must have 5 fields, got %d", spec, len(fields))
+	}
+
+	var schedule cronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if schedule.dayOfWeek, err = parseCronField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+	// 7 is an alias for Sunday
+	if schedule.dayOfWeek.has(7) {
+		schedule.dayOfWeek |= 1
+	}
+	// As in Vixie cron, a day field starting with "*" does not restrict the day, so
+	// "*/2" in one day field still requires the other day field to match
+	schedule.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+	schedule.anyHour = fields[1] == "*"
+	return schedule, nil
+}
+
+// parseInterval parses a positive Go duration into an interval schedule.
+func parseInterval(value string) (Schedule, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval %q: %w", value, err)
+	}
+	if err := validateInterval(interval); err != nil {
+		return nil, err
+	}
+	return intervalSchedule{interval: interval}, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges, and steps.
+func parseCronField(field string, bounds cronBounds) (cronField, error) {
+	var set cronField
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if slash := strings.Index(part, "/"); slash >= 0 {
+			var err error
+			rangePart = part[:slash]
+			if step, err = strconv.Atoi(part[slash+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = bounds.min, bounds.max
+		case strings.Contains(rangePart, "-"):
+			dash := strings.Index(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(rangePart[:dash], bounds); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(rangePart[dash+1:], bounds); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("range %q is backwards", rangePart)
+			}
+		default:
+			value, err := parseCronValue(rangePart, bounds)
+			if err != nil {
+				return 0, err
+			}
+			// "5/15" means every 15 starting at 5
+			start, end = value, value
+			if step > 1 {
+				end = bounds.max
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a number or name within the field's bounds.
+func parseCronValue(value string, bounds cronBounds) (int, error) {
+	if number, ok := bounds.names[strings.ToLower(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if number < bounds.min || number > bounds.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", number, bounds.min, bounds.max)
+	}
+	return number, nil
+}
+
+// Next returns the first matching minute after after, in after's location. It gives
+// up and returns the zero time when nothing matches within five years, e.g. "0 0 31 2 *".
+// See ParseSpec for daylight saving time.
+func (s cronSchedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		var next time.Time
+		switch {
+		case !s.month.has(int(month)):
+			next = startOf(time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC), location)
+		case !s.dayMatches(t):
+			next = startOf(time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC), location)
+		case !s.hour.has(t.Hour()):
+			next = startOf(time.Date(year, month, day, t.Hour()+1, 0, 0, 0, time.UTC), location)
+		case !s.minute.has(t.Minute()):
+			next = t.Add(time.Minute)
+		case !s.anyHour && !wallClock(t).After(wallClock(after)):
+			// The clock went back and this fixed time already ran in the first pass
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+		if s.skippedHour(t, next) {
+			return next
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+// startOf returns the first instant in location whose wall clock reads at least wall,
+// a wall-clock reading expressed in UTC. In a daylight saving gap time.Date returns an
+// earlier reading (02:00 on the day clocks jump to 03:00 becomes 01:00), so it moves
+// on to the end of the gap.
+func startOf(wall time.Time, location *time.Location) time.Time {
+	t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), 0, 0, 0, location)
+	for wallClock(t).Before(wall) {
+		t = t.Add(time.Minute)
+	}
+	return t
+}
+
+// skippedHour reports whether moving from from to next jumped over a matching hour
+// that does not exist on next's wall clock because clocks went forward, including a
+// skipped midnight when next is on a later day.
+func (s cronSchedule) skippedHour(from, next time.Time) bool {
+	first := from.Hour() + 1
+	if from.YearDay() != next.YearDay() {
+		if !s.month.has(int(next.Month())) || !s.dayMatches(next) {
+			return false
+		}
+		first = 0
+	}
+	for hour := first; hour < next.Hour(); hour++ {
+		if s.hour.has(hour) {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns t's wall-clock reading as a UTC time, so readings in one zone can
+// be compared across a daylight saving change.
+func wallClock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+// dayMatches applies cron's day rule: both day fields must match when either starts with "*",
+// otherwise matching one of them is enough.
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth.has(t.Day())
+	dayOfWeek := s.dayOfWeek.has(int(t.Weekday()))
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"testing" // testing provides the test harness.
+	"time"    // time provides the reference times.
+)
+
+// TestParseSpecDayFields checks cron's day rule: a day field starting with "*",
+// including a step such as "*/2", does not restrict the day, so both fields must match.
+func TestParseSpecDayFields(t *testing.T) {
+	after := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		// The 1st of the month that falls on an even weekday: Sunday 1 February
+		{"0 0 1 * */2", time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * 1", time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		// Both fields restricted: either may match, so Saturday 3 January
+		{"0 0 1 * 6", time.Date(2026, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseSpec(test.spec)
+		if err != nil {
+			t.Fatalf("ParseSpec(%q): %v", test.spec, err)
+		}
+		if got := schedule.Next(after); !got.Equal(test.want) {
+			t.Errorf("ParseSpec(%q).Next(%v) = %v, want %v", test.spec, after, got, test.want)
+		}
+	}
+}
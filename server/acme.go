@@ -0,0 +1,32 @@
+package server
+
+import (
+	"crypto/tls" // tls provides the certificate callback configuration.
+	"strings"    // strings provides joining of domains for logs.
+
+	"github.com/hekimapro/utils/log"    // log provides colored logging utilities.
+	"golang.org/x/crypto/acme"          // acme provides the TLS-ALPN-01 protocol name.
+	"golang.org/x/crypto/acme/autocert" // autocert provides Let's Encrypt certificate management.
+)
+
+// newACMEManager creates the autocert manager for ACMEDomains. Certificates are
+// requested on the first TLS handshake for a domain and renewed automatically, and
+// are kept in ACMECacheDir so restarts do not hit Let's Encrypt rate limits.
+func newACMEManager(config ServerConfig) *autocert.Manager {
+	log.Info("ACME enabled for domains: " + strings.Join(config.ACMEDomains, ", "))
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(config.ACMEDomains...),
+		Cache:      autocert.DirCache(config.ACMECacheDir),
+		Email:      config.ACMEEmail,
+	}
+}
+
+// createACMETLSConfig returns the secure TLS configuration with certificates from manager.
+func createACMETLSConfig(manager *autocert.Manager) *tls.Config {
+	tlsConfig := createTLSConfig()
+	tlsConfig.GetCertificate = manager.GetCertificate
+	// Also answer TLS-ALPN-01 challenges on the HTTPS port
+	tlsConfig.NextProtos = append(tlsConfig.NextProtos, acme.ALPNProto)
+	return tlsConfig
+}
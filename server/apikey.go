@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"       // context provides request-scoped key identity storage.
+	"crypto/sha256" // sha256 provides hashing of keys for database lookups.
+	"database/sql"  // sql provides database access for the table-backed store.
+	"encoding/hex"  // hex provides hexadecimal encoding of key hashes.
+	"errors"        // errors provides detection of sql.ErrNoRows.
+	"fmt"           // fmt provides formatting and printing functions.
+	"net/http"      // http provides HTTP middleware types.
+	"strings"       // strings provides string manipulation utilities.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides constant-time comparison of keys.
+	"github.com/hekimapro/utils/env"        // env provides environment list parsing.
+	"github.com/hekimapro/utils/helpers"    // helpers provides JSON responses and error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models"     // models provides the ContextKey type.
+	"github.com/hekimapro/utils/ratelimit"  // ratelimit provides per-key request limits.
+)
+
+// ContextKeyAPIKey is the context key under which APIKeyMiddleware stores the matched *APIKey.
+const ContextKeyAPIKey models.ContextKey = "api_key"
+
+// APIKey describes an authenticated API key. The secret itself is never stored in the context.
+type APIKey struct {
+	ID        string // ID uniquely identifies the key (used for logging and rate limiting)
+	Name      string // Name is a human-readable label for the key owner
+	RateLimit int    // RateLimit is the maximum requests per minute for this key (0 = use the middleware default)
+}
+
+// APIKeyStore resolves a presented key to its identity.
+// Lookup returns nil (and no error) when the key is unknown.
+type APIKeyStore interface {
+	Lookup(ctx context.Context, key string) (*APIKey, error)
+}
+
+// APIKeyStoreFunc adapts a callback to the APIKeyStore interface.
+type APIKeyStoreFunc func(ctx context.Context, key string) (*APIKey, error)
+
+// Lookup calls the callback.
+func (f APIKeyStoreFunc) Lookup(ctx context.Context, key string) (*APIKey, error) {
+	return f(ctx, key)
+}
+
+// StaticAPIKeyStore holds a fixed set of keys, usually loaded from the environment.
+type StaticAPIKeyStore struct {
+	keys map[string]*APIKey // keys maps secrets to identities
+}
+
+// NewStaticAPIKeyStore creates a store from secrets mapped to identities.
+func NewStaticAPIKeyStore(keys map[string]*APIKey) *StaticAPIKeyStore {
+	return &StaticAPIKeyStore{keys: keys}
+}
+
+// NewStaticAPIKeyStoreFromEnv creates a store from API_KEYS, a comma-separated list of
+// "name:key" entries (a bare "key" uses its position as the name).
+func NewStaticAPIKeyStoreFromEnv() *StaticAPIKeyStore {
+	keys := make(map[string]*APIKey)
+	for index, entry := range env.GetList("API_KEYS") {
+		name, key, found := strings.Cut(entry, ":")
+		if !found {
+			name, key = fmt.Sprintf("key-%d", index+1), entry
+		}
+		keys[key] = &APIKey{ID: name, Name: name}
+	}
+	return NewStaticAPIKeyStore(keys)
+}
+
+// Lookup compares the presented key against every configured key in constant time.
+func (s *StaticAPIKeyStore) Lookup(ctx context.Context, key string) (*APIKey, error) {
+	var match *APIKey
+	for secret, identity := range s.keys {
+		// Compare every key so the timing does not reveal which (or whether a) key matched.
+		if encryption.SecureCompareString(secret, key) {
+			match = identity
+		}
+	}
+	return match, nil
+}
+
+// DatabaseAPIKeyStore looks keys up in a database table holding SHA-256 hashes of the keys.
+// The table needs the columns id, name, key_hash (hex), rate_limit, and revoked_at.
+type DatabaseAPIKeyStore struct {
+	DB    *sql.DB // DB is the database connection
+	Table string  // Table is the table name (default "api_keys")
+}
+
+// NewDatabaseAPIKeyStore creates a store backed by the given table.
+func NewDatabaseAPIKeyStore(db *sql.DB, table string) *DatabaseAPIKeyStore {
+	return &DatabaseAPIKeyStore{DB: db, Table: helpers.DefaultIfEmpty(table, "api_keys")}
+}
+
+// HashAPIKey returns the hex SHA-256 hash stored in the key_hash column for a key.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup finds a non-revoked key by its hash. Only hashes are compared, so the
+// database never sees or leaks the raw key through comparison timing.
+func (s *DatabaseAPIKeyStore) Lookup(ctx context.Context, key string) (*APIKey, error) {
+	query := fmt.Sprintf(
+		"SELECT id, name, COALESCE(rate_limit, 0) FROM %s WHERE key_hash = $1 AND revoked_at IS NULL",
+		s.Table,
+	)
+
+	var identity APIKey
+	err := s.DB.QueryRowContext(ctx, query, HashAPIKey(key)).Scan(&identity.ID, &identity.Name, &identity.RateLimit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to look up API key")
+	}
+	return &identity, nil
+}
+
+// APIKeyConfig holds configuration for APIKeyMiddleware.
+type APIKeyConfig struct {
+	Store      APIKeyStore       // Store resolves keys (required)
+	Header     string            `env:"API_KEY_HEADER" default:"X-API-Key"` // Header is the request header carrying the key
+	QueryParam string            `env:"API_KEY_QUERY_PARAM"`                // QueryParam is an optional query parameter carrying the key
+	RateLimit  int               `env:"API_KEY_RATE_LIMIT" default:"0"`     // RateLimit is the default requests per minute per key (0 = unlimited)
+	Limiter    ratelimit.Limiter // Limiter enforces per-key rate limits (default in-memory sliding window; use a Redis limiter to share limits across instances)
+}
+
+// LoadAPIKeyConfig loads API key configuration from environment variables with the given store.
+func LoadAPIKeyConfig(store APIKeyStore) APIKeyConfig {
+	config := APIKeyConfig{Store: store}
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid API key configuration: " + err.Error())
+	}
+	return config
+}
+
+// APIKeyMiddleware creates a middleware that requires a valid API key in the configured
+// header or query parameter. The matched *APIKey is stored in the request context under
+// ContextKeyAPIKey. Responds with JSON 401 for missing/unknown keys and 429 when the key's
+// per-minute rate limit is exceeded.
+//
+// Example:
+//
+//	config := server.LoadAPIKeyConfig(server.NewStaticAPIKeyStoreFromEnv())
+//	handler := server.ChainMiddlewares(router, server.APIKeyMiddleware(config))
+func APIKeyMiddleware(config APIKeyConfig) func(http.Handler) http.Handler {
+	header := helpers.DefaultIfEmpty(config.Header, "X-API-Key")
+	limiter := config.Limiter
+	if limiter == nil {
+		limiter = ratelimit.NewSlidingWindow()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(header)
+			if key == "" && config.QueryParam != "" {
+				key = r.URL.Query().Get(config.QueryParam)
+			}
+			if key == "" {
+				helpers.RespondWithJSON(w, http.StatusUnauthorized, "missing API key")
+				return
+			}
+
+			identity, err := config.Store.Lookup(r.Context(), key)
+			if err != nil {
+				log.Error("❌ API key lookup failed: " + err.Error())
+				helpers.RespondWithJSON(w, http.StatusInternalServerError, "failed to verify API key")
+				return
+			}
+			if identity == nil {
+				log.Warning("⚠️ Rejected request with unknown API key from " + r.RemoteAddr)
+				helpers.RespondWithJSON(w, http.StatusUnauthorized, "invalid API key")
+				return
+			}
+
+			limit := identity.RateLimit
+			if limit <= 0 {
+				limit = config.RateLimit
+			}
+			if limit > 0 {
+				result, err := ratelimit.Allow(r.Context(), limiter, "apikey:"+identity.ID, ratelimit.PerMinute(limit))
+				if err != nil {
+					log.Error("❌ API key rate limiter failed, allowing request: " + err.Error())
+				} else {
+					ratelimit.SetHeaders(w, result)
+					if !result.Allowed {
+						log.Warning("⚠️ Rate limit exceeded for API key " + identity.ID)
+						helpers.RespondWithJSON(w, http.StatusTooManyRequests, "rate limit exceeded")
+						return
+					}
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), ContextKeyAPIKey, identity)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetAPIKey returns the API key identity stored in the request context by APIKeyMiddleware.
+func GetAPIKey(r *http.Request) *APIKey {
+	identity, _ := r.Context().Value(ContextKeyAPIKey).(*APIKey)
+	return identity
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"   // context provides the shared shutdown signal.
+	"errors"    // errors provides the empty group error.
+	"fmt"       // fmt provides wrapping of member errors.
+	"net/http"  // http provides the member handlers.
+	"os"        // os provides the interrupt signal.
+	"os/signal" // signal provides system signal handling.
+	"syscall"   // syscall provides the SIGTERM constant.
+	"time"      // time provides the hook timeout.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// Group runs several servers in one process, e.g. a public API and an internal admin
+// server, and shuts them all down together.
+type Group struct {
+	members []groupMember // members are started in the order they were added
+}
+
+// groupMember is one server of a Group.
+type groupMember struct {
+	name    string       // name identifies the server in logs and errors
+	handler http.Handler // handler serves the server's requests
+	config  ServerConfig // config is resolved against LoadConfig when the group starts
+}
+
+// NewGroup creates an empty server group.
+//
+// Example:
+//
+//	group := server.NewGroup()
+//	group.Add("api", apiRouter, server.ServerConfig{Port: "8080"})
+//	group.Add("admin", adminRouter, server.ServerConfig{Port: "9090", MetricsEnabled: true})
+//	err := group.Run()
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add adds a server to the group. Zero config fields fall back to LoadConfig, as in
+// StartServerWithConfig, so each member needs at least its own Port or Listeners.
+func (g *Group) Add(name string, handler http.Handler, config ServerConfig) *Group {
+	g.members = append(g.members, groupMember{name: name, handler: handler, config: config})
+	return g
+}
+
+// Run starts every server and blocks until SIGINT or SIGTERM, then shuts them all
+// down gracefully. It returns the first error from any server, which also stops the
+// others.
+func (g *Group) Run() error {
+	// Set up context for graceful shutdown on OS signals
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return g.RunWithContext(ctx)
+}
+
+// RunWithContext starts every server like Run, but shuts them down when ctx is
+// cancelled instead of installing its own signal handler. OnShutdown hooks run once,
+// after every server has stopped.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	go func() { errs <- group.RunWithContext(ctx) }()
+//	...
+//	cancel() // graceful shutdown of every server
+func (g *Group) RunWithContext(ctx context.Context) error {
+	if len(g.members) == 0 {
+		return errors.New("server group has no servers")
+	}
+
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fallback := LoadConfig()
+	var hookTimeout time.Duration
+	running := make([]*RunningServer, 0, len(g.members))
+	var firstErr error
+
+	for _, member := range g.members {
+		config := member.config.withFallback(fallback)
+		hookTimeout = max(hookTimeout, config.ShutdownHookTimeout)
+
+		log.Info("Starting group server: " + member.name)
+		server, err := start(groupCtx, member.handler, config, false)
+		if err != nil {
+			// Stop the servers already started before reporting the failure
+			firstErr = fmt.Errorf("server %s: %w", member.name, err)
+			cancel()
+			break
+		}
+		running = append(running, server)
+	}
+
+	// Stop every server as soon as one of them stops on its own
+	stopped := make(chan error, len(running))
+	for i, server := range running {
+		go func(name string, server *RunningServer) {
+			err := server.Wait()
+			if err != nil {
+				err = fmt.Errorf("server %s: %w", name, err)
+			}
+			stopped <- err
+			cancel()
+		}(g.members[i].name, server)
+	}
+	for range running {
+		if err := <-stopped; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	runShutdownHooks(hookTimeout)
+	return firstErr
+}
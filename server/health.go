@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"     // context provides check cancellation.
+	"net/http"    // http provides the probe handlers.
+	"sync/atomic" // atomic provides the draining flag.
+
+	"github.com/hekimapro/utils/health" // health provides the shared check registry behind /health.
+)
+
+// RegisterHealthCheck adds a critical check to the /health endpoint: the endpoint
+// reports each check's status and responds 503 while any critical check fails. It
+// registers in the health package's Default registry, so checks added by other
+// modules (e.g. the database) are reported too; use health.Register directly for a
+// custom timeout or a non-critical check. A check with an existing name replaces it.
+//
+// Example:
+//
+//	server.RegisterHealthCheck("redis", func(ctx context.Context) error {
+//	    return redisClient.Ping(ctx).Err()
+//	})
+//	server.RegisterHealthCheck("payments-api", health.HTTPCheck("https://api.example.com/status"))
+func RegisterHealthCheck(name string, check func(ctx context.Context) error) error {
+	return health.Register(health.Check{Name: name, Check: check, Critical: true})
+}
+
+// probeEnabled reports whether a probe path is set and not disabled with "-".
+func probeEnabled(path string) bool {
+	return path != "" && path != "-"
+}
+
+// livenessHandler answers the liveness probe: the process is up and serving, so
+// Kubernetes should not restart it. Dependencies are deliberately not checked, so a
+// slow database does not get healthy pods killed.
+func livenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Write([]byte(`{"status":"alive"}` + "\n"))
+	})
+}
+
+// readinessHandler answers the readiness probe with the health report: 200 when the
+// critical checks pass and 503 otherwise, or 503 once draining is set during shutdown
+// so traffic moves elsewhere before connections close.
+func readinessHandler(draining *atomic.Bool) http.Handler {
+	reportHandler := health.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if draining.Load() {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"shutting down"}` + "\n"))
+			return
+		}
+		reportHandler.ServeHTTP(w, r)
+	})
+}
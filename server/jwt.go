@@ -0,0 +1,296 @@
+package server
+
+import (
+	"context"         // context provides request-scoped claim storage.
+	"crypto"          // crypto provides hash identifiers for RSA verification.
+	"crypto/hmac"     // hmac provides HS256 signature verification.
+	"crypto/rsa"      // rsa provides RS256 signature verification.
+	"crypto/sha256"   // sha256 provides the SHA-256 hash used by HS256 and RS256.
+	"crypto/x509"     // x509 provides public key parsing.
+	"encoding/base64" // base64 provides base64url decoding of token segments.
+	"encoding/json"   // json provides decoding of token headers and claims.
+	"encoding/pem"    // pem provides PEM block decoding.
+	"fmt"             // fmt provides formatting and printing functions.
+	"net/http"        // http provides HTTP middleware types.
+	"os"              // os provides file reading for public keys.
+	"strconv"         // strconv provides formatting of numeric user IDs.
+	"strings"         // strings provides string manipulation utilities.
+	"time"            // time provides expiry validation.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides constant-time comparison of signatures.
+	"github.com/hekimapro/utils/env"        // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"    // helpers provides JSON responses.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models"     // models provides the ContextKey type.
+)
+
+// Context keys under which the JWT middleware stores authentication data.
+const (
+	ContextKeyUserID models.ContextKey = "user_id"    // ContextKeyUserID holds the subject as a string
+	ContextKeyRoles  models.ContextKey = "roles"      // ContextKeyRoles holds the roles as []string
+	ContextKeyClaims models.ContextKey = "jwt_claims" // ContextKeyClaims holds all claims as map[string]interface{}
+)
+
+// JWTConfig holds configuration for Bearer token validation.
+// Set Secret for HS256 tokens and/or PublicKey (or PublicKeyPath) for RS256 tokens.
+type JWTConfig struct {
+	Secret        string         `env:"JWT_SECRET"`                           // Secret is the HS256 shared secret
+	PublicKeyPath string         `env:"JWT_PUBLIC_KEY_PATH"`                  // PublicKeyPath is a PEM file with the RS256 public key
+	PublicKey     *rsa.PublicKey `env:"-"`                                    // PublicKey is the RS256 public key (loaded from PublicKeyPath when nil)
+	Issuer        string         `env:"JWT_ISSUER"`                           // Issuer is the expected "iss" claim (empty = not checked)
+	Audience      string         `env:"JWT_AUDIENCE"`                         // Audience is the expected "aud" claim (empty = not checked)
+	ClockSkew     time.Duration  `env:"JWT_CLOCK_SKEW" default:"30" unit:"s"` // ClockSkew is the tolerance applied to exp/nbf/iat
+	UserIDClaim   string         `env:"JWT_USER_ID_CLAIM" default:"sub"`      // UserIDClaim names the claim holding the user ID
+	RolesClaim    string         `env:"JWT_ROLES_CLAIM" default:"roles"`      // RolesClaim names the claim holding the roles
+}
+
+// LoadJWTConfig loads JWT configuration from environment variables with defaults.
+// Returns an error if neither a secret nor a readable public key is configured.
+func LoadJWTConfig() (JWTConfig, error) {
+	var config JWTConfig
+	if err := env.Bind(&config); err != nil {
+		return config, err
+	}
+
+	if config.PublicKeyPath != "" {
+		publicKey, err := LoadRSAPublicKey(config.PublicKeyPath)
+		if err != nil {
+			return config, err
+		}
+		config.PublicKey = publicKey
+	}
+
+	if config.Secret == "" && config.PublicKey == nil {
+		return config, helpers.CreateError("JWT_SECRET or JWT_PUBLIC_KEY_PATH must be set")
+	}
+
+	return config, nil
+}
+
+// LoadRSAPublicKey reads a PEM-encoded RSA public key (PKIX or PKCS#1) from a file.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to read JWT public key")
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, helpers.CreateError("JWT public key is not valid PEM")
+	}
+
+	if parsed, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		if publicKey, ok := parsed.(*rsa.PublicKey); ok {
+			return publicKey, nil
+		}
+		return nil, helpers.CreateError("JWT public key is not an RSA key")
+	}
+
+	publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to parse JWT public key")
+	}
+	return publicKey, nil
+}
+
+// ValidateJWT verifies a compact JWT's signature and registered claims and returns its claims.
+func ValidateJWT(token string, config JWTConfig) (map[string]interface{}, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, helpers.CreateError("malformed token")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(segments[0], &header); err != nil {
+		return nil, helpers.WrapError(err, "invalid token header")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, helpers.CreateError("invalid token signature encoding")
+	}
+
+	signingInput := segments[0] + "." + segments[1]
+	digest := sha256.Sum256([]byte(signingInput))
+
+	// The algorithm must match a configured key; "none" and unknown algorithms are rejected.
+	switch header.Algorithm {
+	case "HS256":
+		if config.Secret == "" {
+			return nil, helpers.CreateError("HS256 tokens are not accepted")
+		}
+		mac := hmac.New(sha256.New, []byte(config.Secret))
+		mac.Write([]byte(signingInput))
+		if !encryption.SecureCompare(signature, mac.Sum(nil)) {
+			return nil, helpers.CreateError("invalid token signature")
+		}
+	case "RS256":
+		if config.PublicKey == nil {
+			return nil, helpers.CreateError("RS256 tokens are not accepted")
+		}
+		if err := rsa.VerifyPKCS1v15(config.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return nil, helpers.CreateError("invalid token signature")
+		}
+	default:
+		return nil, helpers.CreateErrorf("unsupported token algorithm %q", header.Algorithm)
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(segments[1], &claims); err != nil {
+		return nil, helpers.WrapError(err, "invalid token claims")
+	}
+
+	if err := validateRegisteredClaims(claims, config); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// decodeSegment base64url-decodes and unmarshals a token segment.
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// validateRegisteredClaims checks exp, nbf, iat, iss, and aud with the configured clock skew.
+func validateRegisteredClaims(claims map[string]interface{}, config JWTConfig) error {
+	now := time.Now()
+
+	if exp, ok := numericClaim(claims, "exp"); ok && now.After(exp.Add(config.ClockSkew)) {
+		return helpers.CreateError("token has expired")
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(config.ClockSkew).Before(nbf) {
+		return helpers.CreateError("token is not valid yet")
+	}
+	if iat, ok := numericClaim(claims, "iat"); ok && now.Add(config.ClockSkew).Before(iat) {
+		return helpers.CreateError("token issued in the future")
+	}
+
+	if config.Issuer != "" {
+		if issuer, _ := claims["iss"].(string); issuer != config.Issuer {
+			return helpers.CreateError("invalid token issuer")
+		}
+	}
+
+	if config.Audience != "" && !helpers.ContainsString(stringsClaim(claims, "aud"), config.Audience) {
+		return helpers.CreateError("invalid token audience")
+	}
+
+	return nil
+}
+
+// numericClaim reads a NumericDate claim as a time.
+func numericClaim(claims map[string]interface{}, name string) (time.Time, bool) {
+	value, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0), true
+}
+
+// claimString formats a claim value as a string. Numeric IDs decode as float64, so
+// they are printed in full rather than in fmt's exponent form ("1.23456789e+09").
+func claimString(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// stringsClaim reads a claim that may be a single string or an array of strings.
+func stringsClaim(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		if value == "" {
+			return nil
+		}
+		return strings.Fields(strings.ReplaceAll(value, ",", " "))
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// JWTMiddleware creates a middleware that requires a valid Bearer token.
+// On success the user ID, roles, and claims are stored in the request context under
+// ContextKeyUserID, ContextKeyRoles, and ContextKeyClaims. Invalid or missing tokens get a JSON 401.
+//
+// Example:
+//
+//	config, err := server.LoadJWTConfig()
+//	handler := server.ChainMiddlewares(router, server.JWTMiddleware(config))
+func JWTMiddleware(config JWTConfig) func(http.Handler) http.Handler {
+	userIDClaim := helpers.DefaultIfEmpty(config.UserIDClaim, "sub")
+	rolesClaim := helpers.DefaultIfEmpty(config.RolesClaim, "roles")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization := r.Header.Get("Authorization")
+			token, found := strings.CutPrefix(authorization, "Bearer ")
+			if !found || strings.TrimSpace(token) == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				helpers.RespondWithJSON(w, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+
+			claims, err := ValidateJWT(strings.TrimSpace(token), config)
+			if err != nil {
+				log.Warning("⚠️ JWT validation failed: " + err.Error())
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				helpers.RespondWithJSON(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+
+			ctx := r.Context()
+			if userID, ok := claims[userIDClaim]; ok {
+				ctx = context.WithValue(ctx, ContextKeyUserID, claimString(userID))
+			}
+			ctx = context.WithValue(ctx, ContextKeyRoles, stringsClaim(claims, rolesClaim))
+			ctx = context.WithValue(ctx, ContextKeyClaims, claims)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRoles creates a middleware that allows the request only if the authenticated
+// user has at least one of the given roles. Must run after JWTMiddleware. Responds with a JSON 403 otherwise.
+func RequireRoles(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userRoles := GetRoles(r)
+			for _, role := range roles {
+				if helpers.ContainsString(userRoles, role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			helpers.RespondWithJSON(w, http.StatusForbidden, "insufficient permissions")
+		})
+	}
+}
+
+// GetRoles returns the roles stored in the request context by JWTMiddleware.
+func GetRoles(r *http.Request) []string {
+	roles, _ := r.Context().Value(ContextKeyRoles).([]string)
+	return roles
+}
+
+// GetJWTClaims returns all claims stored in the request context by JWTMiddleware.
+func GetJWTClaims(r *http.Request) map[string]interface{} {
+	claims, _ := r.Context().Value(ContextKeyClaims).(map[string]interface{})
+	return claims
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"errors"  // errors provides detection of a missing stale socket file.
+	"fmt"     // fmt provides formatting of listener errors.
+	"io/fs"   // fs provides the socket file mode check.
+	"net"     // net provides the TCP and Unix listeners.
+	"os"      // os provides removal of stale socket files.
+	"strings" // strings provides parsing of unix: addresses.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// unixPrefix marks a listener address as a Unix domain socket path.
+const unixPrefix = "unix:"
+
+// listenAddresses returns the configured listener addresses, or ":Port" when
+// Listeners is empty.
+func listenAddresses(config ServerConfig) []string {
+	if len(config.Listeners) > 0 {
+		return config.Listeners
+	}
+	return []string{":" + config.Port}
+}
+
+// listen binds one address: "unix:/path" binds a Unix domain socket, anything else a
+// TCP host:port. A socket file left behind by a crashed process is removed first;
+// the file is removed again when the listener closes.
+func listen(address string) (net.Listener, error) {
+	path, isUnix := strings.CutPrefix(address, unixPrefix)
+	if !isUnix {
+		return net.Listen("tcp", address)
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return net.Listen("unix", path)
+}
+
+// listenAll binds every configured address, closing those already bound if one fails.
+func listenAll(config ServerConfig) ([]net.Listener, error) {
+	var listeners []net.Listener
+	for _, address := range listenAddresses(config) {
+		listener, err := listen(address)
+		if err != nil {
+			closeListeners(listeners)
+			return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+		}
+		log.Info("Listening on " + listener.Addr().Network() + " " + listener.Addr().String())
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+// closeListeners closes listeners that were bound but never served.
+func closeListeners(listeners []net.Listener) {
+	for _, listener := range listeners {
+		listener.Close()
+	}
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"encoding/json" // json provides the JSON access log format.
+	"fmt"           // fmt provides the common log format.
+	"math/rand"     // rand provides request sampling.
+	"net"           // net provides remote address parsing.
+	"net/http"      // http provides the middleware types.
+	"strings"       // strings provides exclusion pattern matching.
+	"time"          // time provides request latency and timestamps.
+
+	"github.com/hekimapro/utils/env"               // env provides binding of configuration from environment variables.
+	"github.com/hekimapro/utils/internal/recorder" // recorder provides the shared response status recorder.
+	"github.com/hekimapro/utils/log"               // log provides colored logging utilities.
+)
+
+// Access log formats.
+const (
+	AccessLogCommon = "common" // AccessLogCommon is the Apache combined log format with latency appended
+	AccessLogJSON   = "json"   // AccessLogJSON writes one JSON object per request
+)
+
+// AccessLogConfig holds configuration for AccessLog.
+type AccessLogConfig struct {
+	Format     string   `env:"ACCESS_LOG_FORMAT" default:"common"`                             // Format is "common" or "json"
+	Exclude    []string `env:"ACCESS_LOG_EXCLUDE" default:"/health,/healthz,/readyz,/metrics"` // Exclude lists paths never logged; a trailing "*" matches a prefix
+	SampleRate float64  `env:"ACCESS_LOG_SAMPLE_RATE" default:"1"`                             // SampleRate is the fraction of successful requests logged, 0 to 1; errors are always logged
+}
+
+// LoadAccessLogConfig loads access log configuration from environment variables with defaults.
+func LoadAccessLogConfig() AccessLogConfig {
+	var config AccessLogConfig
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid access log configuration: " + err.Error())
+	}
+	return config
+}
+
+// accessLogEntry is one request in the JSON format.
+type accessLogEntry struct {
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Status    int     `json:"status"`
+	Bytes     int64   `json:"bytes"`
+	LatencyMS float64 `json:"latency_ms"`
+	RemoteIP  string  `json:"remote_ip"`
+	UserAgent string  `json:"user_agent"`
+}
+
+// AccessLog creates a middleware that logs method, path, status, bytes, latency,
+// remote IP, and user agent for every request through the log package: 5xx as
+// errors, 4xx as warnings, and the rest as info. Paths matching Exclude are skipped,
+// and successful requests are sampled at SampleRate, so probes and busy endpoints
+// do not flood the logs. For structured entries carrying the request ID, use
+// log.Middleware instead.
+//
+// Example:
+//
+//	handler := server.ChainMiddlewares(router, middleware.AccessLog(middleware.LoadAccessLogConfig()))
+func AccessLog(config AccessLogConfig) func(http.Handler) http.Handler {
+	if config.Format != AccessLogCommon && config.Format != AccessLogJSON {
+		log.Warning("⚠️ Unknown access log format " + config.Format + ", using common")
+		config.Format = AccessLogCommon
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if excluded(config.Exclude, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			writer := recorder.New(w)
+			next.ServeHTTP(writer, r)
+			latency := time.Since(start)
+
+			status := writer.Status()
+			if status < http.StatusBadRequest && config.SampleRate < 1 && rand.Float64() >= config.SampleRate {
+				return
+			}
+
+			var line string
+			if config.Format == AccessLogJSON {
+				encoded, _ := json.Marshal(accessLogEntry{
+					Method:    r.Method,
+					Path:      r.URL.Path,
+					Status:    status,
+					Bytes:     writer.Bytes(),
+					LatencyMS: float64(latency.Microseconds()) / 1000,
+					RemoteIP:  remoteIP(r),
+					UserAgent: r.UserAgent(),
+				})
+				line = string(encoded)
+			} else {
+				line = fmt.Sprintf("%s - - [%s] %q %d %d %q %q %v",
+					remoteIP(r), start.Format("02/Jan/2006:15:04:05 -0700"),
+					r.Method+" "+r.URL.RequestURI()+" "+r.Proto, status, writer.Bytes(),
+					r.Referer(), r.UserAgent(), latency)
+			}
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				log.Error(line)
+			case status >= http.StatusBadRequest:
+				log.Warning(line)
+			default:
+				log.Info(line)
+			}
+		})
+	}
+}
+
+// excluded reports whether path equals a pattern or starts with a pattern ending in "*".
+func excluded(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the host part of the request's remote address.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
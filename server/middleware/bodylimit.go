@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"   // errors provides matching of the body limit error.
+	"net/http" // http provides the middleware types and the limited body reader.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides the JSON error response.
+)
+
+// BodyLimit creates a middleware that rejects request bodies larger than maxBytes
+// with 413 Request Entity Too Large. A declared Content-Length over the limit is
+// rejected before the handler runs; chunked bodies are cut off at the limit and the
+// handler's read fails with an error IsBodyTooLarge recognises. A maxBytes of zero or
+// less disables the limit.
+//
+// The server applies MAX_BODY_BYTES to every route outside any route middleware, so a
+// route limit can only be stricter: for an upload service, raise MAX_BODY_BYTES to the
+// largest upload and lower the limit on the other routes.
+//
+// Example:
+//
+//	// MAX_BODY_BYTES=52428800 (50MB) for uploads
+//	mux.Handle("/uploads", uploadHandler)
+//	mux.Handle("/profile", server.ChainMiddlewares(profileHandler, middleware.BodyLimit(1<<20))) // 1MB
+func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				w.Header().Set("Connection", "close")
+				helpers.RespondWithJSON(w, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IsBodyTooLarge reports whether err comes from reading a body past the BodyLimit,
+// so handlers can answer 413 instead of a generic 400.
+//
+// Example:
+//
+//	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+//	    if middleware.IsBodyTooLarge(err) {
+//	        helpers.RespondWithJSON(w, http.StatusRequestEntityTooLarge, "request body too large")
+//	        return
+//	    }
+//	    helpers.RespondWithJSON(w, http.StatusBadRequest, "invalid JSON")
+//	    return
+//	}
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
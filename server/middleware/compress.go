@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"bufio"         // bufio provides the hijacked connection reader/writer.
+	"compress/gzip" // gzip provides response compression.
+	"errors"        // errors provides the unsupported hijack error.
+	"net"           // net provides the hijacked connection type.
+	"net/http"      // http provides the middleware types.
+	"strings"       // strings provides header and content type matching.
+	"sync"          // sync provides pooling of gzip writers.
+
+	"github.com/hekimapro/utils/env" // env provides binding of configuration from environment variables.
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// CompressConfig holds configuration for Compress.
+type CompressConfig struct {
+	Level        int      `env:"COMPRESS_LEVEL" default:"-1"`                                                                                  // Level is the gzip level, 1 (fastest) to 9 (smallest), -1 for the default
+	MinSize      int      `env:"COMPRESS_MIN_SIZE" default:"1024"`                                                                             // MinSize is the smallest body worth compressing, in bytes
+	ContentTypes []string `env:"COMPRESS_CONTENT_TYPES" default:"text/,application/json,application/javascript,application/xml,image/svg+xml"` // ContentTypes lists compressed content type prefixes
+}
+
+// LoadCompressConfig loads compression configuration from environment variables with defaults.
+func LoadCompressConfig() CompressConfig {
+	var config CompressConfig
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid compression configuration: " + err.Error())
+	}
+	return config
+}
+
+// Compress creates a middleware that gzips responses for clients sending
+// Accept-Encoding: gzip. Bodies smaller than MinSize, content types outside
+// ContentTypes (images and archives are already compressed), and responses that
+// already set Content-Encoding are sent as is.
+//
+// Example:
+//
+//	handler := server.ChainMiddlewares(router, middleware.Compress(middleware.LoadCompressConfig()))
+func Compress(config CompressConfig) func(http.Handler) http.Handler {
+	if _, err := gzip.NewWriterLevel(nil, config.Level); err != nil {
+		log.Warning("⚠️ Invalid gzip level, using the default: " + err.Error())
+		config.Level = gzip.DefaultCompression
+	}
+	writers := &sync.Pool{New: func() interface{} {
+		writer, _ := gzip.NewWriterLevel(nil, config.Level)
+		return writer
+	}}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			writer := &compressWriter{ResponseWriter: w, config: &config, writers: writers}
+			defer writer.close()
+			next.ServeHTTP(writer, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding allows gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter buffers the start of the body until it knows whether to compress.
+type compressWriter struct {
+	http.ResponseWriter
+	config   *CompressConfig
+	writers  *sync.Pool
+	gzip     *gzip.Writer // gzip is set once compression is chosen
+	buffered []byte       // buffered holds the body until MinSize is reached
+	status   int
+	decided  bool
+}
+
+// WriteHeader records the status code; it is sent once compression is decided.
+func (w *compressWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write buffers the body until MinSize bytes, then writes it compressed or as is.
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.decided {
+		w.buffered = append(w.buffered, data...)
+		if len(w.buffered) < w.config.MinSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.gzip != nil {
+		return w.gzip.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// decide sends the header, compressing when the buffered body is large enough and
+// its content type is compressible, and writes the buffered body.
+func (w *compressWriter) decide() error {
+	w.decided = true
+	header := w.Header()
+	if header.Get("Content-Type") == "" && len(w.buffered) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buffered))
+	}
+
+	if len(w.buffered) >= w.config.MinSize && header.Get("Content-Encoding") == "" &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		compressible(w.config.ContentTypes, header.Get("Content-Type")) {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", "gzip")
+		w.gzip = w.writers.Get().(*gzip.Writer)
+		w.gzip.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	buffered := w.buffered
+	w.buffered = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if w.gzip != nil {
+		_, err := w.gzip.Write(buffered)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffered)
+	return err
+}
+
+// close sends anything still buffered and finishes the gzip stream.
+func (w *compressWriter) close() {
+	if !w.decided && w.status != 0 {
+		w.decide()
+	}
+	if w.gzip != nil {
+		w.gzip.Close()
+		w.writers.Put(w.gzip)
+		w.gzip = nil
+	}
+}
+
+// Flush sends buffered data for streaming responses, deciding compression early.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		w.decide()
+	}
+	if w.gzip != nil {
+		w.gzip.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack supports WebSocket upgrades, which bypass compression.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("middleware: response writer does not support hijacking")
+	}
+	w.decided = true
+	return hijacker.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible reports whether contentType starts with one of the configured prefixes.
+func compressible(prefixes []string, contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(contentType, strings.ToLower(strings.TrimSpace(prefix))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Package middleware provides CORS, security header, response compression, panic
+// recovery, access logging, and body size limiting middlewares. Each is a
+// func(http.Handler) http.Handler, so they compose with server.ChainMiddlewares.
+package middleware
+
+import (
+	"net/http" // http provides the middleware types.
+	"strconv"  // strconv provides header formatting.
+	"strings"  // strings provides origin matching and header joining.
+	"time"     // time provides the preflight cache duration.
+
+	"github.com/hekimapro/utils/env" // env provides binding of configuration from environment variables.
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// CORSConfig holds configuration for CORS.
+type CORSConfig struct {
+	AllowedOrigins   []string      `env:"CORS_ALLOWED_ORIGINS" default:"*"`                                       // AllowedOrigins lists allowed origins; "*" allows any, "https://*.example.com" allows subdomains
+	AllowedMethods   []string      `env:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`       // AllowedMethods lists methods allowed in preflight responses
+	AllowedHeaders   []string      `env:"CORS_ALLOWED_HEADERS" default:"Authorization,Content-Type,X-Request-ID"` // AllowedHeaders lists request headers allowed in preflight responses
+	ExposedHeaders   []string      `env:"CORS_EXPOSED_HEADERS"`                                                   // ExposedHeaders lists response headers readable by browser scripts
+	AllowCredentials bool          `env:"CORS_ALLOW_CREDENTIALS" default:"false"`                                 // AllowCredentials allows cookies and Authorization headers from browsers
+	MaxAge           time.Duration `env:"CORS_MAX_AGE" default:"600" unit:"s"`                                    // MaxAge is how long browsers may cache preflight responses
+}
+
+// LoadCORSConfig loads CORS configuration from environment variables with defaults.
+func LoadCORSConfig() CORSConfig {
+	var config CORSConfig
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid CORS configuration: " + err.Error())
+	}
+	return config
+}
+
+// CORS creates a middleware that answers preflight OPTIONS requests and sets the
+// Access-Control-* headers for allowed origins. Requests from other origins are
+// served without CORS headers, so browsers block the response. With credentials
+// allowed, the matched request origin is echoed instead of "*", as browsers require.
+// Credentials need an explicit origin list: combined with "*" they would let any site
+// make credentialed requests, so they are disabled and an error is logged.
+//
+// Example:
+//
+//	handler := server.ChainMiddlewares(router, middleware.CORS(middleware.LoadCORSConfig()))
+func CORS(config CORSConfig) func(http.Handler) http.Handler {
+	allowAny := false
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+		}
+	}
+	if allowAny && config.AllowCredentials {
+		log.Error("❌ CORS_ALLOW_CREDENTIALS requires an explicit CORS_ALLOWED_ORIGINS list, not \"*\"; credentials are disabled")
+		config.AllowCredentials = false
+	}
+	methods := strings.Join(config.AllowedMethods, ", ")
+	headers := strings.Join(config.AllowedHeaders, ", ")
+	exposed := strings.Join(config.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(config.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			if !allowAny && !originAllowed(config.AllowedOrigins, origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowAny {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			if config.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			// Answer preflight requests without calling the handler
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				if headers != "" {
+					w.Header().Set("Access-Control-Allow-Headers", headers)
+				}
+				if config.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if exposed != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposed)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// originAllowed reports whether origin matches an allowed origin, where a "*." host
+// prefix matches any subdomain.
+func originAllowed(allowed []string, origin string) bool {
+	for _, pattern := range allowed {
+		if strings.EqualFold(pattern, origin) {
+			return true
+		}
+		if prefix, suffix, found := strings.Cut(pattern, "*."); found {
+			if len(origin) > len(prefix)+len(suffix) &&
+				strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+				strings.HasSuffix(strings.ToLower(origin), "."+strings.ToLower(suffix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
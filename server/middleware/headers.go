@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"fmt"       // fmt provides formatting of the HSTS header.
+	"net/http"  // http provides the middleware types.
+	"net/netip" // netip provides parsing of the proxy address.
+	"time"      // time provides the HSTS max-age.
+
+	"github.com/hekimapro/utils/config" // config provides the server's HSTS settings.
+	"github.com/hekimapro/utils/env"    // env provides binding of configuration from environment variables.
+	"github.com/hekimapro/utils/geo"    // geo provides trusted proxy matching.
+	"github.com/hekimapro/utils/log"    // log provides colored logging utilities.
+)
+
+// SecureHeadersConfig holds configuration for SecureHeaders. Empty values omit their header.
+type SecureHeadersConfig struct {
+	HSTSMaxAge            time.Duration // HSTSMaxAge sets Strict-Transport-Security on HTTPS requests when positive; loaded from HSTS_MAX_AGE
+	HSTSIncludeSubdomains bool          // HSTSIncludeSubdomains extends HSTS to every subdomain; loaded from HSTS_INCLUDE_SUBDOMAINS
+	TrustedProxies        []string      `env:"TRUSTED_PROXIES"`                                                  // TrustedProxies lists proxy IPs or CIDRs whose X-Forwarded-Proto is believed
+	FrameOptions          string        `env:"SECURE_FRAME_OPTIONS" default:"DENY"`                              // FrameOptions is X-Frame-Options, e.g. DENY or SAMEORIGIN
+	ContentSecurityPolicy string        `env:"SECURE_CONTENT_SECURITY_POLICY"`                                   // ContentSecurityPolicy is the Content-Security-Policy value
+	ReferrerPolicy        string        `env:"SECURE_REFERRER_POLICY" default:"strict-origin-when-cross-origin"` // ReferrerPolicy is the Referrer-Policy value
+	PermissionsPolicy     string        `env:"SECURE_PERMISSIONS_POLICY"`                                        // PermissionsPolicy is the Permissions-Policy value
+	NoSniff               bool          `env:"SECURE_NO_SNIFF" default:"true"`                                   // NoSniff sets X-Content-Type-Options: nosniff
+}
+
+// LoadSecureHeadersConfig loads security header configuration from environment variables
+// with defaults. HSTS uses the server's HSTS_MAX_AGE and HSTS_INCLUDE_SUBDOMAINS.
+func LoadSecureHeadersConfig() SecureHeadersConfig {
+	var headersConfig SecureHeadersConfig
+	if err := env.Bind(&headersConfig); err != nil {
+		log.Warning("⚠️ Invalid security headers configuration: " + err.Error())
+	}
+	server := config.Get().Server
+	headersConfig.HSTSMaxAge = server.HSTSMaxAge
+	headersConfig.HSTSIncludeSubdomains = server.HSTSIncludeSubdomains
+	return headersConfig
+}
+
+// SecureHeaders creates a middleware that sets security headers on every response.
+// HSTS is only sent on TLS requests, or when a trusted proxy reports X-Forwarded-Proto:
+// https, since browsers ignore it over plain HTTP and clients can set the header themselves.
+//
+// Example:
+//
+//	headers := middleware.LoadSecureHeadersConfig()
+//	headers.ContentSecurityPolicy = "default-src 'self'"
+//	handler := server.ChainMiddlewares(router, middleware.SecureHeaders(headers))
+func SecureHeaders(config SecureHeadersConfig) func(http.Handler) http.Handler {
+	trusted, err := geo.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		log.Error("❌ Invalid trusted proxies, ignoring X-Forwarded-Proto: " + err.Error())
+	}
+
+	hsts := ""
+	if config.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d", int64(config.HSTSMaxAge.Seconds()))
+		if config.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			if hsts != "" && (r.TLS != nil || forwardedHTTPS(r, trusted)) {
+				header.Set("Strict-Transport-Security", hsts)
+			}
+			if config.FrameOptions != "" {
+				header.Set("X-Frame-Options", config.FrameOptions)
+			}
+			if config.ContentSecurityPolicy != "" {
+				header.Set("Content-Security-Policy", config.ContentSecurityPolicy)
+			}
+			if config.ReferrerPolicy != "" {
+				header.Set("Referrer-Policy", config.ReferrerPolicy)
+			}
+			if config.PermissionsPolicy != "" {
+				header.Set("Permissions-Policy", config.PermissionsPolicy)
+			}
+			if config.NoSniff {
+				header.Set("X-Content-Type-Options", "nosniff")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedHTTPS reports whether a trusted proxy forwarded the request from HTTPS.
+func forwardedHTTPS(r *http.Request, trusted []netip.Prefix) bool {
+	if len(trusted) == 0 || r.Header.Get("X-Forwarded-Proto") != "https" {
+		return false
+	}
+	remote, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range trusted {
+		if prefix.Contains(remote.Addr().Unmap()) {
+			return true
+		}
+	}
+	return false
+}
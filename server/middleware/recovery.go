@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"      // fmt provides formatting of panic values.
+	"net/http" // http provides the middleware types.
+	"runtime"  // runtime provides the stack trace.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides the JSON error response.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Recovery creates a middleware that recovers from panics in handlers, logs the panic
+// with its stack trace, and responds with a JSON 500 instead of dropping the
+// connection. http.ErrAbortHandler is re-panicked, as net/http expects.
+//
+// Example:
+//
+//	handler := server.ChainMiddlewares(router, middleware.Recovery())
+func Recovery() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				log.Error(fmt.Sprintf("🚨 PANIC in %s %s: %v", r.Method, r.URL.Path, recovered))
+				buf := make([]byte, 4096)
+				n := runtime.Stack(buf, false)
+				log.Warning(fmt.Sprintf("Stack trace: %s", string(buf[:n])))
+
+				helpers.RespondWithJSON(w, http.StatusInternalServerError, "internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"           // context provides detection of upstream timeouts.
+	"errors"            // errors provides matching of timeout errors.
+	"net"               // net provides the upstream dialer.
+	"net/http"          // http provides the proxy handler and transport.
+	"net/http/httputil" // httputil provides the reverse proxy.
+	"net/url"           // url provides parsing of the upstream address.
+	"strings"           // strings provides prefix stripping.
+	"time"              // time provides the upstream timeouts.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error creation and JSON responses.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// ProxyOptions controls how Proxy forwards requests to an upstream.
+type ProxyOptions struct {
+	StripPrefix  bool                     // StripPrefix removes prefix from the path before forwarding
+	RewritePath  func(path string) string // RewritePath rewrites the path after stripping, e.g. "/v1/x" to "/api/x"
+	Headers      http.Header              // Headers are set on every upstream request, e.g. an internal auth token
+	PreserveHost bool                     // PreserveHost forwards the client's Host header instead of the upstream host
+	Timeout      time.Duration            // Timeout bounds connecting and waiting for response headers (default 30s)
+}
+
+// timeout returns the configured upstream timeout or the default.
+func (o ProxyOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return o.Timeout
+}
+
+// Proxy creates a reverse proxy handler forwarding requests under prefix to target,
+// e.g. "http://billing:8080". X-Forwarded-For, X-Forwarded-Host, and
+// X-Forwarded-Proto are set for the upstream. An unreachable upstream answers 502
+// and one exceeding Timeout answers 504. Streaming responses are flushed as they
+// arrive, so the timeout does not cut long downloads.
+//
+// Example:
+//
+//	billing, err := server.Proxy("/billing", "http://billing:8080", server.ProxyOptions{
+//	    StripPrefix: true, // /billing/invoices -> /invoices
+//	    Timeout:     10 * time.Second,
+//	})
+//	mux.Handle("/billing/", billing)
+func Proxy(prefix, target string, opts ProxyOptions) (http.Handler, error) {
+	upstream, err := url.Parse(target)
+	if err != nil {
+		return nil, helpers.WrapError(err, "invalid proxy target")
+	}
+	if upstream.Scheme != "http" && upstream.Scheme != "https" || upstream.Host == "" {
+		return nil, helpers.CreateErrorf("proxy target must be an absolute http or https URL, got %q", target)
+	}
+
+	timeout := opts.timeout()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = timeout
+	transport.ResponseHeaderTimeout = timeout
+
+	proxy := &httputil.ReverseProxy{
+		Transport:     transport,
+		FlushInterval: -1,
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(upstream)
+			pr.SetXForwarded()
+
+			// SetURL joined the upstream path with the incoming one; rewrite the
+			// incoming part only
+			path := pr.In.URL.Path
+			if opts.StripPrefix {
+				path = "/" + strings.TrimPrefix(strings.TrimPrefix(path, prefix), "/")
+			}
+			if opts.RewritePath != nil {
+				path = opts.RewritePath(path)
+			}
+			pr.Out.URL.Path = singleJoiningSlash(upstream.Path, path)
+			pr.Out.URL.RawPath = ""
+
+			if opts.PreserveHost {
+				pr.Out.Host = pr.In.Host
+			}
+			for name, values := range opts.Headers {
+				pr.Out.Header[http.CanonicalHeaderKey(name)] = values
+			}
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Error("Proxy error for " + r.URL.Path + " to " + upstream.Host + ": " + err.Error())
+			var netErr net.Error
+			if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+				helpers.RespondWithJSON(w, http.StatusGatewayTimeout, "upstream timed out")
+				return
+			}
+			helpers.RespondWithJSON(w, http.StatusBadGateway, "upstream unavailable")
+		},
+	}
+	return proxy, nil
+}
+
+// singleJoiningSlash joins two URL paths with exactly one slash between them.
+func singleJoiningSlash(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case strings.HasSuffix(a, "/") && strings.HasPrefix(b, "/"):
+		return a + b[1:]
+	case !strings.HasSuffix(a, "/") && !strings.HasPrefix(b, "/"):
+		return a + "/" + b
+	}
+	return a + b
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"      // fmt provides formatting of the HSTS header.
+	"net"      // net provides host and port splitting and the listener.
+	"net/http" // http provides the redirect server and handlers.
+	"time"     // time provides the HSTS max-age.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// httpsRedirectHandler redirects every request to the same host and path over HTTPS
+// on tlsPort with 301 Moved Permanently, omitting the port when it is 443.
+func httpsRedirectHandler(tlsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+		if tlsPort != "443" {
+			host = net.JoinHostPort(host, tlsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
+// startRedirectListener binds a plain-HTTP port and serves handler on it in the
+// background. The caller shuts the returned server down with the main one.
+func startRedirectListener(port string, handler http.Handler, config ServerConfig) (*http.Server, error) {
+	redirectServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: config.ReadTimeout,
+		IdleTimeout:       config.IdleTimeout,
+	}
+
+	listener, err := net.Listen("tcp", redirectServer.Addr)
+	if err != nil {
+		log.Error("Failed to start HTTP redirect listener: " + err.Error())
+		return nil, err
+	}
+	log.Info("Redirecting plain HTTP on port " + port + " to HTTPS")
+
+	go func() {
+		if err := redirectServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error("HTTP redirect listener error: " + err.Error())
+		}
+	}()
+	return redirectServer, nil
+}
+
+// hstsHeader creates a middleware that sets Strict-Transport-Security on every response.
+func hstsHeader(maxAge time.Duration, includeSubdomains bool) func(http.Handler) http.Handler {
+	value := fmt.Sprintf("max-age=%d", int64(maxAge.Seconds()))
+	if includeSubdomains {
+		value += "; includeSubDomains"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Strict-Transport-Security", value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context" // context provides the per-hook timeout.
+	"fmt"     // fmt provides formatting of hook log messages.
+	"sync"    // sync provides thread-safe hook registration.
+	"time"    // time provides the hook timeout.
+
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+var (
+	shutdownHooks     []func(ctx context.Context) error // shutdownHooks run after the server stops, in registration order
+	shutdownHooksLock sync.Mutex                        // shutdownHooksLock guards shutdownHooks
+)
+
+// OnShutdown registers a cleanup hook that runs once the server has stopped serving,
+// so in-flight requests can still use the resources it closes. Hooks run one at a time
+// in registration order, each with ShutdownHookTimeout; an error or timeout is logged
+// and the remaining hooks still run. Each hook runs once per process.
+//
+// Example:
+//
+//	server.OnShutdown(func(ctx context.Context) error {
+//		scheduler.Stop()
+//		return nil
+//	})
+//	server.OnShutdown(func(ctx context.Context) error {
+//		return db.Close()
+//	})
+//	server.StartServer(router)
+func OnShutdown(hook func(ctx context.Context) error) {
+	shutdownHooksLock.Lock()
+	defer shutdownHooksLock.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// runShutdownHooks runs and clears the registered hooks. A hook that ignores its
+// context is abandoned after the timeout so shutdown cannot hang.
+func runShutdownHooks(timeout time.Duration) {
+	shutdownHooksLock.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownHooksLock.Unlock()
+
+	for i, hook := range hooks {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		result := make(chan error, 1)
+		go func() {
+			result <- hook(ctx)
+		}()
+
+		select {
+		case err := <-result:
+			if err != nil {
+				log.Error(fmt.Sprintf("Shutdown hook %d failed: %s", i+1, err.Error()))
+			}
+		case <-ctx.Done():
+			log.Error(fmt.Sprintf("Shutdown hook %d timed out after %v", i+1, timeout))
+		}
+		cancel()
+	}
+
+	if len(hooks) > 0 {
+		log.Info(fmt.Sprintf("Ran %d shutdown hooks", len(hooks)))
+	}
+}
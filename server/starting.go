@@ -1,55 +1,102 @@
 package server
 
 import (
-	"context"    // context provides support for cancellation and timeouts.
-	"crypto/tls" // tls provides support for TLS configuration and certificates.
-	"errors"     // errors provides utilities for error handling.
-	"fmt"        // fmt provides formatting and printing functions.
-	"net/http"   // http provides HTTP server functionality.
-	"os"         // os provides file system operations for checking SSL files.
-	"os/signal"  // signal provides system signal handling.
-	"runtime"    // runtime provides access to system resources like CPU count.
-	"strconv"    // strconv provides string conversion utilities.
-	"syscall"    // syscall provides system call constants.
-	"time"       // time provides functionality for timeouts and durations.
-
-	"github.com/hekimapro/utils/helpers" // helpers provides utility functions for environment variables.
-	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"context"     // context provides support for cancellation and timeouts.
+	"crypto/tls"  // tls provides support for TLS configuration and certificates.
+	"errors"      // errors provides utilities for error handling.
+	"fmt"         // fmt provides formatting and printing functions.
+	"net"         // net provides the TCP listener and its bound address.
+	"net/http"    // http provides HTTP server functionality.
+	"os"          // os provides file system operations for checking SSL files.
+	"os/signal"   // signal provides system signal handling.
+	"runtime"     // runtime provides access to system resources like CPU count.
+	"strconv"     // strconv provides string conversion utilities.
+	"strings"     // strings provides joining of listener addresses for logs.
+	"sync/atomic" // atomic provides the draining flag read by the readiness probe.
+	"syscall"     // syscall provides system call constants.
+	"time"        // time provides functionality for timeouts and durations.
+
+	"github.com/hekimapro/utils/config"            // config provides the shared server configuration.
+	"github.com/hekimapro/utils/health"            // health provides the aggregate /health report.
+	"github.com/hekimapro/utils/log"               // log provides colored logging utilities.
+	"github.com/hekimapro/utils/metrics"           // metrics provides request instrumentation and the Prometheus endpoint.
+	"github.com/hekimapro/utils/server/middleware" // middleware provides the request body size limit.
+	"golang.org/x/crypto/acme/autocert"            // autocert provides Let's Encrypt certificate management.
 )
 
 // ServerConfig holds configuration parameters for the HTTP server.
 // This struct centralizes all server settings for better maintainability.
 type ServerConfig struct {
-	Port            string        // Port specifies the TCP port for the server to listen on
-	SSLKeyPath      string        // SSLKeyPath specifies the file path to the SSL private key
-	SSLCertPath     string        // SSLCertPath specifies the file path to the SSL certificate
-	ReadTimeout     time.Duration // ReadTimeout is the maximum duration for reading the entire request
-	WriteTimeout    time.Duration // WriteTimeout is the maximum duration for writing the response
-	IdleTimeout     time.Duration // IdleTimeout is the maximum duration for idle connections
-	ShutdownTimeout time.Duration // ShutdownTimeout is the duration for graceful shutdown
-	MaxHeaderBytes  int           // MaxHeaderBytes limits the maximum size of request headers
-	MaxConnections  int           // MaxConnections limits concurrent connections (0 = no limit)
+	Port                  string        `env:"PORT"` // Port specifies the TCP port for the server to listen on
+	Listeners             []string      // Listeners replaces Port with one or more addresses: ":8080", "127.0.0.1:9090", or "unix:/run/app.sock"
+	SSLKeyPath            string        `env:"SSL_KEY_PATH"`  // SSLKeyPath specifies the file path to the SSL private key
+	SSLCertPath           string        `env:"SSL_CERT_PATH"` // SSLCertPath specifies the file path to the SSL certificate
+	ReadTimeout           time.Duration // ReadTimeout is the maximum duration for reading the entire request
+	WriteTimeout          time.Duration // WriteTimeout is the maximum duration for writing the response
+	IdleTimeout           time.Duration // IdleTimeout is the maximum duration for idle connections
+	ShutdownTimeout       time.Duration // ShutdownTimeout is the duration for graceful shutdown
+	ShutdownHookTimeout   time.Duration // ShutdownHookTimeout bounds each OnShutdown hook
+	MaxHeaderBytes        int           // MaxHeaderBytes limits the maximum size of request headers
+	MaxBodyBytes          int64         // MaxBodyBytes limits request bodies, answering 413 when exceeded (negative = no limit)
+	MaxConnections        int           // MaxConnections limits concurrent connections (0 = no limit)
+	MetricsEnabled        bool          `env:"METRICS_ENABLED" default:"false"` // MetricsEnabled exposes Prometheus metrics at MetricsPath
+	MetricsPath           string        `env:"METRICS_PATH" default:"/metrics"` // MetricsPath is the metrics endpoint path
+	ACMEDomains           []string      // ACMEDomains enables automatic Let's Encrypt certificates for these domains
+	ACMEEmail             string        // ACMEEmail is the optional contact for certificate expiry notices
+	ACMECacheDir          string        // ACMECacheDir stores issued certificates across restarts
+	ACMEHTTPPort          string        // ACMEHTTPPort serves the HTTP-01 challenge and redirects other traffic to HTTPS
+	HTTPRedirectPort      string        // HTTPRedirectPort is a plain-HTTP port redirecting to HTTPS with certificate files (empty = none)
+	HSTSMaxAge            time.Duration // HSTSMaxAge sends Strict-Transport-Security over HTTPS when positive
+	HSTSIncludeSubdomains bool          // HSTSIncludeSubdomains extends HSTS to every subdomain
+	HealthPath            string        // HealthPath serves the aggregate health report ("-" = disabled, e.g. when the application has its own /health)
+	LivenessPath          string        // LivenessPath serves the liveness probe, 200 while the process runs ("-" = disabled)
+	ReadinessPath         string        // ReadinessPath serves the readiness probe, 503 while a critical check fails or during shutdown ("-" = disabled)
+	ShutdownDrainDelay    time.Duration // ShutdownDrainDelay is how long readiness fails before shutdown starts
 }
 
-// LoadConfig loads server configuration from environment variables with defaults.
-// Returns a ServerConfig struct with validated and default values.
+// maxBodyBytes maps MAX_BODY_BYTES to ServerConfig, where zero means "use the fallback"
+// and a negative value disables the limit.
+func maxBodyBytes(configured int64) int64 {
+	if configured <= 0 {
+		return -1
+	}
+	return configured
+}
+
+// LoadConfig loads server configuration from the server section of the shared
+// configuration with defaults. Returns a ServerConfig struct with validated and default values.
 func LoadConfig() ServerConfig {
-	port := helpers.GetENVValue("port")
-	if port == "" {
-		port = "8080"
-		log.Warning(".env PORT is not set, defaulting to 8080")
+	settings := config.Get()
+	if err := settings.Err(config.SectionServer); err != nil {
+		log.Error("❌ Invalid server configuration: " + err.Error())
 	}
 
 	return ServerConfig{
-		Port:            port,
-		SSLKeyPath:      helpers.GetENVValue("ssl key path"),
-		SSLCertPath:     helpers.GetENVValue("ssl cert path"),
-		ReadTimeout:     30 * time.Second,
-		WriteTimeout:    30 * time.Second,
-		IdleTimeout:     10 * time.Second,
-		ShutdownTimeout: 10 * time.Second,
-		MaxHeaderBytes:  1 << 20, // 1MB
-		MaxConnections:  0,       // No limit by default
+		Port:                  settings.Server.Port,
+		Listeners:             settings.Server.Listeners,
+		SSLKeyPath:            settings.Server.SSLKeyPath,
+		SSLCertPath:           settings.Server.SSLCertPath,
+		ReadTimeout:           30 * time.Second,
+		WriteTimeout:          30 * time.Second,
+		IdleTimeout:           10 * time.Second,
+		ShutdownTimeout:       10 * time.Second,
+		ShutdownHookTimeout:   5 * time.Second,
+		MaxHeaderBytes:        1 << 20, // 1MB
+		MaxBodyBytes:          maxBodyBytes(settings.Server.MaxBodyBytes),
+		MaxConnections:        0, // No limit by default
+		MetricsEnabled:        settings.Server.MetricsEnabled,
+		MetricsPath:           settings.Server.MetricsPath,
+		ACMEDomains:           settings.Server.ACMEDomains,
+		ACMEEmail:             settings.Server.ACMEEmail,
+		ACMECacheDir:          settings.Server.ACMECacheDir,
+		ACMEHTTPPort:          settings.Server.ACMEHTTPPort,
+		HTTPRedirectPort:      settings.Server.HTTPRedirectPort,
+		HSTSMaxAge:            settings.Server.HSTSMaxAge,
+		HSTSIncludeSubdomains: settings.Server.HSTSIncludeSubdomains,
+		HealthPath:            settings.Server.HealthPath,
+		LivenessPath:          settings.Server.LivenessPath,
+		ReadinessPath:         settings.Server.ReadinessPath,
+		ShutdownDrainDelay:    settings.Server.ShutdownDrainDelay,
 	}
 }
 
@@ -65,8 +112,9 @@ func validatePort(port string) error {
 		return fmt.Errorf("invalid port number: %s", port)
 	}
 
-	if portNum < 1 || portNum > 65535 {
-		return fmt.Errorf("port number %d out of range (1-65535)", portNum)
+	// Port 0 asks the OS for a free port, e.g. in tests
+	if portNum < 0 || portNum > 65535 {
+		return fmt.Errorf("port number %d out of range (0-65535)", portNum)
 	}
 
 	return nil
@@ -161,10 +209,12 @@ func createTLSConfig() *tls.Config {
 	}
 }
 
-// healthCheckHandler creates a basic health check endpoint handler.
-// Returns an http.Handler that responds with a JSON health status.
-// This provides a simple way to monitor server availability at /health.
+// healthCheckHandler creates the health check endpoint handler.
+// Returns an http.Handler that responds with the JSON report of the checks registered
+// in the health package: 200 when healthy or degraded, 503 when a critical check fails.
+// With no checks registered it reports the server itself as healthy.
 func healthCheckHandler() http.Handler {
+	reportHandler := health.Handler()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Only respond to GET requests
 		if r.Method != http.MethodGet {
@@ -172,13 +222,7 @@ func healthCheckHandler() http.Handler {
 			return
 		}
 
-		// Set response headers
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-
-		// Simple JSON response
-		response := `{"status":"healthy","timestamp":"` + time.Now().Format(time.RFC3339) + `"}`
-		w.Write([]byte(response))
+		reportHandler.ServeHTTP(w, r)
 	})
 }
 
@@ -214,19 +258,35 @@ func connectionLimiter(maxConnections int) func(http.Handler) http.Handler {
 }
 
 // wrapHandlerWithHealthAndLimits wraps the provided handler with health endpoint and connection limiting.
-// This internal function creates a new mux that includes the /health endpoint and applies connection limits.
-func wrapHandlerWithHealthAndLimits(handler http.Handler, maxConnections int) http.Handler {
+// This internal function creates a new mux that includes the health, liveness, and readiness
+// endpoints (and /metrics when enabled), records request metrics, and applies connection limits.
+// Readiness fails once draining is set.
+func wrapHandlerWithHealthAndLimits(handler http.Handler, config ServerConfig, draining *atomic.Bool) http.Handler {
 	// Create a new multiplexer
 	mux := http.NewServeMux()
 
-	// Register health check handler at /health
-	mux.Handle("/health", healthCheckHandler())
+	// Register the health report and the Kubernetes liveness and readiness probes
+	if probeEnabled(config.HealthPath) {
+		mux.Handle(config.HealthPath, healthCheckHandler())
+	}
+	if probeEnabled(config.LivenessPath) {
+		mux.Handle(config.LivenessPath, livenessHandler())
+	}
+	if probeEnabled(config.ReadinessPath) {
+		mux.Handle(config.ReadinessPath, readinessHandler(draining))
+	}
+
+	// Register the Prometheus endpoint when enabled
+	if config.MetricsEnabled {
+		metrics.RegisterRuntimeMetrics()
+		mux.Handle(config.MetricsPath, metrics.Handler())
+	}
 
-	// Register main application handler for all other routes
-	mux.Handle("/", handler)
+	// Register main application handler for all other routes, with the body size limit
+	mux.Handle("/", middleware.BodyLimit(config.MaxBodyBytes)(handler))
 
-	// Apply connection limiting if specified
-	wrappedHandler := connectionLimiter(maxConnections)(mux)
+	// Apply connection limiting if specified, counting rejected requests in the metrics
+	wrappedHandler := metrics.InstrumentHandler(connectionLimiter(config.MaxConnections)(mux))
 
 	return wrappedHandler
 }
@@ -245,7 +305,7 @@ func ChainMiddlewares(finalHandler http.Handler, middlewares ...func(http.Handle
 
 // StartServer starts an HTTP or HTTPS server with graceful shutdown support.
 // Uses the provided handler and configuration, supporting TLS for Production mode.
-// This function maintains backward compatibility while adding health endpoint at /health
+// This function maintains backward compatibility while adding a health endpoint at HEALTH_PATH (/health by default)
 // and optional connection limiting.
 //
 // Parameters:
@@ -261,31 +321,230 @@ func ChainMiddlewares(finalHandler http.Handler, middlewares ...func(http.Handle
 //	    log.Fatal("Server failed: " + err.Error())
 //	}
 func StartServer(handler http.Handler) error {
-	// Set the number of OS threads to the number of CPU cores for optimal performance
-	runtime.GOMAXPROCS(runtime.NumCPU())
+	// Set up context for graceful shutdown on OS signals
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Load configuration from environment variables
-	config := LoadConfig()
+	return Run(ctx, handler)
+}
 
-	// Validate port configuration
-	if err := validatePort(config.Port); err != nil {
-		return fmt.Errorf("port validation failed: %w", err)
-	}
+// Run starts an HTTP or HTTPS server like StartServer, but shuts it down gracefully
+// when ctx is cancelled instead of installing its own signal handler. Use it when
+// the caller already owns the process lifecycle, e.g. app.Runner.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	go func() { errs <- server.Run(ctx, router) }()
+//	...
+//	cancel() // graceful shutdown
+func Run(ctx context.Context, handler http.Handler) error {
+	return run(ctx, handler, LoadConfig())
+}
 
+// StartServerWithConfig starts the server like StartServer with settings given in
+// code. Zero fields fall back to the environment and defaults of LoadConfig, and
+// metrics are exposed when enabled either here or by METRICS_ENABLED.
+//
+// Example:
+//
+//	err := server.StartServerWithConfig(router, server.ServerConfig{
+//	    Port:           "9000",
+//	    WriteTimeout:   2 * time.Minute, // long report downloads
+//	    MaxConnections: 500,
+//	})
+func StartServerWithConfig(handler http.Handler, config ServerConfig) error {
 	// Set up context for graceful shutdown on OS signals
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// Determine server environment (Production or Development)
-	env := DetermineEnvironment(config.SSLKeyPath, config.SSLCertPath)
+	return run(ctx, handler, config.withFallback(LoadConfig()))
+}
+
+// withFallback fills the zero fields of c from fallback.
+func (c ServerConfig) withFallback(fallback ServerConfig) ServerConfig {
+	if c.Port == "" {
+		c.Port = fallback.Port
+	}
+	if c.SSLKeyPath == "" && c.SSLCertPath == "" {
+		c.SSLKeyPath, c.SSLCertPath = fallback.SSLKeyPath, fallback.SSLCertPath
+	}
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = fallback.ReadTimeout
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = fallback.WriteTimeout
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = fallback.IdleTimeout
+	}
+	if c.ShutdownTimeout == 0 {
+		c.ShutdownTimeout = fallback.ShutdownTimeout
+	}
+	if c.ShutdownHookTimeout == 0 {
+		c.ShutdownHookTimeout = fallback.ShutdownHookTimeout
+	}
+	if c.MaxBodyBytes == 0 {
+		c.MaxBodyBytes = fallback.MaxBodyBytes
+	}
+	if c.MaxHeaderBytes == 0 {
+		c.MaxHeaderBytes = fallback.MaxHeaderBytes
+	}
+	if c.MaxConnections == 0 {
+		c.MaxConnections = fallback.MaxConnections
+	}
+	c.MetricsEnabled = c.MetricsEnabled || fallback.MetricsEnabled
+	if c.MetricsPath == "" {
+		c.MetricsPath = fallback.MetricsPath
+	}
+	if len(c.ACMEDomains) == 0 {
+		c.ACMEDomains = fallback.ACMEDomains
+	}
+	if c.ACMEEmail == "" {
+		c.ACMEEmail = fallback.ACMEEmail
+	}
+	if len(c.Listeners) == 0 {
+		c.Listeners = fallback.Listeners
+	}
+	if c.ACMECacheDir == "" {
+		c.ACMECacheDir = fallback.ACMECacheDir
+	}
+	if c.ACMEHTTPPort == "" {
+		c.ACMEHTTPPort = fallback.ACMEHTTPPort
+	}
+	if c.HTTPRedirectPort == "" {
+		c.HTTPRedirectPort = fallback.HTTPRedirectPort
+	}
+	if c.HSTSMaxAge == 0 {
+		c.HSTSMaxAge = fallback.HSTSMaxAge
+	}
+	c.HSTSIncludeSubdomains = c.HSTSIncludeSubdomains || fallback.HSTSIncludeSubdomains
+	if c.HealthPath == "" {
+		c.HealthPath = fallback.HealthPath
+	}
+	if c.LivenessPath == "" {
+		c.LivenessPath = fallback.LivenessPath
+	}
+	if c.ReadinessPath == "" {
+		c.ReadinessPath = fallback.ReadinessPath
+	}
+	if c.ShutdownDrainDelay == 0 {
+		c.ShutdownDrainDelay = fallback.ShutdownDrainDelay
+	}
+	return c
+}
+
+// run starts the server with a resolved configuration and shuts it down when ctx ends.
+func run(ctx context.Context, handler http.Handler, config ServerConfig) error {
+	running, err := start(ctx, handler, config, true)
+	if err != nil {
+		return err
+	}
+	return running.Wait()
+}
+
+// RunningServer is a server started by StartServerWithContext.
+type RunningServer struct {
+	addrs []net.Addr    // addrs are the bound listener addresses, in configuration order
+	tls   bool          // tls reports whether the listeners serve HTTPS
+	done  chan struct{} // done is closed once the server has stopped
+	err   error         // err is the serve or shutdown error, set before done is closed
+}
+
+// Addr returns the address the server listens on, with the actual port when
+// ServerConfig.Port is "0". With several listeners it is the first one.
+func (s *RunningServer) Addr() net.Addr {
+	return s.addrs[0]
+}
+
+// Addrs returns the addresses of every listener, in the order of ServerConfig.Listeners.
+func (s *RunningServer) Addrs() []net.Addr {
+	return s.addrs
+}
+
+// URL returns the base URL of the first TCP listener on the loopback interface, e.g.
+// "http://127.0.0.1:41234", for tests and local clients. It is empty when the
+// server only listens on Unix sockets.
+func (s *RunningServer) URL() string {
+	scheme := "http"
+	if s.tls {
+		scheme = "https"
+	}
+	for _, addr := range s.addrs {
+		if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+			return scheme + "://127.0.0.1:" + strconv.Itoa(tcpAddr.Port)
+		}
+	}
+	return ""
+}
+
+// Done returns a channel closed once the server has stopped.
+func (s *RunningServer) Done() <-chan struct{} {
+	return s.done
+}
+
+// Wait blocks until the server has stopped and returns the serve or shutdown error,
+// or nil after a graceful shutdown.
+func (s *RunningServer) Wait() error {
+	<-s.done
+	return s.err
+}
+
+// StartServerWithContext starts the server in the background and returns once it is
+// listening. Cancelling ctx shuts it down gracefully; Wait returns when that is done.
+// Zero config fields fall back to LoadConfig like StartServerWithConfig, and Port "0"
+// binds a free port, reported by Addr and URL.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	running, err := server.StartServerWithContext(ctx, router, server.ServerConfig{Port: "0"})
+//	resp, err := http.Get(running.URL() + "/health")
+//	cancel()
+//	err = running.Wait()
+func StartServerWithContext(ctx context.Context, handler http.Handler, config ServerConfig) (*RunningServer, error) {
+	return start(ctx, handler, config.withFallback(LoadConfig()), true)
+}
+
+// start binds the listeners, serves in the background, and shuts down when ctx ends,
+// running the OnShutdown hooks afterwards when runHooks is set.
+func start(ctx context.Context, handler http.Handler, config ServerConfig, runHooks bool) (*RunningServer, error) {
+	// Set the number of OS threads to the number of CPU cores for optimal performance
+	runtime.GOMAXPROCS(runtime.NumCPU())
+
+	// Validate port configuration
+	if err := validatePort(config.Port); err != nil {
+		return nil, fmt.Errorf("port validation failed: %w", err)
+	}
+
+	// Determine server environment (Production or Development); ACME domains take
+	// precedence over certificate files
+	var acmeManager *autocert.Manager
+	env := "Production"
+	if len(config.ACMEDomains) > 0 {
+		acmeManager = newACMEManager(config)
+	} else {
+		env = DetermineEnvironment(config.SSLKeyPath, config.SSLCertPath)
+	}
 
 	// Log server startup details with configuration
-	log.Info(fmt.Sprintf("Starting %s server on port %s", env, config.Port))
+	if len(config.Listeners) > 0 {
+		log.Info(fmt.Sprintf("Starting %s server on %s", env, strings.Join(config.Listeners, ", ")))
+	} else {
+		log.Info(fmt.Sprintf("Starting %s server on port %s", env, config.Port))
+	}
 	log.Info(fmt.Sprintf("Timeouts - Read: %v, Write: %v, Idle: %v, Shutdown: %v",
 		config.ReadTimeout, config.WriteTimeout, config.IdleTimeout, config.ShutdownTimeout))
 
 	// Wrap the handler with health endpoint and connection limiting
-	wrappedHandler := wrapHandlerWithHealthAndLimits(handler, config.MaxConnections)
+	var draining atomic.Bool
+	wrappedHandler := wrapHandlerWithHealthAndLimits(handler, config, &draining)
+
+	// Tell browsers to use HTTPS only; the header is ignored over plain HTTP
+	if env != "Development" && config.HSTSMaxAge > 0 {
+		wrappedHandler = hstsHeader(config.HSTSMaxAge, config.HSTSIncludeSubdomains)(wrappedHandler)
+		log.Info(fmt.Sprintf("HSTS enabled (max-age %v)", config.HSTSMaxAge))
+	}
 
 	// Log connection limiting status
 	if config.MaxConnections > 0 {
@@ -295,7 +554,18 @@ func StartServer(handler http.Handler) error {
 	}
 
 	// Log health endpoint availability
-	log.Info("Health endpoint available at: /health")
+	if probeEnabled(config.HealthPath) {
+		log.Info("Health endpoint available at: " + config.HealthPath)
+	}
+	if probeEnabled(config.LivenessPath) {
+		log.Info("Liveness endpoint available at: " + config.LivenessPath)
+	}
+	if probeEnabled(config.ReadinessPath) {
+		log.Info("Readiness endpoint available at: " + config.ReadinessPath)
+	}
+	if config.MetricsEnabled {
+		log.Info("Metrics endpoint available at: " + config.MetricsPath)
+	}
 
 	// Configure the HTTP server with timeouts and limits
 	server := &http.Server{
@@ -307,73 +577,131 @@ func StartServer(handler http.Handler) error {
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
 
+	// Bind before returning so the addresses are known and bind errors reach the caller
+	listeners, err := listenAll(config)
+	if err != nil {
+		log.Error("Failed to start listener: " + err.Error())
+		return nil, err
+	}
+
+	// auxiliary lists plain-HTTP servers started next to the main one, shut down with it
+	var auxiliary []*http.Server
+
+	if env == "Development" {
+		// Serve HTTP in Development mode
+		log.Info("Launching HTTP server (Development)")
+	} else if acmeManager != nil {
+		// Serve HTTPS with Let's Encrypt certificates
+		log.Info("Launching HTTPS server (Production) with ACME certificates")
+		tlsConfig := createACMETLSConfig(acmeManager)
+		for i := range listeners {
+			listeners[i] = tls.NewListener(listeners[i], tlsConfig)
+		}
+	} else {
+		// Serve HTTPS in Production mode with TLS
+		log.Info("Launching HTTPS server (Production) with TLS")
+
+		// Create secure TLS configuration
+		tlsConfig := createTLSConfig()
+
+		// Load the SSL certificate and key pair
+		cert, loadErr := tls.LoadX509KeyPair(config.SSLCertPath, config.SSLKeyPath)
+		if loadErr != nil {
+			log.Error("Failed to load SSL cert and key: " + loadErr.Error())
+			closeListeners(listeners)
+			return nil, loadErr
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+
+		// Wrap the listeners with TLS
+		for i := range listeners {
+			listeners[i] = tls.NewListener(listeners[i], tlsConfig)
+		}
+	}
+
+	// Next to HTTPS, listen on a plain-HTTP port that redirects to HTTPS and, in ACME
+	// mode, answers HTTP-01 challenges
+	if env != "Development" {
+		redirectPort, redirectHandler := config.HTTPRedirectPort, httpsRedirectHandler(config.Port)
+		if acmeManager != nil {
+			redirectPort, redirectHandler = config.ACMEHTTPPort, acmeManager.HTTPHandler(redirectHandler)
+		}
+		if redirectPort != "" {
+			redirectServer, err := startRedirectListener(redirectPort, redirectHandler, config)
+			if err != nil {
+				closeListeners(listeners)
+				return nil, err
+			}
+			auxiliary = append(auxiliary, redirectServer)
+		}
+	}
+
+	running := &RunningServer{tls: env != "Development", done: make(chan struct{})}
+	for _, listener := range listeners {
+		running.addrs = append(running.addrs, listener.Addr())
+	}
+
 	// Create a channel to receive server errors
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, len(listeners))
+
+	// Serve each listener in a goroutine, sending any error except graceful shutdown to the channel
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+				log.Error("Server error: " + err.Error())
+				serverErrors <- err
+			}
+		}(listener)
+	}
 
-	// Start the server in a goroutine to handle HTTP or HTTPS based on environment
+	// Wait for either a context cancellation (shutdown signal) or a server error
 	go func() {
-		var err error
-		if env == "Development" {
-			// Start an HTTP server in Development mode
-			log.Info("Launching HTTP server (Development)")
-			err = server.ListenAndServe()
-		} else {
-			// Start an HTTPS server in Production mode with TLS
-			log.Info("Launching HTTPS server (Production) with TLS")
-
-			// Create secure TLS configuration
-			tlsConfig := createTLSConfig()
-
-			// Load the SSL certificate and key pair
-			cert, loadErr := tls.LoadX509KeyPair(config.SSLCertPath, config.SSLKeyPath)
-			if loadErr != nil {
-				log.Error("Failed to load SSL cert and key: " + loadErr.Error())
-				serverErrors <- loadErr
-				return
+		defer close(running.done)
+		// Close resources registered with OnShutdown once serving has stopped
+		if runHooks {
+			defer runShutdownHooks(config.ShutdownHookTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			// Handle graceful shutdown on context cancellation
+			log.Info("Received shutdown signal, shutting down server gracefully...")
+
+			// Fail readiness first so load balancers stop sending traffic before
+			// connections are closed
+			draining.Store(true)
+			if config.ShutdownDrainDelay > 0 {
+				log.Info(fmt.Sprintf("Draining: readiness failing for %v before shutdown", config.ShutdownDrainDelay))
+				time.Sleep(config.ShutdownDrainDelay)
 			}
-			tlsConfig.Certificates = []tls.Certificate{cert}
 
-			// Create a TLS listener for the server
-			listener, listenErr := tls.Listen("tcp", server.Addr, tlsConfig)
-			if listenErr != nil {
-				log.Error("Failed to start TLS listener: " + listenErr.Error())
-				serverErrors <- listenErr
+			// Create a timeout context for shutdown
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+			defer cancel()
+
+			// Stop the plain-HTTP listeners first, then the main server gracefully
+			for _, auxiliaryServer := range auxiliary {
+				auxiliaryServer.Shutdown(shutdownCtx)
+			}
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				log.Error("Error during server shutdown: " + err.Error())
+				running.err = err
 				return
 			}
 
-			// Start the HTTPS server with TLS listener
-			err = server.Serve(listener)
-		}
+			// Log successful shutdown
+			log.Success("Server shutdown completed successfully")
 
-		// Send any server errors (except graceful shutdown) to the error channel
-		if err != nil && err != http.ErrServerClosed {
-			log.Error("Server error: " + err.Error())
-			serverErrors <- err
+		case err := <-serverErrors:
+			// Record any server error received from a serving goroutine, stopping the
+			// remaining listeners
+			server.Close()
+			for _, auxiliaryServer := range auxiliary {
+				auxiliaryServer.Close()
+			}
+			running.err = err
 		}
 	}()
 
-	// Wait for either a context cancellation (shutdown signal) or a server error
-	select {
-	case <-ctx.Done():
-		// Handle graceful shutdown on context cancellation
-		log.Info("Received shutdown signal, shutting down server gracefully...")
-
-		// Create a timeout context for shutdown
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
-		defer cancel()
-
-		// Attempt to shut down the server gracefully
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			log.Error("Error during server shutdown: " + err.Error())
-			return err
-		}
-
-		// Log successful shutdown
-		log.Success("Server shutdown completed successfully")
-		return nil
-
-	case err := <-serverErrors:
-		// Return any server error received from the goroutine
-		return err
-	}
+	return running, nil
 }
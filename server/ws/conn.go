@@ -0,0 +1,221 @@
+package ws
+
+import (
+	"encoding/json" // json provides encoding of outgoing messages.
+	"net/http"      // http provides the upgrade handler.
+	"sync"          // sync provides guarding of the send channel.
+	"time"          // time provides keepalive deadlines.
+
+	"github.com/gorilla/websocket"       // websocket provides the WebSocket protocol.
+	"github.com/hekimapro/utils/helpers" // helpers provides error wrapping and origin matching.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+)
+
+// Conn is a single upgraded WebSocket connection. Incoming messages arrive on
+// Messages and outgoing ones are queued by Send; pings keep the connection alive
+// and a peer that stops answering is disconnected.
+type Conn struct {
+	connection *websocket.Conn
+	config     Config
+	send       chan []byte
+	messages   chan []byte
+	done       chan struct{}
+	closing    chan struct{} // closing is closed by Close so a blocked read pump can exit
+	closed     bool
+	mutex      sync.Mutex
+}
+
+// withDefaults fills unset keepalive and buffer settings, as socket.NewHub does.
+func withDefaults(config Config) Config {
+	if config.PingInterval <= 0 {
+		config.PingInterval = 30 * time.Second
+	}
+	if config.PongTimeout <= config.PingInterval {
+		config.PongTimeout = 2 * config.PingInterval
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+	if config.SendBufferSize <= 0 {
+		config.SendBufferSize = 256
+	}
+	return config
+}
+
+// Upgrade upgrades the request to a WebSocket connection and starts its read and
+// write pumps. Origins outside AllowedOrigins are rejected with 403.
+//
+// Example:
+//
+//	conn, err := ws.Upgrade(w, r, ws.LoadConfig())
+//	if err != nil {
+//	    return
+//	}
+//	go relay(conn)
+func Upgrade(w http.ResponseWriter, r *http.Request, config Config) (*Conn, error) {
+	config = withDefaults(config)
+	upgrader := websocket.Upgrader{CheckOrigin: func(request *http.Request) bool {
+		origin := request.Header.Get("Origin")
+		return len(config.AllowedOrigins) == 0 || origin == "" || helpers.ContainsString(config.AllowedOrigins, origin)
+	}}
+
+	connection, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, helpers.WrapError(err, "websocket upgrade failed")
+	}
+
+	conn := &Conn{
+		connection: connection,
+		config:     config,
+		send:       make(chan []byte, config.SendBufferSize),
+		messages:   make(chan []byte),
+		done:       make(chan struct{}),
+		closing:    make(chan struct{}),
+	}
+	go conn.writePump()
+	go conn.readPump()
+	return conn, nil
+}
+
+// Handler creates an upgrade handler that runs serve for each connection and closes
+// the connection when serve returns.
+//
+// Example:
+//
+//	router.Handle("/echo", ws.Handler(ws.LoadConfig(), func(conn *ws.Conn) {
+//		for message := range conn.Messages() {
+//			conn.SendRaw(message)
+//		}
+//	}))
+func Handler(config Config, serve func(conn *Conn)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, config)
+		if err != nil {
+			log.Errorf("❌ WebSocket upgrade failed for %s | Error: %v", r.RemoteAddr, err)
+			return
+		}
+		defer conn.Close()
+		serve(conn)
+	})
+}
+
+// Messages returns the channel of incoming messages, closed when the connection ends.
+// Reading applies backpressure: the peer's next message waits until this one is received.
+func (conn *Conn) Messages() <-chan []byte {
+	return conn.messages
+}
+
+// Done returns a channel closed when the connection has ended.
+func (conn *Conn) Done() <-chan struct{} {
+	return conn.done
+}
+
+// Send marshals value to JSON and queues it for delivery.
+func (conn *Conn) Send(value any) error {
+	message, err := json.Marshal(value)
+	if err != nil {
+		return helpers.WrapError(err, "failed to marshal websocket message")
+	}
+	return conn.SendRaw(message)
+}
+
+// SendRaw queues an already-encoded text message. A peer whose buffer is full is
+// disconnected rather than blocking the sender.
+func (conn *Conn) SendRaw(message []byte) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	if conn.closed {
+		return ErrClosed
+	}
+
+	select {
+	case conn.send <- message:
+		return nil
+	default:
+		log.Warning("⚠️ WebSocket send buffer full - disconnecting " + conn.connection.RemoteAddr().String())
+		conn.closeLocked()
+		return ErrClosed
+	}
+}
+
+// Close sends any queued messages, then closes the connection with a normal closure.
+func (conn *Conn) Close() {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.closeLocked()
+}
+
+// closeLocked closes the send channel once; the write pump then flushes and closes the connection.
+func (conn *Conn) closeLocked() {
+	if !conn.closed {
+		conn.closed = true
+		close(conn.send)
+		close(conn.closing)
+	}
+}
+
+// readPump delivers incoming messages and handles pong keepalives until the connection closes.
+func (conn *Conn) readPump() {
+	// Closing stops the write pump, which flushes the queue and closes the connection
+	defer func() {
+		conn.Close()
+		close(conn.messages)
+		close(conn.done)
+	}()
+
+	if conn.config.MaxMessageSize > 0 {
+		conn.connection.SetReadLimit(conn.config.MaxMessageSize)
+	}
+	conn.connection.SetReadDeadline(time.Now().Add(conn.config.PongTimeout))
+	conn.connection.SetPongHandler(func(string) error {
+		return conn.connection.SetReadDeadline(time.Now().Add(conn.config.PongTimeout))
+	})
+
+	for {
+		_, message, err := conn.connection.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				log.Warningf("⚠️ Unexpected WebSocket close | Error: %v", err)
+			}
+			return
+		}
+		select {
+		case conn.messages <- message:
+		case <-conn.closing:
+			return
+		}
+	}
+}
+
+// writePump sends queued messages and pings; it flushes the queue and sends a close frame when the connection is closed.
+func (conn *Conn) writePump() {
+	pingTicker := time.NewTicker(conn.config.PingInterval)
+	defer func() {
+		pingTicker.Stop()
+		conn.connection.Close()
+	}()
+
+	for {
+		select {
+		case message, channelOpen := <-conn.send:
+			conn.connection.SetWriteDeadline(time.Now().Add(conn.config.WriteTimeout))
+			if !channelOpen {
+				conn.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+
+			if err := conn.connection.WriteMessage(websocket.TextMessage, message); err != nil {
+				log.Errorf("❌ Failed to write WebSocket message | Error: %v", err)
+				return
+			}
+
+		case <-pingTicker.C:
+			conn.connection.SetWriteDeadline(time.Now().Add(conn.config.WriteTimeout))
+			if err := conn.connection.WriteMessage(websocket.PingMessage, nil); err != nil {
+				log.Warningf("⚠️ Failed to send WebSocket ping | Error: %v", err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package ws provides WebSocket helpers for servers started by the server package:
+// an upgrade handler with read/write pumps and ping/pong keepalive for single
+// connections, and the socket package's broadcast Hub, drained on graceful shutdown.
+package ws
+
+import (
+	"github.com/hekimapro/utils/server" // server provides shutdown hook registration.
+	"github.com/hekimapro/utils/socket" // socket provides the broadcast hub and its configuration.
+)
+
+// Config holds keepalive, size, and origin settings, read from the WEBSOCKET_*
+// environment variables by LoadConfig.
+type Config = socket.HubConfig
+
+// Hub tracks connections and broadcasts to everyone, to rooms, or to users.
+type Hub = socket.Hub
+
+// Client is one connection managed by a Hub.
+type Client = socket.HubClient
+
+// ErrClosed is returned when sending on a closed connection.
+var ErrClosed = socket.ErrClientClosed
+
+// LoadConfig loads WebSocket configuration from environment variables with defaults.
+func LoadConfig() Config {
+	return socket.LoadHubConfig()
+}
+
+// NewHub creates a broadcast hub whose connections are closed with a going-away frame
+// when the server shuts down gracefully.
+//
+// Example:
+//
+//	hub := ws.NewHub(ws.LoadConfig())
+//	hub.OnConnect = func(client *ws.Client) { client.Join("orders") }
+//	router.Handle("/ws", server.ChainMiddlewares(hub, server.JWTMiddleware(jwtConfig)))
+//	hub.BroadcastToRoom("orders", order)
+func NewHub(config Config) *Hub {
+	hub := socket.NewHub(config)
+	server.OnShutdown(hub.Shutdown)
+	return hub
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"context"       // context provides support for cancellation and timeouts.
+	"database/sql"  // sql provides database access.
+	"encoding/json" // json provides serialization of session values.
+	"errors"        // errors provides detection of sql.ErrNoRows.
+	"fmt"           // fmt provides query formatting.
+	"time"          // time provides expiry handling.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+)
+
+// DatabaseStore keeps sessions in a PostgreSQL table. Values are stored as JSON,
+// so numbers are read back as float64 and structs as maps.
+type DatabaseStore struct {
+	DB    *sql.DB // DB is the database connection
+	Table string  // Table is the table name (default "sessions")
+}
+
+// NewDatabaseStore creates a store backed by the given table.
+func NewDatabaseStore(db *sql.DB, table string) *DatabaseStore {
+	return &DatabaseStore{DB: db, Table: helpers.DefaultIfEmpty(table, "sessions")}
+}
+
+// CreateTable creates the sessions table and its expiry index if they do not exist.
+func (d *DatabaseStore) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %[1]s (
+			id TEXT PRIMARY KEY,
+			data JSONB NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS %[1]s_expires_at_idx ON %[1]s (expires_at);`, d.Table)
+
+	if _, err := d.DB.ExecContext(ctx, query); err != nil {
+		return helpers.WrapError(err, "failed to create sessions table")
+	}
+	return nil
+}
+
+// Load returns the record for id if it has not expired.
+func (d *DatabaseStore) Load(ctx context.Context, id string) (*Record, error) {
+	query := fmt.Sprintf("SELECT data FROM %s WHERE id = $1 AND expires_at > NOW()", d.Table)
+
+	var data []byte
+	err := d.DB.QueryRowContext(ctx, query, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to load session")
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, helpers.WrapError(err, "failed to decode session")
+	}
+	return &record, nil
+}
+
+// Save upserts the record with an expiry of now + ttl.
+func (d *DatabaseStore) Save(ctx context.Context, id string, record Record, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return helpers.WrapError(err, "failed to encode session")
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, data, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data, expires_at = EXCLUDED.expires_at`, d.Table)
+
+	if _, err := d.DB.ExecContext(ctx, query, id, data, time.Now().Add(ttl)); err != nil {
+		return helpers.WrapError(err, "failed to save session")
+	}
+	return nil
+}
+
+// Delete removes the record for id.
+func (d *DatabaseStore) Delete(ctx context.Context, id string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", d.Table)
+	if _, err := d.DB.ExecContext(ctx, query, id); err != nil {
+		return helpers.WrapError(err, "failed to delete session")
+	}
+	return nil
+}
+
+// DeleteExpired removes expired sessions and returns how many were deleted.
+// Run it periodically, e.g. from the scheduler package.
+func (d *DatabaseStore) DeleteExpired(ctx context.Context) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE expires_at <= NOW()", d.Table)
+	result, err := d.DB.ExecContext(ctx, query)
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to delete expired sessions")
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,256 @@
+package session
+
+import (
+	"context"         // context provides request-scoped session storage.
+	"crypto/rand"     // rand provides secure session ID generation.
+	"encoding/base64" // base64 provides encoding of session IDs.
+	"net/http"        // http provides cookies and middleware types.
+	"strings"         // strings provides string manipulation utilities.
+	"sync"            // sync provides one-time session commits.
+	"time"            // time provides expiry handling.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides cookie signing and ID hashing.
+	"github.com/hekimapro/utils/env"        // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/helpers"    // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+	"github.com/hekimapro/utils/models"     // models provides the ContextKey type.
+)
+
+// ContextKeySession is the context key under which the middleware stores the *Session.
+const ContextKeySession models.ContextKey = "session"
+
+// Config holds session cookie and expiry configuration.
+type Config struct {
+	CookieName      string        `env:"SESSION_COOKIE_NAME" default:"session_id"`       // CookieName is the session cookie name
+	Secret          string        `env:"SESSION_SECRET"`                                 // Secret signs the cookie (falls back to ENCRYPTION_KEY)
+	IdleTimeout     time.Duration `env:"SESSION_IDLE_TIMEOUT" default:"30" unit:"m"`     // IdleTimeout expires sessions unused for this long
+	AbsoluteTimeout time.Duration `env:"SESSION_ABSOLUTE_TIMEOUT" default:"24" unit:"h"` // AbsoluteTimeout expires sessions this long after creation
+	Domain          string        `env:"SESSION_COOKIE_DOMAIN"`                          // Domain is the cookie domain
+	Path            string        `env:"SESSION_COOKIE_PATH" default:"/"`                // Path is the cookie path
+	Secure          bool          `env:"SESSION_COOKIE_SECURE" default:"true"`           // Secure restricts the cookie to HTTPS
+	SameSite        string        `env:"SESSION_COOKIE_SAME_SITE" default:"lax"`         // SameSite is "lax", "strict", or "none"
+}
+
+// LoadConfig loads session configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid session configuration: " + err.Error())
+	}
+	if config.Secret == "" {
+		config.Secret = env.GetValue("ENCRYPTION_KEY")
+	}
+	return config
+}
+
+// Manager loads and saves sessions around HTTP handlers.
+type Manager struct {
+	store  Store
+	config Config
+}
+
+// NewManager creates a session manager for the given store.
+// Returns an error if no signing secret is configured.
+func NewManager(store Store, config Config) (*Manager, error) {
+	if store == nil {
+		return nil, helpers.CreateError("session store is required")
+	}
+	if config.Secret == "" {
+		return nil, helpers.CreateError("SESSION_SECRET or ENCRYPTION_KEY must be set")
+	}
+	config.CookieName = helpers.DefaultIfEmpty(config.CookieName, "session_id")
+	config.Path = helpers.DefaultIfEmpty(config.Path, "/")
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = 30 * time.Minute
+	}
+	if config.AbsoluteTimeout <= 0 {
+		config.AbsoluteTimeout = 24 * time.Hour
+	}
+	return &Manager{store: store, config: config}, nil
+}
+
+// Middleware loads the request's session (or starts a new one) and saves it before
+// the response headers are written. Handlers access it with FromRequest.
+//
+// Example:
+//
+//	manager, err := session.NewManager(session.NewMemoryStore(), session.LoadConfig())
+//	handler := server.ChainMiddlewares(router, manager.Middleware)
+func (m *Manager) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := m.load(r)
+
+		writer := &sessionWriter{ResponseWriter: w}
+		writer.commit = func() { m.save(r.Context(), w, current) }
+
+		ctx := context.WithValue(r.Context(), ContextKeySession, current)
+		next.ServeHTTP(writer, r.WithContext(ctx))
+
+		// Save sessions for handlers that wrote nothing.
+		writer.commitOnce()
+	})
+}
+
+// FromRequest returns the session stored in the request context by the middleware, or nil.
+func FromRequest(r *http.Request) *Session {
+	return FromContext(r.Context())
+}
+
+// FromContext returns the session stored in ctx by the middleware, or nil.
+func FromContext(ctx context.Context) *Session {
+	current, _ := ctx.Value(ContextKeySession).(*Session)
+	return current
+}
+
+// load resolves the session cookie to a stored session, starting a new one when
+// the cookie is missing, tampered with, or expired.
+func (m *Manager) load(r *http.Request) *Session {
+	now := time.Now()
+
+	cookie, err := r.Cookie(m.config.CookieName)
+	if err == nil {
+		if id, valid := encryption.VerifySignedToken(cookie.Value, m.config.Secret); valid {
+			record, err := m.store.Load(r.Context(), encryption.HashToken(id))
+			if err != nil {
+				log.Error("❌ Failed to load session: " + err.Error())
+			} else if record != nil && !m.expired(record, now) {
+				current := newSession(id, record.CreatedAt)
+				current.LastAccessedAt = record.LastAccessedAt
+				if record.Values != nil {
+					current.values = record.Values
+				}
+				current.isNew = false
+				return current
+			}
+		} else {
+			log.Warning("⚠️ Ignoring session cookie with invalid signature")
+		}
+	}
+
+	id, err := generateID()
+	if err != nil {
+		log.Error("❌ Failed to generate session ID: " + err.Error())
+	}
+	return newSession(id, now)
+}
+
+// expired reports whether a record passed its idle or absolute deadline.
+func (m *Manager) expired(record *Record, now time.Time) bool {
+	return now.After(record.LastAccessedAt.Add(m.config.IdleTimeout)) ||
+		now.After(record.CreatedAt.Add(m.config.AbsoluteTimeout))
+}
+
+// save persists or deletes the session and sets the cookie accordingly.
+func (m *Manager) save(ctx context.Context, w http.ResponseWriter, current *Session) {
+	current.mu.Lock()
+	destroyed, previousID, isNew, empty := current.destroyed, current.regenerated, current.isNew, len(current.values) == 0
+	current.regenerated = ""
+	current.mu.Unlock()
+
+	if previousID != "" {
+		if err := m.store.Delete(ctx, encryption.HashToken(previousID)); err != nil {
+			log.Error("❌ Failed to delete regenerated session: " + err.Error())
+		}
+	}
+
+	if destroyed {
+		if !isNew {
+			if err := m.store.Delete(ctx, encryption.HashToken(current.ID)); err != nil {
+				log.Error("❌ Failed to delete session: " + err.Error())
+			}
+		}
+		http.SetCookie(w, m.cookie("", time.Unix(0, 0), -1))
+		return
+	}
+
+	// Do not persist empty sessions for anonymous visitors.
+	if isNew && empty {
+		return
+	}
+
+	now := time.Now()
+	current.LastAccessedAt = now
+	deadline := current.CreatedAt.Add(m.config.AbsoluteTimeout)
+	ttl := m.config.IdleTimeout
+	if remaining := deadline.Sub(now); remaining < ttl {
+		ttl = remaining
+	}
+
+	if err := m.store.Save(ctx, encryption.HashToken(current.ID), current.record(), ttl); err != nil {
+		log.Error("❌ Failed to save session: " + err.Error())
+		return
+	}
+
+	if isNew || previousID != "" {
+		http.SetCookie(w, m.cookie(encryption.SignToken(current.ID, m.config.Secret), deadline, 0))
+	}
+
+	current.mu.Lock()
+	current.isNew = false
+	current.mu.Unlock()
+}
+
+// cookie builds the session cookie with the configured attributes.
+func (m *Manager) cookie(value string, expires time.Time, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     m.config.CookieName,
+		Value:    value,
+		Path:     m.config.Path,
+		Domain:   m.config.Domain,
+		Expires:  expires,
+		MaxAge:   maxAge,
+		Secure:   m.config.Secure,
+		HttpOnly: true,
+		SameSite: parseSameSite(m.config.SameSite),
+	}
+}
+
+// parseSameSite converts a configuration value to an http.SameSite mode (default Lax).
+func parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// generateID returns a random 256-bit session ID.
+func generateID() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", helpers.WrapError(err, "failed to generate session ID")
+	}
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+// sessionWriter saves the session right before the response headers are sent.
+type sessionWriter struct {
+	http.ResponseWriter
+	once   sync.Once
+	commit func()
+}
+
+// commitOnce saves the session the first time it is called.
+func (s *sessionWriter) commitOnce() {
+	s.once.Do(s.commit)
+}
+
+// WriteHeader saves the session, then writes the status code.
+func (s *sessionWriter) WriteHeader(statusCode int) {
+	s.commitOnce()
+	s.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write saves the session, then writes the body.
+func (s *sessionWriter) Write(data []byte) (int, error) {
+	s.commitOnce()
+	return s.ResponseWriter.Write(data)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (s *sessionWriter) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,45 @@
+package session
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"time"    // time provides expiry handling.
+
+	"github.com/hekimapro/utils/redis"     // redis provides JSON value helpers.
+	goredis "github.com/redis/go-redis/v9" // goredis provides the Redis client.
+)
+
+// RedisStore keeps sessions in Redis, letting Redis expire them. Values are stored
+// as JSON, so numbers are read back as float64 and structs as maps.
+type RedisStore struct {
+	Client goredis.Cmdable // Client is the Redis client
+	Prefix string          // Prefix is prepended to session keys (default "session:")
+}
+
+// NewRedisStore creates a store using the given client and key prefix.
+func NewRedisStore(client goredis.Cmdable, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "session:"
+	}
+	return &RedisStore{Client: client, Prefix: prefix}
+}
+
+// Load returns the record for id if it has not expired.
+func (r *RedisStore) Load(ctx context.Context, id string) (*Record, error) {
+	var record Record
+	found, err := redis.GetJSON(ctx, r.Client, r.Prefix+id, &record)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Save stores the record with a Redis expiry of ttl.
+func (r *RedisStore) Save(ctx context.Context, id string, record Record, ttl time.Duration) error {
+	return redis.SetJSON(ctx, r.Client, r.Prefix+id, record, ttl)
+}
+
+// Delete removes the record for id.
+func (r *RedisStore) Delete(ctx context.Context, id string) error {
+	_, err := redis.Delete(ctx, r.Client, r.Prefix+id)
+	return err
+}
@@ -0,0 +1,104 @@
+// Package session provides cookie-based HTTP sessions backed by pluggable stores.
+// The cookie only carries a random, HMAC-signed session ID; session data lives in the store.
+package session
+
+import (
+	"sync" // sync provides synchronization primitives for session values.
+	"time" // time provides session timestamps.
+)
+
+// Session holds the data of a single user session.
+// Values must be JSON-serializable when used with a persistent store.
+type Session struct {
+	ID             string    // ID is the random session identifier
+	CreatedAt      time.Time // CreatedAt is when the session was created (absolute expiry reference)
+	LastAccessedAt time.Time // LastAccessedAt is when the session was last used (idle expiry reference)
+
+	mu          sync.RWMutex
+	values      map[string]interface{}
+	isNew       bool   // isNew is true until the session has been saved once
+	destroyed   bool   // destroyed marks the session for deletion
+	regenerated string // regenerated holds the previous ID after Regenerate
+}
+
+// newSession creates an empty session with the given ID.
+func newSession(id string, now time.Time) *Session {
+	return &Session{
+		ID:             id,
+		CreatedAt:      now,
+		LastAccessedAt: now,
+		values:         make(map[string]interface{}),
+		isNew:          true,
+	}
+}
+
+// Get returns the value stored under key, or nil if absent.
+func (s *Session) Get(key string) interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[key]
+}
+
+// GetString returns the value stored under key as a string, or "" if absent or not a string.
+func (s *Session) GetString(key string) string {
+	value, _ := s.Get(key).(string)
+	return value
+}
+
+// Set stores a value under key.
+func (s *Session) Set(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// Delete removes the value stored under key.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+}
+
+// Values returns a copy of all session values.
+func (s *Session) Values() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := make(map[string]interface{}, len(s.values))
+	for key, value := range s.values {
+		values[key] = value
+	}
+	return values
+}
+
+// Destroy marks the session for deletion; the store entry and cookie are removed when the response is written.
+func (s *Session) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.destroyed = true
+}
+
+// Regenerate assigns a new session ID while keeping the data. Call it after login
+// or privilege changes to prevent session fixation.
+func (s *Session) Regenerate() error {
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.regenerated == "" && !s.isNew {
+		s.regenerated = s.ID
+	}
+	s.ID = id
+	return nil
+}
+
+// record converts the session to its stored representation.
+func (s *Session) record() Record {
+	return Record{
+		Values:         s.Values(),
+		CreatedAt:      s.CreatedAt,
+		LastAccessedAt: s.LastAccessedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"context" // context provides support for cancellation and timeouts.
+	"sync"    // sync provides synchronization of the sweep time.
+	"time"    // time provides expiry handling.
+
+	"github.com/hekimapro/utils/cache" // cache provides the in-memory store backend.
+)
+
+// Record is the stored representation of a session.
+type Record struct {
+	Values         map[string]interface{} `json:"values"`           // Values holds the session data
+	CreatedAt      time.Time              `json:"created_at"`       // CreatedAt is when the session was created
+	LastAccessedAt time.Time              `json:"last_accessed_at"` // LastAccessedAt is when the session was last used
+}
+
+// Store persists session records. Implementations receive the hashed session ID,
+// never the raw cookie value. Load returns nil (and no error) for unknown or expired sessions.
+type Store interface {
+	Load(ctx context.Context, id string) (*Record, error)                        // Load fetches a session record
+	Save(ctx context.Context, id string, record Record, ttl time.Duration) error // Save stores a record for ttl
+	Delete(ctx context.Context, id string) error                                 // Delete removes a record
+}
+
+// MemoryStore keeps sessions in process memory. Suitable for a single instance or development.
+type MemoryStore struct {
+	cache       *cache.Cache
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+// NewMemoryStore creates an empty in-memory session store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cache: cache.New(cache.Config{})}
+}
+
+// Load returns the record for id if it has not expired.
+func (m *MemoryStore) Load(ctx context.Context, id string) (*Record, error) {
+	value, found := m.cache.Get(id)
+	if !found {
+		return nil, nil
+	}
+	record := value.(Record)
+	return &record, nil
+}
+
+// Save stores the record for ttl and periodically removes expired entries.
+func (m *MemoryStore) Save(ctx context.Context, id string, record Record, ttl time.Duration) error {
+	m.cache.SetWithTTL(id, record, ttl)
+
+	// Sweep expired sessions at most once a minute so abandoned sessions do not accumulate.
+	m.mu.Lock()
+	now := time.Now()
+	sweep := now.Sub(m.lastCleanup) >= time.Minute
+	if sweep {
+		m.lastCleanup = now
+	}
+	m.mu.Unlock()
+	if sweep {
+		m.cache.Cleanup()
+	}
+	return nil
+}
+
+// Delete removes the record for id.
+func (m *MemoryStore) Delete(ctx context.Context, id string) error {
+	m.cache.Delete(id)
+	return nil
+}
+
+// Len returns the number of stored sessions, including expired ones not yet swept.
+func (m *MemoryStore) Len() int {
+	return m.cache.Len()
+}
+
+// StartCleanup removes expired sessions every interval until ctx is cancelled, for
+// stores that see too few saves for the sweep in Save to keep up.
+func (m *MemoryStore) StartCleanup(ctx context.Context, interval time.Duration) {
+	m.cache.StartCleanup(ctx, interval)
+}
@@ -0,0 +1,510 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hekimapro/utils/env"
+	"github.com/hekimapro/utils/helpers"
+	"github.com/hekimapro/utils/log"
+	"github.com/hekimapro/utils/models"
+	"github.com/hekimapro/utils/snowflake"
+)
+
+// UserIDContextKey is the request context key the hub reads the user ID from by default.
+// It matches the key used by the server package's JWT middleware.
+const UserIDContextKey models.ContextKey = "user_id"
+
+// ErrClientClosed is returned when sending to a client whose connection has closed
+var ErrClientClosed = errors.New("websocket client is closed")
+
+// HubConfig holds connection and keepalive settings for a Hub
+type HubConfig struct {
+	AllowedOrigins []string      `env:"WEBSOCKET_ALLOWED_ORIGINS"`                     // AllowedOrigins lists accepted Origin headers (empty allows all)
+	PingInterval   time.Duration `env:"WEBSOCKET_PING_INTERVAL" default:"30" unit:"s"` // PingInterval is how often pings are sent
+	PongTimeout    time.Duration `env:"WEBSOCKET_PONG_TIMEOUT" default:"60" unit:"s"`  // PongTimeout closes connections that stop answering pings
+	WriteTimeout   time.Duration `env:"WEBSOCKET_WRITE_TIMEOUT" default:"10" unit:"s"` // WriteTimeout bounds each write
+	MaxMessageSize int64         `env:"WEBSOCKET_MAX_MESSAGE_SIZE" default:"65536"`    // MaxMessageSize is the largest accepted client message in bytes
+	SendBufferSize int           `env:"WEBSOCKET_SEND_BUFFER" default:"256"`           // SendBufferSize is the number of queued outgoing messages per client
+}
+
+// LoadHubConfig loads hub configuration from environment variables with defaults
+func LoadHubConfig() HubConfig {
+	var config HubConfig
+	if err := env.Bind(&config); err != nil {
+		log.Warningf("⚠️ Invalid WebSocket configuration, using defaults where needed: %v", err)
+	}
+	return config
+}
+
+// HubClient is a single WebSocket connection managed by a Hub
+type HubClient struct {
+	ID     int64  // ID uniquely identifies the connection
+	UserID string // UserID is the authenticated user, empty for anonymous connections
+
+	connection *websocket.Conn
+	hub        *Hub
+	send       chan []byte
+	rooms      map[string]bool
+	closed     bool
+	mutex      sync.RWMutex
+}
+
+// Send marshals value to JSON and queues it for delivery to this client
+func (client *HubClient) Send(value any) error {
+	message, err := json.Marshal(value)
+	if err != nil {
+		return helpers.WrapError(err, "failed to marshal websocket message")
+	}
+	return client.SendRaw(message)
+}
+
+// SendRaw queues an already-encoded text message for delivery to this client.
+// Clients whose buffer is full are disconnected so one slow reader cannot stall the hub.
+func (client *HubClient) SendRaw(message []byte) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.closed {
+		return ErrClientClosed
+	}
+
+	select {
+	case client.send <- message:
+		return nil
+	default:
+		log.Warningf("⚠️ Client %d buffer full - disconnecting", client.ID)
+		client.closeLocked()
+		return ErrClientClosed
+	}
+}
+
+// Join adds the client to a room
+func (client *HubClient) Join(room string) {
+	client.hub.join(client, room)
+}
+
+// Leave removes the client from a room
+func (client *HubClient) Leave(room string) {
+	client.hub.leave(client, room)
+}
+
+// Rooms returns the rooms the client has joined
+func (client *HubClient) Rooms() []string {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	rooms := make([]string, 0, len(client.rooms))
+	for room := range client.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// Close sends any queued messages, then closes the connection with a normal closure
+func (client *HubClient) Close() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.closeLocked()
+}
+
+// closeLocked closes the send channel once; the write pump then flushes and closes the connection
+func (client *HubClient) closeLocked() {
+	if !client.closed {
+		client.closed = true
+		close(client.send)
+	}
+}
+
+// Hub tracks WebSocket clients and delivers messages to everyone, to rooms, or to users
+type Hub struct {
+	config   HubConfig
+	upgrader websocket.Upgrader
+
+	clients map[*HubClient]bool
+	rooms   map[string]map[*HubClient]bool
+	users   map[string]map[*HubClient]bool
+	mutex   sync.RWMutex
+
+	draining    bool
+	connections sync.WaitGroup
+	deliveries  sync.WaitGroup // deliveries tracks broadcasts in flight so Shutdown closes clients after them
+
+	// UserID extracts the user ID from the upgrade request (default reads UserIDContextKey)
+	UserID func(request *http.Request) string
+	// OnConnect is called after a client connects
+	OnConnect func(client *HubClient)
+	// OnMessage is called for every message a client sends
+	OnMessage func(client *HubClient, message []byte)
+	// OnDisconnect is called after a client disconnects
+	OnDisconnect func(client *HubClient)
+}
+
+// NewHub creates a hub. The hub is an http.Handler, so it can be wrapped with the
+// server package's middleware chain (for example JWT authentication).
+//
+// Example:
+//
+//	hub := socket.NewHub(socket.LoadHubConfig())
+//	hub.OnMessage = func(client *socket.HubClient, message []byte) { ... }
+//	router.Handle("/ws", server.ChainMiddlewares(hub, server.JWTMiddleware(jwtConfig)))
+//	hub.SendToUser(userID, notification)
+func NewHub(config HubConfig) *Hub {
+	if config.PingInterval <= 0 {
+		config.PingInterval = 30 * time.Second
+	}
+	if config.PongTimeout <= config.PingInterval {
+		config.PongTimeout = 2 * config.PingInterval
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+	if config.SendBufferSize <= 0 {
+		config.SendBufferSize = 256
+	}
+
+	hub := &Hub{
+		config:  config,
+		clients: make(map[*HubClient]bool),
+		rooms:   make(map[string]map[*HubClient]bool),
+		users:   make(map[string]map[*HubClient]bool),
+		UserID: func(request *http.Request) string {
+			return helpers.GetStringFromContext(request.Context(), UserIDContextKey)
+		},
+	}
+
+	hub.upgrader = websocket.Upgrader{CheckOrigin: hub.checkOrigin}
+	return hub
+}
+
+// checkOrigin accepts requests whose Origin header is in AllowedOrigins (or any origin when the list is empty)
+func (hub *Hub) checkOrigin(request *http.Request) bool {
+	if len(hub.config.AllowedOrigins) == 0 {
+		return true
+	}
+	origin := request.Header.Get("Origin")
+	return origin == "" || helpers.ContainsString(hub.config.AllowedOrigins, origin)
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and registers the client
+func (hub *Hub) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	hub.mutex.RLock()
+	draining := hub.draining
+	hub.mutex.RUnlock()
+
+	if draining {
+		helpers.RespondWithJSON(response, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+
+	websocketConnection, err := hub.upgrader.Upgrade(response, request, nil)
+	if err != nil {
+		log.Errorf("❌ WebSocket upgrade failed for %s | Error: %v", request.RemoteAddr, err)
+		return
+	}
+
+	client := &HubClient{
+		ID:         snowflake.NextID(),
+		UserID:     hub.UserID(request),
+		connection: websocketConnection,
+		hub:        hub,
+		send:       make(chan []byte, hub.config.SendBufferSize),
+		rooms:      make(map[string]bool),
+	}
+
+	hub.register(client)
+
+	go hub.writePump(client)
+	go hub.readPump(client)
+}
+
+// register adds a client to the hub and its user index
+func (hub *Hub) register(client *HubClient) {
+	hub.mutex.Lock()
+	hub.clients[client] = true
+	if client.UserID != "" {
+		if hub.users[client.UserID] == nil {
+			hub.users[client.UserID] = make(map[*HubClient]bool)
+		}
+		hub.users[client.UserID][client] = true
+	}
+	clientCount := len(hub.clients)
+	hub.connections.Add(1)
+	hub.mutex.Unlock()
+
+	log.Successf("✅ WebSocket client connected | ClientID: %d | UserID: %s | Total connections: %d",
+		client.ID, client.UserID, clientCount)
+
+	if hub.OnConnect != nil {
+		hub.safely("connect", func() { hub.OnConnect(client) })
+	}
+}
+
+// unregister removes a client from the hub, its rooms, and its user index
+func (hub *Hub) unregister(client *HubClient) {
+	hub.mutex.Lock()
+	if _, exists := hub.clients[client]; !exists {
+		hub.mutex.Unlock()
+		return
+	}
+
+	delete(hub.clients, client)
+	for room := range client.rooms {
+		delete(hub.rooms[room], client)
+		if len(hub.rooms[room]) == 0 {
+			delete(hub.rooms, room)
+		}
+	}
+	if client.UserID != "" {
+		delete(hub.users[client.UserID], client)
+		if len(hub.users[client.UserID]) == 0 {
+			delete(hub.users, client.UserID)
+		}
+	}
+	clientCount := len(hub.clients)
+	hub.mutex.Unlock()
+
+	client.Close()
+	hub.connections.Done()
+
+	log.Infof("📤 WebSocket client disconnected | ClientID: %d | Remaining connections: %d", client.ID, clientCount)
+
+	if hub.OnDisconnect != nil {
+		hub.safely("disconnect", func() { hub.OnDisconnect(client) })
+	}
+}
+
+// join adds a client to a room
+func (hub *Hub) join(client *HubClient, room string) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	if _, exists := hub.clients[client]; !exists {
+		return
+	}
+	if hub.rooms[room] == nil {
+		hub.rooms[room] = make(map[*HubClient]bool)
+	}
+	hub.rooms[room][client] = true
+
+	client.mutex.Lock()
+	client.rooms[room] = true
+	client.mutex.Unlock()
+}
+
+// leave removes a client from a room
+func (hub *Hub) leave(client *HubClient, room string) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	delete(hub.rooms[room], client)
+	if len(hub.rooms[room]) == 0 {
+		delete(hub.rooms, room)
+	}
+
+	client.mutex.Lock()
+	delete(client.rooms, room)
+	client.mutex.Unlock()
+}
+
+// Broadcast sends value to every connected client and returns how many received it
+func (hub *Hub) Broadcast(value any) int {
+	return hub.sendTo(value, func() map[*HubClient]bool { return hub.clients })
+}
+
+// BroadcastToRoom sends value to every client in room and returns how many received it
+func (hub *Hub) BroadcastToRoom(room string, value any) int {
+	return hub.sendTo(value, func() map[*HubClient]bool { return hub.rooms[room] })
+}
+
+// SendToUser sends value to every connection of userID and returns how many received it
+func (hub *Hub) SendToUser(userID string, value any) int {
+	return hub.sendTo(value, func() map[*HubClient]bool { return hub.users[userID] })
+}
+
+// sendTo delivers value to the clients returned by members, which runs under the hub
+// lock. Nothing is sent once the hub is draining, and the delivery is tracked so
+// Shutdown waits for it before closing client connections.
+func (hub *Hub) sendTo(value any, members func() map[*HubClient]bool) int {
+	hub.mutex.RLock()
+	if hub.draining {
+		hub.mutex.RUnlock()
+		return 0
+	}
+	clients := members()
+	targets := make([]*HubClient, 0, len(clients))
+	for client := range clients {
+		targets = append(targets, client)
+	}
+	hub.deliveries.Add(1)
+	hub.mutex.RUnlock()
+	defer hub.deliveries.Done()
+
+	return hub.deliver(targets, value)
+}
+
+// deliver marshals value once and queues it for each target
+func (hub *Hub) deliver(targets []*HubClient, value any) int {
+	if len(targets) == 0 {
+		return 0
+	}
+
+	message, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("❌ Failed to marshal websocket message | Error: %v", err)
+		return 0
+	}
+
+	sentCount := 0
+	for _, client := range targets {
+		if client.SendRaw(message) == nil {
+			sentCount++
+		}
+	}
+	return sentCount
+}
+
+// ClientCount returns the number of connected clients
+func (hub *Hub) ClientCount() int {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	return len(hub.clients)
+}
+
+// RoomCount returns the number of clients in room
+func (hub *Hub) RoomCount(room string) int {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	return len(hub.rooms[room])
+}
+
+// IsUserOnline reports whether userID has at least one open connection
+func (hub *Hub) IsUserOnline(userID string) bool {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	return len(hub.users[userID]) > 0
+}
+
+// Shutdown stops accepting connections and broadcasts, waits for broadcasts already
+// in flight, flushes queued messages, closes every connection with a going-away
+// frame, and waits for them to finish or ctx to expire
+func (hub *Hub) Shutdown(ctx context.Context) error {
+	hub.mutex.Lock()
+	hub.draining = true
+	clients := make([]*HubClient, 0, len(hub.clients))
+	for client := range hub.clients {
+		clients = append(clients, client)
+	}
+	hub.mutex.Unlock()
+
+	// No new broadcast can start now; let the running ones queue their messages
+	// before the send channels are closed.
+	hub.deliveries.Wait()
+
+	log.Infof("🛑 Draining %d WebSocket connection(s)", len(clients))
+	for _, client := range clients {
+		client.Close()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		hub.connections.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Success("✅ All WebSocket connections closed")
+		return nil
+	case <-ctx.Done():
+		// Force-close whatever did not finish the closing handshake in time.
+		for _, client := range clients {
+			client.connection.Close()
+		}
+		return helpers.WrapError(ctx.Err(), "websocket drain timed out")
+	}
+}
+
+// readPump reads client messages and handles pong keepalives until the connection closes
+func (hub *Hub) readPump(client *HubClient) {
+	defer func() {
+		hub.unregister(client)
+		client.connection.Close()
+	}()
+
+	if hub.config.MaxMessageSize > 0 {
+		client.connection.SetReadLimit(hub.config.MaxMessageSize)
+	}
+	client.connection.SetReadDeadline(time.Now().Add(hub.config.PongTimeout))
+	client.connection.SetPongHandler(func(string) error {
+		return client.connection.SetReadDeadline(time.Now().Add(hub.config.PongTimeout))
+	})
+
+	for {
+		_, message, err := client.connection.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseAbnormalClosure) {
+				log.Warningf("⚠️ Unexpected connection close for client %d | Error: %v", client.ID, err)
+			}
+			return
+		}
+
+		if hub.OnMessage != nil {
+			hub.safely("message", func() { hub.OnMessage(client, message) })
+		}
+	}
+}
+
+// writePump sends queued messages and pings; it flushes the queue and sends a close frame when the client is closed
+func (hub *Hub) writePump(client *HubClient) {
+	pingTicker := time.NewTicker(hub.config.PingInterval)
+	defer func() {
+		pingTicker.Stop()
+		client.connection.Close()
+	}()
+
+	for {
+		select {
+		case message, channelOpen := <-client.send:
+			client.connection.SetWriteDeadline(time.Now().Add(hub.config.WriteTimeout))
+			if !channelOpen {
+				hub.mutex.RLock()
+				closeCode := websocket.CloseNormalClosure
+				if hub.draining {
+					closeCode = websocket.CloseGoingAway
+				}
+				hub.mutex.RUnlock()
+
+				client.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, ""))
+				return
+			}
+
+			if err := client.connection.WriteMessage(websocket.TextMessage, message); err != nil {
+				log.Errorf("❌ Failed to write message to client %d | Error: %v", client.ID, err)
+				return
+			}
+
+		case <-pingTicker.C:
+			client.connection.SetWriteDeadline(time.Now().Add(hub.config.WriteTimeout))
+			if err := client.connection.WriteMessage(websocket.PingMessage, nil); err != nil {
+				log.Warningf("⚠️ Failed to send ping to client %d | Error: %v", client.ID, err)
+				return
+			}
+		}
+	}
+}
+
+// safely runs a callback, recovering and logging panics
+func (hub *Hub) safely(name string, callback func()) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Errorf("🚨 PANIC in WebSocket %s handler: %v", name, recovered)
+		}
+	}()
+	callback()
+}
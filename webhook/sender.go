@@ -0,0 +1,276 @@
+package webhook
+
+import (
+	"bytes"         // bytes provides the request body reader.
+	"context"       // context provides cancellation of deliveries and backoff waits.
+	"encoding/json" // json provides event serialization.
+	"errors"        // errors provides permanent failure detection.
+	"fmt"           // fmt provides formatting and printing functions.
+	"io"            // io provides response draining.
+	"net/http"      // http provides the delivery client.
+	"strconv"       // strconv provides attempt and Retry-After formatting.
+	"time"          // time provides timeouts, backoff, and attempt timing.
+
+	"github.com/google/uuid"             // uuid provides event IDs.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/jobs"    // jobs provides persistent delivery retries.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/hekimapro/utils/metrics" // metrics provides delivery counters.
+	"github.com/hekimapro/utils/retry"   // retry provides the in-process delivery attempts.
+)
+
+// JobType is the job type used by Enqueue and JobHandler.
+const JobType = "webhook.deliver"
+
+// Delivery metrics.
+var (
+	deliveryCount = metrics.NewCounter("webhook_deliveries_total",
+		"Webhook deliveries, by result (delivered or failed).", "result")
+	attemptDuration = metrics.NewHistogram("webhook_attempt_duration_seconds",
+		"Webhook delivery attempt latency in seconds.", nil)
+)
+
+// statusError is a non-2xx response from an endpoint.
+type statusError struct {
+	code       int
+	retryAfter time.Duration
+}
+
+// Error returns the string representation of the status error.
+func (e *statusError) Error() string {
+	return fmt.Sprintf("endpoint answered with status %d", e.code)
+}
+
+// permanent reports whether err is a rejection that retrying will not fix:
+// a 4xx response other than 408 Request Timeout and 429 Too Many Requests.
+func permanent(err error) bool {
+	var status *statusError
+	if !errors.As(err, &status) {
+		return false
+	}
+	return status.code >= 400 && status.code < 500 &&
+		status.code != http.StatusRequestTimeout && status.code != http.StatusTooManyRequests
+}
+
+// Sender delivers events to endpoints.
+type Sender struct {
+	config Config
+	client *http.Client
+
+	// OnDelivery, when set, is called after every Send with the full attempt log,
+	// e.g. to store deliveries for a customer-facing dashboard.
+	OnDelivery func(ctx context.Context, delivery *Delivery)
+
+	// ResolveSecret looks up the signing secret of a queued delivery's endpoint by its
+	// ID or URL when the job runs, since job payloads do not carry secrets. Required
+	// by Enqueue.
+	ResolveSecret func(ctx context.Context, endpoint Endpoint) (string, error)
+}
+
+// NewSender creates a Sender, applying defaults to unset configuration values.
+//
+// Example:
+//
+//	sender := webhook.NewSender(webhook.LoadConfig())
+//	delivery, err := sender.Send(ctx, webhook.Endpoint{URL: customer.WebhookURL, Secret: customer.WebhookSecret},
+//	    webhook.Event{Type: "invoice.paid", Data: invoice})
+func NewSender(config Config) *Sender {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = time.Second
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = time.Minute
+	}
+	config.UserAgent = helpers.DefaultIfEmpty(config.UserAgent, "hekimapro-webhooks/1.0")
+
+	return &Sender{config: config, client: &http.Client{}}
+}
+
+// prepare fills the event ID and timestamp and encodes the body.
+func prepare(event Event) (Event, []byte, error) {
+	if event.Type == "" {
+		return event, nil, helpers.CreateError("webhook event type cannot be empty")
+	}
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return event, nil, helpers.WrapError(err, "failed to encode webhook event")
+	}
+	return event, body, nil
+}
+
+// Send delivers an event, retrying network errors, 5xx, 408, and 429 responses with
+// exponential backoff up to MaxAttempts. Other 4xx responses are not retried.
+// The delivery log is returned even when delivery fails.
+func (s *Sender) Send(ctx context.Context, endpoint Endpoint, event Event) (*Delivery, error) {
+	event, body, err := prepare(event)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &Delivery{EventID: event.ID, EventType: event.Type, URL: endpoint.URL}
+	attempt := 0
+	err = retry.Do(ctx, func(ctx context.Context) error {
+		attempt++
+		err := s.attempt(ctx, endpoint, event, body, attempt, delivery)
+		var status *statusError
+		switch {
+		case err == nil:
+			return nil
+		case permanent(err):
+			return retry.Permanent(err)
+		case errors.As(err, &status) && status.retryAfter > 0:
+			return retry.After(err, min(status.retryAfter, s.config.MaxBackoff))
+		}
+		return err
+	},
+		retry.WithMaxAttempts(s.config.MaxAttempts),
+		retry.WithExponentialBackoff(s.config.BaseBackoff, s.config.MaxBackoff),
+		retry.WithRetryIf(func(error) bool { return true }),
+		retry.WithOnRetry(func(attempt int, err error, delay time.Duration) {
+			log.Warning(fmt.Sprintf("⚠️ Webhook %s to %s failed (attempt %d/%d): %v, retrying in %v",
+				event.Type, endpoint.URL, attempt, s.config.MaxAttempts, err, delay))
+		}),
+	)
+
+	s.finish(ctx, delivery)
+	if err != nil {
+		return delivery, helpers.WrapErrorf(err, "webhook delivery to %s failed after %d attempt(s)", endpoint.URL, len(delivery.Attempts))
+	}
+	return delivery, nil
+}
+
+// attempt makes one signed POST request and appends it to the delivery log.
+func (s *Sender) attempt(ctx context.Context, endpoint Endpoint, event Event, body []byte, number int, delivery *Delivery) (err error) {
+	record := Attempt{Number: number, At: time.Now()}
+	defer func() {
+		record.Duration = time.Since(record.At)
+		if err != nil {
+			record.Error = err.Error()
+		}
+		delivery.Attempts = append(delivery.Attempts, record)
+		attemptDuration.Observe(record.Duration.Seconds())
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return helpers.WrapError(err, "invalid webhook endpoint")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", s.config.UserAgent)
+	request.Header.Set(HeaderID, event.ID)
+	request.Header.Set(HeaderEvent, event.Type)
+	request.Header.Set(HeaderAttempt, strconv.Itoa(number))
+	request.Header.Set(HeaderSignature, Sign(endpoint.Secret, time.Now(), body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	record.StatusCode = response.StatusCode
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		retryAfter, _ := strconv.Atoi(response.Header.Get("Retry-After"))
+		return &statusError{code: response.StatusCode, retryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	return nil
+}
+
+// finish logs the outcome, records metrics, and calls OnDelivery.
+func (s *Sender) finish(ctx context.Context, delivery *Delivery) {
+	if len(delivery.Attempts) > 0 && delivery.Attempts[len(delivery.Attempts)-1].Error == "" {
+		delivery.Delivered = true
+	}
+
+	if delivery.Delivered {
+		deliveryCount.Inc("delivered")
+		log.Success(fmt.Sprintf("✅ Webhook %s delivered to %s", delivery.EventType, delivery.URL))
+	} else {
+		deliveryCount.Inc("failed")
+		log.Error(fmt.Sprintf("❌ Webhook %s to %s failed after %d attempt(s)", delivery.EventType, delivery.URL, len(delivery.Attempts)))
+	}
+
+	if s.OnDelivery != nil {
+		s.OnDelivery(ctx, delivery)
+	}
+}
+
+// Job is the payload of a persistent delivery job.
+type Job struct {
+	Endpoint Endpoint `json:"endpoint"` // Endpoint receives the event
+	Event    Event    `json:"event"`    // Event is delivered with its ID fixed at enqueue time
+}
+
+// Enqueue stores a delivery in the jobs queue so it survives restarts. Register
+// JobHandler on the same manager to process it; the jobs queue then owns retries.
+// The endpoint's secret is not stored with the job: ResolveSecret must be set to
+// look it up when the delivery is sent.
+//
+// Example:
+//
+//	sender.ResolveSecret = func(ctx context.Context, endpoint webhook.Endpoint) (string, error) {
+//	    return customers.WebhookSecret(ctx, endpoint.ID)
+//	}
+//	manager.Register(webhook.JobType, sender.JobHandler())
+//	sender.Enqueue(ctx, manager, webhook.Endpoint{ID: customer.ID, URL: customer.WebhookURL},
+//	    webhook.Event{Type: "order.shipped", Data: order})
+func (s *Sender) Enqueue(ctx context.Context, manager *jobs.Manager, endpoint Endpoint, event Event) (int64, error) {
+	if s.ResolveSecret == nil {
+		return 0, helpers.CreateError("webhook sender has no ResolveSecret for queued deliveries")
+	}
+	event, _, err := prepare(event)
+	if err != nil {
+		return 0, err
+	}
+	return manager.Enqueue(ctx, JobType, Job{Endpoint: endpoint, Event: event})
+}
+
+// JobHandler returns a jobs handler that makes one delivery attempt per job run,
+// leaving backoff to the jobs queue. Permanent rejections (4xx) are logged and not retried.
+func (s *Sender) JobHandler() jobs.Handler {
+	return func(ctx context.Context, job *jobs.Job) error {
+		var payload Job
+		if err := job.Decode(&payload); err != nil {
+			return err
+		}
+
+		if s.ResolveSecret == nil {
+			return helpers.CreateError("webhook sender has no ResolveSecret for queued deliveries")
+		}
+		secret, err := s.ResolveSecret(ctx, payload.Endpoint)
+		if err != nil {
+			return helpers.WrapErrorf(err, "failed to resolve webhook secret for %s", payload.Endpoint.URL)
+		}
+		payload.Endpoint.Secret = secret
+
+		event, body, err := prepare(payload.Event)
+		if err != nil {
+			return err
+		}
+
+		delivery := &Delivery{EventID: event.ID, EventType: event.Type, URL: payload.Endpoint.URL}
+		err = s.attempt(ctx, payload.Endpoint, event, body, job.Attempts, delivery)
+		s.finish(ctx, delivery)
+		if permanent(err) {
+			return nil
+		}
+		return err
+	}
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"    // bytes provides body restoration after verification.
+	"errors"   // errors provides sentinel verification errors.
+	"io"       // io provides body reading.
+	"net/http" // http provides request verification and middleware.
+	"strconv"  // strconv provides timestamp formatting and parsing.
+	"strings"  // strings provides header parsing.
+	"time"     // time provides replay tolerance.
+
+	"github.com/hekimapro/utils/encryption" // encryption provides HMAC-SHA256 signing.
+	"github.com/hekimapro/utils/helpers"    // helpers provides JSON responses and error utilities.
+	"github.com/hekimapro/utils/log"        // log provides colored logging utilities.
+)
+
+// DefaultTolerance is how old a signature may be before Verify rejects it as a replay.
+const DefaultTolerance = 5 * time.Minute
+
+// maxBodySize limits the body read by VerifyRequest.
+const maxBodySize = 1 << 20
+
+// Verification errors.
+var (
+	ErrMissingSignature = errors.New("webhook: missing or malformed signature header")
+	ErrInvalidSignature = errors.New("webhook: signature does not match")
+	ErrExpiredSignature = errors.New("webhook: signature timestamp outside tolerance")
+)
+
+// Sign returns the signature header value for body signed at timestamp.
+//
+// Example:
+//
+//	request.Header.Set(webhook.HeaderSignature, webhook.Sign(secret, time.Now(), body))
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + unix + ",v1=" + encryption.SignHMAC(signedPayload(unix, body), secret)
+}
+
+// signedPayload is the message covered by the signature: "<timestamp>.<body>".
+func signedPayload(unix string, body []byte) []byte {
+	payload := make([]byte, 0, len(unix)+1+len(body))
+	payload = append(payload, unix...)
+	payload = append(payload, '.')
+	return append(payload, body...)
+}
+
+// Verify checks a signature header against body and secret. Signatures older or
+// newer than tolerance are rejected; zero uses DefaultTolerance. Several v1 values
+// are accepted so senders can rotate secrets.
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	var unix string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+
+	age := time.Since(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrExpiredSignature
+	}
+
+	payload := signedPayload(unix, body)
+	for _, signature := range signatures {
+		if encryption.VerifyHMAC(payload, secret, signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// VerifyRequest reads and verifies a delivery received by r with DefaultTolerance.
+// It returns the body and leaves r.Body readable again for the next handler.
+func VerifyRequest(r *http.Request, secret string) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return nil, helpers.WrapError(err, "failed to read webhook body")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := Verify(secret, r.Header.Get(HeaderSignature), body, DefaultTolerance); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// Middleware rejects requests without a valid signature with 401 Unauthorized.
+//
+// Example:
+//
+//	router.Handle("/webhooks/orders", webhook.Middleware(secret)(ordersWebhookHandler))
+func Middleware(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := VerifyRequest(r, secret); err != nil {
+				log.Warning("⚠️ Rejected webhook: " + err.Error())
+				helpers.RespondWithJSON(w, http.StatusUnauthorized, "invalid webhook signature")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package webhook delivers signed events to customer HTTP endpoints with retries
+// and exponential backoff, and verifies those signatures on the receiving side.
+//
+// Each request carries the event as JSON and a signature header of the form
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>">", so receivers
+// can reject forged and replayed deliveries.
+package webhook
+
+import (
+	"time" // time provides event timestamps and backoff durations.
+
+	"github.com/hekimapro/utils/env" // env provides binding of environment variables onto config structs.
+	"github.com/hekimapro/utils/log" // log provides colored logging utilities.
+)
+
+// Header names set on every delivery.
+const (
+	HeaderID        = "X-Webhook-ID"        // HeaderID carries the event ID, stable across retries for deduplication
+	HeaderEvent     = "X-Webhook-Event"     // HeaderEvent carries the event type
+	HeaderSignature = "X-Webhook-Signature" // HeaderSignature carries the timestamped HMAC signature
+	HeaderAttempt   = "X-Webhook-Attempt"   // HeaderAttempt carries the 1-based attempt number
+)
+
+// Config holds webhook delivery configuration.
+type Config struct {
+	Timeout     time.Duration `env:"WEBHOOK_TIMEOUT" default:"10" unit:"s"`               // Timeout bounds a single delivery attempt
+	MaxAttempts int           `env:"WEBHOOK_MAX_ATTEMPTS" default:"5"`                    // MaxAttempts is the attempt limit for Send
+	BaseBackoff time.Duration `env:"WEBHOOK_BASE_BACKOFF" default:"1" unit:"s"`           // BaseBackoff is the delay before the first retry
+	MaxBackoff  time.Duration `env:"WEBHOOK_MAX_BACKOFF" default:"60" unit:"s"`           // MaxBackoff caps the retry delay
+	UserAgent   string        `env:"WEBHOOK_USER_AGENT" default:"hekimapro-webhooks/1.0"` // UserAgent identifies the sender to receivers
+}
+
+// LoadConfig loads webhook configuration from environment variables with defaults.
+func LoadConfig() Config {
+	var config Config
+	if err := env.Bind(&config); err != nil {
+		log.Warning("⚠️ Invalid webhook configuration, using defaults where needed: " + err.Error())
+	}
+	return config
+}
+
+// Endpoint is a customer URL that receives events, with the secret shared with that
+// customer. The secret is never encoded, so it stays out of job payloads and logs.
+type Endpoint struct {
+	ID     string `json:"id,omitempty"` // ID identifies the endpoint to Sender.ResolveSecret for queued deliveries
+	URL    string `json:"url"`          // URL receives POST requests
+	Secret string `json:"-"`            // Secret signs deliveries to this endpoint
+}
+
+// Event is a notification sent to endpoints. The JSON body is the event itself.
+type Event struct {
+	ID        string      `json:"id"`         // ID identifies the event; generated when empty
+	Type      string      `json:"type"`       // Type names the event, e.g. "invoice.paid"
+	CreatedAt time.Time   `json:"created_at"` // CreatedAt is when the event happened; defaults to now
+	Data      interface{} `json:"data"`       // Data is the event payload
+}
+
+// Attempt records one delivery attempt.
+type Attempt struct {
+	Number     int           `json:"number"`          // Number is the 1-based attempt number
+	StatusCode int           `json:"status_code"`     // StatusCode is the response status, or 0 when no response was received
+	Duration   time.Duration `json:"duration"`        // Duration is how long the attempt took
+	Error      string        `json:"error,omitempty"` // Error describes a failed attempt
+	At         time.Time     `json:"at"`              // At is when the attempt started
+}
+
+// Delivery records the attempts made to deliver one event to one endpoint.
+type Delivery struct {
+	EventID   string    `json:"event_id"`   // EventID is the delivered event's ID
+	EventType string    `json:"event_type"` // EventType is the delivered event's type
+	URL       string    `json:"url"`        // URL is the endpoint URL
+	Delivered bool      `json:"delivered"`  // Delivered reports whether an attempt received a 2xx response
+	Attempts  []Attempt `json:"attempts"`   // Attempts lists every attempt in order
+}
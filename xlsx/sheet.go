@@ -0,0 +1,441 @@
+package xlsx
+
+import (
+	"database/sql" // sql provides streaming export of query results.
+	"encoding"     // encoding provides the TextMarshaler interface.
+	"fmt"          // fmt provides formatting and printing functions.
+	"reflect"      // reflect provides struct field access.
+	"strconv"      // strconv provides number parsing.
+	"strings"      // strings provides number format assembly.
+	"time"         // time provides date handling.
+	"unicode/utf8" // utf8 provides character counts for column widths.
+
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/money"   // money provides currency-aware amounts.
+	"github.com/xuri/excelize/v2"        // excelize provides XLSX encoding.
+)
+
+// Struct tags understood by AddSheet and SheetWriter.
+const (
+	tagName   = "xlsx"   // tagName sets the column header; "-" skips the field
+	tagFormat = "format" // tagFormat sets an Excel number format, e.g. "#,##0.00" or "dd/mm/yyyy"
+)
+
+// Column width limits in characters.
+const (
+	minColumnWidth = 8
+	maxColumnWidth = 60
+	sampleRows     = 100 // sampleRows are buffered to size columns before streaming starts
+)
+
+// Default number formats for typed values.
+const (
+	dateFormat     = "yyyy-mm-dd"
+	dateTimeFormat = "yyyy-mm-dd hh:mm"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	moneyType         = reflect.TypeOf(money.Money{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// column maps a struct field to a sheet column.
+type column struct {
+	header string
+	index  []int
+	format string
+}
+
+// columnsOf returns the columns of a struct type in field order, including untagged embedded structs.
+func columnsOf(recordType reflect.Type, parent []int) []column {
+	var columns []column
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		index := append(append([]int{}, parent...), i)
+		header, tagged := field.Tag.Lookup(tagName)
+		if header == "-" {
+			continue
+		}
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, columnsOf(field.Type, index)...)
+			continue
+		}
+		if header == "" {
+			header = field.Name
+		}
+		columns = append(columns, column{header: header, index: index, format: field.Tag.Get(tagFormat)})
+	}
+	return columns
+}
+
+// moneyFormat returns an Excel number format showing the currency symbol and its minor-unit digits.
+func moneyFormat(currency money.Currency) string {
+	format := "#,##0"
+	if exponent := currency.Exponent(); exponent > 0 {
+		format += "." + strings.Repeat("0", exponent)
+	}
+
+	symbol := currency.Symbol()
+	if utf8.RuneCountInString(symbol) > 1 {
+		symbol += " "
+	}
+	return `"` + symbol + `"` + format
+}
+
+// cellValue converts a Go value into an Excel value with its default number format.
+func cellValue(value reflect.Value) (interface{}, string, error) {
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, "", nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Type() {
+	case timeType:
+		t := value.Interface().(time.Time)
+		if t.IsZero() {
+			return nil, "", nil
+		}
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+			return t, dateFormat, nil
+		}
+		return t, dateTimeFormat, nil
+	case moneyType:
+		amount := value.Interface().(money.Money)
+		major, err := strconv.ParseFloat(amount.Decimal(), 64)
+		if err != nil {
+			return nil, "", err
+		}
+		return major, moneyFormat(amount.Currency()), nil
+	}
+
+	if value.Type().Implements(textMarshalerType) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), "", err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), "", nil
+	case reflect.Bool:
+		return value.Bool(), "", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Type() == reflect.TypeOf(time.Duration(0)) {
+			return time.Duration(value.Int()).String(), "", nil
+		}
+		return value.Int(), "", nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), "", nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), "", nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), "", nil
+		}
+	}
+	return fmt.Sprint(value.Interface()), "", nil
+}
+
+// displayWidth estimates how many characters a value occupies when shown.
+func displayWidth(value interface{}, format string) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		// Only the longest line counts for multi-line text.
+		longest := 0
+		for _, line := range strings.Split(v, "\n") {
+			if width := utf8.RuneCountInString(line); width > longest {
+				longest = width
+			}
+		}
+		return longest
+	case time.Time:
+		return len(format)
+	case float64:
+		// Grouping separators, decimals, and a currency prefix add to the digits.
+		return len(strconv.FormatFloat(v, 'f', 2, 64))*4/3 + len(format)/3
+	default:
+		return len(fmt.Sprint(v)) * 4 / 3
+	}
+}
+
+// sheet streams rows to one worksheet. The first sampleRows rows are buffered
+// so column widths can be sized from real data before streaming starts.
+type sheet struct {
+	workbook *Workbook
+	name     string
+	stream   *excelize.StreamWriter
+	header   []string
+	formats  []string
+	widths   []int
+	buffer   [][]interface{}
+	row      int
+	started  bool
+	flushed  bool
+}
+
+// addSheet creates a sheet with the given column headers and per-column number formats.
+func (w *Workbook) addSheet(name string, header, formats []string) (*sheet, error) {
+	stream, err := w.newSheet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	widths := make([]int, len(header))
+	for i, title := range header {
+		widths[i] = utf8.RuneCountInString(title) + 2
+	}
+
+	s := &sheet{workbook: w, name: name, stream: stream, header: header, formats: formats, widths: widths, row: 1}
+	w.sheets = append(w.sheets, s)
+	return s, nil
+}
+
+// add appends a row of values.
+func (s *sheet) add(values []interface{}, formats []string) error {
+	if s.flushed {
+		return helpers.CreateErrorf("sheet %q has already been written", s.name)
+	}
+
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		format := formats[i]
+		if i < len(s.formats) && s.formats[i] != "" {
+			format = s.formats[i]
+		}
+		style, err := s.workbook.numberStyle(format)
+		if err != nil {
+			return err
+		}
+		cells[i] = excelize.Cell{StyleID: style, Value: value}
+
+		if !s.started && i < len(s.widths) {
+			if width := displayWidth(value, format) + 2; width > s.widths[i] {
+				s.widths[i] = width
+			}
+		}
+	}
+
+	if !s.started {
+		s.buffer = append(s.buffer, cells)
+		if len(s.buffer) >= sampleRows {
+			return s.start()
+		}
+		return nil
+	}
+	return s.writeRow(cells, excelize.RowOpts{})
+}
+
+// start sets column widths, freezes and writes the header row, and writes buffered rows.
+func (s *sheet) start() error {
+	s.started = true
+
+	for i, width := range s.widths {
+		width = max(minColumnWidth, min(width, maxColumnWidth))
+		if err := s.stream.SetColWidth(i+1, i+1, float64(width)); err != nil {
+			return helpers.WrapError(err, "failed to set column width")
+		}
+	}
+	if err := s.stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return helpers.WrapError(err, "failed to freeze header row")
+	}
+
+	headerStyle, err := s.workbook.headerStyle()
+	if err != nil {
+		return err
+	}
+	header := make([]interface{}, len(s.header))
+	for i, title := range s.header {
+		header[i] = title
+	}
+	if err := s.writeRow(header, excelize.RowOpts{StyleID: headerStyle, Height: 20}); err != nil {
+		return err
+	}
+
+	for _, cells := range s.buffer {
+		if err := s.writeRow(cells, excelize.RowOpts{}); err != nil {
+			return err
+		}
+	}
+	s.buffer = nil
+	return nil
+}
+
+// writeRow streams one row at the next row number.
+func (s *sheet) writeRow(cells []interface{}, options excelize.RowOpts) error {
+	cell, err := excelize.CoordinatesToCellName(1, s.row)
+	if err != nil {
+		return helpers.WrapError(err, "sheet row limit exceeded")
+	}
+	if err := s.stream.SetRow(cell, cells, options); err != nil {
+		return helpers.WrapErrorf(err, "failed to write row %d", s.row)
+	}
+	s.row++
+	return nil
+}
+
+// flush completes the sheet. It is safe to call more than once.
+func (s *sheet) flush() error {
+	if s.flushed {
+		return nil
+	}
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	s.flushed = true
+	if err := s.stream.Flush(); err != nil {
+		return helpers.WrapErrorf(err, "failed to finish sheet %q", s.name)
+	}
+	return nil
+}
+
+// SheetWriter streams records of type T into a worksheet, one row per record.
+type SheetWriter[T any] struct {
+	sheet   *sheet
+	columns []column
+}
+
+// NewSheetWriter adds a sheet for struct type T (or a pointer to one). Headers come
+// from xlsx tags (or field names); time.Time and money.Money values are formatted
+// as dates and currency amounts. Rows are streamed, so exports of any size are supported.
+//
+// Example:
+//
+//	writer, err := xlsx.NewSheetWriter[Payment](workbook, "Payments")
+//	for rows.Next() {
+//	    ...scan payment...
+//	    if err := writer.Write(payment); err != nil { ... }
+//	}
+func NewSheetWriter[T any](workbook *Workbook, name string) (*SheetWriter[T], error) {
+	recordType := reflect.TypeOf((*T)(nil)).Elem()
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType.Kind() != reflect.Struct {
+		return nil, helpers.CreateErrorf("xlsx: record type must be a struct, got %s", recordType)
+	}
+
+	columns := columnsOf(recordType, nil)
+	header := make([]string, len(columns))
+	formats := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+		formats[i] = column.format
+	}
+
+	sheet, err := workbook.addSheet(name, header, formats)
+	if err != nil {
+		return nil, err
+	}
+	return &SheetWriter[T]{sheet: sheet, columns: columns}, nil
+}
+
+// Write appends one record as a row.
+func (s *SheetWriter[T]) Write(record T) error {
+	value := reflect.ValueOf(&record).Elem()
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return helpers.CreateError("xlsx: cannot write a nil record")
+		}
+		value = value.Elem()
+	}
+
+	values := make([]interface{}, len(s.columns))
+	formats := make([]string, len(s.columns))
+	for i, column := range s.columns {
+		field, err := value.FieldByIndexErr(column.index)
+		if err != nil {
+			continue
+		}
+		if values[i], formats[i], err = cellValue(field); err != nil {
+			return helpers.WrapErrorf(err, "failed to convert column %s", column.header)
+		}
+	}
+	return s.sheet.add(values, formats)
+}
+
+// AddSheet adds a sheet containing records, one row per element.
+//
+// Example:
+//
+//	type Invoice struct {
+//	    Number   string      `xlsx:"Invoice No"`
+//	    Customer string      `xlsx:"Customer"`
+//	    Total    money.Money `xlsx:"Total"`
+//	    IssuedAt time.Time   `xlsx:"Issued" format:"dd/mm/yyyy"`
+//	}
+//	err := xlsx.AddSheet(workbook, "Invoices", invoices)
+func AddSheet[T any](workbook *Workbook, name string, records []T) error {
+	writer, err := NewSheetWriter[T](workbook, name)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddRows adds a sheet from SQL query results, using column names as headers.
+// Rows are streamed one at a time. Returns the number of data rows written.
+// The caller still closes rows.
+//
+// Example:
+//
+//	rows, err := database.QueryWithContext(ctx, db, "SELECT reference, amount, created_at FROM payments")
+//	defer rows.Close()
+//	count, err := workbook.AddRows("Payments", rows)
+func (w *Workbook) AddRows(name string, rows *sql.Rows) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to read result columns")
+	}
+
+	sheet, err := w.addSheet(name, columns, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return count, helpers.WrapError(err, "failed to scan row")
+		}
+
+		cells := make([]interface{}, len(columns))
+		formats := make([]string, len(columns))
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			if cells[i], formats[i], err = cellValue(reflect.ValueOf(value)); err != nil {
+				return count, helpers.WrapErrorf(err, "failed to convert column %s", columns[i])
+			}
+		}
+		if err := sheet.add(cells, formats); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, helpers.WrapError(err, "failed to iterate rows")
+	}
+	return count, nil
+}
@@ -0,0 +1,169 @@
+// Package xlsx produces Excel workbooks from struct slices and SQL rows, with
+// styled headers, automatic column widths, multiple sheets, and streaming output.
+package xlsx
+
+import (
+	"io"       // io provides the output writer.
+	"mime"     // mime provides Content-Disposition formatting.
+	"net/http" // http provides download responses.
+	"strings"  // strings provides file name handling.
+
+	"github.com/hekimapro/utils/file"    // file provides saving workbooks to the upload directory.
+	"github.com/hekimapro/utils/helpers" // helpers provides error utilities.
+	"github.com/hekimapro/utils/log"     // log provides colored logging utilities.
+	"github.com/xuri/excelize/v2"        // excelize provides XLSX encoding.
+)
+
+// ContentType is the MIME type of XLSX files.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Workbook is an Excel file under construction. Each sheet is streamed to a
+// temporary buffer as rows are added, so large exports stay out of memory.
+// Call Close when done to remove temporary files.
+type Workbook struct {
+	file   *excelize.File
+	sheets []*sheet
+	styles map[string]int
+	header int
+}
+
+// New creates an empty workbook.
+//
+// Example:
+//
+//	workbook := xlsx.New()
+//	defer workbook.Close()
+//	err := xlsx.AddSheet(workbook, "Invoices", invoices)
+//	err = workbook.Download(w, "invoices.xlsx")
+func New() *Workbook {
+	return &Workbook{
+		file:   excelize.NewFile(),
+		styles: make(map[string]int),
+	}
+}
+
+// newSheet creates a worksheet, reusing the default sheet for the first one.
+func (w *Workbook) newSheet(name string) (*excelize.StreamWriter, error) {
+	if name == "" {
+		return nil, helpers.CreateError("sheet name cannot be empty")
+	}
+	for _, existing := range w.sheets {
+		if strings.EqualFold(existing.name, name) {
+			return nil, helpers.CreateErrorf("sheet %q already exists", name)
+		}
+	}
+
+	if len(w.sheets) == 0 {
+		if err := w.file.SetSheetName(w.file.GetSheetName(0), name); err != nil {
+			return nil, helpers.WrapErrorf(err, "invalid sheet name %q", name)
+		}
+	} else if _, err := w.file.NewSheet(name); err != nil {
+		return nil, helpers.WrapErrorf(err, "invalid sheet name %q", name)
+	}
+
+	stream, err := w.file.NewStreamWriter(name)
+	if err != nil {
+		return nil, helpers.WrapErrorf(err, "failed to create sheet %q", name)
+	}
+	return stream, nil
+}
+
+// headerStyle returns the shared bold, shaded header style.
+func (w *Workbook) headerStyle() (int, error) {
+	if w.header != 0 {
+		return w.header, nil
+	}
+	style, err := w.file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"1F4E78"}},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+		Border:    []excelize.Border{{Type: "bottom", Color: "000000", Style: 1}},
+	})
+	if err != nil {
+		return 0, helpers.WrapError(err, "failed to create header style")
+	}
+	w.header = style
+	return style, nil
+}
+
+// numberStyle returns a cached style for a custom number format such as "#,##0.00" or "yyyy-mm-dd".
+func (w *Workbook) numberStyle(format string) (int, error) {
+	if format == "" {
+		return 0, nil
+	}
+	if style, exists := w.styles[format]; exists {
+		return style, nil
+	}
+	style, err := w.file.NewStyle(&excelize.Style{CustomNumFmt: &format})
+	if err != nil {
+		return 0, helpers.WrapErrorf(err, "invalid number format %q", format)
+	}
+	w.styles[format] = style
+	return style, nil
+}
+
+// flush finishes every sheet, writing any rows still buffered for width sampling.
+func (w *Workbook) flush() error {
+	for _, sheet := range w.sheets {
+		if err := sheet.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write finishes all sheets and writes the workbook to out.
+func (w *Workbook) Write(out io.Writer) error {
+	if len(w.sheets) == 0 {
+		return helpers.CreateError("workbook has no sheets")
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if err := w.file.Write(out); err != nil {
+		return helpers.WrapError(err, "failed to write workbook")
+	}
+	return nil
+}
+
+// Download writes the workbook to the response as an attachment.
+func (w *Workbook) Download(response http.ResponseWriter, filename string) error {
+	if !strings.HasSuffix(strings.ToLower(filename), ".xlsx") {
+		filename += ".xlsx"
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	response.Header().Set("Content-Type", ContentType)
+	response.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	if err := w.Write(response); err != nil {
+		log.Error("❌ XLSX download failed: " + err.Error())
+		return err
+	}
+	return nil
+}
+
+// Save writes the workbook to uploadDirectory using the file package.
+// Returns the unique filename generated for the workbook.
+func (w *Workbook) Save(name, uploadDirectory string) (string, error) {
+	reader, pipe := io.Pipe()
+	go func() {
+		pipe.CloseWithError(w.Write(pipe))
+	}()
+
+	filename, err := file.UploadFile(reader, strings.TrimSuffix(name, ".xlsx")+".xlsx", uploadDirectory, false)
+	reader.Close()
+	if err != nil {
+		return "", helpers.WrapError(err, "failed to save workbook")
+	}
+	return filename, nil
+}
+
+// Close removes temporary files used while streaming sheets.
+func (w *Workbook) Close() error {
+	if err := w.file.Close(); err != nil {
+		return helpers.WrapError(err, "failed to close workbook")
+	}
+	return nil
+}